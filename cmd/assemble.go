@@ -3,13 +3,19 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/verity-org/verity/internal"
 )
 
-var errRegistryRequired = errors.New("--registry is required when --publish is set")
+var errRegistryRequired = errors.New("--registry is required when --publish or --dry-run is set")
+var errInvalidReportsMode = errors.New("--reports-mode must be one of: embed, referrer, both")
+var errInvalidSignMode = errors.New("--sign-mode must be one of: keyless, key")
+var errSignKeyRequired = errors.New("--sign-mode=key requires --sign-key")
+var errSignKeyConflict = errors.New("--sign-mode=keyless does not accept --sign-key; omit it or pass --sign-mode=key")
+var errInvalidOnConflict = errors.New("--on-conflict must be one of: bump, fail, overwrite")
 
 // AssembleCommand creates wrapper charts from patch results.
 var AssembleCommand = &cli.Command{
@@ -30,6 +36,11 @@ var AssembleCommand = &cli.Command{
 			Name:  "reports-dir",
 			Usage: "directory with Trivy reports",
 		},
+		&cli.StringFlag{
+			Name:  "starters-dir",
+			Value: internal.DefaultStartersDir(),
+			Usage: "directory of installed starter chart trees (see `verity starter`), consulted for charts whose manifest entry sets a starter",
+		},
 		&cli.StringFlag{
 			Name:  "output-dir",
 			Value: ".verity/charts",
@@ -43,6 +54,49 @@ var AssembleCommand = &cli.Command{
 			Name:  "publish",
 			Usage: "actually push to OCI (without it, just creates locally)",
 		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "render each wrapper chart with Helm's template action and write assemble-plan.json describing what --publish would do, instead of packaging and pushing (takes priority over --publish)",
+		},
+		&cli.BoolFlag{
+			Name:  "legacy-numeric-versions",
+			Usage: "use the old auto-incrementing \"{version}-{N}\" scheme instead of content-addressable versions",
+		},
+		&cli.BoolFlag{
+			Name:  "sign",
+			Usage: "cosign-sign each published chart's OCI manifest and its packaged archive, attaching the archive's provenance as an in-toto attestation",
+		},
+		&cli.StringFlag{
+			Name:  "sign-key",
+			Usage: "path to a cosign private key for --sign; omit for keyless (Fulcio/OIDC) signing",
+		},
+		&cli.StringFlag{
+			Name:  "sign-mode",
+			Value: "keyless",
+			Usage: "cosign signing mode for --sign: \"keyless\" (Fulcio/OIDC, the default) or \"key\" (requires --sign-key)",
+		},
+		&cli.StringFlag{
+			Name:  "cosign-identity-token",
+			Usage: "OIDC identity token for --sign-mode=keyless in a non-interactive environment (e.g. a CI job's ambient token), instead of cosign's interactive browser login",
+		},
+		&cli.StringFlag{
+			Name:  "dest-layout",
+			Usage: "resolve patched image digests from a local OCI image layout (the patch step's -dest-layout) instead of crane.Digest against a live registry",
+		},
+		&cli.StringFlag{
+			Name:  "reports-mode",
+			Value: string(internal.ReportsModeReferrer),
+			Usage: "how to attach Trivy reports to a published chart: \"referrer\" pushes each as an OCI 1.1 referrer artifact (keeps the chart manifest strictly OCI-compliant), \"embed\" bundles them into the chart package's reports/ directory, \"both\" does both",
+		},
+		&cli.StringFlag{
+			Name:  "summary-file",
+			Usage: "where to write assemble-summary.json, a machine-readable record of every wrapper chart produced (default: <output-dir>/assemble-summary.json)",
+		},
+		&cli.StringFlag{
+			Name:  "on-conflict",
+			Value: string(internal.OnConflictBump),
+			Usage: "what to do when a chart's content-addressable version already exists in registry: \"bump\" (the default) skips the redundant publish and logs it as unchanged, \"fail\" errors out instead, \"overwrite\" republishes anyway",
+		},
 	},
 	Action: runAssemble,
 }
@@ -51,20 +105,60 @@ func runAssemble(c *cli.Context) error {
 	manifestPath := c.String("manifest")
 	resultsDir := c.String("results-dir")
 	reportsDir := c.String("reports-dir")
+	startersDir := c.String("starters-dir")
 	outputDir := c.String("output-dir")
 	registry := c.String("registry")
 	publish := c.Bool("publish")
+	dryRun := c.Bool("dry-run")
+	legacyNumericVersions := c.Bool("legacy-numeric-versions")
+	sign := c.Bool("sign")
+	signKey := c.String("sign-key")
+	signMode := c.String("sign-mode")
+	identityToken := c.String("cosign-identity-token")
+	destLayout := c.String("dest-layout")
+	reportsMode := internal.ReportsMode(c.String("reports-mode"))
+	summaryFile := c.String("summary-file")
+	if summaryFile == "" {
+		summaryFile = filepath.Join(outputDir, "assemble-summary.json")
+	}
+	onConflict := internal.OnConflict(c.String("on-conflict"))
 
-	if publish && registry == "" {
+	if (publish || dryRun) && registry == "" {
 		return errRegistryRequired
 	}
+	switch reportsMode {
+	case internal.ReportsModeReferrer, internal.ReportsModeEmbed, internal.ReportsModeBoth:
+	default:
+		return errInvalidReportsMode
+	}
+	if sign {
+		switch signMode {
+		case "keyless":
+			if signKey != "" {
+				return errSignKeyConflict
+			}
+		case "key":
+			if signKey == "" {
+				return errSignKeyRequired
+			}
+		default:
+			return errInvalidSignMode
+		}
+	}
+	switch onConflict {
+	case internal.OnConflictBump, internal.OnConflictFail, internal.OnConflictOverwrite:
+	default:
+		return errInvalidOnConflict
+	}
 
 	fmt.Printf("Assembling wrapper charts from %s\n", manifestPath)
-	if publish {
+	if dryRun {
+		fmt.Println("Dry run: rendering manifests and writing assemble-plan.json instead of publishing")
+	} else if publish {
 		fmt.Printf("Publishing to %s\n", registry)
 	}
 
-	err := internal.AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, publish)
+	err := internal.AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, startersDir, publish, dryRun, legacyNumericVersions, sign, signKey, destLayout, reportsMode, identityToken, summaryFile, onConflict)
 	if err != nil {
 		return fmt.Errorf("assemble failed: %w", err)
 	}
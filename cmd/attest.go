@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal/attest"
+)
+
+// AttestCommand generates a CycloneDX SBOM for a patched image and attaches
+// it, along with its Trivy vulnerability report, as in-toto attestations.
+// It appends the resulting image to images.json in the schema
+// CatalogCommand's --images-json flag already expects, so callers no longer
+// need the external sign-and-attest script to populate it.
+var AttestCommand = &cli.Command{
+	Name:  "attest",
+	Usage: "Generate an SBOM and attach SBOM/vuln attestations to a patched image",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "image",
+			Required: true,
+			Usage:    "patched image reference to attest (e.g. ghcr.io/verity-org/prometheus:v3.9.1-patched)",
+		},
+		&cli.StringFlag{
+			Name:  "original",
+			Usage: "original (pre-patch) image reference, recorded in images.json",
+		},
+		&cli.StringFlag{
+			Name:  "vuln-report",
+			Usage: "path to the Trivy JSON vulnerability report for --image",
+		},
+		&cli.StringFlag{
+			Name:  "key",
+			Usage: "path to a cosign private key; omit for keyless (Fulcio/OIDC) signing",
+		},
+		&cli.StringFlag{
+			Name:  "signature-ref",
+			Usage: "signature ref from a prior `verity sign`, recorded in images.json",
+		},
+		&cli.StringFlag{
+			Name:     "images-json",
+			Required: true,
+			Usage:    "path to images.json to append this image's attestation result to",
+		},
+	},
+	Action: runAttest,
+}
+
+func runAttest(c *cli.Context) error {
+	imageRef := c.String("image")
+	ctx := context.Background()
+
+	fmt.Printf("Generating SBOM for %s ...\n", imageRef)
+	sbom, err := attest.GenerateSBOM(ctx, imageRef)
+	if err != nil {
+		return fmt.Errorf("generating SBOM: %w", err)
+	}
+
+	fmt.Printf("Attesting %s ...\n", imageRef)
+	result, err := attest.AttestImage(ctx, imageRef, sbom, c.String("vuln-report"), attest.AttestOptions{Key: c.String("key")})
+	if err != nil {
+		return fmt.Errorf("attestation failed: %w", err)
+	}
+
+	_, digest, _ := strings.Cut(result.Reference, "@")
+
+	entry := attest.ImagesJSONEntry{
+		Original:         c.String("original"),
+		Patched:          imageRef,
+		Digest:           digest,
+		SignatureRef:     c.String("signature-ref"),
+		SBOMPredicateRef: result.SBOMPredicateRef,
+		VulnPredicateRef: result.VulnPredicateRef,
+	}
+	if err := attest.WriteImagesJSON(c.String("images-json"), []attest.ImagesJSONEntry{entry}); err != nil {
+		return fmt.Errorf("writing images.json: %w", err)
+	}
+
+	fmt.Printf("Attestations → %s\n", result.SBOMPredicateRef)
+	return nil
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+	"github.com/verity-org/verity/internal/attest"
+)
+
+// AttestAllCommand signs and attests every successfully patched image in a
+// discovery/patch matrix, the batch counterpart to AttestCommand the same
+// way PatchAllCommand is to PatchCommand — so the GH Actions workflow can
+// shard attestation across jobs the same way it already shards patching.
+var AttestAllCommand = &cli.Command{
+	Name:  "attest-all",
+	Usage: "Sign and attest every patched image in a discovery matrix",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "matrix",
+			Required: true,
+			Usage:    "path to discover/patch's matrix.json",
+		},
+		&cli.StringFlag{
+			Name:     "result-dir",
+			Required: true,
+			Usage:    "directory of per-image SinglePatchResult JSON written by PatchSingleImage, used to find each entry's patched ref",
+		},
+		&cli.StringFlag{
+			Name:     "reports-dir",
+			Required: true,
+			Usage:    "directory of per-image Trivy JSON reports (PatchOptions.ReportDir from the patch step), attached as a vuln attestation",
+		},
+		&cli.StringFlag{
+			Name:  "key",
+			Usage: "path to a cosign private key, or a KMS ref (e.g. awskms://...); omit for keyless (Fulcio/OIDC) signing",
+		},
+		&cli.StringFlag{
+			Name:     "attestations-json",
+			Required: true,
+			Usage:    "path to write a summary of every image's sign/attest result",
+		},
+	},
+	Action: runAttestAll,
+}
+
+func runAttestAll(c *cli.Context) error {
+	matrixPath := c.String("matrix")
+	resultDir := c.String("result-dir")
+	reportsDir := c.String("reports-dir")
+	keyRef := c.String("key")
+	attestationsJSONPath := c.String("attestations-json")
+
+	data, err := os.ReadFile(matrixPath)
+	if err != nil {
+		return fmt.Errorf("reading matrix %s: %w", matrixPath, err)
+	}
+	var matrix internal.MatrixOutput
+	if err := json.Unmarshal(data, &matrix); err != nil {
+		return fmt.Errorf("parsing matrix %s: %w", matrixPath, err)
+	}
+
+	results, err := internal.LoadResults(resultDir)
+	if err != nil {
+		return fmt.Errorf("reading results: %w", err)
+	}
+
+	ctx := context.Background()
+	opts := attest.AttestOptions{Key: keyRef}
+	var entries []attest.ImagesJSONEntry
+	var attestErrors []error
+
+	for _, e := range matrix.Include {
+		r, ok := results[internal.PlatformKey(e.ImageRef, e.Platform)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: no result for %s, skipping attestation\n", e.ImageRef)
+			continue
+		}
+		if r.Error != "" || r.PatchedRepository == "" {
+			// Failed, or skipped with nothing new to attest (e.g. already
+			// up to date) — nothing patched for this entry to attest.
+			continue
+		}
+
+		patchedRef := patchedRefString(r)
+		fmt.Printf("Attesting %s ...\n", patchedRef)
+
+		entry, err := attestOne(ctx, patchedRef, e.ImageRef, reportsDir, opts)
+		if err != nil {
+			attestErrors = append(attestErrors, fmt.Errorf("%s: %w", patchedRef, err))
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := attest.WriteImagesJSON(attestationsJSONPath, entries); err != nil {
+		return fmt.Errorf("writing %s: %w", attestationsJSONPath, err)
+	}
+
+	if len(attestErrors) > 0 {
+		fmt.Fprintf(os.Stderr, "\nWarnings (%d attestations failed):\n", len(attestErrors))
+		for _, err := range attestErrors {
+			fmt.Fprintf(os.Stderr, "  - %v\n", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\nAttestation complete: %d/%d successful\n", len(entries), len(matrix.Include))
+	fmt.Fprintf(os.Stderr, "Attestations → %s\n", attestationsJSONPath)
+	return nil
+}
+
+// attestOne signs patchedRef and attaches its SBOM and (if present) Trivy
+// vuln report from reportsDir as in-toto attestations, the same two-step
+// AttestCommand's runAttest performs for a single image.
+func attestOne(ctx context.Context, patchedRef, originalRef, reportsDir string, opts attest.AttestOptions) (*attest.ImagesJSONEntry, error) {
+	signResult, err := attest.SignImage(ctx, patchedRef, attest.SignOptions{Key: opts.Key})
+	if err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+
+	sbom, err := attest.GenerateSBOM(ctx, patchedRef)
+	if err != nil {
+		return nil, fmt.Errorf("generating SBOM: %w", err)
+	}
+
+	vulnReport := filepath.Join(reportsDir, sanitizeRef(patchedRef)+".json")
+	if _, err := os.Stat(vulnReport); err != nil {
+		vulnReport = ""
+	}
+
+	attResult, err := attest.AttestImage(ctx, patchedRef, sbom, vulnReport, opts)
+	if err != nil {
+		return nil, fmt.Errorf("attesting: %w", err)
+	}
+
+	return &attest.ImagesJSONEntry{
+		Original:         originalRef,
+		Patched:          patchedRef,
+		Digest:           signResult.Digest,
+		SignatureRef:     signResult.SignatureRef,
+		SBOMPredicateRef: attResult.SBOMPredicateRef,
+		VulnPredicateRef: attResult.VulnPredicateRef,
+	}, nil
+}
+
+// sanitizeRef mirrors internal's unexported sanitize(ref string) used when
+// PatchOptions.ReportDir names a Trivy report after its patched ref, so a
+// report written during the patch step can be found again here.
+func sanitizeRef(ref string) string {
+	r := strings.NewReplacer("/", "_", ":", "_")
+	return r.Replace(ref)
+}
+
+// patchedRefString rebuilds the full patched image reference from a
+// SinglePatchResult's PatchedRegistry/Repository/Tag fields.
+func patchedRefString(r *internal.SinglePatchResult) string {
+	ref := r.PatchedRepository
+	if r.PatchedRegistry != "" {
+		ref = r.PatchedRegistry + "/" + ref
+	}
+	if r.PatchedTag != "" {
+		ref += ":" + r.PatchedTag
+	}
+	return ref
+}
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/verity-org/verity/internal"
+	"github.com/verity-org/verity/internal/report"
 )
 
 // CatalogCommand generates the site catalog JSON from patch reports.
@@ -37,6 +39,23 @@ var CatalogCommand = &cli.Command{
 			Name:  "post-reports-dir",
 			Usage: "directory containing post-patch Trivy vulnerability reports (for before/after comparison)",
 		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "json",
+			Usage: "comma-separated output formats to produce: json, html, md",
+		},
+		&cli.StringFlag{
+			Name:  "report-output-dir",
+			Usage: "output directory for html/md reports (required when --format includes html or md)",
+		},
+		&cli.StringFlag{
+			Name:  "template-dir",
+			Usage: "directory of custom index.html.tmpl/tag.html.tmpl overriding the default html theme",
+		},
+		&cli.StringFlag{
+			Name:  "vex-dir",
+			Usage: "directory of *.vex.json OpenVEX documents suppressing vulns resolved as not_affected/fixed (see internal.LoadVEXCorpus)",
+		},
 	},
 	Action: runCatalog,
 }
@@ -47,10 +66,49 @@ func runCatalog(c *cli.Context) error {
 	registry := c.String("registry")
 	reportsDir := c.String("reports-dir")
 	postReportsDir := c.String("post-reports-dir")
+	vexDir := c.String("vex-dir")
 
-	if err := internal.GenerateSiteDataFromJSON(imagesJSON, reportsDir, postReportsDir, registry, output); err != nil {
+	formats, err := parseReportFormats(c.String("format"))
+	if err != nil {
+		return err
+	}
+
+	if err := internal.GenerateSiteDataFromJSON(imagesJSON, reportsDir, postReportsDir, registry, output, vexDir); err != nil {
 		return fmt.Errorf("failed to generate site data from JSON: %w", err)
 	}
 	fmt.Printf("Site catalog → %s\n", output)
+
+	if len(formats) > 0 {
+		outputDir := c.String("report-output-dir")
+		if outputDir == "" {
+			return fmt.Errorf("--report-output-dir is required when --format includes html or md")
+		}
+		if err := report.GenerateReports(reportsDir, postReportsDir, outputDir, c.String("template-dir"), formats); err != nil {
+			return fmt.Errorf("failed to generate vulnerability reports: %w", err)
+		}
+		fmt.Printf("Vulnerability reports → %s\n", outputDir)
+	}
 	return nil
 }
+
+// parseReportFormats splits --format's comma-separated value into the
+// report.Format values GenerateReports needs. "json" (and the empty
+// string, e.g. an unset --format) name catalog.json itself, which runCatalog
+// always generates regardless of this list, so neither produces a
+// report.Format entry here.
+func parseReportFormats(formatFlag string) ([]report.Format, error) {
+	var formats []report.Format
+	for _, f := range strings.Split(formatFlag, ",") {
+		switch strings.TrimSpace(f) {
+		case "json", "":
+			// handled unconditionally by GenerateSiteDataFromJSON
+		case "html":
+			formats = append(formats, report.FormatHTML)
+		case "md":
+			formats = append(formats, report.FormatMD)
+		default:
+			return nil, fmt.Errorf("unsupported --format value %q", f)
+		}
+	}
+	return formats, nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/verity-org/verity/internal/report"
+)
+
+func TestParseReportFormats(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatStr string
+		want      []report.Format
+		wantErr   bool
+	}{
+		{name: "empty defaults to json-only, no report.Format entries", formatStr: "", want: nil},
+		{name: "explicit json, no report.Format entries", formatStr: "json", want: nil},
+		{name: "html", formatStr: "html", want: []report.Format{report.FormatHTML}},
+		{name: "md", formatStr: "md", want: []report.Format{report.FormatMD}},
+		{name: "html and md, with json and whitespace mixed in", formatStr: "json, html, md", want: []report.Format{report.FormatHTML, report.FormatMD}},
+		{name: "unsupported value", formatStr: "pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReportFormats(tt.formatStr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseReportFormats(%q) = nil error, want one", tt.formatStr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseReportFormats(%q) error = %v", tt.formatStr, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseReportFormats(%q) = %v, want %v", tt.formatStr, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseReportFormats(%q)[%d] = %v, want %v", tt.formatStr, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+var errInvalidChartsOutput = errors.New("--output must be one of: table, json")
+
+// ChartsCommand lists wrapper charts already published to an OCI registry.
+// Named "charts" rather than "list" to avoid colliding with ListCommand's
+// existing, unrelated "list" (which lists images from a local values.yaml).
+var ChartsCommand = &cli.Command{
+	Name:  "charts",
+	Usage: "List wrapper charts published to an OCI registry",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "registry",
+			Required: true,
+			Usage:    "OCI registry to list wrapper charts from (e.g. ghcr.io/verity-org)",
+		},
+		&cli.StringFlag{
+			Name:  "prefix",
+			Usage: "only list charts whose name starts with this prefix",
+		},
+		&cli.StringFlag{
+			Name:  "chart",
+			Usage: "only list this exact chart name, skipping the registry-wide catalog walk",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Value: "table",
+			Usage: "output format: \"table\" (the default) or \"json\"",
+		},
+	},
+	Action: runCharts,
+}
+
+func runCharts(c *cli.Context) error {
+	registry := c.String("registry")
+	prefix := c.String("prefix")
+	chart := c.String("chart")
+	output := c.String("output")
+
+	switch output {
+	case "table", "json":
+	default:
+		return errInvalidChartsOutput
+	}
+
+	charts, err := internal.ListPublishedCharts(registry, prefix, chart)
+	if err != nil {
+		return fmt.Errorf("listing published charts: %w", err)
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(charts)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tLATEST VERSION\tPUBLISHED AT\tSOURCE\tREFERRERS")
+	for _, ch := range charts {
+		source := ch.SourceRepository
+		if ch.SourceVersion != "" {
+			source += "@" + ch.SourceVersion
+		}
+		publishedAt := ch.PublishedAt
+		if publishedAt == "" {
+			publishedAt = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", ch.Name, ch.LatestVersion, publishedAt, source, ch.ReferrerCount)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing table: %w", err)
+	}
+	fmt.Printf("\nTotal: %d chart(s)\n", len(charts))
+	return nil
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// driftSeverity ranks how far an image's current tag has fallen behind the
+// latest available semver tag, in ascending order so a numeric comparison
+// against --fail-on's threshold is just "found >= threshold".
+type driftSeverity int
+
+const (
+	driftUpToDate driftSeverity = iota
+	driftPatchBehind
+	driftMinorBehind
+	driftMajorBehind
+)
+
+func (s driftSeverity) String() string {
+	switch s {
+	case driftUpToDate:
+		return "up-to-date"
+	case driftPatchBehind:
+		return "patch-behind"
+	case driftMinorBehind:
+		return "minor-behind"
+	default:
+		return "major-behind"
+	}
+}
+
+// errUnknownFailOn is returned when --fail-on names a severity driftSeverities doesn't recognize.
+var errUnknownFailOn = errors.New("unknown --fail-on severity")
+
+// driftSeverities maps a --fail-on flag value to the driftSeverity an image
+// must reach (or exceed) to fail the command.
+var driftSeverities = map[string]driftSeverity{
+	"patch": driftPatchBehind,
+	"minor": driftMinorBehind,
+	"major": driftMajorBehind,
+}
+
+// imageStatus is one values.yaml image's outdated-ness, both for the TTY
+// table and the --format json report.
+type imageStatus struct {
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	Tag        string `json:"tag"`
+	LatestTag  string `json:"latestTag,omitempty"`
+	Severity   string `json:"severity"`
+	Unresolved string `json:"error,omitempty"`
+}
+
+// CheckCommand reports how far behind the latest registry tag each image
+// in values.yaml is, classified by semver drift, so CI can gate on images
+// falling too far out of date without patching anything itself.
+var CheckCommand = &cli.Command{
+	Name:  "check",
+	Usage: "Report how outdated each image in values.yaml is relative to the latest registry tag",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "images",
+			Aliases: []string{"i"},
+			Value:   "values.yaml",
+			Usage:   "path to images values.yaml",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "table",
+			Usage: "output format: table (colorized TTY table) or json",
+		},
+		&cli.StringFlag{
+			Name:  "fail-on",
+			Usage: "exit non-zero if any image is at or beyond this severity: patch, minor, major",
+		},
+	},
+	Action: runCheck,
+}
+
+func runCheck(c *cli.Context) error {
+	imagesFile := c.String("images")
+	format := c.String("format")
+
+	var failThreshold driftSeverity = -1
+	if v := c.String("fail-on"); v != "" {
+		threshold, ok := driftSeverities[v]
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnknownFailOn, v)
+		}
+		failThreshold = threshold
+	}
+
+	overrides, err := parseOverridesFromFile(imagesFile)
+	if err != nil {
+		return err
+	}
+
+	images, err := internal.ParseImagesFile(c.Context(), imagesFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", imagesFile, err)
+	}
+	images = internal.ApplyOverrides(c.Context(), images, overrides)
+
+	statuses := make([]imageStatus, len(images))
+	worst := driftUpToDate
+	for i, img := range images {
+		statuses[i] = checkImage(img, overrides)
+		if sev, ok := driftSeverities[statuses[i].Severity]; ok && sev > worst {
+			worst = sev
+		}
+	}
+
+	switch format {
+	case "json":
+		if err := printCheckJSON(statuses); err != nil {
+			return err
+		}
+	case "table":
+		printCheckTable(statuses)
+	default:
+		return fmt.Errorf("unsupported --format value %q", format)
+	}
+
+	if failThreshold >= 0 && worst >= failThreshold {
+		return fmt.Errorf("%w: found images at or beyond %q severity", errCheckFailed, driftSeverity(worst))
+	}
+	return nil
+}
+
+var errCheckFailed = errors.New("check failed")
+
+// checkImage lists img's registry tags, filters them to the same override
+// variant family img.Tag is in (if any — see variantFilter) so an "-alpine"
+// tag never gets compared against a "-debian" line, and classifies the
+// drift between img.Tag and the latest matching semver tag.
+func checkImage(img internal.Image, overrides []internal.ImageOverride) imageStatus {
+	status := imageStatus{Name: img.Path, Image: img.Repository, Tag: img.Tag}
+
+	current, err := semver.NewVersion(img.Tag)
+	if err != nil {
+		status.Unresolved = fmt.Sprintf("current tag %q is not valid semver: %v", img.Tag, err)
+		status.Severity = driftUpToDate.String()
+		return status
+	}
+
+	ref := img.Repository
+	if img.Registry != "" {
+		ref = img.Registry + "/" + ref
+	}
+	allTags, err := crane.ListTags(ref, crane.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		status.Unresolved = fmt.Sprintf("listing tags: %v", err)
+		status.Severity = driftUpToDate.String()
+		return status
+	}
+
+	filter := variantFilter(img, overrides)
+	var versions []*semver.Version
+	for _, tag := range allTags {
+		if filter != "" && !strings.Contains(tag, filter) {
+			continue
+		}
+		if v, err := semver.NewVersion(tag); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		status.Severity = driftUpToDate.String()
+		return status
+	}
+	sort.Sort(semver.Collection(versions))
+	latest := versions[len(versions)-1]
+	status.LatestTag = latest.Original()
+	status.Severity = classifyDrift(current, latest).String()
+	return status
+}
+
+// variantFilter returns the override substring (ImageOverride.To) that
+// img's tag was rewritten to, if any override matched img — so registry
+// tags compared against img are restricted to the same variant family
+// (e.g. "-debian") instead of spuriously flagging drift against an
+// unrelated variant line (e.g. "-alpine") that happens to have a higher
+// version.
+func variantFilter(img internal.Image, overrides []internal.ImageOverride) string {
+	for _, o := range overrides {
+		if o.Match(img) && o.To != "" {
+			return o.To
+		}
+	}
+	return ""
+}
+
+// classifyDrift compares current against latest, both drawn from the same
+// variant family (see variantFilter), into up-to-date/patch-behind/
+// minor-behind/major-behind.
+func classifyDrift(current, latest *semver.Version) driftSeverity {
+	switch {
+	case !latest.GreaterThan(current):
+		return driftUpToDate
+	case latest.Major() != current.Major():
+		return driftMajorBehind
+	case latest.Minor() != current.Minor():
+		return driftMinorBehind
+	default:
+		return driftPatchBehind
+	}
+}
+
+func printCheckJSON(statuses []imageStatus) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(statuses)
+}
+
+// printCheckTable renders a colorized (green/yellow/red) table when stdout
+// is a terminal, plain text otherwise.
+func printCheckTable(statuses []imageStatus) {
+	colorize := term.IsTerminal(int(os.Stdout.Fd()))
+	fmt.Printf("%-30s %-15s %-15s %s\n", "IMAGE", "TAG", "LATEST", "STATUS")
+	for _, s := range statuses {
+		latest := s.LatestTag
+		if latest == "" {
+			latest = "-"
+		}
+		line := fmt.Sprintf("%-30s %-15s %-15s %s", s.Name, s.Tag, latest, s.Severity)
+		if s.Unresolved != "" {
+			line += fmt.Sprintf(" (%s)", s.Unresolved)
+		}
+		fmt.Println(colorizeLine(line, s.Severity, colorize))
+	}
+}
+
+func colorizeLine(line, severity string, colorize bool) string {
+	if !colorize {
+		return line
+	}
+	const (
+		green  = "\033[32m"
+		yellow = "\033[33m"
+		red    = "\033[31m"
+		reset  = "\033[0m"
+	)
+	switch severity {
+	case driftUpToDate.String():
+		return green + line + reset
+	case driftPatchBehind.String(), driftMinorBehind.String():
+		return yellow + line + reset
+	case driftMajorBehind.String():
+		return red + line + reset
+	default:
+		return line
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/verity-org/verity/internal"
+)
+
+func TestClassifyDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		latest  string
+		want    driftSeverity
+	}{
+		{"up to date", "1.26.0", "1.26.0", driftUpToDate},
+		{"current ahead of latest", "1.27.0", "1.26.0", driftUpToDate},
+		{"patch behind", "1.26.0", "1.26.1", driftPatchBehind},
+		{"minor behind", "1.26.0", "1.27.0", driftMinorBehind},
+		{"major behind", "1.26.0", "2.0.0", driftMajorBehind},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			current := semver.MustParse(tc.current)
+			latest := semver.MustParse(tc.latest)
+			if got := classifyDrift(current, latest); got != tc.want {
+				t.Errorf("classifyDrift(%s, %s) = %s, want %s", tc.current, tc.latest, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVariantFilter(t *testing.T) {
+	img := internal.Image{Repository: "library/nginx", Tag: "1.26.0-alpine"}
+	overrides := []internal.ImageOverride{
+		{Repository: "library/nginx", To: "-alpine"},
+	}
+
+	if got := variantFilter(img, overrides); got != "-alpine" {
+		t.Errorf("variantFilter() = %q, want %q", got, "-alpine")
+	}
+
+	if got := variantFilter(img, nil); got != "" {
+		t.Errorf("variantFilter() with no overrides = %q, want empty", got)
+	}
+}
+
+func TestRunCheck_UnknownFailOn(t *testing.T) {
+	_, ok := driftSeverities["bogus"]
+	if ok {
+		t.Fatal("expected \"bogus\" to be an unrecognized --fail-on value")
+	}
+}
@@ -1,14 +1,27 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/urfave/cli/v2"
 
 	"github.com/verity-org/verity/internal"
 )
 
+// errDiscoveryHadFailures is returned by runDiscover when one or more chart
+// dependencies failed to download/scan, so the command exits non-zero even
+// though discovery kept going and produced a manifest for everything else.
+var errDiscoveryHadFailures = errors.New("one or more chart dependencies failed discovery")
+
+var errRepoNeedsChartAndVersion = errors.New("--repo requires --chart and --version to be set")
+
 // DiscoverCommand scans images from values.yaml and outputs a GitHub Actions matrix.
 var DiscoverCommand = &cli.Command{
 	Name:  "discover",
@@ -24,12 +37,77 @@ var DiscoverCommand = &cli.Command{
 			Name:  "chart-file",
 			Usage: "path to Chart.yaml (enables chart-based discovery)",
 		},
+		&cli.StringFlag{
+			Name:  "oci-chart",
+			Usage: "OCI chart reference to render and scan (e.g. oci://ghcr.io/org/chart:1.2.3)",
+		},
+		&cli.StringFlag{
+			Name:  "repo",
+			Usage: "HTTP chart repository URL (used with --chart and --version instead of --oci-chart)",
+		},
+		&cli.StringFlag{
+			Name:  "chart",
+			Usage: "chart name to pull from --repo",
+		},
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "chart version to pull from --repo or --oci-chart",
+		},
+		&cli.StringFlag{
+			Name:  "values",
+			Usage: "values override file to render the chart with (used with --oci-chart/--repo)",
+		},
 		&cli.StringFlag{
 			Name:    "discover-dir",
 			Aliases: []string{"d"},
 			Value:   ".verity",
 			Usage:   "output directory for discover artifacts",
 		},
+		&cli.StringFlag{
+			Name:  "lockfile",
+			Usage: "path to a tags.lock.yaml; written on first run, then pins discovery to its tag set on subsequent runs (e.g. .verity/tags.lock.yaml)",
+		},
+		&cli.StringFlag{
+			Name:  "reports-dir",
+			Usage: "directory with Trivy reports from a previous run, used with --min-severity to drop already-clean images from the matrix",
+		},
+		&cli.StringFlag{
+			Name:  "min-severity",
+			Usage: "drop images from the matrix whose cached report (--reports-dir) has nothing fixable at or above this severity",
+		},
+		&cli.IntFlag{
+			Name:  "jobs",
+			Usage: "number of charts to download and scan concurrently during chart-based discovery",
+			Value: runtime.NumCPU(),
+		},
+		&cli.StringFlag{
+			Name:  "registry-config",
+			Usage: "path to a sysregistriesv2-style YAML file of registry mirrors/blocks to rewrite discovered images through",
+		},
+		&cli.BoolFlag{
+			Name:  "cluster",
+			Usage: "discover images from live Pods in a Kubernetes cluster instead of (or alongside) --chart-file",
+		},
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "restrict --cluster discovery to one namespace; empty scans every namespace",
+		},
+		&cli.StringFlag{
+			Name:  "label-selector",
+			Usage: "restrict --cluster discovery to Pods matching this label selector (e.g. app.kubernetes.io/managed-by=Helm)",
+		},
+		&cli.StringFlag{
+			Name:  "kubeconfig",
+			Usage: "path to a kubeconfig for --cluster discovery; empty uses the in-cluster config",
+		},
+		&cli.StringFlag{
+			Name:  "verify-chart-provenance",
+			Usage: "verify .prov signatures when pulling --oci-chart/--repo charts: \"if-present\" or \"always\" (default: no verification, see internal.ChartVerifyMode)",
+		},
+		&cli.StringFlag{
+			Name:  "keyring",
+			Usage: "keyring path for --verify-chart-provenance (default: Helm's default keyring)",
+		},
 	},
 	Action: runDiscover,
 }
@@ -37,7 +115,23 @@ var DiscoverCommand = &cli.Command{
 func runDiscover(c *cli.Context) error {
 	imagesFile := c.String("images")
 	chartFile := c.String("chart-file")
+	ociChart := c.String("oci-chart")
+	repo := c.String("repo")
+	chartName := c.String("chart")
+	version := c.String("version")
+	valuesFile := c.String("values")
 	discoverDir := c.String("discover-dir")
+	lockfilePath := c.String("lockfile")
+	reportsDir := c.String("reports-dir")
+	minSeverity := c.String("min-severity")
+	jobs := c.Int("jobs")
+	registryConfigPath := c.String("registry-config")
+	cluster := c.Bool("cluster")
+	namespace := c.String("namespace")
+	labelSelector := c.String("label-selector")
+	kubeconfig := c.String("kubeconfig")
+	verify := internal.ChartVerifyMode(c.String("verify-chart-provenance"))
+	keyringPath := c.String("keyring")
 
 	overrides, err := parseOverridesFromFile(imagesFile)
 	if err != nil {
@@ -46,7 +140,23 @@ func runDiscover(c *cli.Context) error {
 
 	var manifest *internal.DiscoveryManifest
 
-	if chartFile != "" {
+	switch {
+	case ociChart != "":
+		dep := ociChartDependency(ociChart, version)
+		manifest, err = renderChartDiscovery(dep, valuesFile, verify, keyringPath)
+		if err != nil {
+			return err
+		}
+	case repo != "":
+		if chartName == "" || version == "" {
+			return errRepoNeedsChartAndVersion
+		}
+		dep := internal.Dependency{Name: chartName, Version: version, Repository: repo}
+		manifest, err = renderChartDiscovery(dep, valuesFile, verify, keyringPath)
+		if err != nil {
+			return err
+		}
+	case chartFile != "":
 		// Chart-based discovery: scan Chart.yaml dependencies + merge into images file.
 		// Create a separate temp directory for chart downloads to avoid polluting output dir
 		tmpDir, err := os.MkdirTemp("", "verity-discover-*")
@@ -55,13 +165,25 @@ func runDiscover(c *cli.Context) error {
 		}
 		defer os.RemoveAll(tmpDir)
 
-		manifest, err = internal.DiscoverImages(chartFile, imagesFile, tmpDir)
+		var reports []internal.DiscoveryReport
+		manifest, reports, err = internal.DiscoverImages(chartFile, imagesFile, tmpDir, jobs)
 		if err != nil {
 			return fmt.Errorf("discovery failed: %w", err)
 		}
-	} else {
+		if len(reports) > 0 {
+			fmt.Fprintf(os.Stderr, "\nDiscovery failures (%d chart(s)):\n", len(reports))
+			for _, r := range reports {
+				fmt.Fprintf(os.Stderr, "  - %s (%s): %v\n", r.Chart, r.Stage, r.Err)
+			}
+			return fmt.Errorf("%w: %d chart(s)", errDiscoveryHadFailures, len(reports))
+		}
+	case cluster:
+		// Cluster-only discovery: no chart source at all, so skip the flat
+		// images-file parse below and start from an empty manifest.
+		manifest = &internal.DiscoveryManifest{}
+	default:
 		// Flat discovery: just parse the images file.
-		images, err := internal.ParseImagesFile(imagesFile)
+		images, err := internal.ParseImagesFile(c.Context(), imagesFile)
 		if err != nil {
 			return fmt.Errorf("discovery failed: %w", err)
 		}
@@ -75,13 +197,54 @@ func runDiscover(c *cli.Context) error {
 		}
 	}
 
+	// --cluster augments whichever source above ran (or stands alone) with
+	// images found in live Pod specs — overrides applied at install time,
+	// injected sidecars, initContainers — none of which a chart's
+	// values.yaml alone would show (see internal.ClusterSource). These
+	// have no owning chart, so they flow into manifest.Images only, never
+	// manifest.Charts; AssembleResults records their patched refs in
+	// patched-images.json instead of a wrapper chart.
+	if cluster {
+		clusterSource := &internal.ClusterSource{
+			Kubeconfig:    kubeconfig,
+			Namespace:     namespace,
+			LabelSelector: labelSelector,
+		}
+		clusterImages, err := clusterSource.Discover(c.Context())
+		if err != nil {
+			return fmt.Errorf("cluster discovery failed: %w", err)
+		}
+		manifest.Images = append(manifest.Images, clusterImages...)
+		fmt.Printf("Cluster discovery: %d image(s) from live Pods\n", len(clusterImages))
+	}
+
 	// Apply image tag overrides (e.g. distroless → debian) so the matrix
 	// contains Copa-compatible refs. Apply to both flat Images and Charts[*].Images.
 	if len(overrides) > 0 {
-		internal.ApplyOverridesToManifest(manifest, overrides)
+		internal.ApplyOverridesToManifest(c.Context(), manifest, overrides)
+	}
+
+	// Rewrite through -registry-config's mirror/block rules, if given, so
+	// the matrix contains the refs Copa will actually pull instead of the
+	// upstream ones discovery found.
+	registryConfig, err := internal.LoadRegistryConfig(registryConfigPath)
+	if err != nil {
+		return err
+	}
+	mirrors, registryReports := internal.RewriteManifestRegistries(manifest, registryConfig)
+	if len(registryReports) > 0 {
+		fmt.Fprintf(os.Stderr, "\nRegistry config failures (%d image(s)):\n", len(registryReports))
+		for _, r := range registryReports {
+			fmt.Fprintf(os.Stderr, "  - %s (%s): %v\n", r.Chart, r.Stage, r.Err)
+		}
+		return fmt.Errorf("%w: %d image(s)", errDiscoveryHadFailures, len(registryReports))
+	}
+
+	if err := pinOrLockManifestTags(manifest, lockfilePath); err != nil {
+		return err
 	}
 
-	matrix := internal.GenerateMatrix(manifest)
+	matrix := internal.GenerateMatrix(manifest, reportsDir, minSeverity, mirrors)
 
 	if err := internal.WriteDiscoveryOutput(manifest, matrix, discoverDir); err != nil {
 		return fmt.Errorf("failed to write discovery output: %w", err)
@@ -93,6 +256,136 @@ func runDiscover(c *cli.Context) error {
 	return nil
 }
 
+// ociChartDependency builds a Dependency for an "oci://host/org/chart[:version]"
+// reference, splitting off a trailing ":version" when --version isn't given
+// explicitly.
+func ociChartDependency(ociChart, version string) internal.Dependency {
+	repo := ociChart
+	name := ""
+	if idx := strings.LastIndex(repo, "/"); idx != -1 {
+		name = repo[idx+1:]
+		repo = repo[:idx]
+	}
+	if version == "" {
+		if idx := strings.LastIndex(name, ":"); idx != -1 {
+			version = name[idx+1:]
+			name = name[:idx]
+		}
+	}
+	return internal.Dependency{Name: name, Version: version, Repository: repo}
+}
+
+// renderChartDiscovery pulls and renders dep with the Helm template engine,
+// walking every manifest (including subchart templates) for image
+// references, and groups the results into a DiscoveryManifest with one
+// ChartDiscovery per originating chart/subchart. verify and keyringPath
+// control .prov signature checking on the pulled chart (see
+// internal.ChartVerifyMode).
+func renderChartDiscovery(dep internal.Dependency, valuesFile string, verify internal.ChartVerifyMode, keyringPath string) (*internal.DiscoveryManifest, error) {
+	tmpDir, err := os.MkdirTemp("", "verity-discover-render-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	images, err := internal.DiscoverImagesFromChart(dep, valuesFile, tmpDir, verify, keyringPath)
+	if err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", dep.Name, err)
+	}
+
+	groups := make(map[string][]internal.ImageDiscovery)
+	var order []string
+	for _, img := range images {
+		subchart := internal.SubchartFromTemplatePath(img.Path)
+		if _, ok := groups[subchart]; !ok {
+			order = append(order, subchart)
+		}
+		groups[subchart] = append(groups[subchart], img)
+	}
+
+	manifest := &internal.DiscoveryManifest{}
+	for _, subchart := range order {
+		name := dep.Name
+		if subchart != "" {
+			name = subchart
+		}
+		manifest.Charts = append(manifest.Charts, internal.ChartDiscovery{
+			Name:       name,
+			Version:    dep.Version,
+			Repository: dep.Repository,
+			Images:     groups[subchart],
+		})
+		manifest.Images = append(manifest.Images, groups[subchart]...)
+	}
+
+	return manifest, nil
+}
+
+// pinOrLockManifestTags applies the same --lockfile contract as ScanCommand
+// to a discovery manifest: grouping its resolved images by repository, it
+// writes a fresh tags.lock.yaml when lockfilePath doesn't exist yet, or
+// verifies every locked tag is still served by the registry when it does.
+func pinOrLockManifestTags(manifest *internal.DiscoveryManifest, lockfilePath string) error {
+	if lockfilePath == "" {
+		return nil
+	}
+
+	lock, err := internal.LoadTagLock(lockfilePath)
+	if err != nil {
+		return err
+	}
+
+	resolved := make(map[string][]string)
+	var order []string
+	for _, img := range manifest.Images {
+		repo := img.Registry + "/" + img.Repository
+		if _, ok := resolved[repo]; !ok {
+			order = append(order, repo)
+		}
+		resolved[repo] = appendUnique(resolved[repo], img.Tag)
+	}
+
+	if lock != nil {
+		for _, repo := range order {
+			repository, err := name.NewRepository(repo)
+			if err != nil {
+				return fmt.Errorf("parsing repository %s: %w", repo, err)
+			}
+			liveTags, err := remote.List(repository, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+			if err != nil {
+				return fmt.Errorf("listing tags for %s: %w", repo, err)
+			}
+			locked := lock.Find(repo)
+			if locked == nil {
+				return fmt.Errorf("%w: %s", errImageNotInLockfile, repo)
+			}
+			if err := internal.VerifyLockedTags(repo, locked, liveTags); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	newLock := &internal.TagLockFile{}
+	for _, repo := range order {
+		newLock.Set(repo, resolved[repo])
+	}
+	if err := internal.SaveTagLock(lockfilePath, newLock); err != nil {
+		return fmt.Errorf("writing lockfile: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Lockfile written → %s\n", lockfilePath)
+	return nil
+}
+
+func appendUnique(tags []string, tag string) []string {
+	for _, t := range tags {
+		if t == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
 // parseOverridesFromFile loads image tag overrides from the images file, if present.
 func parseOverridesFromFile(imagesFile string) ([]internal.ImageOverride, error) {
 	if imagesFile == "" {
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal/discovery"
+)
+
+// DiscoverConfigCommand runs discovery/internal/discovery's copa-config.yaml
+// path (charts + standalone images, mirrors, include/exclude filters) —
+// distinct from DiscoverCommand, which drives the values.yaml/Chart.yaml
+// path in package internal.
+var DiscoverConfigCommand = &cli.Command{
+	Name:  "discover-config",
+	Usage: "Discover images from a copa-config.yaml, applying mirrors and include/exclude filters",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Required: true,
+			Usage:    "path to copa-config.yaml",
+		},
+		&cli.StringFlag{
+			Name:  "registry",
+			Usage: "target registry override",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run-filters",
+			Usage: "print the include/exclude decision for every candidate image and exit, without emitting the final image list",
+		},
+	},
+	Action: runDiscoverConfig,
+}
+
+func runDiscoverConfig(c *cli.Context) error {
+	configPath := c.String("config")
+	registry := c.String("registry")
+	dryRunFilters := c.Bool("dry-run-filters")
+
+	cfg, err := discovery.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	if dryRunFilters {
+		decisions, err := discovery.AuditFilters(cfg, registry, nil)
+		if err != nil {
+			return err
+		}
+		for _, d := range decisions {
+			data, err := json.Marshal(d)
+			if err != nil {
+				return fmt.Errorf("marshaling filter decision: %w", err)
+			}
+			fmt.Println(string(data))
+		}
+		return nil
+	}
+
+	images, err := discovery.Discover(cfg, registry, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Discovery complete: %d image(s)\n", len(images))
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -25,7 +26,7 @@ func TestDiscoverCommand_ParseImages(t *testing.T) {
 	}
 
 	// Parse the images
-	images, err := internal.ParseImagesFile(valuesPath)
+	images, err := internal.ParseImagesFile(context.Background(), valuesPath)
 	if err != nil {
 		t.Fatalf("failed to parse images: %v", err)
 	}
@@ -112,7 +113,7 @@ prometheus:
 		t.Fatalf("failed to create test values.yaml: %v", err)
 	}
 
-	images, err := internal.ParseImagesFile(valuesPath)
+	images, err := internal.ParseImagesFile(context.Background(), valuesPath)
 	if err != nil {
 		t.Fatalf("failed to parse images: %v", err)
 	}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal/discovery"
+)
+
+// ExplainCommand reports every place a given image reference was
+// discovered from a copa-config.yaml, mirroring DiscoverConfigCommand's
+// config path but for a single image rather than the full list.
+var ExplainCommand = &cli.Command{
+	Name:  "explain",
+	Usage: "Report why an image was discovered (--why) from a copa-config.yaml",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Required: true,
+			Usage:    "path to copa-config.yaml",
+		},
+		&cli.StringFlag{
+			Name:     "why",
+			Required: true,
+			Usage:    "image reference to explain",
+		},
+	},
+	Action: runExplain,
+}
+
+func runExplain(c *cli.Context) error {
+	configPath := c.String("config")
+	imageRef := c.String("why")
+
+	cfg, err := discovery.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	origins, err := discovery.Explain(cfg, nil, imageRef)
+	if err != nil {
+		return err
+	}
+
+	if len(origins) == 0 {
+		fmt.Printf("%s was not discovered by any image or chart in %s\n", imageRef, configPath)
+		return nil
+	}
+
+	for _, origin := range origins {
+		data, err := json.Marshal(origin)
+		if err != nil {
+			return fmt.Errorf("marshaling origin: %w", err)
+		}
+		fmt.Println(string(data))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// GateCommand evaluates a severity gate against a previously generated
+// catalog.json, without re-scanning anything — the same data GenerateSiteData
+// already wrote, or the -site-data output of a prior `verity assemble` run.
+var GateCommand = &cli.Command{
+	Name:  "gate",
+	Usage: "Fail CI if a catalog.json has unpatched vulns at or above a severity threshold",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "catalog",
+			Aliases:  []string{"c"},
+			Required: true,
+			Usage:    "path to catalog.json (as written by GenerateSiteData/-site-data)",
+		},
+		&cli.StringFlag{
+			Name:     "threshold",
+			Required: true,
+			Usage:    "minimum severity that counts as an offense: LOW, MEDIUM, HIGH, CRITICAL",
+		},
+		&cli.IntFlag{
+			Name:  "min-count",
+			Value: 1,
+			Usage: "number of qualifying vulns required to breach the gate",
+		},
+		&cli.StringFlag{
+			Name:  "allowlist",
+			Usage: "path to a YAML allowlist of exempted CVEs (see internal.LoadAllowlist)",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "table",
+			Usage: "output format: table (human-readable summary) or json (machine-readable diff)",
+		},
+	},
+	Action: runGate,
+}
+
+func runGate(c *cli.Context) error {
+	catalogPath := c.String("catalog")
+	format := c.String("format")
+
+	raw, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", catalogPath, err)
+	}
+	var data internal.SiteData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("parsing %s: %w", catalogPath, err)
+	}
+
+	opts := internal.SeverityGateOptions{
+		Threshold: c.String("threshold"),
+		MinCount:  c.Int("min-count"),
+	}
+	if allowlistPath := c.String("allowlist"); allowlistPath != "" {
+		allowlist, err := internal.LoadAllowlist(allowlistPath)
+		if err != nil {
+			return err
+		}
+		opts.Allowlist = allowlist
+	}
+
+	result, err := internal.EvaluateSeverityGate(data, opts)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	case "table":
+		fmt.Print(internal.FormatGateSummary(result))
+	default:
+		return fmt.Errorf("unsupported --format value %q", format)
+	}
+
+	if result.Breached {
+		return fmt.Errorf("%w: %d vuln(s) at or above %s", errGateBreached, result.Count, result.Threshold)
+	}
+	return nil
+}
+
+var errGateBreached = errors.New("severity gate breached")
@@ -31,12 +31,12 @@ func runList(c *cli.Context) error {
 		return err
 	}
 
-	images, err := internal.ParseImagesFile(imagesFile)
+	images, err := internal.ParseImagesFile(c.Context(), imagesFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse %s: %w", imagesFile, err)
 	}
 
-	images = internal.ApplyOverrides(images, overrides)
+	images = internal.ApplyOverrides(c.Context(), images, overrides)
 
 	fmt.Printf("Images from %s:\n", imagesFile)
 	for _, img := range images {
@@ -38,16 +38,52 @@ var PatchCommand = &cli.Command{
 			Required: true,
 			Usage:    "directory to write patch result JSON",
 		},
+		&cli.StringFlag{
+			Name:  "scanner",
+			Usage: "Scanner backend to use: trivy-exec (default, shells out to the trivy CLI), trivy (native library), grype, or clair",
+			Value: "trivy-exec",
+		},
+		&cli.StringFlag{
+			Name:  "docker-host",
+			Usage: "Docker host to scan local socket images, passed through to the selected scanner",
+		},
+		&cli.StringFlag{
+			Name:  "scanner-config",
+			Usage: "path to a scanners.yaml with per-backend settings (e.g. Clair indexer/matcher addresses); CLI flags override it",
+		},
+		&cli.StringFlag{
+			Name:  "platform",
+			Usage: "single platform to patch (e.g. linux/amd64), for an image discovered with more than one (see MatrixEntry.Platform); leave unset for an ordinary single-platform image",
+		},
+		&cli.StringSliceFlag{
+			Name:  "mirror",
+			Usage: "additional ref(s) to retry against, in order, if -image fails with a transient registry error (see MatrixEntry.Mirrors); repeatable",
+		},
+		&cli.StringFlag{
+			Name:  "source-layout",
+			Usage: "read -image from a local OCI image layout or archive instead of a live registry (see PatchOptions.SourceLayout), e.g. oci:/mnt/bundle or oci-archive:/mnt/bundle.tar",
+		},
+		&cli.StringFlag{
+			Name:  "dest-layout",
+			Usage: "write the patched image to a local OCI image layout directory instead of pushing to -registry (see PatchOptions.DestLayout)",
+		},
 	},
 	Action: runPatch,
 }
 
 func runPatch(c *cli.Context) error {
 	imageRef := c.String("image")
+	platform := c.String("platform")
+	mirrors := c.StringSlice("mirror")
 	registry := c.String("registry")
 	buildkitAddr := c.String("buildkit-addr")
 	reportDir := c.String("report-dir")
 	resultDir := c.String("result-dir")
+	scannerName := c.String("scanner")
+	dockerHost := c.String("docker-host")
+	scannerConfigPath := c.String("scanner-config")
+	sourceLayout := c.String("source-layout")
+	destLayout := c.String("dest-layout")
 
 	tmpDir, err := os.MkdirTemp("", "verity-patch-")
 	if err != nil {
@@ -65,15 +101,20 @@ func runPatch(c *cli.Context) error {
 	}
 
 	opts := internal.PatchOptions{
-		TargetRegistry: registry,
-		BuildKitAddr:   buildkitAddr,
-		ReportDir:      rDir,
-		WorkDir:        tmpDir,
+		TargetRegistry:    registry,
+		BuildKitAddr:      buildkitAddr,
+		ReportDir:         rDir,
+		WorkDir:           tmpDir,
+		Scanner:           scannerName,
+		DockerHost:        dockerHost,
+		ScannerConfigPath: scannerConfigPath,
+		SourceLayout:      sourceLayout,
+		DestLayout:        destLayout,
 	}
 
 	fmt.Printf("Patching %s ...\n", imageRef)
 	ctx := context.Background()
-	if err := internal.PatchSingleImage(ctx, imageRef, opts, resultDir); err != nil {
+	if err := internal.PatchSingleImage(ctx, imageRef, platform, opts, resultDir, mirrors); err != nil {
 		return fmt.Errorf("patch failed: %w", err)
 	}
 	fmt.Println("Done.")
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// PatchAllCommand patches every image in a discovery matrix against a
+// single shared BuildKit session, fanning builds out across
+// --buildkit-workers goroutines instead of launching one copa process (and
+// BuildKit session) per image the way running PatchCommand once per matrix
+// entry would.
+var PatchAllCommand = &cli.Command{
+	Name:  "patch-all",
+	Usage: "Patch every image in a discovery matrix against one shared BuildKit session",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "matrix",
+			Required: true,
+			Usage:    "path to discover's matrix.json",
+		},
+		&cli.StringFlag{
+			Name:  "registry",
+			Usage: "target registry for patched images",
+		},
+		&cli.StringFlag{
+			Name:  "buildkit-addr",
+			Usage: "BuildKit address shared by all workers (e.g. docker-container://buildkitd)",
+		},
+		&cli.IntFlag{
+			Name:  "buildkit-workers",
+			Value: 4,
+			Usage: "number of images to build concurrently against the shared BuildKit session",
+		},
+		&cli.StringFlag{
+			Name:  "report-dir",
+			Value: "reports",
+			Usage: "directory to store Trivy JSON reports",
+		},
+		&cli.StringFlag{
+			Name:     "result-dir",
+			Required: true,
+			Usage:    "directory to write per-image patch result JSON",
+		},
+		&cli.StringFlag{
+			Name:  "scanner",
+			Value: "trivy-exec",
+			Usage: "Scanner backend to use: trivy-exec (default, shells out to the trivy CLI), trivy (native library), grype, or clair",
+		},
+		&cli.StringFlag{
+			Name:  "docker-host",
+			Usage: "Docker host to scan local socket images, passed through to the selected scanner",
+		},
+		&cli.StringFlag{
+			Name:  "scanner-config",
+			Usage: "path to a scanners.yaml with per-backend settings (e.g. Clair indexer/matcher addresses); CLI flags override it",
+		},
+		&cli.StringFlag{
+			Name:  "source-layout",
+			Usage: "read every matrix entry's image from a local OCI image layout or archive instead of a live registry (see PatchOptions.SourceLayout), e.g. oci:/mnt/bundle or oci-archive:/mnt/bundle.tar",
+		},
+		&cli.StringFlag{
+			Name:  "dest-layout",
+			Usage: "write every patched image to a local OCI image layout directory instead of pushing to -registry (see PatchOptions.DestLayout)",
+		},
+	},
+	Action: runPatchAll,
+}
+
+func runPatchAll(c *cli.Context) error {
+	matrixPath := c.String("matrix")
+	registry := c.String("registry")
+	buildkitAddr := c.String("buildkit-addr")
+	workers := c.Int("buildkit-workers")
+	reportDir := c.String("report-dir")
+	resultDir := c.String("result-dir")
+	scannerName := c.String("scanner")
+	dockerHost := c.String("docker-host")
+	scannerConfigPath := c.String("scanner-config")
+	sourceLayout := c.String("source-layout")
+	destLayout := c.String("dest-layout")
+
+	data, err := os.ReadFile(matrixPath)
+	if err != nil {
+		return fmt.Errorf("reading matrix %s: %w", matrixPath, err)
+	}
+	var matrix internal.MatrixOutput
+	if err := json.Unmarshal(data, &matrix); err != nil {
+		return fmt.Errorf("parsing matrix %s: %w", matrixPath, err)
+	}
+
+	ctx := context.Background()
+	session, err := internal.NewBuildKitSession(ctx, buildkitAddr)
+	if err != nil {
+		return fmt.Errorf("connecting to BuildKit: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	fmt.Fprintf(os.Stderr, "Patching %d images against one BuildKit session (workers: %d, scanner: %s)...\n",
+		len(matrix.Include), workers, scannerName)
+
+	// Fan out across one shared BuildKit session rather than dialing a new
+	// one per image: the semaphore/WaitGroup/errChan shape mirrors
+	// ScanCommand's parallel scan loop above, just bounded by
+	// --buildkit-workers instead of --parallel.
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, workers)
+	errChan := make(chan error, len(matrix.Include))
+
+	for _, entry := range matrix.Include {
+		wg.Add(1)
+		go func(e internal.MatrixEntry) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			if err := patchOneAgainstSession(ctx, e.ImageRef, e.Platform, e.Mirrors, session, registry, reportDir, resultDir, scannerName, dockerHost, scannerConfigPath, sourceLayout, destLayout); err != nil {
+				errChan <- fmt.Errorf("%s: %w", e.ImageRef, err)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "✓ %s\n", e.ImageRef)
+		}(entry)
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	var patchErrors []error
+	for err := range errChan {
+		patchErrors = append(patchErrors, err)
+	}
+	if len(patchErrors) > 0 {
+		fmt.Fprintf(os.Stderr, "\nWarnings (%d patches failed):\n", len(patchErrors))
+		for _, err := range patchErrors {
+			fmt.Fprintf(os.Stderr, "  - %v\n", err)
+		}
+	}
+
+	successCount := len(matrix.Include) - len(patchErrors)
+	fmt.Fprintf(os.Stderr, "\nPatch complete: %d/%d successful\n", successCount, len(matrix.Include))
+	fmt.Fprintf(os.Stderr, "Results saved to: %s\n", resultDir)
+
+	return nil
+}
+
+// patchOneAgainstSession patches a single matrix entry, giving it its own
+// temp work dir (for the OCI layout pull) but the run's shared
+// BuildKitSession.
+func patchOneAgainstSession(ctx context.Context, imageRef, platform string, mirrors []string, session *internal.BuildKitSession, registry, reportDir, resultDir, scannerName, dockerHost, scannerConfigPath, sourceLayout, destLayout string) error {
+	tmpDir, err := os.MkdirTemp("", "verity-patch-")
+	if err != nil {
+		return fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	opts := internal.PatchOptions{
+		TargetRegistry:    registry,
+		BuildKit:          session,
+		ReportDir:         reportDir,
+		WorkDir:           tmpDir,
+		Scanner:           scannerName,
+		DockerHost:        dockerHost,
+		ScannerConfigPath: scannerConfigPath,
+		SourceLayout:      sourceLayout,
+		DestLayout:        destLayout,
+	}
+
+	return internal.PatchSingleImage(ctx, imageRef, platform, opts, resultDir, mirrors)
+}
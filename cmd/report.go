@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal/report"
+)
+
+// ReportCommand renders a self-contained HTML/Markdown vulnerability report
+// site directly from Trivy JSON, without requiring the full catalog site.
+var ReportCommand = &cli.Command{
+	Name:  "report",
+	Usage: "Render a standalone HTML/Markdown vulnerability report from Trivy JSON",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "reports-dir",
+			Required: true,
+			Usage:    "directory containing pre-patch Trivy vulnerability reports",
+		},
+		&cli.StringFlag{
+			Name:  "post-reports-dir",
+			Usage: "directory containing post-patch Trivy vulnerability reports (for before/after comparison)",
+		},
+		&cli.StringFlag{
+			Name:     "output-dir",
+			Aliases:  []string{"o"},
+			Required: true,
+			Usage:    "output directory for the rendered report site",
+		},
+		&cli.StringFlag{
+			Name:  "template-dir",
+			Usage: "directory of custom index.html.tmpl/tag.html.tmpl overriding the default html theme",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "html",
+			Usage: "comma-separated output formats to produce: html, md",
+		},
+	},
+	Subcommands: []*cli.Command{
+		ReportServeCommand,
+	},
+	Action: runReport,
+}
+
+func runReport(c *cli.Context) error {
+	var formats []report.Format
+	for _, f := range strings.Split(c.String("format"), ",") {
+		switch strings.TrimSpace(f) {
+		case "html":
+			formats = append(formats, report.FormatHTML)
+		case "md":
+			formats = append(formats, report.FormatMD)
+		default:
+			return fmt.Errorf("unsupported --format value %q", f)
+		}
+	}
+
+	outputDir := c.String("output-dir")
+	if err := report.GenerateReports(c.String("reports-dir"), c.String("post-reports-dir"), outputDir, c.String("template-dir"), formats); err != nil {
+		return fmt.Errorf("failed to generate vulnerability reports: %w", err)
+	}
+	fmt.Printf("Vulnerability reports → %s\n", outputDir)
+	return nil
+}
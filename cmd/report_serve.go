@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+	"github.com/verity-org/verity/internal/report"
+)
+
+// ReportServeCommand starts an embedded HTTP dashboard over a reports-dir of
+// Trivy JSON plus a result-dir of SinglePatchResult JSON (as written by
+// PatchSingleImage/patch-all), rather than rendering a static site to disk.
+var ReportServeCommand = &cli.Command{
+	Name:  "serve",
+	Usage: "Serve an interactive vulnerability report dashboard over HTTP",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "reports-dir",
+			Required: true,
+			Usage:    "directory containing pre-patch Trivy vulnerability reports",
+		},
+		&cli.StringFlag{
+			Name:  "post-reports-dir",
+			Usage: "directory containing post-patch Trivy vulnerability reports (for before/after diffs)",
+		},
+		&cli.StringFlag{
+			Name:  "result-dir",
+			Usage: "directory of per-image SinglePatchResult JSON (as written by patch-all), for skip/error status",
+		},
+		&cli.StringFlag{
+			Name:  "addr",
+			Value: ":8090",
+			Usage: "address to serve the dashboard on",
+		},
+	},
+	Action: runReportServe,
+}
+
+func runReportServe(c *cli.Context) error {
+	reportsDir := c.String("reports-dir")
+	postReportsDir := c.String("post-reports-dir")
+	resultDir := c.String("result-dir")
+	addr := c.String("addr")
+
+	statuses, err := loadImageStatuses(resultDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving vulnerability report dashboard on %s\n", addr)
+	return report.ServeReports(reportsDir, postReportsDir, statuses, addr)
+}
+
+// loadImageStatuses reads every SinglePatchResult JSON in resultDir (as
+// written by internal.PatchSingleImage) and converts it into the
+// report.ImageStatus the dashboard needs. Returns nil (not an error) when
+// resultDir is empty, so the dashboard still works from Trivy reports alone.
+func loadImageStatuses(resultDir string) ([]report.ImageStatus, error) {
+	if resultDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(resultDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading result dir: %w", err)
+	}
+
+	var statuses []report.ImageStatus
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(resultDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", e.Name(), err)
+		}
+		var result internal.SinglePatchResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", e.Name(), err)
+		}
+		statuses = append(statuses, report.ImageStatus{
+			Reference:  result.ImageRef,
+			Skipped:    result.Skipped,
+			SkipReason: result.SkipReason,
+			Error:      result.Error,
+			VulnCount:  result.VulnCount,
+		})
+	}
+	return statuses, nil
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -19,6 +18,9 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/urfave/cli/v2"
 	"gopkg.in/yaml.v3"
+
+	"github.com/verity-org/verity/internal"
+	"github.com/verity-org/verity/internal/scanner"
 )
 
 // CopaConfig represents the copa-config.yaml structure.
@@ -43,11 +45,14 @@ type TargetSpec struct {
 }
 
 type TagStrategy struct {
-	Strategy string   `yaml:"strategy"`
-	Pattern  string   `yaml:"pattern,omitempty"`
-	MaxTags  int      `yaml:"maxTags,omitempty"`
-	List     []string `yaml:"list,omitempty"`
-	Exclude  []string `yaml:"exclude,omitempty"`
+	Strategy   string   `yaml:"strategy"`
+	Pattern    string   `yaml:"pattern,omitempty"`
+	MaxTags    int      `yaml:"maxTags,omitempty"`
+	List       []string `yaml:"list,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty"`
+	Constraint string   `yaml:"constraint,omitempty"`
+	Prerelease bool     `yaml:"prerelease,omitempty"`
+	Channel    string   `yaml:"channel,omitempty"`
 }
 
 // ScanCommand generates Trivy vulnerability reports for all images in copa-config.yaml.
@@ -84,6 +89,23 @@ var ScanCommand = &cli.Command{
 			Name:  "patched-only",
 			Usage: "Scan only patched images in the target registry (skip source images). Requires --target-registry.",
 		},
+		&cli.StringFlag{
+			Name:  "scanner",
+			Usage: "Scanner backend to use: trivy-exec (default, shells out to the trivy CLI), trivy (native library), grype, or clair",
+			Value: "trivy-exec",
+		},
+		&cli.StringFlag{
+			Name:  "docker-host",
+			Usage: "Docker host to scan local socket images (e.g. unix:///var/run/docker.sock), passed through to the selected scanner",
+		},
+		&cli.StringFlag{
+			Name:  "scanner-config",
+			Usage: "path to a scanners.yaml with per-backend settings (e.g. Clair indexer/matcher addresses); CLI flags override it",
+		},
+		&cli.StringFlag{
+			Name:  "lockfile",
+			Usage: "path to a tags.lock.yaml; written on first run, then pins discovery to its tag sets on subsequent runs (e.g. .verity/tags.lock.yaml)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		configPath := c.String("config")
@@ -92,6 +114,10 @@ var ScanCommand = &cli.Command{
 		targetRegistry := c.String("target-registry")
 		trivyServer := c.String("trivy-server")
 		patchedOnly := c.Bool("patched-only")
+		scannerName := c.String("scanner")
+		dockerHost := c.String("docker-host")
+		scannerConfigPath := c.String("scanner-config")
+		lockfilePath := c.String("lockfile")
 
 		if patchedOnly && targetRegistry == "" {
 			return errPatchedOnlyNeedsTarget
@@ -113,6 +139,12 @@ var ScanCommand = &cli.Command{
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
+		lock, err := internal.LoadTagLock(lockfilePath)
+		if err != nil {
+			return err
+		}
+		newLock := &internal.TagLockFile{}
+
 		// Discover all image:tag combinations
 		type scanJob struct {
 			name       string
@@ -123,10 +155,19 @@ var ScanCommand = &cli.Command{
 
 		var jobs []scanJob
 		for _, imageSpec := range config.Images {
-			tags, err := findTagsToPatch(&imageSpec)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to discover tags for '%s': %v\n", imageSpec.Name, err)
-				continue
+			var tags []string
+			if lock != nil {
+				tags, err = pinTagsFromLock(lock, &imageSpec)
+				if err != nil {
+					return err
+				}
+			} else {
+				tags, err = findTagsToPatch(&imageSpec)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to discover tags for '%s': %v\n", imageSpec.Name, err)
+					continue
+				}
+				newLock.Set(imageSpec.Image, tags)
 			}
 
 			for _, tag := range tags {
@@ -156,9 +197,27 @@ var ScanCommand = &cli.Command{
 			}
 		}
 
-		fmt.Fprintf(os.Stderr, "Scanning %d images in parallel (concurrency: %d)...\n", len(jobs), parallel)
+		if lock == nil && lockfilePath != "" {
+			if err := internal.SaveTagLock(lockfilePath, newLock); err != nil {
+				return fmt.Errorf("writing lockfile: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Lockfile written → %s\n", lockfilePath)
+		}
+
+		scannerCfg, err := scanner.LoadConfig(scannerConfigPath)
+		if err != nil {
+			return err
+		}
+		scannerOpts := scannerCfg.OptionsFor(scannerName, scanner.Options{DockerHost: dockerHost, ServerAddr: trivyServer})
+		sc, err := scanner.New(scannerName, scannerOpts)
+		if err != nil {
+			return fmt.Errorf("configuring scanner: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Scanning %d images in parallel (concurrency: %d, scanner: %s)...\n", len(jobs), parallel, sc.Name())
 
-		// Scan images in parallel
+		// Scan images in parallel. A single Scanner instance is shared across
+		// all workers so in-process backends (e.g. the native Trivy library)
+		// reuse one vuln DB handle instead of paying a per-image load cost.
 		var wg sync.WaitGroup
 		semaphore := make(chan struct{}, parallel)
 		errChan := make(chan error, len(jobs))
@@ -170,7 +229,7 @@ var ScanCommand = &cli.Command{
 				semaphore <- struct{}{}        // Acquire
 				defer func() { <-semaphore }() // Release
 
-				if err := scanImage(j.imageRef, j.outputFile, j.isPatched, trivyServer); err != nil {
+				if err := scanImage(sc, j.imageRef, j.outputFile, j.isPatched); err != nil {
 					errChan <- fmt.Errorf("%s: %w", j.imageRef, err)
 				} else {
 					fmt.Fprintf(os.Stderr, "✓ %s\n", j.imageRef)
@@ -202,30 +261,10 @@ var ScanCommand = &cli.Command{
 	},
 }
 
-func scanImage(imageRef, outputFile string, isPatched bool, trivyServer string) error {
+func scanImage(sc scanner.Scanner, imageRef, outputFile string, isPatched bool) error {
 	ctx := context.Background()
-	var cmd *exec.Cmd
-
-	if trivyServer != "" {
-		// Use Trivy server mode (client pulls image, uses server's DB)
-		cmd = exec.CommandContext(ctx, "trivy", "image",
-			"--server", trivyServer,
-			"--vuln-type", "os,library",
-			"--format", "json",
-			"--quiet",
-			imageRef,
-		)
-	} else {
-		// Use Trivy standalone mode (direct DB access)
-		cmd = exec.CommandContext(ctx, "trivy", "image",
-			"--vuln-type", "os,library",
-			"--format", "json",
-			"--quiet",
-			imageRef,
-		)
-	}
 
-	output, err := cmd.CombinedOutput()
+	report, err := sc.Scan(ctx, imageRef)
 	if err != nil {
 		if isPatched {
 			// Patched image might not exist yet, create empty report
@@ -239,10 +278,14 @@ func scanImage(imageRef, outputFile string, isPatched bool, trivyServer string)
 			}
 			return os.WriteFile(outputFile, data, 0o644)
 		}
-		return fmt.Errorf("trivy scan failed: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("%s scan failed: %w", sc.Name(), err)
 	}
 
-	return os.WriteFile(outputFile, output, 0o644)
+	data, err := report.MarshalTrivyCompat()
+	if err != nil {
+		return fmt.Errorf("marshaling report: %w", err)
+	}
+	return os.WriteFile(outputFile, data, 0o644)
 }
 
 func sanitizeFilename(filename string) string {
@@ -255,6 +298,7 @@ func sanitizeFilename(filename string) string {
 var (
 	errUnknownStrategy        = errors.New("unknown tag strategy")
 	errPatchedOnlyNeedsTarget = errors.New("--patched-only requires --target-registry to be set")
+	errImageNotInLockfile     = errors.New("image not present in lockfile")
 )
 
 // findTagsToPatch discovers tags for an image (reused from discover logic).
@@ -271,6 +315,8 @@ func findTagsToPatch(spec *ImageSpec) ([]string, error) {
 		return findTagsByPattern(repo, spec)
 	case "latest":
 		return findTagsByLatest(repo, spec)
+	case "constraint":
+		return findTagsByConstraint(repo, spec)
 	default:
 		return nil, fmt.Errorf("%w: %s", errUnknownStrategy, spec.Tags.Strategy)
 	}
@@ -343,6 +389,96 @@ func findTagsByPattern(repo name.Repository, spec *ImageSpec) ([]string, error)
 	return result, nil
 }
 
+// findTagsByConstraint lists every tag matching a Masterminds/semver/v3
+// constraint such as ">=1.29.3, <1.30.0", so users can track a version
+// range instead of a brittle regex. Channel, when set, additionally
+// restricts candidates to tags matching that regex first (e.g. "-ubuntu$"
+// to select OS-variant tags before they're parsed as versions).
+func findTagsByConstraint(repo name.Repository, spec *ImageSpec) ([]string, error) {
+	constraint, err := semver.NewConstraint(spec.Tags.Constraint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing constraint %q: %w", spec.Tags.Constraint, err)
+	}
+
+	allTags, err := remote.List(repo, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, err
+	}
+	candidates := allTags
+
+	if spec.Tags.Channel != "" {
+		channel, err := regexp.Compile(spec.Tags.Channel)
+		if err != nil {
+			return nil, fmt.Errorf("parsing channel %q: %w", spec.Tags.Channel, err)
+		}
+		filtered := candidates[:0:0]
+		for _, tag := range candidates {
+			if channel.MatchString(tag) {
+				filtered = append(filtered, tag)
+			}
+		}
+		candidates = filtered
+	}
+
+	candidates = excludeTags(candidates, spec.Tags.Exclude)
+
+	versions := []*semver.Version{}
+	for _, t := range candidates {
+		v, err := semver.NewVersion(t)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() != "" && !spec.Tags.Prerelease {
+			continue
+		}
+		if !constraint.Check(v) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	if len(versions) == 0 {
+		return []string{}, nil
+	}
+
+	sort.Sort(semver.Collection(versions))
+
+	if spec.Tags.MaxTags > 0 && len(versions) > spec.Tags.MaxTags {
+		versions = versions[len(versions)-spec.Tags.MaxTags:]
+	}
+
+	result := make([]string, len(versions))
+	for i, v := range versions {
+		result[i] = v.Original()
+	}
+	return result, nil
+}
+
+// pinTagsFromLock returns the locked tag set for spec, verifying against a
+// fresh registry listing that every locked tag is still served. It fails
+// loudly (rather than silently shrinking the matrix) both when the image
+// isn't present in the lockfile and when a locked tag has disappeared.
+func pinTagsFromLock(lock *internal.TagLockFile, spec *ImageSpec) ([]string, error) {
+	locked := lock.Find(spec.Image)
+	if locked == nil {
+		return nil, fmt.Errorf("%w: %s", errImageNotInLockfile, spec.Image)
+	}
+
+	repo, err := name.NewRepository(spec.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository: %w", err)
+	}
+	liveTags, err := remote.List(repo, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %w", spec.Image, err)
+	}
+	if err := internal.VerifyLockedTags(spec.Image, locked, liveTags); err != nil {
+		return nil, err
+	}
+
+	return locked, nil
+}
+
 func excludeTags(tags, exclusions []string) []string {
 	if len(exclusions) == 0 {
 		return tags
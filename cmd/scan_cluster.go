@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// ScanClusterCommand discovers images running in a live Kubernetes cluster
+// (via internal.ClusterSource) and merges them into a values.yaml images
+// file using the same MergeChartImages path DiscoverImages uses for
+// chart-discovered images — so images this Pod scan finds (a sidecar an
+// admission controller injected, an image override applied at install
+// time) merge cleanly alongside chart-discovered ones rather than needing
+// a separate file. Lets an operator bootstrap Verity's patch matrix from a
+// running cluster instead of hand-curating values.yaml.
+var ScanClusterCommand = &cli.Command{
+	Name:  "scan-cluster",
+	Usage: "Discover images from live Pods in a Kubernetes cluster and merge them into values.yaml",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "kubeconfig",
+			Usage: "path to a kubeconfig; empty uses the in-cluster config",
+		},
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "kubeconfig context to use; empty uses its current-context",
+		},
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "restrict discovery to one namespace; empty scans every namespace the caller can read",
+		},
+		&cli.StringFlag{
+			Name:  "selector",
+			Usage: "restrict discovery to Pods matching this label selector (e.g. app.kubernetes.io/managed-by=Helm)",
+		},
+		&cli.StringFlag{
+			Name:    "images",
+			Aliases: []string{"i"},
+			Value:   "values.yaml",
+			Usage:   "images values.yaml to merge discovered images into",
+		},
+	},
+	Action: runScanCluster,
+}
+
+func runScanCluster(c *cli.Context) error {
+	source := &internal.ClusterSource{
+		Kubeconfig:    c.String("kubeconfig"),
+		Context:       c.String("context"),
+		Namespace:     c.String("namespace"),
+		LabelSelector: c.String("selector"),
+	}
+
+	discovered, err := source.Discover(c.Context())
+	if err != nil {
+		return fmt.Errorf("cluster discovery failed: %w", err)
+	}
+
+	images := make([]internal.Image, len(discovered))
+	for i, d := range discovered {
+		images[i] = internal.Image(d)
+	}
+
+	fmt.Printf("Cluster discovery: %d image(s) found across live Pods\n", len(images))
+	for _, img := range images {
+		fmt.Printf("  %s  (%s)\n", img.Reference(), img.Path)
+	}
+
+	imagesFile := c.String("images")
+	if err := internal.MergeChartImages(imagesFile, images); err != nil {
+		return fmt.Errorf("merging cluster images into %s: %w", imagesFile, err)
+	}
+	fmt.Printf("Merged into %s\n", imagesFile)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal/attest"
+)
+
+// SignCommand signs a patched image with cosign, replacing the external
+// sign-and-attest script's signing step.
+var SignCommand = &cli.Command{
+	Name:  "sign",
+	Usage: "Sign a patched image with cosign (keyless by default)",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "image",
+			Required: true,
+			Usage:    "patched image reference to sign (e.g. ghcr.io/verity-org/prometheus:v3.9.1-patched)",
+		},
+		&cli.StringFlag{
+			Name:  "key",
+			Usage: "path to a cosign private key; omit for keyless (Fulcio/OIDC) signing",
+		},
+	},
+	Action: runSign,
+}
+
+func runSign(c *cli.Context) error {
+	imageRef := c.String("image")
+
+	fmt.Printf("Signing %s ...\n", imageRef)
+	result, err := attest.SignImage(context.Background(), imageRef, attest.SignOptions{Key: c.String("key")})
+	if err != nil {
+		return fmt.Errorf("signing failed: %w", err)
+	}
+	fmt.Printf("Signature → %s\n", result.SignatureRef)
+	return nil
+}
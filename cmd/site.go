@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// SiteCommand renders a previously generated catalog.json as a browsable
+// static HTML site (index of charts, per-chart image listings, per-image
+// CVE tables), publishable as-is to GitHub Pages.
+var SiteCommand = &cli.Command{
+	Name:  "site",
+	Usage: "Render catalog.json as a static HTML site",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "catalog",
+			Aliases:  []string{"c"},
+			Required: true,
+			Usage:    "path to catalog.json (as written by GenerateSiteData/-site-data)",
+		},
+		&cli.StringFlag{
+			Name:     "output-dir",
+			Aliases:  []string{"o"},
+			Required: true,
+			Usage:    "output directory for the rendered site",
+		},
+		&cli.StringFlag{
+			Name:  "theme",
+			Usage: "directory of custom index.html.tmpl/chart.html.tmpl/image.html.tmpl overriding the default theme",
+		},
+	},
+	Action: runSite,
+}
+
+func runSite(c *cli.Context) error {
+	catalogPath := c.String("catalog")
+
+	raw, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", catalogPath, err)
+	}
+	var data internal.SiteData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("parsing %s: %w", catalogPath, err)
+	}
+
+	outputDir := c.String("output-dir")
+	renderer := internal.SiteRenderer{ThemeDir: c.String("theme")}
+	if err := renderer.Render(data, outputDir); err != nil {
+		return fmt.Errorf("rendering site: %w", err)
+	}
+	fmt.Printf("Static site → %s\n", outputDir)
+	return nil
+}
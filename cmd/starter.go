@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+)
+
+// StarterCommand manages the starter chart trees AssembleCommand scaffolds
+// wrapper charts from (see ChartDiscovery.Starter / CopyStarter).
+var StarterCommand = &cli.Command{
+	Name:  "starter",
+	Usage: "Manage starter chart trees used to scaffold wrapper charts",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Value: internal.DefaultStartersDir(),
+			Usage: "starters directory (default $XDG_DATA_HOME/verity/starters)",
+		},
+	},
+	Subcommands: []*cli.Command{
+		StarterListCommand,
+		StarterAddCommand,
+		StarterRemoveCommand,
+	},
+}
+
+// StarterListCommand lists installed starters.
+var StarterListCommand = &cli.Command{
+	Name:   "list",
+	Usage:  "List installed starters",
+	Action: runStarterList,
+}
+
+func runStarterList(c *cli.Context) error {
+	dir := c.String("dir")
+	names, err := internal.ListStarters(dir)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Printf("No starters installed in %s\n", dir)
+		return nil
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// StarterAddCommand installs a starter chart tree under a name.
+var StarterAddCommand = &cli.Command{
+	Name:      "add",
+	Usage:     "Install a starter chart tree under a name",
+	ArgsUsage: "<name> <path>",
+	Action:    runStarterAdd,
+}
+
+func runStarterAdd(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("usage: verity starter add <name> <path>")
+	}
+	name := c.Args().Get(0)
+	srcPath := c.Args().Get(1)
+	dir := c.String("dir")
+
+	if err := internal.AddStarter(dir, name, srcPath); err != nil {
+		return err
+	}
+	fmt.Printf("Installed starter %q → %s\n", name, dir)
+	return nil
+}
+
+// StarterRemoveCommand removes an installed starter.
+var StarterRemoveCommand = &cli.Command{
+	Name:      "remove",
+	Usage:     "Remove an installed starter",
+	ArgsUsage: "<name>",
+	Action:    runStarterRemove,
+}
+
+func runStarterRemove(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return fmt.Errorf("usage: verity starter remove <name>")
+	}
+	name := c.Args().Get(0)
+	dir := c.String("dir")
+
+	if err := internal.RemoveStarter(dir, name); err != nil {
+		return err
+	}
+	fmt.Printf("Removed starter %q from %s\n", name, dir)
+	return nil
+}
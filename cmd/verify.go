@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/internal"
+	"github.com/verity-org/verity/internal/attest"
+)
+
+var errImageOrChartRequired = errors.New("exactly one of --image or --chart is required")
+
+// VerifyCommand pulls an image's signature and attestations back from the
+// registry and validates that Verity actually scanned and rebuilt it: the
+// SBOM and vulnerability predicates must be present and correctly signed,
+// and for keyless signing, backed by a Rekor transparency log entry.
+//
+// Passing --chart instead verifies a wrapper chart archive's signature and
+// provenance attestation (see internal.VerifyWrapperChart) rather than an
+// image.
+var VerifyCommand = &cli.Command{
+	Name:  "verify",
+	Usage: "Verify a patched image's or wrapper chart's cosign signature and attestations",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "image",
+			Usage: "image reference to verify",
+		},
+		&cli.StringFlag{
+			Name:  "chart",
+			Usage: "path to a wrapper chart archive (.tgz) to verify instead of an image",
+		},
+		&cli.StringFlag{
+			Name:  "key",
+			Usage: "path to a cosign public key; omit for keyless (Fulcio/Rekor) verification (required for --chart)",
+		},
+		&cli.StringFlag{
+			Name:  "cert-identity",
+			Usage: "expected Fulcio certificate identity (e.g. CI workflow ref), for keyless image verification",
+		},
+		&cli.StringFlag{
+			Name:  "cert-oidc-issuer",
+			Usage: "expected Fulcio certificate OIDC issuer, for keyless image verification",
+		},
+	},
+	Action: runVerify,
+}
+
+func runVerify(c *cli.Context) error {
+	imageRef := c.String("image")
+	chartPath := c.String("chart")
+
+	switch {
+	case imageRef != "" && chartPath != "":
+		return errImageOrChartRequired
+	case chartPath != "":
+		return runVerifyChart(chartPath, c.String("key"))
+	case imageRef != "":
+		return runVerifyImage(imageRef, c)
+	default:
+		return errImageOrChartRequired
+	}
+}
+
+func runVerifyImage(imageRef string, c *cli.Context) error {
+	fmt.Printf("Verifying %s ...\n", imageRef)
+	result, err := attest.VerifyImage(context.Background(), imageRef, attest.VerifyOptions{
+		Key:            c.String("key"),
+		CertIdentity:   c.String("cert-identity"),
+		CertOIDCIssuer: c.String("cert-oidc-issuer"),
+	})
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	if !result.SBOMVerified {
+		return fmt.Errorf("%s: no verified SBOM attestation found", imageRef)
+	}
+	if !result.VulnVerified {
+		return fmt.Errorf("%s: no verified vulnerability attestation found", imageRef)
+	}
+
+	fmt.Printf("%s: signature OK, SBOM attested, vuln report attested", imageRef)
+	if result.RekorVerified {
+		fmt.Print(", Rekor entry verified")
+	}
+	fmt.Println()
+	return nil
+}
+
+func runVerifyChart(chartPath, key string) error {
+	fmt.Printf("Verifying %s ...\n", chartPath)
+	result, err := internal.VerifyWrapperChart(chartPath, key)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	if !result.ProvenanceVerified {
+		return fmt.Errorf("%s: no verified provenance attestation found", chartPath)
+	}
+
+	fmt.Printf("%s: signature OK, provenance attested\n", chartPath)
+	return nil
+}
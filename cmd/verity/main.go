@@ -0,0 +1,48 @@
+// Command verity wires the subcommands in github.com/verity-org/verity/cmd
+// (gate, verify, sign, attest, check, catalog, etc.) into a single runnable
+// CLI. The legacy top-level main.go predates this package and is driven by
+// its own -flag-style modes instead.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/verity-org/verity/cmd"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "verity",
+		Usage: "supply-chain patching, signing, and catalog tooling for wrapper charts",
+		Commands: []*cli.Command{
+			cmd.AssembleCommand,
+			cmd.AttestCommand,
+			cmd.AttestAllCommand,
+			cmd.CatalogCommand,
+			cmd.ChartsCommand,
+			cmd.CheckCommand,
+			cmd.DiscoverCommand,
+			cmd.DiscoverConfigCommand,
+			cmd.ExplainCommand,
+			cmd.GateCommand,
+			cmd.ListCommand,
+			cmd.PatchCommand,
+			cmd.PatchAllCommand,
+			cmd.ReportCommand,
+			cmd.ReportServeCommand,
+			cmd.ScanCommand,
+			cmd.ScanClusterCommand,
+			cmd.SignCommand,
+			cmd.SiteCommand,
+			cmd.StarterCommand,
+			cmd.VerifyCommand,
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
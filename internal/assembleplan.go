@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// dryRunNamespace is the ephemeral namespace used only to satisfy wrapper
+// chart templates that reference .Release.Namespace during a --dry-run
+// render; nothing is actually installed into a cluster. Mirrors
+// internal/discovery's renderNamespace for the same reason.
+const dryRunNamespace = "verity-assemble"
+
+// fakeKubeVersion is the Kubernetes version a --dry-run render reports to
+// chart templates that branch on .Capabilities.KubeVersion, keeping the
+// render cluster-independent the same way `helm template --kube-version`
+// does.
+const fakeKubeVersion = "v1.29.0"
+
+// AssemblePlanChart is one chart's entry in assemble-plan.json, written by
+// AssembleResults when dryRun is true for every chart that would actually
+// be published (a chart whose content-addressable version already exists
+// in registry is skipped entirely — see AssembleResults's alreadyPublished
+// check — the same as a real --publish run would skip it). It describes
+// the version it would push, the target OCI reference, and the same
+// per-image digest/CVE evidence CreateWrapperChart signs into
+// provenance.json (see LoadWrapperProvenance), without actually packaging
+// or pushing anything.
+type AssemblePlanChart struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	TargetRef    string            `json:"targetRef"`
+	RenderedPath string            `json:"renderedPath"`
+	Images       []ImageProvenance `json:"images"`
+}
+
+// renderWrapperChartDryRun packages chartDir (resolving its dependency the
+// same way PublishChart does, via action.Package's DependencyUpdate, so a
+// remote-repository dependency is fetched rather than left unresolved) and
+// runs the result through action.Install in ClientOnly/DryRun mode against
+// a fake KubeVersion, mirroring `helm template` with no cluster required.
+// Returns the concatenated rendered manifest.
+func renderWrapperChartDryRun(chartDir, releaseName string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "verity-assemble-dryrun-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp dir for dry-run package: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pkg := action.NewPackage()
+	pkg.Destination = tmpDir
+	pkg.DependencyUpdate = true
+	tgzPath, err := pkg.Run(chartDir, nil)
+	if err != nil {
+		return "", fmt.Errorf("packaging %s for dry-run render: %w", chartDir, err)
+	}
+
+	ch, err := loader.LoadFile(tgzPath)
+	if err != nil {
+		return "", fmt.Errorf("loading packaged chart %s: %w", tgzPath, err)
+	}
+
+	install := action.NewInstall(&action.Configuration{})
+	install.ClientOnly = true
+	install.DryRun = true
+	install.IncludeCRDs = true
+	install.ReleaseName = releaseName
+	install.Namespace = dryRunNamespace
+	if kubeVersion, err := chartutil.ParseKubeVersion(fakeKubeVersion); err == nil {
+		install.KubeVersion = kubeVersion
+	}
+
+	rel, err := install.Run(ch, map[string]interface{}{})
+	if err != nil {
+		return "", fmt.Errorf("templating chart %s: %w", releaseName, err)
+	}
+	return rel.Manifest, nil
+}
+
+// writeAssemblePlan marshals plan as assemble-plan.json under outputDir. A
+// no-op when plan is empty (e.g. every chart was skipped for having no
+// image changes), matching writeOrphanImagePatches's empty-skip behavior.
+func writeAssemblePlan(plan []AssemblePlanChart, outputDir string) error {
+	if len(plan) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling assemble plan: %w", err)
+	}
+	planPath := filepath.Join(outputDir, "assemble-plan.json")
+	if err := os.WriteFile(planPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing assemble plan: %w", err)
+	}
+	fmt.Printf("Assemble plan (%d chart(s)) → %s\n", len(plan), planPath)
+	return nil
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAssemblePlan(t *testing.T) {
+	dir := t.TempDir()
+	plan := []AssemblePlanChart{
+		{
+			Name:         "myapp",
+			Version:      "1.0.0-pabcd1234",
+			TargetRef:    "ghcr.io/test/charts/myapp:1.0.0-pabcd1234",
+			RenderedPath: filepath.Join(dir, "myapp", "rendered.yaml"),
+			Images: []ImageProvenance{
+				{Original: "docker.io/library/nginx:1.25", Patched: "ghcr.io/test/library/nginx:1.25-patched"},
+			},
+		},
+	}
+
+	if err := writeAssemblePlan(plan, dir); err != nil {
+		t.Fatalf("writeAssemblePlan() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "assemble-plan.json"))
+	if err != nil {
+		t.Fatalf("reading assemble-plan.json: %v", err)
+	}
+	var got []AssemblePlanChart
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshaling assemble-plan.json: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "myapp" || got[0].TargetRef != plan[0].TargetRef {
+		t.Fatalf("unexpected plan contents: %+v", got)
+	}
+}
+
+func TestWriteAssemblePlanEmptyIsNoOp(t *testing.T) {
+	dir := t.TempDir()
+	if err := writeAssemblePlan(nil, dir); err != nil {
+		t.Fatalf("writeAssemblePlan() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "assemble-plan.json")); !os.IsNotExist(err) {
+		t.Errorf("expected no assemble-plan.json for an empty plan")
+	}
+}
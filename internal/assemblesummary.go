@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// AssembleSummaryChart is one chart's entry in assemble-summary.json,
+// written by AssembleResults for every wrapper it produces — whether
+// --publish pushed it, it was skipped as already-published, or neither
+// flag was set at all — so downstream GitOps/attestation tooling has a
+// stable artifact to consume instead of re-parsing stdout or reaching
+// into published-charts.json (which only covers charts a real --publish
+// run actually pushed).
+type AssembleSummaryChart struct {
+	Name             string `json:"name"`
+	SourceRepository string `json:"sourceRepository"`
+	SourceVersion    string `json:"sourceVersion"`
+	WrapperVersion   string `json:"wrapperVersion"`
+	ValuesHash       string `json:"valuesHash"`
+	TargetRef        string `json:"targetRef"`
+
+	// ResultFiles lists the matrix-job result JSONs (see LoadResults) that
+	// contributed a PatchResult to this chart, in buildPatchResults's
+	// order.
+	ResultFiles []string `json:"resultFiles,omitempty"`
+	// ReportPaths lists the Trivy report files (see buildPatchResults'
+	// reportsDir lookup) included in this chart's evidence, in the same
+	// order as ResultFiles.
+	ReportPaths []string `json:"reportPaths,omitempty"`
+
+	// Published is true once a --publish run has actually pushed this
+	// exact wrapper version (false for a dry run, a --publish-less local
+	// build, or a version skipped as already-published).
+	Published bool `json:"published"`
+	// Digest is the OCI manifest digest TargetRef resolves to. When
+	// Published is true, it's the digest PublishResult.Digest reported for
+	// this push. When a chart was instead skipped as already-published
+	// (the default --on-conflict=bump behavior), it's the existing remote
+	// digest (see remoteChartDigest) confirming what "unchanged" refers
+	// to; best-effort, so it may be empty if the lookup itself failed.
+	Digest string `json:"digest,omitempty"`
+}
+
+// buildAssembleSummaryChart assembles ch's assemble-summary.json entry from
+// the same results buildPatchResults built for CreateWrapperChart, plus the
+// version/valuesHash/digest/publish state AssembleResults already computed
+// for ch (valuesHash is CreateWrapperChart's own contentDigest(results)
+// result, passed through rather than recomputed here so a chart's reports
+// aren't re-hashed a second time).
+func buildAssembleSummaryChart(ch ChartDiscovery, results []*PatchResult, resultsDir, version, valuesHash, targetRef string, published bool, digest string) AssembleSummaryChart {
+	summary := AssembleSummaryChart{
+		Name:             ch.Name,
+		SourceRepository: ch.Repository,
+		SourceVersion:    ch.Version,
+		WrapperVersion:   version,
+		ValuesHash:       valuesHash,
+		TargetRef:        targetRef,
+		Published:        published,
+		Digest:           digest,
+	}
+	for _, r := range results {
+		// A result with no matching matrix-job JSON (see buildPatchResults'
+		// !ok branch, SkipReasonNoPatchResult) never had a file written for
+		// it, so resultsDir/<sanitized key>.json wouldn't exist — only
+		// record results LoadResults actually found on disk.
+		if r.SkipReason == SkipReasonNoPatchResult {
+			continue
+		}
+		summary.ResultFiles = append(summary.ResultFiles, filepath.Join(resultsDir, sanitize(PlatformKey(r.Original.Reference(), r.Platform))+".json"))
+		if r.ReportPath != "" {
+			summary.ReportPaths = append(summary.ReportPaths, r.ReportPath)
+		}
+	}
+	return summary
+}
+
+// writeAssembleSummary marshals summary as summaryPath. A no-op when
+// summary is empty (e.g. every chart was skipped for having no image
+// changes), matching writeAssemblePlan's empty-skip behavior.
+func writeAssembleSummary(summary []AssembleSummaryChart, summaryPath string) error {
+	if len(summary) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(summaryPath), 0o755); err != nil {
+		return fmt.Errorf("creating summary dir: %w", err)
+	}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling assemble summary: %w", err)
+	}
+	if err := os.WriteFile(summaryPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing assemble summary: %w", err)
+	}
+	fmt.Printf("Assemble summary (%d chart(s)) → %s\n", len(summary), summaryPath)
+	return nil
+}
@@ -0,0 +1,376 @@
+// Package attest brings Verity's image signing, SBOM generation, and
+// attestation publishing in-house so the pipeline no longer depends on an
+// external "sign-and-attest" script. It signs patched image refs with
+// cosign (keyless OIDC via Fulcio, or a supplied key), generates a
+// CycloneDX SBOM with syft as a library, and attaches both the SBOM and
+// the Trivy vulnerability report to the image as in-toto attestations.
+package attest
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+
+	// Blank-imported so their init() registers the scheme with kms.Get -
+	// newSigner only knows it has a "awskms://", "azurekms://", "gcpkms://"
+	// or "hashivault://" ref, not which cloud it belongs to.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/format/cyclonedxjson"
+	"github.com/anchore/syft/syft/source"
+)
+
+// SignOptions configures how an image is signed.
+type SignOptions struct {
+	// Key is a path to a cosign private key. When empty, signing falls
+	// back to cosign's keyless flow (a Fulcio-issued certificate backed by
+	// an OIDC identity token).
+	Key string
+	// IdentityToken, when set, is an OIDC identity token to drive keyless
+	// signing non-interactively (e.g. a CI job's ambient OIDC token),
+	// instead of cosign's default interactive browser-based flow. Ignored
+	// when Key is set.
+	IdentityToken string
+}
+
+// SignResult records the outcome of signing a single image.
+type SignResult struct {
+	Reference    string `json:"reference"`
+	Digest       string `json:"digest"`
+	SignatureRef string `json:"signature_ref"`
+}
+
+// SignImage signs ref with cosign, returning the digest that was signed and
+// a reference to the pushed signature. Keyless signing is used unless
+// opts.Key is set.
+func SignImage(ctx context.Context, ref string, opts SignOptions) (*SignResult, error) {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newSigner(ctx, opts.Key, opts.IdentityToken)
+	if err != nil {
+		return nil, fmt.Errorf("configuring signer for %s: %w", ref, err)
+	}
+
+	payload, err := cosign.NewDigestPayload(digestRef)
+	if err != nil {
+		return nil, fmt.Errorf("building signature payload for %s: %w", ref, err)
+	}
+
+	rawSig, signedPayload, err := signer.Sign(ctx, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("signing %s: %w", ref, err)
+	}
+
+	sig, err := static.NewSignature(signedPayload, string(rawSig))
+	if err != nil {
+		return nil, fmt.Errorf("building cosign signature for %s: %w", ref, err)
+	}
+
+	se, err := ociremote.SignedEntity(digestRef)
+	if err != nil {
+		return nil, fmt.Errorf("resolving signed entity for %s: %w", ref, err)
+	}
+	newSE, err := ociremote.WriteSignatures(digestRef.Repository, se, sig)
+	if err != nil {
+		return nil, fmt.Errorf("writing signature for %s: %w", ref, err)
+	}
+
+	return &SignResult{
+		Reference:    digestRef.Name(),
+		Digest:       digestRef.DigestStr(),
+		SignatureRef: newSE.Reference().String(),
+	}, nil
+}
+
+// GenerateSBOM produces a CycloneDX JSON SBOM for ref using syft as a
+// library (no syft CLI dependency).
+func GenerateSBOM(ctx context.Context, ref string) ([]byte, error) {
+	src, err := syft.GetSource(ctx, ref, syft.DefaultGetSourceConfig().WithSources(source.ImageTag))
+	if err != nil {
+		return nil, fmt.Errorf("loading source %s: %w", ref, err)
+	}
+
+	sbom, err := syft.CreateSBOM(ctx, src, syft.DefaultCreateSBOMConfig())
+	if err != nil {
+		return nil, fmt.Errorf("generating SBOM for %s: %w", ref, err)
+	}
+
+	encoder, err := cyclonedxjson.NewFormatEncoderWithConfig(cyclonedxjson.DefaultEncoderConfig())
+	if err != nil {
+		return nil, fmt.Errorf("configuring CycloneDX encoder: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, *sbom); err != nil {
+		return nil, fmt.Errorf("encoding SBOM for %s: %w", ref, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// AttestOptions configures attestation signing, mirroring SignOptions.
+type AttestOptions struct {
+	Key string
+}
+
+// AttestResult records the attestations published for one image.
+type AttestResult struct {
+	Reference        string `json:"reference"`
+	SBOMPredicateRef string `json:"sbom_predicate_ref"`
+	VulnPredicateRef string `json:"vuln_predicate_ref,omitempty"`
+}
+
+// AttestImage attaches sbom (CycloneDX JSON) and, if vulnReport is
+// non-empty, the Trivy vulnerability report at vulnReport, to ref as
+// separate in-toto attestations.
+func AttestImage(ctx context.Context, ref string, sbom []byte, vulnReport string, opts AttestOptions) (*AttestResult, error) {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newSigner(ctx, opts.Key, "")
+	if err != nil {
+		return nil, fmt.Errorf("configuring signer for %s: %w", ref, err)
+	}
+
+	sbomAttRef, err := attachAttestation(ctx, digestRef, signer, "https://cyclonedx.org/bom", sbom)
+	if err != nil {
+		return nil, fmt.Errorf("attaching SBOM attestation to %s: %w", ref, err)
+	}
+
+	result := &AttestResult{
+		Reference:        digestRef.Name(),
+		SBOMPredicateRef: sbomAttRef,
+	}
+
+	if vulnReport != "" {
+		data, err := os.ReadFile(vulnReport)
+		if err != nil {
+			return nil, fmt.Errorf("reading vuln report %s: %w", vulnReport, err)
+		}
+		vulnAttRef, err := attachAttestation(ctx, digestRef, signer, "https://cosign.sigstore.dev/attestation/vuln/v1", data)
+		if err != nil {
+			return nil, fmt.Errorf("attaching vuln attestation to %s: %w", ref, err)
+		}
+		result.VulnPredicateRef = vulnAttRef
+	}
+
+	return result, nil
+}
+
+// AttestCustom attaches predicate (arbitrary JSON) to ref as a single
+// in-toto attestation under predicateType, for callers whose predicate
+// doesn't fit AttestImage's fixed SBOM/vuln-report shape (e.g. a manifest
+// of files bundled into a non-image OCI artifact).
+func AttestCustom(ctx context.Context, ref, predicateType string, predicate []byte, opts AttestOptions) (string, error) {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := newSigner(ctx, opts.Key, "")
+	if err != nil {
+		return "", fmt.Errorf("configuring signer for %s: %w", ref, err)
+	}
+
+	attRef, err := attachAttestation(ctx, digestRef, signer, predicateType, predicate)
+	if err != nil {
+		return "", fmt.Errorf("attaching attestation to %s: %w", ref, err)
+	}
+	return attRef, nil
+}
+
+// attachAttestation wraps predicate in an in-toto statement and pushes it
+// to the image's attestation tag in the target registry.
+func attachAttestation(ctx context.Context, digestRef name.Digest, signer signature.Signer, predicateType string, predicate []byte) (string, error) {
+	statement, err := inTotoStatement(digestRef, predicateType, predicate)
+	if err != nil {
+		return "", fmt.Errorf("building in-toto statement: %w", err)
+	}
+
+	rawSig, signedPayload, err := signer.Sign(ctx, bytes.NewReader(statement))
+	if err != nil {
+		return "", fmt.Errorf("signing attestation: %w", err)
+	}
+
+	att, err := static.NewAttestation(signedPayload, static.WithLayerMediaType(intotoMediaType), static.WithSignature(rawSig))
+	if err != nil {
+		return "", fmt.Errorf("building cosign attestation: %w", err)
+	}
+
+	se, err := ociremote.SignedEntity(digestRef)
+	if err != nil {
+		return "", fmt.Errorf("resolving signed entity: %w", err)
+	}
+	newSE, err := ociremote.WriteAttestations(digestRef.Repository, se, att)
+	if err != nil {
+		return "", fmt.Errorf("writing attestation: %w", err)
+	}
+	return newSE.Reference().String(), nil
+}
+
+const intotoMediaType = "application/vnd.in-toto+json"
+
+// inTotoStatement wraps predicate in a minimal in-toto v0.1 statement
+// binding it to subjectRef's digest, matching the shape cosign attest
+// produces for custom predicate types.
+func inTotoStatement(subjectRef name.Digest, predicateType string, predicate []byte) ([]byte, error) {
+	var decoded any
+	if err := json.Unmarshal(predicate, &decoded); err != nil {
+		return nil, fmt.Errorf("predicate is not valid JSON: %w", err)
+	}
+
+	statement := map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": predicateType,
+		"subject": []map[string]any{
+			{
+				"name": subjectRef.Repository.Name(),
+				"digest": map[string]string{
+					"sha256": strings.TrimPrefix(subjectRef.DigestStr(), "sha256:"),
+				},
+			},
+		},
+		"predicate": decoded,
+	}
+	return json.Marshal(statement)
+}
+
+// ImagesJSONEntry is one row of the images.json artifact, matching the
+// schema CatalogCommand's --images-json flag already expects from the
+// external sign-and-attest script.
+type ImagesJSONEntry struct {
+	Original         string `json:"original"`
+	Patched          string `json:"patched"`
+	Digest           string `json:"digest"`
+	SignatureRef     string `json:"signature_ref,omitempty"`
+	SBOMPredicateRef string `json:"sbom_predicate_ref,omitempty"`
+	VulnPredicateRef string `json:"vuln_predicate_ref,omitempty"`
+}
+
+// WriteImagesJSON appends entries to the images.json at path, creating it
+// (and its parent directory) if it does not already exist.
+func WriteImagesJSON(path string, entries []ImagesJSONEntry) error {
+	existing, err := readImagesJSON(path)
+	if err != nil {
+		return err
+	}
+	existing = append(existing, entries...)
+
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling images.json: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating images.json dir: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadImagesJSON reads the images.json entries at path (the format
+// WriteImagesJSON appends to). Returns a nil slice, not an error, if path
+// doesn't exist yet.
+func ReadImagesJSON(path string) ([]ImagesJSONEntry, error) {
+	return readImagesJSON(path)
+}
+
+func readImagesJSON(path string) ([]ImagesJSONEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading images.json: %w", err)
+	}
+	var entries []ImagesJSONEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing images.json: %w", err)
+	}
+	return entries, nil
+}
+
+// resolveDigest resolves ref to its immutable digest form, since cosign
+// signatures and attestations are always keyed by digest rather than tag.
+func resolveDigest(ref string) (name.Digest, error) {
+	digest, err := crane.Digest(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("resolving digest for %s: %w", ref, err)
+	}
+	repo, err := name.NewRepository(stripTag(ref))
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("parsing repository from %s: %w", ref, err)
+	}
+	digestRef, err := name.NewDigest(fmt.Sprintf("%s@%s", repo.Name(), digest))
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("building digest reference for %s: %w", ref, err)
+	}
+	return digestRef, nil
+}
+
+// stripTag drops a trailing ":tag" from ref, leaving the bare repository.
+func stripTag(ref string) string {
+	if idx := strings.LastIndex(ref, "@"); idx != -1 {
+		return ref[:idx]
+	}
+	repoPart := ref
+	if slash := strings.LastIndex(ref, "/"); slash != -1 {
+		repoPart = ref[slash+1:]
+	}
+	if colon := strings.LastIndex(repoPart, ":"); colon != -1 {
+		trim := len(ref) - len(repoPart) + colon
+		return ref[:trim]
+	}
+	return ref
+}
+
+// newSigner returns a keyless (Fulcio/OIDC) signer, unless keyPath is set,
+// in which case it loads either a KMS-backed signer (when keyPath is a
+// "<scheme>://..." ref such as "awskms://...") or a cosign private key from
+// disk. identityToken, when non-empty, is only consulted on the keyless
+// path: it's exported as SIGSTORE_ID_TOKEN so cosign's own ambient-OIDC
+// detection picks it up instead of falling back to an interactive browser
+// flow, letting a non-interactive CI job drive keyless signing with a
+// token it already has rather than cosign's default login prompt.
+func newSigner(ctx context.Context, keyPath, identityToken string) (signature.Signer, error) {
+	switch {
+	case keyPath == "":
+		if identityToken != "" {
+			prev, hadPrev := os.LookupEnv("SIGSTORE_ID_TOKEN")
+			if err := os.Setenv("SIGSTORE_ID_TOKEN", identityToken); err != nil {
+				return nil, fmt.Errorf("setting SIGSTORE_ID_TOKEN: %w", err)
+			}
+			defer func() {
+				if hadPrev {
+					os.Setenv("SIGSTORE_ID_TOKEN", prev)
+				} else {
+					os.Unsetenv("SIGSTORE_ID_TOKEN")
+				}
+			}()
+		}
+		return cosign.NewKeylessSigner(ctx)
+	case strings.Contains(keyPath, "://"):
+		return kms.Get(ctx, keyPath, crypto.SHA256)
+	default:
+		return cosign.LoadPrivateKey(ctx, keyPath)
+	}
+}
@@ -0,0 +1,54 @@
+package attest
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteImagesJSONAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images.json")
+
+	first := []ImagesJSONEntry{{Original: "a:1", Patched: "a:1-patched", Digest: "sha256:aaa"}}
+	if err := WriteImagesJSON(path, first); err != nil {
+		t.Fatalf("WriteImagesJSON returned error: %v", err)
+	}
+
+	second := []ImagesJSONEntry{{Original: "b:1", Patched: "b:1-patched", Digest: "sha256:bbb"}}
+	if err := WriteImagesJSON(path, second); err != nil {
+		t.Fatalf("WriteImagesJSON returned error: %v", err)
+	}
+
+	entries, err := readImagesJSON(path)
+	if err != nil {
+		t.Fatalf("readImagesJSON returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Original != "a:1" || entries[1].Original != "b:1" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestReadImagesJSONMissingFileIsNotError(t *testing.T) {
+	entries, err := readImagesJSON(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if entries != nil {
+		t.Errorf("expected nil entries, got %v", entries)
+	}
+}
+
+func TestStripTag(t *testing.T) {
+	tests := []struct{ ref, want string }{
+		{"ghcr.io/verity-org/app:v1.0", "ghcr.io/verity-org/app"},
+		{"ghcr.io/verity-org/app@sha256:abc", "ghcr.io/verity-org/app"},
+		{"ghcr.io/verity-org/app", "ghcr.io/verity-org/app"},
+	}
+	for _, tc := range tests {
+		if got := stripTag(tc.ref); got != tc.want {
+			t.Errorf("stripTag(%q) = %q, want %q", tc.ref, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package attest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/sigstore/pkg/fulcioroots"
+)
+
+// VerifyOptions configures verification of a previously signed/attested
+// image, mirroring SignOptions: Key selects keyed verification, otherwise
+// keyless (Fulcio cert chain + Rekor transparency log) verification is used.
+type VerifyOptions struct {
+	Key            string
+	CertIdentity   string
+	CertOIDCIssuer string
+	RekorURL       string
+}
+
+// VerifyResult reports what VerifyImage was able to confirm about ref.
+type VerifyResult struct {
+	Reference     string `json:"reference"`
+	SBOMVerified  bool   `json:"sbom_verified"`
+	VulnVerified  bool   `json:"vuln_verified"`
+	RekorVerified bool   `json:"rekor_verified"`
+}
+
+// VerifyImage pulls the signature and attestations for ref back from the
+// registry and validates them, confirming both that the SBOM/vuln
+// predicates are present and correctly signed, and (for keyless signing)
+// that a matching Rekor transparency log entry exists.
+func VerifyImage(ctx context.Context, ref string, opts VerifyOptions) (*VerifyResult, error) {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	checkOpts, err := buildCheckOpts(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("configuring verification for %s: %w", ref, err)
+	}
+
+	if _, _, err := cosign.VerifyImageSignatures(ctx, digestRef, checkOpts); err != nil {
+		return nil, fmt.Errorf("verifying signature for %s: %w", ref, err)
+	}
+
+	attestations, _, err := cosign.VerifyImageAttestations(ctx, digestRef, checkOpts)
+	if err != nil {
+		return nil, fmt.Errorf("verifying attestations for %s: %w", ref, err)
+	}
+
+	result := &VerifyResult{Reference: digestRef.Name(), RekorVerified: opts.Key == ""}
+	for _, att := range attestations {
+		predicateType, err := attestationPredicateType(att)
+		if err != nil {
+			continue
+		}
+		switch predicateType {
+		case "https://cyclonedx.org/bom":
+			result.SBOMVerified = true
+		case "https://cosign.sigstore.dev/attestation/vuln/v1":
+			result.VulnVerified = true
+		}
+	}
+
+	return result, nil
+}
+
+// attestationPredicateType extracts the in-toto predicateType from a
+// verified cosign attestation's payload.
+func attestationPredicateType(att interface{ Payload() ([]byte, error) }) (string, error) {
+	payload, err := att.Payload()
+	if err != nil {
+		return "", fmt.Errorf("reading attestation payload: %w", err)
+	}
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+	}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return "", fmt.Errorf("parsing attestation payload: %w", err)
+	}
+	return statement.PredicateType, nil
+}
+
+// VerifySignatureOnly validates only ref's cosign signature, without
+// requiring attestations. Unlike VerifyImage, this fits refs that were only
+// ever signed (SignImage/SignChartArchive) and never attested
+// (AttestImage) — published charts are one such case, since PublishChart
+// signs but does not attest them.
+func VerifySignatureOnly(ctx context.Context, ref string, opts VerifyOptions) error {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return err
+	}
+
+	checkOpts, err := buildCheckOpts(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("configuring verification for %s: %w", ref, err)
+	}
+
+	if _, _, err := cosign.VerifyImageSignatures(ctx, digestRef, checkOpts); err != nil {
+		return fmt.Errorf("verifying signature for %s: %w", ref, err)
+	}
+	return nil
+}
+
+// VulnReportPredicateType is the in-toto predicate type a Trivy vulnerability
+// report is attached under when pushed as a standalone attestation (as
+// opposed to the "https://cosign.sigstore.dev/attestation/vuln/v1" predicate
+// AttestImage uses for per-image vuln attestations).
+const VulnReportPredicateType = "https://vuln.dev/spec/v1"
+
+// FetchVerifiedAttestationPredicate verifies ref's attestations with opts
+// and returns the raw predicate bytes of the first one matching
+// predicateType, or nil if verification succeeds but none match.
+func FetchVerifiedAttestationPredicate(ctx context.Context, ref, predicateType string, opts VerifyOptions) ([]byte, error) {
+	digestRef, err := resolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	checkOpts, err := buildCheckOpts(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("configuring verification for %s: %w", ref, err)
+	}
+
+	attestations, _, err := cosign.VerifyImageAttestations(ctx, digestRef, checkOpts)
+	if err != nil {
+		return nil, fmt.Errorf("verifying attestations for %s: %w", ref, err)
+	}
+
+	for _, att := range attestations {
+		pt, err := attestationPredicateType(att)
+		if err != nil || pt != predicateType {
+			continue
+		}
+		payload, err := att.Payload()
+		if err != nil {
+			return nil, fmt.Errorf("reading attestation payload: %w", err)
+		}
+		var statement struct {
+			Predicate json.RawMessage `json:"predicate"`
+		}
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			return nil, fmt.Errorf("parsing attestation statement: %w", err)
+		}
+		return statement.Predicate, nil
+	}
+	return nil, nil
+}
+
+// buildCheckOpts assembles cosign's verification options for either keyed
+// or keyless verification, wiring in the Fulcio root pool and Rekor client
+// for the keyless (identity-based) case.
+func buildCheckOpts(ctx context.Context, opts VerifyOptions) (*cosign.CheckOpts, error) {
+	co := &cosign.CheckOpts{}
+
+	if opts.Key != "" {
+		verifier, err := cosign.LoadPublicKey(ctx, opts.Key)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key %s: %w", opts.Key, err)
+		}
+		co.SigVerifier = verifier
+		return co, nil
+	}
+
+	roots, err := fulcioroots.Get()
+	if err != nil {
+		return nil, fmt.Errorf("loading Fulcio root pool: %w", err)
+	}
+	co.RootCerts = roots
+	co.CertIdentity = opts.CertIdentity
+	co.CertOidcIssuer = opts.CertOIDCIssuer
+
+	rekorURL := opts.RekorURL
+	if rekorURL == "" {
+		rekorURL = "https://rekor.sigstore.dev"
+	}
+	rekor, err := rekorclient.GetRekorClient(rekorURL)
+	if err != nil {
+		return nil, fmt.Errorf("configuring Rekor client: %w", err)
+	}
+	co.RekorClient = rekor
+
+	return co, nil
+}
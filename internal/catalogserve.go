@@ -0,0 +1,355 @@
+package internal
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed templates/catalogserve/*.html.tmpl
+var catalogServeTemplates embed.FS
+
+// catalogCacheTTL is how long ServeCatalog's in-memory discovery cache is
+// trusted before the next request triggers a full re-discovery, regardless
+// of whether listChartTags has seen any new tags.
+const catalogCacheTTL = 5 * time.Minute
+
+// ServeCatalog starts an HTTP server exposing the same data GenerateSiteData
+// writes to catalog.json, but live: a small HTML dashboard at "/", and a
+// JSON API at /api/catalog, /api/charts/{name}/{version},
+// /api/images/{sanitizedID}, and /api/images/{sanitizedID}/vulns (filterable
+// by ?severity=HIGH,CRITICAL&fixable=true). This lets users browse a
+// registry without a build step, and gives CI a queryable API for policy
+// gates ("fail if any image has fixable CRITICAL").
+//
+// Discovery results are cached in memory for catalogCacheTTL and refreshed
+// early the moment any chart's registry tags change (see
+// catalogServer.catalog), so most requests don't pay discoverCharts' full
+// per-version registry walk. ignoreStatus is forwarded to discoverCatalog
+// (see GenerateSiteData) to drop matching per-vulnerability Trivy statuses.
+func ServeCatalog(chartsDir, imagesFile, registry, addr string, ignoreStatus []string) error {
+	s := &catalogServer{chartsDir: chartsDir, imagesFile: imagesFile, registry: registry, ignoreStatus: ignoreStatus}
+
+	tmpl, err := template.ParseFS(catalogServeTemplates, "templates/catalogserve/*.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("loading catalog templates: %w", err)
+	}
+	s.tmpl = tmpl
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleDashboard)
+	mux.HandleFunc("/api/catalog", s.handleAPICatalog)
+	mux.HandleFunc("/api/charts/", s.handleAPIChart)
+	mux.HandleFunc("/api/images/", s.handleAPIImage)
+
+	fmt.Printf("Serving catalog on %s\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// catalogServer holds the discovery inputs ServeCatalog was started with,
+// plus the cached SiteData every handler reads from.
+type catalogServer struct {
+	chartsDir    string
+	imagesFile   string
+	registry     string
+	ignoreStatus []string
+	tmpl         *template.Template
+
+	mu        sync.Mutex
+	data      SiteData
+	tags      map[string][]string // chart name -> tags seen at last refresh
+	fetchedAt time.Time
+}
+
+// catalog returns the cached SiteData, refreshing it first if the cache has
+// aged past catalogCacheTTL or a chart's registry tags have changed since
+// the last refresh. A refresh that errors falls back to serving the
+// previous snapshot (if any) rather than failing the request outright,
+// since a transient registry hiccup shouldn't take the dashboard down.
+func (s *catalogServer) catalog() (SiteData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stale := s.fetchedAt.IsZero() || time.Since(s.fetchedAt) > catalogCacheTTL || s.tagsChanged()
+	if !stale {
+		return s.data, nil
+	}
+
+	data, err := discoverCatalog(s.chartsDir, s.imagesFile, s.registry, s.ignoreStatus, nil)
+	if err != nil {
+		if !s.fetchedAt.IsZero() {
+			fmt.Fprintf(os.Stderr, "Warning: catalog refresh failed, serving cached data: %v\n", err)
+			return s.data, nil
+		}
+		return SiteData{}, err
+	}
+
+	s.data = data
+	s.tags = s.currentTags(data)
+	s.fetchedAt = time.Now()
+	return s.data, nil
+}
+
+// tagsChanged reports whether any chart's registry tags differ from what
+// was recorded at the last refresh, requiring s.mu to already be held.
+// Returns false before the first refresh (s.tags is nil) and when
+// s.registry is empty, since there's no registry to list tags from.
+func (s *catalogServer) tagsChanged() bool {
+	if s.tags == nil || s.registry == "" {
+		return false
+	}
+	for name, prev := range s.tags {
+		tags, err := listChartTags(s.registry, name)
+		if err != nil {
+			continue // can't tell; don't force a refresh on a transient listing error
+		}
+		if !sameTagSet(tags, prev) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentTags records, for every distinct chart name in data, the tags
+// listChartTags currently reports for it — the baseline tagsChanged
+// compares future requests against.
+func (s *catalogServer) currentTags(data SiteData) map[string][]string {
+	if s.registry == "" {
+		return nil
+	}
+	names := make(map[string]struct{})
+	for _, c := range data.Charts {
+		names[c.Name] = struct{}{}
+	}
+	tags := make(map[string][]string, len(names))
+	for name := range names {
+		t, err := listChartTags(s.registry, name)
+		if err != nil {
+			continue
+		}
+		tags[name] = t
+	}
+	return tags
+}
+
+func sameTagSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := append([]string(nil), a...)
+	bs := append([]string(nil), b...)
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// dashboardRepo groups every published version of one chart for the "/"
+// HTML dashboard's per-repository tag listing.
+type dashboardRepo struct {
+	Name     string
+	Versions []dashboardVersion
+}
+
+type dashboardVersion struct {
+	Name            string
+	Version         string
+	UpstreamVersion string
+	Images          []SiteImage
+	TotalVulns      int
+	FixableVulns    int
+}
+
+type dashboardData struct {
+	SiteData
+	Repos []dashboardRepo
+}
+
+func (s *catalogServer) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := s.catalog()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	byName := make(map[string]*dashboardRepo)
+	var order []string
+	for _, c := range data.Charts {
+		repo, ok := byName[c.Name]
+		if !ok {
+			repo = &dashboardRepo{Name: c.Name}
+			byName[c.Name] = repo
+			order = append(order, c.Name)
+		}
+		var total, fixable int
+		for _, img := range c.Images {
+			total += img.VulnSummary.Total
+			fixable += img.VulnSummary.Fixable
+		}
+		repo.Versions = append(repo.Versions, dashboardVersion{
+			Name:            c.Name,
+			Version:         c.Version,
+			UpstreamVersion: c.UpstreamVersion,
+			Images:          c.Images,
+			TotalVulns:      total,
+			FixableVulns:    fixable,
+		})
+	}
+	sort.Strings(order)
+
+	view := dashboardData{SiteData: data}
+	for _, name := range order {
+		view.Repos = append(view.Repos, *byName[name])
+	}
+
+	if err := s.tmpl.ExecuteTemplate(w, "dashboard.html.tmpl", view); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *catalogServer) handleAPICatalog(w http.ResponseWriter, r *http.Request) {
+	data, err := s.catalog()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, data)
+}
+
+func (s *catalogServer) handleAPIChart(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/charts/"), "/")
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.NotFound(w, r)
+		return
+	}
+	name, version := parts[0], parts[1]
+
+	data, err := s.catalog()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, c := range data.Charts {
+		if c.Name == name && c.Version == version {
+			writeJSON(w, c)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (s *catalogServer) handleAPIImage(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/images/"), "/")
+	parts := strings.SplitN(rest, "/", 2)
+	if parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := s.catalog()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	img, ok := findImageByID(data, parts[0])
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "vulns" {
+		severities := splitCSV(r.URL.Query().Get("severity"))
+		fixableOnly := r.URL.Query().Get("fixable") == "true"
+		writeJSON(w, filterSiteVulns(img.Vulnerabilities, severities, fixableOnly))
+		return
+	}
+
+	writeJSON(w, img)
+}
+
+// findImageByID searches every chart's images and the standalone images
+// for the one whose ID (the sanitized original ref) matches id.
+func findImageByID(data SiteData, id string) (SiteImage, bool) {
+	for _, c := range data.Charts {
+		for _, img := range c.Images {
+			if img.ID == id {
+				return img, true
+			}
+		}
+	}
+	for _, img := range data.StandaloneImages {
+		if img.ID == id {
+			return img, true
+		}
+	}
+	return SiteImage{}, false
+}
+
+// filterSiteVulns returns the vulns matching every given severity (OR'd
+// together, case-insensitive) and, if fixableOnly is set, that also have a
+// fixed version. A nil/empty severities filter matches everything.
+func filterSiteVulns(vulns []SiteVuln, severities []string, fixableOnly bool) []SiteVuln {
+	if len(severities) == 0 && !fixableOnly {
+		return vulns
+	}
+	result := make([]SiteVuln, 0, len(vulns))
+	for _, v := range vulns {
+		if fixableOnly && v.FixedVersion == "" {
+			continue
+		}
+		if len(severities) > 0 && !containsFold(severities, v.Severity) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func containsFold(haystack []string, needle string) bool {
+	for _, h := range haystack {
+		if strings.EqualFold(h, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
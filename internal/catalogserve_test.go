@@ -0,0 +1,94 @@
+package internal
+
+import "testing"
+
+func TestFilterSiteVulns(t *testing.T) {
+	vulns := []SiteVuln{
+		{ID: "CVE-1", Severity: "CRITICAL", FixedVersion: "1.2.3"},
+		{ID: "CVE-2", Severity: "HIGH", FixedVersion: ""},
+		{ID: "CVE-3", Severity: "LOW", FixedVersion: "4.5.6"},
+	}
+
+	tests := []struct {
+		name        string
+		severities  []string
+		fixableOnly bool
+		want        []string
+	}{
+		{name: "no filter", want: []string{"CVE-1", "CVE-2", "CVE-3"}},
+		{name: "severity filter", severities: []string{"high", "low"}, want: []string{"CVE-2", "CVE-3"}},
+		{name: "fixable only", fixableOnly: true, want: []string{"CVE-1", "CVE-3"}},
+		{name: "severity and fixable", severities: []string{"CRITICAL", "HIGH"}, fixableOnly: true, want: []string{"CVE-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterSiteVulns(vulns, tt.severities, tt.fixableOnly)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterSiteVulns() = %v, want %v", got, tt.want)
+			}
+			for i, v := range got {
+				if v.ID != tt.want[i] {
+					t.Errorf("filterSiteVulns()[%d] = %q, want %q", i, v.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitCSV(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: "", want: nil},
+		{input: "HIGH", want: []string{"HIGH"}},
+		{input: "HIGH,CRITICAL", want: []string{"HIGH", "CRITICAL"}},
+		{input: "HIGH, CRITICAL , ", want: []string{"HIGH", "CRITICAL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := splitCSV(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitCSV(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("splitCSV(%q)[%d] = %q, want %q", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSameTagSet(t *testing.T) {
+	if !sameTagSet([]string{"1.0.0", "1.1.0"}, []string{"1.1.0", "1.0.0"}) {
+		t.Error("expected same tag sets in different order to be equal")
+	}
+	if sameTagSet([]string{"1.0.0"}, []string{"1.0.0", "1.1.0"}) {
+		t.Error("expected different-length tag sets to be unequal")
+	}
+	if sameTagSet([]string{"1.0.0"}, []string{"1.1.0"}) {
+		t.Error("expected different tag sets to be unequal")
+	}
+}
+
+func TestFindImageByID(t *testing.T) {
+	data := SiteData{
+		Charts: []SiteChart{
+			{Name: "myapp", Images: []SiteImage{{ID: "img-1"}}},
+		},
+		StandaloneImages: []SiteImage{{ID: "img-2"}},
+	}
+
+	if _, ok := findImageByID(data, "img-1"); !ok {
+		t.Error("expected to find chart image by ID")
+	}
+	if _, ok := findImageByID(data, "img-2"); !ok {
+		t.Error("expected to find standalone image by ID")
+	}
+	if _, ok := findImageByID(data, "missing"); ok {
+		t.Error("expected no match for unknown ID")
+	}
+}
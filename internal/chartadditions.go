@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Harbor-style "chart additions" artifact types: verity pushes the
+// wrapper's values.yaml, a synthesized README, and a dependency manifest
+// as OCI 1.1 referrer artifacts alongside the chart, the same way Harbor
+// attaches README.md/values.yaml/DEPENDENCIES to a chart version. Unlike
+// Harbor's HTTP API, these live in the registry itself (see
+// pushReferrerArtifact), so any OCI 1.1 registry works, not just Harbor.
+const (
+	additionsValuesArtifactType       = "application/vnd.verity.chart.values.v1+yaml"
+	additionsReadmeArtifactType       = "application/vnd.verity.chart.readme.v1+markdown"
+	additionsDependenciesArtifactType = "application/vnd.verity.chart.dependencies.v1+json"
+)
+
+// ChartAdditions bundles the auxiliary artifacts FetchAdditions pulled
+// back for a published wrapper chart. A nil field means that addition
+// wasn't found as a referrer (e.g. an older chart published before
+// PushChartAdditions existed).
+type ChartAdditions struct {
+	values       []byte
+	readme       []byte
+	dependencies []byte
+}
+
+// Values returns the wrapper chart's values.yaml contents.
+func (a *ChartAdditions) Values() []byte { return a.values }
+
+// Readme returns the synthesized README.md describing the CVE deltas per
+// patched image.
+func (a *ChartAdditions) Readme() []byte { return a.readme }
+
+// Dependencies returns the JSON dependency manifest (see
+// additionDependency).
+func (a *ChartAdditions) Dependencies() []byte { return a.dependencies }
+
+// additionDependency is one entry of the dependencies.json addition: the
+// upstream chart dependency this wrapper subcharts, alongside the patched
+// image it carries for it.
+type additionDependency struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Repository string   `json:"repository"`
+	Images     []string `json:"images"`
+}
+
+// PushChartAdditions pushes chartDir's values.yaml, a synthesized
+// README.md summarizing results' CVE deltas, and a JSON manifest of dep
+// and the patched images it carries, as OCI 1.1 referrer artifacts
+// pointing at chartRef@chartDigest — the chart manifest PublishChart just
+// pushed. It returns the pushed digest references, in
+// values/README/dependencies order.
+func PushChartAdditions(chartRef, chartDigest, chartDir string, dep Dependency, results []*PatchResult) ([]string, error) {
+	subjectRef, subject, err := resolveSubject(chartRef, chartDigest)
+	if err != nil {
+		return nil, err
+	}
+	repo := subjectRef.Context()
+
+	values, err := os.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("reading values.yaml: %w", err)
+	}
+
+	readme := renderAdditionsReadme(dep, results)
+	dependencies, err := renderAdditionsDependencies(dep, results)
+	if err != nil {
+		return nil, err
+	}
+
+	additions := []struct {
+		title        string
+		artifactType string
+		data         []byte
+	}{
+		{"values.yaml", additionsValuesArtifactType, values},
+		{"README.md", additionsReadmeArtifactType, readme},
+		{"dependencies.json", additionsDependenciesArtifactType, dependencies},
+	}
+
+	var pushed []string
+	for _, a := range additions {
+		dst, err := pushReferrerArtifact(repo, subject, a.artifactType, a.title, a.data)
+		if err != nil {
+			return nil, err
+		}
+		pushed = append(pushed, dst)
+	}
+	return pushed, nil
+}
+
+// renderAdditionsReadme builds a short per-image CVE-delta table for dep,
+// the same "reduced Critical from N to 0" figures VulnSummary tracks per
+// PatchResult, so a chart consumer can see what patching bought them
+// without pulling a Trivy report themselves.
+func renderAdditionsReadme(dep Dependency, results []*PatchResult) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n\n", dep.Name)
+	fmt.Fprintf(&buf, "%s with Copa-patched container images.\n\n", dep.Name)
+	fmt.Fprintf(&buf, "| Image | Before | After | Fixed |\n")
+	fmt.Fprintf(&buf, "|---|---|---|---|\n")
+	for _, r := range results {
+		if r.Skipped || r.Error != nil {
+			continue
+		}
+		fmt.Fprintf(&buf, "| %s | %d | %d | %d |\n",
+			r.Original.Reference(), r.Before.Total, r.After.Total, r.VulnCount)
+	}
+	return buf.Bytes()
+}
+
+// renderAdditionsDependencies builds the dependencies.json addition: dep
+// itself, plus the patched image references it carries, sorted for
+// deterministic output.
+func renderAdditionsDependencies(dep Dependency, results []*PatchResult) ([]byte, error) {
+	images := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.Skipped || r.Error != nil {
+			continue
+		}
+		images = append(images, r.Patched.Reference())
+	}
+	sort.Strings(images)
+
+	manifest := []additionDependency{{
+		Name:       dep.Name,
+		Version:    dep.Version,
+		Repository: dep.Repository,
+		Images:     images,
+	}}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling dependencies manifest: %w", err)
+	}
+	return data, nil
+}
+
+// FetchAdditions pulls back the values/README/dependencies OCI referrer
+// artifacts PushChartAdditions pushed for chartRef@chartDigest, so
+// downstream tools (e.g. the site generator) can read them without
+// cloning the source chart's repo.
+func FetchAdditions(chartRef, chartDigest string) (*ChartAdditions, error) {
+	repo, descs, err := listReferrers(chartRef, chartDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	additions := &ChartAdditions{}
+	for _, desc := range descs {
+		switch desc.ArtifactType {
+		case additionsValuesArtifactType:
+			data, _, err := fetchReferrerBlob(repo, desc)
+			if err != nil {
+				return nil, err
+			}
+			additions.values = data
+		case additionsReadmeArtifactType:
+			data, _, err := fetchReferrerBlob(repo, desc)
+			if err != nil {
+				return nil, err
+			}
+			additions.readme = data
+		case additionsDependenciesArtifactType:
+			data, _, err := fetchReferrerBlob(repo, desc)
+			if err != nil {
+				return nil, err
+			}
+			additions.dependencies = data
+		}
+	}
+	return additions, nil
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/crane"
+)
+
+// channelTagPattern matches the floating channel tags PushChannelTags
+// pushes alongside every real wrapper version ("latest", "stable", a bare
+// major like "25", or a bare major.minor like "25.8") — each pointing at
+// the same digest as some real version tag, but with no prerelease
+// component, so they'd otherwise outrank every content-addressable
+// "{upstream}-p{digest}" tag by semver precedence.
+var channelTagPattern = regexp.MustCompile(`^(latest|stable|\d+|\d+\.\d+)$`)
+
+// listPublishedChartsJobs bounds how many charts ListPublishedCharts
+// inspects concurrently, the same semaphore-backed worker-pool shape
+// discoverDependenciesConcurrently uses for chart-dependency discovery —
+// each chart's metadata/digest/referrer lookups are independent registry
+// round-trips, so there's no reason to serialize them.
+const listPublishedChartsJobs = 4
+
+// PublishedChartSummary is one wrapper chart's entry in `verity charts`'s
+// output: its latest published version plus the source-chart/publish
+// provenance CreateWrapperChart/PublishChart recorded as Chart.yaml
+// annotations (see fetchWrapperChartMeta), and how many referrer artifacts
+// (Trivy reports, cosign signatures) are attached to that version's
+// manifest (see listReferrers).
+type PublishedChartSummary struct {
+	Name             string
+	LatestVersion    string
+	PublishedAt      string
+	SourceRepository string
+	SourceVersion    string
+	ReferrerCount    int
+}
+
+// ListPublishedCharts enumerates wrapper charts published to registry's
+// "charts/" path, using the OCI `_catalog` and `tags/list` endpoints
+// (crane.Catalog/crane.ListTags — the same crane-backed pattern
+// wrapperVersionExists already uses for version queries) rather than
+// requiring the `crane`/`skopeo` binaries operators would otherwise reach
+// for. prefix, when non-empty, restricts results to chart names with that
+// prefix; chart, when non-empty, restricts to that exact chart name and
+// skips the registry-wide catalog walk entirely (useful against a
+// registry whose _catalog is slow or access-restricted).
+//
+// For each matching chart, only the latest version (by semver precedence,
+// falling back to the raw tag string for anything that doesn't parse) has
+// its metadata and referrers fetched; older versions are listed implicitly
+// by their presence in the registry but not detailed here. A chart whose
+// metadata or referrers can't be fetched (a transient registry error, or
+// missing pull access to that one repo) is still listed, with a warning
+// printed to stderr — its PublishedAt/Source/ReferrerCount fields are left
+// blank/zero rather than failing the whole command, the same
+// graceful-degradation tradeoff BuildWrapperProvenance makes for a failed
+// digest lookup.
+func ListPublishedCharts(registry, prefix, chart string) ([]PublishedChartSummary, error) {
+	names, err := chartRepoNames(registry, prefix, chart)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]PublishedChartSummary, len(names))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, listPublishedChartsJobs)
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			out[i] = summarizePublishedChart(registry, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	filtered := out[:0]
+	for _, summary := range out {
+		if summary.Name != "" {
+			filtered = append(filtered, summary)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered, nil
+}
+
+// summarizePublishedChart builds name's PublishedChartSummary, warning to
+// stderr (rather than failing ListPublishedCharts outright) on any lookup
+// that fails. Returns a zero-value summary (Name left empty, filtered out
+// by the caller) if name has no published versions at all.
+func summarizePublishedChart(registry, name string) PublishedChartSummary {
+	repo := fmt.Sprintf("%s/charts/%s", registry, name)
+	versions, err := crane.ListTags(repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: listing versions for %s: %v\n", name, err)
+		return PublishedChartSummary{}
+	}
+	if len(versions) == 0 {
+		return PublishedChartSummary{}
+	}
+
+	summary := PublishedChartSummary{Name: name, LatestVersion: latestVersion(versions)}
+
+	meta, err := fetchWrapperChartMeta(registry, name, summary.LatestVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: fetching metadata for %s@%s: %v\n", name, summary.LatestVersion, err)
+	} else {
+		summary.PublishedAt = meta.Annotations["org.verity.published-at"]
+		summary.SourceRepository = meta.Annotations["org.verity.source-repository"]
+		summary.SourceVersion = meta.Annotations["org.verity.source-version"]
+	}
+
+	chartRef := fmt.Sprintf("%s:%s", repo, summary.LatestVersion)
+	digest, err := crane.Digest(chartRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: resolving digest for %s: %v\n", chartRef, err)
+		return summary
+	}
+	_, descs, err := listReferrers(repo, digest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: listing referrers for %s: %v\n", chartRef, err)
+		return summary
+	}
+	summary.ReferrerCount = len(descs)
+	return summary
+}
+
+// latestVersion returns versions' highest entry by semver precedence
+// (wrapper versions are valid semver: "{upstream}-p{digest}" or legacy
+// "{upstream}-{N}" both parse as a base version plus prerelease/build
+// metadata), falling back to the lexicographically greatest raw string for
+// any that don't parse, so a malformed tag doesn't make the whole lookup
+// fail. Floating channel tags (see channelTagPattern — PushChannelTags'
+// "latest"/"stable"/"{major}"/"{major}.{minor}" aliases) are excluded from
+// the candidate set first: a bare "25.8" has no prerelease component, so
+// it would otherwise outrank the real "25.8.0-p1a2b3c4d" version tag it
+// points at by semver precedence alone.
+func latestVersion(versions []string) string {
+	var candidates []string
+	for _, v := range versions {
+		if !channelTagPattern.MatchString(v) {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = versions
+	}
+
+	sort.Strings(candidates)
+	best := candidates[len(candidates)-1]
+	var bestParsed *semver.Version
+
+	for _, v := range candidates {
+		parsed, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if bestParsed == nil || parsed.GreaterThan(bestParsed) {
+			bestParsed = parsed
+			best = v
+		}
+	}
+	return best
+}
+
+// chartRepoNames resolves which repo names under registry's "charts/" path
+// to list: just chart if set (skipping the registry-wide catalog walk,
+// since the caller already knows the exact name), else every catalog
+// entry under "<path>/charts/" filtered by prefix.
+func chartRepoNames(registry, prefix, chart string) ([]string, error) {
+	if chart != "" {
+		return []string{chart}, nil
+	}
+
+	host := hostOf(registry)
+	repos, err := crane.Catalog(host)
+	if err != nil {
+		return nil, fmt.Errorf("listing catalog for %s: %w", host, err)
+	}
+
+	chartsPath := strings.TrimSuffix(strings.TrimPrefix(registry, host+"/"), "/") + "/charts/"
+	if !strings.Contains(registry, "/") {
+		chartsPath = "charts/"
+	}
+
+	var names []string
+	for _, repo := range repos {
+		name, ok := strings.CutPrefix(repo, chartsPath)
+		if !ok || name == "" {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,41 @@
+package internal
+
+import "testing"
+
+func TestLatestVersionExcludesChannelTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     string
+	}{
+		{
+			name:     "channel tags outrank the real latest version by bare precedence",
+			versions: []string{"25.8.0-p1a2b3c4d", "latest", "stable", "25", "25.8"},
+			want:     "25.8.0-p1a2b3c4d",
+		},
+		{
+			name:     "multiple real versions, channel tags mixed in",
+			versions: []string{"25.7.0-pdeadbeef", "25.8.0-p1a2b3c4d", "latest", "25.8"},
+			want:     "25.8.0-p1a2b3c4d",
+		},
+		{
+			name:     "legacy numeric versions, no channel tags at all",
+			versions: []string{"25.8.0-0", "25.8.0-1", "25.8.0-2"},
+			want:     "25.8.0-2",
+		},
+		{
+			name:     "only channel tags present falls back to them",
+			versions: []string{"latest", "stable"},
+			want:     "stable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := latestVersion(tt.versions)
+			if got != tt.want {
+				t.Errorf("latestVersion(%v) = %q, want %q", tt.versions, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/verity-org/verity/internal/attest"
+)
+
+// VerifyOpts controls the optional cosign checks discoverRegistryVersions
+// runs against each published chart tag it pulls. The zero value runs no
+// checks, leaving SiteChart.SignatureStatus empty.
+type VerifyOpts struct {
+	// RequireSignature verifies the chart's cosign signature (see
+	// VerifyChartSignature).
+	RequireSignature bool
+	// RequireAttestation verifies the chart's vuln-report attestation, if
+	// any (see VerifyReportAttestation).
+	RequireAttestation bool
+	// TrustedIdentities restricts keyless verification to these Fulcio
+	// certificate identities (e.g. a GitHub Actions workflow ref). Only the
+	// first entry is used today — this is a slice to match how
+	// cosign verify --certificate-identity-regexp callers typically
+	// configure a small allowlist, not because multiple are checked.
+	TrustedIdentities []string
+}
+
+// VerifyChartSignature verifies ref's cosign signature — keyless by
+// default, restricted to opts.TrustedIdentities when set. It does not
+// require an attestation to be present, since PublishChart signs a chart's
+// OCI artifact but never attests it (see internal/attest.SignImage vs.
+// AttestImage).
+func VerifyChartSignature(ref string, opts VerifyOpts) error {
+	return attest.VerifySignatureOnly(context.Background(), ref, chartVerifyOptions(opts))
+}
+
+// VerifyReportAttestation verifies ref's cosign attestations and, if one
+// carries a Trivy vulnerability report under attest.VulnReportPredicateType,
+// parses and returns it. Returns a nil report (no error) when verification
+// succeeds but ref has no such attestation — most charts today, since
+// nothing in this repo pushes vuln reports as chart attestations yet; reports
+// are still read from the embedded chart package or via the referrers API
+// (see fetchChartReportsViaReferrers) until a publisher attaches one.
+func VerifyReportAttestation(ref string, opts VerifyOpts) (*trivyReportFull, error) {
+	predicate, err := attest.FetchVerifiedAttestationPredicate(context.Background(), ref, attest.VulnReportPredicateType, chartVerifyOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	if predicate == nil {
+		return nil, nil
+	}
+	var report trivyReportFull
+	if err := json.Unmarshal(predicate, &report); err != nil {
+		return nil, fmt.Errorf("parsing vuln report attestation for %s: %w", ref, err)
+	}
+	return &report, nil
+}
+
+func chartVerifyOptions(opts VerifyOpts) attest.VerifyOptions {
+	v := attest.VerifyOptions{}
+	if len(opts.TrustedIdentities) > 0 {
+		v.CertIdentity = opts.TrustedIdentities[0]
+	}
+	return v
+}
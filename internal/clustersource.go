@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DiscoverySource produces a flat list of discovered images from some
+// origin — a Chart.yaml's dependency tree, or a live Kubernetes cluster —
+// so DiscoverImages-style callers can merge sources that otherwise share
+// no machinery. Each ImageDiscovery's Path encodes where it came from
+// (an existing values-file path for a chart hit, "pod/<ns>/<name>/<container>"
+// for a cluster hit — see ClusterSource), so the assemble step can tell
+// whether an image has a chart to round-trip an override into at all (see
+// orphanImages).
+type DiscoverySource interface {
+	Discover(ctx context.Context) ([]ImageDiscovery, error)
+}
+
+// ChartFileSource is the DiscoverySource wrapping chart-tree discovery:
+// the standalone chart in ./charts/standalone (if present) plus every
+// dependency chart, scanned concurrently across Jobs workers (see
+// discoverDependenciesConcurrently). This is the traversal DiscoverImages
+// has always done; it's extracted here so ClusterSource can be merged
+// alongside it without DiscoverImages needing to know which sources ran.
+type ChartFileSource struct {
+	ChartFile string
+	TmpDir    string
+	Jobs      int
+
+	// Charts is populated by Discover with one ChartDiscovery per
+	// dependency (plus "standalone" when present), so callers that need
+	// the chart grouping (DiscoverImages' manifest.Charts, used to build
+	// wrapper charts) don't have to re-derive it from Path.
+	Charts []ChartDiscovery
+	// Reports is populated by Discover with any per-dependency failures,
+	// mirroring DiscoverImages' own second return value.
+	Reports []DiscoveryReport
+}
+
+// Discover implements DiscoverySource. ctx bounds the appVersion-fallback
+// tag probes ScanForImages may run for the standalone chart; dependency
+// downloads/scans still have no cancellation points of their own (see
+// discoverDependenciesConcurrently).
+func (s *ChartFileSource) Discover(ctx context.Context) ([]ImageDiscovery, error) {
+	chart, err := ParseChartFile(s.ChartFile)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", s.ChartFile, err)
+	}
+
+	var images []ImageDiscovery
+
+	// Handle standalone chart (local directory, not a Helm dependency).
+	standalonePath := filepath.Join(filepath.Dir(s.ChartFile), "charts", "standalone")
+	if _, err := os.Stat(standalonePath); err == nil {
+		fmt.Println("Discovering standalone@0.0.0")
+		found, err := ScanForImages(ctx, standalonePath)
+		if err != nil {
+			return nil, fmt.Errorf("scanning standalone: %w", err)
+		}
+
+		if len(found) > 0 {
+			cd := ChartDiscovery{
+				Name:       "standalone",
+				Version:    "0.0.0",
+				Repository: "file://./charts/standalone",
+			}
+			for _, img := range found {
+				cd.Images = append(cd.Images, ImageDiscovery(img))
+			}
+			fmt.Printf("  Found %d images\n", len(found))
+			s.Charts = append(s.Charts, cd)
+			images = append(images, cd.Images...)
+		}
+	}
+
+	depCharts, depImages, reports := discoverDependenciesConcurrently(chart.Dependencies, s.TmpDir, s.Jobs)
+	s.Charts = append(s.Charts, depCharts...)
+	s.Reports = reports
+	for _, img := range depImages {
+		images = append(images, ImageDiscovery(img))
+	}
+
+	return images, nil
+}
+
+// ClusterSource is the DiscoverySource that talks to a live Kubernetes
+// cluster instead of a chart tree: it lists every Pod matching Namespace
+// (all namespaces when empty) and LabelSelector, and yields one
+// ImageDiscovery per container/initContainer, Path-tagged with
+// "pod/<namespace>/<pod-name>/<container-name>" instead of a values-file
+// path. This catches what ChartFileSource can't see from a chart alone —
+// image overrides applied at install time, sidecars an admission
+// controller injected, and initContainers — by scanning what's actually
+// running rather than what a chart claims it runs. A ClusterSource image
+// has no owning ChartDiscovery, so AssembleResults skips wrapper-chart
+// creation for it and records the patched ref in patched-images.json
+// instead (see orphanImages/writeOrphanImagePatches).
+type ClusterSource struct {
+	// Kubeconfig is the path to a kubeconfig file; empty uses the
+	// in-cluster config (see rest.InClusterConfig), for running as a Job
+	// inside the cluster being scanned.
+	Kubeconfig string
+	// Namespace restricts the Pod listing to one namespace; empty lists
+	// every namespace the caller's credentials can see.
+	Namespace string
+	// LabelSelector further restricts the listing (see
+	// metav1.ListOptions.LabelSelector), e.g.
+	// "app.kubernetes.io/managed-by=Helm" to scope to Helm-managed
+	// workloads only.
+	LabelSelector string
+	// Context selects a named context from Kubeconfig (empty uses that
+	// kubeconfig's current-context); ignored when Kubeconfig is empty,
+	// since the in-cluster config has no notion of contexts.
+	Context string
+}
+
+// Discover implements DiscoverySource.
+func (s *ClusterSource) Discover(ctx context.Context) ([]ImageDiscovery, error) {
+	config, err := clusterRESTConfig(s.Kubeconfig, s.Context)
+	if err != nil {
+		return nil, fmt.Errorf("loading kube config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating kube client: %w", err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: s.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	var images []ImageDiscovery
+	for _, pod := range pods.Items {
+		// initContainers run to completion before the main containers
+		// start, and ephemeralContainers are injected after the Pod is
+		// already running (e.g. via `kubectl debug`) — both can carry
+		// their own fixable vulns (e.g. a vault-agent-init sidecar or a
+		// debug toolbox image left attached), so they're discovered the
+		// same as ordinary containers rather than skipped.
+		containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Spec.EphemeralContainers))
+		containers = append(containers, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, ec := range pod.Spec.EphemeralContainers {
+			containers = append(containers, corev1.Container(ec.EphemeralContainerCommon))
+		}
+
+		for _, c := range containers {
+			key := pod.Namespace + "/" + pod.Name + "/" + c.Name
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			img := parseRef(c.Image)
+			img.Path = fmt.Sprintf("pod/%s/%s/%s", pod.Namespace, pod.Name, c.Name)
+			images = append(images, ImageDiscovery(img))
+		}
+	}
+
+	return images, nil
+}
+
+// clusterRESTConfig builds a *rest.Config from kubeconfig using kubeContext
+// (empty uses that kubeconfig's current-context), or the in-cluster config
+// when kubeconfig is empty — the same fallback kubectl/client-go tooling
+// uses, so ClusterSource behaves identically whether run from an
+// operator's workstation or as a Job inside the cluster it's scanning.
+func clusterRESTConfig(kubeconfig, kubeContext string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return rest.InClusterConfig()
+	}
+	overrides := &clientcmd.ConfigOverrides{}
+	if kubeContext != "" {
+		overrides.CurrentContext = kubeContext
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		overrides,
+	).ClientConfig()
+}
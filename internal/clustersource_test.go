@@ -0,0 +1,17 @@
+package internal
+
+import "testing"
+
+func TestClusterRESTConfig_NoKubeconfigOutsideCluster(t *testing.T) {
+	// Outside a Pod there's no in-cluster service account to read, so this
+	// must fail rather than silently return a zero-value config.
+	if _, err := clusterRESTConfig("", ""); err == nil {
+		t.Error("clusterRESTConfig(\"\", \"\") expected an error outside a cluster, got nil")
+	}
+}
+
+func TestClusterRESTConfig_MissingKubeconfigFile(t *testing.T) {
+	if _, err := clusterRESTConfig("/nonexistent/kubeconfig", ""); err == nil {
+		t.Error("clusterRESTConfig() expected an error for a missing kubeconfig file, got nil")
+	}
+}
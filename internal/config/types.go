@@ -8,6 +8,27 @@ type CopaConfig struct {
 	Charts     []ChartSpec         `yaml:"charts,omitempty"`
 	Images     []ImageSpec         `yaml:"images"`
 	Overrides  map[string]Override `yaml:"overrides,omitempty"` // deprecated: use verity.yaml
+
+	// Mirrors is the default ordered list of registry mirrors for every
+	// image in Images that doesn't declare its own. See Mirror.
+	Mirrors []Mirror `yaml:"mirrors,omitempty"`
+
+	// Filters is the default include/exclude filter applied to every
+	// discovered image. Chart-level ChartSpec.Filters are evaluated in
+	// addition to, not instead of, this default. See Filters.
+	Filters Filters `yaml:"filters,omitempty"`
+}
+
+// Filters holds include/exclude regexp patterns matched against a
+// discovered image's fully-qualified reference, letting operators scope
+// patching without editing upstream chart values (e.g. to drop
+// busybox-style utility or test-pod images a chart's templates pull in).
+// When Include is non-empty, a reference must match at least one Include
+// pattern to survive; a reference matching any Exclude pattern is always
+// dropped, even if it also matches an Include pattern.
+type Filters struct {
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
 }
 
 // VerityConfig represents verity.yaml — verity-specific settings that belong
@@ -23,6 +44,22 @@ type ImageSpec struct {
 	Tags      TagStrategy `yaml:"tags"`
 	Target    TargetSpec  `yaml:"target,omitempty"`
 	Platforms []string    `yaml:"platforms,omitempty"`
+
+	// Mirrors is an ordered list of registry mirrors to prefer over Image's
+	// own registry. When empty, CopaConfig.Mirrors is used instead. See
+	// Mirror.
+	Mirrors []Mirror `yaml:"mirrors,omitempty"`
+}
+
+// Mirror describes one registry mirror for a source image, borrowing the
+// mirrorByDigestOnly semantics from containers/image's registries.conf: a
+// mirror flagged MirrorByDigestOnly is only eligible to satisfy a
+// digest-pinned pull, never a plain tag — the mirror's copy of a tag can
+// drift from the upstream of record, but a digest can't, so tag-based
+// pulls always fall through to Image's own registry.
+type Mirror struct {
+	Location           string `yaml:"location"`
+	MirrorByDigestOnly bool   `yaml:"mirrorByDigestOnly,omitempty"`
 }
 
 // TargetSpec describes where to push the patched image.
@@ -38,6 +75,37 @@ type TagStrategy struct {
 	MaxTags  int      `yaml:"maxTags,omitempty"`
 	List     []string `yaml:"list,omitempty"`
 	Exclude  []string `yaml:"exclude,omitempty"`
+
+	// MinSeverity is the minimum fixable-vulnerability severity
+	// ("LOW"/"MEDIUM"/"HIGH"/"CRITICAL") the "vulnerable" strategy requires
+	// a tag to have before including it; defaults to "HIGH".
+	MinSeverity string `yaml:"minSeverity,omitempty"`
+
+	// Scanner selects the vulnerability scanner backend the "vulnerable"
+	// strategy queries: a "trivy://host:port" or "clair://host:port" URL.
+	// Defaults to a local Trivy server.
+	Scanner string `yaml:"scanner,omitempty"`
+
+	// Constraint is a Masterminds/semver constraint expression (e.g.
+	// ">=1.25.0, <1.27.0 || ~1.28") the "constraint" strategy checks each
+	// tag against, in place of Pattern's regex. MinVersion/MaxVersion are
+	// a shorthand that compile down to an equivalent constraint when
+	// Constraint itself is empty.
+	Constraint string `yaml:"constraint,omitempty"`
+
+	// MinVersion/MaxVersion bound the "constraint" strategy the same way
+	// Constraint does, for the common case of a plain inclusive-min,
+	// exclusive-max range that doesn't need a full constraint expression.
+	// Ignored when Constraint is set.
+	MinVersion string `yaml:"minVersion,omitempty"`
+	MaxVersion string `yaml:"maxVersion,omitempty"`
+
+	// PerMinor, when set, keeps only the highest PerMinor patch releases
+	// within each major.minor bucket (e.g. PerMinor: 1 keeps just the
+	// latest 1.25.x and the latest 1.26.x) instead of every tag the
+	// constraint/pattern matched. Applied after Exclude and before
+	// MaxTags.
+	PerMinor int `yaml:"perMinor,omitempty"`
 }
 
 // ChartSpec describes a Helm chart from which to extract images.
@@ -47,6 +115,36 @@ type ChartSpec struct {
 	Name       string `yaml:"name"`
 	Version    string `yaml:"version"`
 	Repository string `yaml:"repository"`
+
+	// Values are rendered into the chart on top of its own defaults, the
+	// same way a user's `helm install -f values.yaml --set ...` would,
+	// so images gated behind an optional component or a non-default
+	// image.tag are still discovered.
+	Values map[string]any `yaml:"values,omitempty"`
+
+	// ValuesFrom points to a local YAML values file merged in underneath
+	// Values (Values wins on key conflicts), so verity.yaml can drive
+	// discovery for charts that gate images behind feature flags without
+	// inlining an entire values file.
+	ValuesFrom string `yaml:"valuesFrom,omitempty"`
+
+	// ValuesFiles is an ordered list of additional local YAML values
+	// files, merged on top of ValuesFrom and in order with each other
+	// (later files win on conflicting keys) — the discovery-time
+	// equivalent of `helm template -f a.yaml -f b.yaml`, so rendering
+	// reflects the same layered values users deploy with.
+	ValuesFiles []string `yaml:"valuesFiles,omitempty"`
+
+	// Set holds `helm template --set`-equivalent key=value overrides
+	// (Helm's own dotted strvals syntax, e.g. "image.tag"), applied last
+	// on top of ValuesFrom, ValuesFiles, and Values — so a pinned
+	// image.tag or an `enabled: true` toggle reaches discovery the same
+	// way a one-off --set would at install time.
+	Set map[string]string `yaml:"set,omitempty"`
+
+	// Filters is evaluated in addition to CopaConfig.Filters for images
+	// discovered from this chart only. See Filters.
+	Filters Filters `yaml:"filters,omitempty"`
 }
 
 // HelmChartFile represents a minimal Helm Chart.yaml, used only for reading
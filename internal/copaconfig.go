@@ -3,7 +3,9 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +38,21 @@ func ParseCopaOutput(path string) (*CopaOutput, error) {
 	return &output, nil
 }
 
+// Transport prefixes for air-gapped image refs, named after the
+// containers/image transports they mirror: TransportOCI points at a local
+// OCI image-layout directory, TransportOCIArchive at a tar of one (e.g.
+// `skopeo copy docker://... oci-archive:bundle.tar`).
+const (
+	TransportOCI        = "oci:"
+	TransportOCIArchive = "oci-archive:"
+)
+
+// IsLayoutRef reports whether ref is a transport-prefixed local path
+// (TransportOCI or TransportOCIArchive) rather than a registry reference.
+func IsLayoutRef(ref string) bool {
+	return strings.HasPrefix(ref, TransportOCI) || strings.HasPrefix(ref, TransportOCIArchive)
+}
+
 // ParseImageRef parses a full image reference into registry, repository, and tag.
 // Handles both tag-based and digest-based references:
 // - "ghcr.io/verity-org/nginx:1.25.3" -> registry="ghcr.io", repository="verity-org/nginx", tag="1.25.3"
@@ -43,7 +60,16 @@ func ParseCopaOutput(path string) (*CopaOutput, error) {
 // - "nginx:1.25@sha256:abc" -> registry="", repository="nginx", tag="sha256:abc" (digest takes precedence)
 //
 // Note: For digest references, the entire digest (e.g., "sha256:abc123") is returned as the tag.
+//
+// A transport-prefixed ref (see IsLayoutRef) isn't a registry/repository/tag
+// triple at all, so it's returned unchanged as repository with registry and
+// tag both empty — callers that only care about round-tripping a ref (e.g.
+// NormalizeImageRef) see it untouched, and PatchImage's own SourceLayout
+// handling parses the transport prefix itself.
 func ParseImageRef(ref string) (registry, repository, tag string) {
+	if IsLayoutRef(ref) {
+		return "", ref, ""
+	}
 	// Check for digest first (@ separator) - digests take precedence over tags
 	if idx := strings.Index(ref, "@"); idx != -1 {
 		tag = ref[idx+1:] // Everything after @ is the digest (e.g., "sha256:abc123")
@@ -81,7 +107,13 @@ func ParseImageRef(ref string) (registry, repository, tag string) {
 
 // NormalizeImageRef converts an image reference to a canonical form for comparison.
 // Adds docker.io registry if missing, normalizes library/ prefix.
+// A transport-prefixed ref (see IsLayoutRef) has no registry to default or
+// repository to prefix, so it's returned unchanged.
 func NormalizeImageRef(ref string) string {
+	if IsLayoutRef(ref) {
+		return ref
+	}
+
 	registry, repository, tag := ParseImageRef(ref)
 
 	// Default to docker.io if no registry
@@ -101,3 +133,39 @@ func NormalizeImageRef(ref string) string {
 
 	return result
 }
+
+// NormalizeRegistryURL validates and canonicalizes a user-supplied OCI
+// registry, mirroring fluxcd/source-controller's repository.NormalizeURL:
+// it strips an "oci://"/"https://"/"http://" scheme and trims trailing
+// slashes, so CreateWrapperChart always builds "{registry}/charts/{name}"
+// against a clean "host[:port][/org[/sub]]" instead of silently
+// mis-building a chartRef from "oci://ghcr.io/org/" and falling back to
+// patch level 0. Like an image ref's registry component, everything up to
+// the first "/" is the host; an org/sub-path after it (e.g.
+// "ghcr.io/verity-org") is left as-is.
+func NormalizeRegistryURL(raw string) (string, error) {
+	registry := raw
+	registry = strings.TrimPrefix(registry, "oci://")
+	registry = strings.TrimPrefix(registry, "https://")
+	registry = strings.TrimPrefix(registry, "http://")
+	registry = strings.TrimRight(registry, "/")
+
+	if registry == "" {
+		return "", fmt.Errorf("registry %q: host is empty", raw)
+	}
+
+	host := registry
+	if idx := strings.Index(host, "/"); idx != -1 {
+		host = host[:idx]
+	}
+	if host == "" {
+		return "", fmt.Errorf("registry %q: host is empty", raw)
+	}
+	if _, port, err := net.SplitHostPort(host); err == nil {
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return "", fmt.Errorf("registry %q: invalid port %q", raw, port)
+		}
+	}
+
+	return registry, nil
+}
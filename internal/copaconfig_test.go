@@ -56,6 +56,20 @@ func TestParseImageRef(t *testing.T) {
 			wantRepository: "myimage",
 			wantTag:        "latest",
 		},
+		{
+			name:           "oci layout ref is returned unchanged",
+			ref:            "oci:/mnt/bundle",
+			wantRegistry:   "",
+			wantRepository: "oci:/mnt/bundle",
+			wantTag:        "",
+		},
+		{
+			name:           "oci-archive ref is returned unchanged",
+			ref:            "oci-archive:/mnt/bundle.tar",
+			wantRegistry:   "",
+			wantRepository: "oci-archive:/mnt/bundle.tar",
+			wantTag:        "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -110,6 +124,11 @@ func TestNormalizeImageRef(t *testing.T) {
 			ref:  "grafana/grafana:12.3.3",
 			want: "docker.io/grafana/grafana:12.3.3",
 		},
+		{
+			name: "oci layout ref is returned unchanged",
+			ref:  "oci:/mnt/bundle",
+			want: "oci:/mnt/bundle",
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,6 +140,41 @@ func TestNormalizeImageRef(t *testing.T) {
 	}
 }
 
+func TestNormalizeRegistryURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare host", raw: "ghcr.io", want: "ghcr.io"},
+		{name: "oci scheme", raw: "oci://ghcr.io/org/", want: "ghcr.io/org"},
+		{name: "https scheme", raw: "https://ghcr.io", want: "ghcr.io"},
+		{name: "trailing slash", raw: "ghcr.io/", want: "ghcr.io"},
+		{name: "host with port", raw: "localhost:5000", want: "localhost:5000"},
+		{name: "empty host", raw: "oci://", wantErr: true},
+		{name: "invalid port", raw: "localhost:notaport", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeRegistryURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NormalizeRegistryURL(%q) = %q, want an error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeRegistryURL(%q) failed: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRegistryURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseCopaOutput(t *testing.T) {
 	tmpDir := t.TempDir()
 	copaOutputPath := filepath.Join(tmpDir, "copa-output.json")
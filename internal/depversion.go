@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"gopkg.in/yaml.v3"
+)
+
+// errNoMatchingVersion is returned by ResolveDependencyVersion when dep's
+// repository has no published version satisfying dep.Version's constraint.
+var errNoMatchingVersion = errors.New("no matching dependency version")
+
+// repoIndexFile is the subset of a classic Helm chart repository's
+// index.yaml this package reads: just enough to list the published
+// versions of one chart by name.
+type repoIndexFile struct {
+	Entries map[string][]struct {
+		Version string `yaml:"version"`
+	} `yaml:"entries"`
+}
+
+// isVersionRange reports whether v is a semver wildcard or range
+// expression (e.g. "1.25.x", "^2.0.0", "*", ">=1.2 <2.0") rather than a
+// single concrete version — anything semver.NewVersion can't parse as an
+// exact version.
+func isVersionRange(v string) bool {
+	if v == "" {
+		return true
+	}
+	_, err := semver.NewVersion(v)
+	return err != nil
+}
+
+// ResolveDependencyVersion pins dep.Version to a concrete upstream
+// version when it's a semver wildcard/range (see isVersionRange),
+// fetching dep.Repository's published versions — via crane.ListTags for
+// an "oci://" repository, or index.yaml for a classic HTTP one — and
+// picking the highest one satisfying the constraint. A dep.Version that's
+// already a concrete version is returned unchanged, with no network
+// access at all.
+//
+// CreateWrapperChart calls this before writing Chart.yaml and before
+// computing the wrapper's own patch level, so both are pinned against the
+// same concrete upstream version rather than the range expression.
+func ResolveDependencyVersion(dep Dependency) (string, error) {
+	if !isVersionRange(dep.Version) {
+		return dep.Version, nil
+	}
+
+	constraint, err := semver.NewConstraint(dep.Version)
+	if err != nil {
+		return "", fmt.Errorf("parsing version constraint %q for %s: %w", dep.Version, dep.Name, err)
+	}
+
+	var candidates []string
+	switch {
+	case strings.HasPrefix(dep.Repository, "oci://"):
+		chartRef := dep.Repository + "/" + dep.Name
+		candidates, err = crane.ListTags(chartRef)
+		if err != nil {
+			return "", fmt.Errorf("listing tags for %s: %w", chartRef, err)
+		}
+	case dep.Repository == "" || strings.HasPrefix(dep.Repository, "file://"):
+		return "", fmt.Errorf("%w: %s is a vendored dependency with no registry to resolve %q against", errNoMatchingVersion, dep.Name, dep.Version)
+	default:
+		candidates, err = fetchIndexVersions(dep.Repository, dep.Name)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	best, ok := pickHighestMatching(candidates, constraint)
+	if !ok {
+		return "", fmt.Errorf("%w: no version of %s matches %q", errNoMatchingVersion, dep.Name, dep.Version)
+	}
+	return best, nil
+}
+
+// pickHighestMatching returns the highest version in candidates satisfying
+// constraint, skipping any candidate that isn't valid semver.
+func pickHighestMatching(candidates []string, constraint *semver.Constraints) (string, bool) {
+	var best *semver.Version
+	var bestRaw string
+	for _, c := range candidates {
+		v, err := semver.NewVersion(c)
+		if err != nil {
+			continue
+		}
+		if !constraint.Check(v) {
+			continue
+		}
+		if best == nil || v.GreaterThan(best) {
+			best = v
+			bestRaw = c
+		}
+	}
+	return bestRaw, best != nil
+}
+
+// fetchIndexVersions downloads repoURL's index.yaml and returns every
+// published version of chartName.
+func fetchIndexVersions(repoURL, chartName string) ([]string, error) {
+	indexURL := strings.TrimSuffix(repoURL, "/") + "/index.yaml"
+	client, err := activeRepoConfig.HTTPClient(indexURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(indexURL) //nolint:noctx // TODO: add context support
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", indexURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", indexURL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", indexURL, err)
+	}
+
+	var idx repoIndexFile
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", indexURL, err)
+	}
+	entries, ok := idx.Entries[chartName]
+	if !ok {
+		return nil, fmt.Errorf("%w: no entry for %s in %s", errNoMatchingVersion, chartName, indexURL)
+	}
+	versions := make([]string, len(entries))
+	for i, e := range entries {
+		versions[i] = e.Version
+	}
+	return versions, nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func TestIsVersionRange(t *testing.T) {
+	tests := map[string]bool{
+		"1.25.0":      false,
+		"1.25.0-rc.1": false,
+		"":            true,
+		"1.25.x":      true,
+		"^2.0.0":      true,
+		"~1.2":        true,
+		"*":           true,
+		">=1.2 <2.0":  true,
+	}
+	for v, want := range tests {
+		if got := isVersionRange(v); got != want {
+			t.Errorf("isVersionRange(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestPickHighestMatching(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		constraint string
+		want       string
+		wantOK     bool
+	}{
+		{
+			name:       "picks the highest satisfying version",
+			candidates: []string{"1.24.0", "1.25.0", "1.25.3", "2.0.0"},
+			constraint: "^1.25",
+			want:       "1.25.3",
+			wantOK:     true,
+		},
+		{
+			name:       "ignores non-semver candidates",
+			candidates: []string{"latest", "1.25.0", "not-a-version"},
+			constraint: "^1.25",
+			want:       "1.25.0",
+			wantOK:     true,
+		},
+		{
+			name:       "no match returns false",
+			candidates: []string{"1.0.0", "1.1.0"},
+			constraint: "^2.0",
+			want:       "",
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, err := semver.NewConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("semver.NewConstraint(%q) failed: %v", tt.constraint, err)
+			}
+			got, ok := pickHighestMatching(tt.candidates, constraint)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("pickHighestMatching() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResolveDependencyVersion_ConcreteVersionSkipsNetwork(t *testing.T) {
+	dep := Dependency{Name: "redis", Version: "18.1.5", Repository: "oci://registry.example.com/charts"}
+	got, err := ResolveDependencyVersion(dep)
+	if err != nil {
+		t.Fatalf("ResolveDependencyVersion() failed: %v", err)
+	}
+	if got != dep.Version {
+		t.Errorf("ResolveDependencyVersion() = %q, want %q", got, dep.Version)
+	}
+}
+
+func TestResolveDependencyVersion_VendoredDependencyRejectsRange(t *testing.T) {
+	dep := Dependency{Name: "inner", Version: "^1.0.0", Repository: "file://../inner"}
+	if _, err := ResolveDependencyVersion(dep); err == nil {
+		t.Error("expected an error resolving a range against a vendored dependency")
+	}
+}
+
+func TestResolveDependencyVersion_ClassicRepoIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`entries:
+  redis:
+    - version: 18.1.0
+    - version: 18.1.5
+    - version: 19.0.0
+`))
+	}))
+	defer srv.Close()
+
+	dep := Dependency{Name: "redis", Version: "^18.0.0", Repository: srv.URL}
+	got, err := ResolveDependencyVersion(dep)
+	if err != nil {
+		t.Fatalf("ResolveDependencyVersion() failed: %v", err)
+	}
+	if got != "18.1.5" {
+		t.Errorf("ResolveDependencyVersion() = %q, want %q", got, "18.1.5")
+	}
+}
+
+func TestResolveDependencyVersion_ClassicRepoNoMatchingVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`entries:
+  redis:
+    - version: 18.1.0
+`))
+	}))
+	defer srv.Close()
+
+	dep := Dependency{Name: "redis", Version: "^99.0.0", Repository: srv.URL}
+	if _, err := ResolveDependencyVersion(dep); err == nil {
+		t.Error("expected an error when no published version satisfies the constraint")
+	}
+}
@@ -2,16 +2,25 @@ package discovery
 
 import (
 	"bytes"
-	"context"
 	"errors"
 	"fmt"
 	"io"
-	"os/exec"
+	"os"
 	"sort"
 	"strings"
-	"time"
+	"sync"
 
+	"github.com/distribution/reference"
 	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/strvals"
 
 	"github.com/verity-org/verity/internal/config"
 )
@@ -23,27 +32,25 @@ var (
 	ErrInvalidChartRepo    = errors.New("chart repository must start with oci://, https://, or http://")
 )
 
-// ExtractChartImages runs helm template for a chart and returns all unique image references found.
-// Overrides are applied to substitute tag variants (e.g., distroless-libc → debian).
+// renderNamespace is the ephemeral namespace used only to satisfy charts
+// whose templates reference .Release.Namespace; nothing is actually
+// installed into a cluster.
+const renderNamespace = "verity-discover"
+
+// ExtractChartImages renders chart with the Helm Go SDK and returns all
+// unique image references found. Overrides are applied to substitute tag
+// variants (e.g., distroless-libc → debian).
 func ExtractChartImages(chart config.ChartSpec, overrides map[string]config.Override) ([]string, error) {
 	if err := validateChartSpec(chart); err != nil {
 		return nil, err
 	}
 
-	args := helmTemplateArgs(chart)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "helm", args...)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("helm template %s: %w\nstderr: %s", chart.Name, err, stderr.String())
+	manifest, err := renderChart(chart)
+	if err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", chart.Name, err)
 	}
 
-	images, err := extractImagesFromManifests(stdout.Bytes())
+	images, err := extractImagesFromManifests([]byte(manifest))
 	if err != nil {
 		return nil, fmt.Errorf("extracting images from %s manifests: %w", chart.Name, err)
 	}
@@ -55,22 +62,282 @@ func ExtractChartImages(chart config.ChartSpec, overrides map[string]config.Over
 	return result, nil
 }
 
-// helmTemplateArgs builds the helm template argument list for a chart spec.
-func helmTemplateArgs(chart config.ChartSpec) []string {
-	if strings.HasPrefix(chart.Repository, "oci://") {
-		// OCI registry: helm template <name> <oci-repo>/<name> --version <ver>
-		return []string{
-			"template", chart.Name,
-			chart.Repository + "/" + chart.Name,
-			"--version", chart.Version,
+var (
+	helmSettingsOnce sync.Once
+	helmSettings     *cli.EnvSettings
+)
+
+// sharedHelmSettings returns a *cli.EnvSettings backed by one cache
+// directory reused across every renderChart call in a Discover run, so
+// HTTPS repo index files (and anything else the Helm SDK caches) are
+// fetched once per run instead of once per chart.
+func sharedHelmSettings() *cli.EnvSettings {
+	helmSettingsOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "verity-discover-helm-cache-*")
+		if err != nil {
+			dir = os.TempDir()
+		}
+		settings := cli.New()
+		settings.RepositoryCache = dir
+		settings.RepositoryConfig = dir + "/repositories.yaml"
+		helmSettings = settings
+	})
+	return helmSettings
+}
+
+// renderChart downloads chart (OCI or HTTPS, via the Helm SDK rather than
+// the helm binary) and dry-run installs it client-side, returning the
+// concatenated rendered manifest the same way `helm template` would print
+// it to stdout.
+func renderChart(chart config.ChartSpec) (string, error) {
+	archivePath, cleanup, err := downloadChartArchive(chart)
+	if err != nil {
+		return "", fmt.Errorf("downloading chart: %w", err)
+	}
+	defer cleanup()
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("opening chart archive %s: %w", archivePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	ch, err := loader.LoadArchive(f)
+	if err != nil {
+		return "", fmt.Errorf("loading chart archive %s: %w", archivePath, err)
+	}
+
+	if err := resolveDependencies(ch); err != nil {
+		return "", fmt.Errorf("resolving dependencies of %s: %w", chart.Name, err)
+	}
+
+	values, err := resolveValues(chart)
+	if err != nil {
+		return "", err
+	}
+
+	install := action.NewInstall(&action.Configuration{})
+	install.ClientOnly = true
+	install.DryRun = true
+	install.IncludeCRDs = true
+	install.ReleaseName = chart.Name
+	install.Namespace = renderNamespace
+
+	rel, err := install.Run(ch, values)
+	if err != nil {
+		return "", fmt.Errorf("templating chart %s: %w", chart.Name, err)
+	}
+	return rel.Manifest, nil
+}
+
+// resolveDependencies fills in any subchart declared in ch.Metadata.Dependencies
+// but missing from ch.Dependencies() — i.e. a dependency whose publisher didn't
+// vendor it into the chart archive via `helm dependency build` — by downloading
+// and attaching it, so images that only appear in an un-vendored subchart's
+// templates are still discovered. Dependencies with no repository (Helm's
+// convention for a chart bundled alongside its parent, e.g. "file://../sub")
+// are skipped: they're expected to already be vendored in the archive.
+func resolveDependencies(ch *chart.Chart) error {
+	loaded := make(map[string]bool, len(ch.Dependencies()))
+	for _, d := range ch.Dependencies() {
+		loaded[d.Name()] = true
+	}
+
+	for _, dep := range ch.Metadata.Dependencies {
+		if loaded[dep.Name] {
+			continue
+		}
+		if dep.Repository == "" || strings.HasPrefix(dep.Repository, "file://") {
+			continue
+		}
+
+		subChart, err := loadDependencyChart(config.ChartSpec{
+			Name:       dep.Name,
+			Version:    dep.Version,
+			Repository: dep.Repository,
+		})
+		if err != nil {
+			return fmt.Errorf("loading dependency %s: %w", dep.Name, err)
+		}
+		ch.AddDependency(subChart)
+	}
+	return nil
+}
+
+// loadDependencyChart downloads and loads a single subchart dependency.
+func loadDependencyChart(spec config.ChartSpec) (*chart.Chart, error) {
+	archivePath, cleanup, err := downloadChartArchive(spec)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening chart archive %s: %w", archivePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return loader.LoadArchive(f)
+}
+
+// resolveValues layers chart's values the same way `helm template -f
+// <valuesFrom> -f <valuesFiles...> --set k=v ...` would: ValuesFrom (a
+// single legacy local YAML file) merges first, then ValuesFiles in order
+// (each later file winning on conflicting keys), then the inline Values
+// map, then Set's dotted key=value overrides last — so a chart that gates
+// an image behind an `enabled: true` toggle or a pinned `image.tag` is
+// rendered the same way users actually deploy it.
+func resolveValues(chart config.ChartSpec) (map[string]any, error) {
+	base := map[string]any{}
+
+	if chart.ValuesFrom != "" {
+		fileValues, err := loadValuesFile(chart.ValuesFrom)
+		if err != nil {
+			return nil, err
+		}
+		base = mergeValues(base, fileValues)
+	}
+	for _, path := range chart.ValuesFiles {
+		fileValues, err := loadValuesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		base = mergeValues(base, fileValues)
+	}
+
+	base = mergeValues(base, chart.Values)
+
+	keys := make([]string, 0, len(chart.Set))
+	for k := range chart.Set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := strvals.ParseInto(fmt.Sprintf("%s=%s", k, chart.Set[k]), base); err != nil {
+			return nil, fmt.Errorf("parsing set %s=%s: %w", k, chart.Set[k], err)
+		}
+	}
+
+	return base, nil
+}
+
+// loadValuesFile reads and parses a single local YAML values file.
+func loadValuesFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading values file %s: %w", path, err)
+	}
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parsing values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// mergeValues deep-merges override on top of base, the same "override wins,
+// recurse into common map keys" semantics Helm itself uses to layer -f/--set
+// values over a chart's defaults.
+func mergeValues(base, override map[string]any) map[string]any {
+	if base == nil {
+		base = map[string]any{}
+	}
+	for k, v := range override {
+		if baseMap, ok := base[k].(map[string]any); ok {
+			if overrideMap, ok := v.(map[string]any); ok {
+				base[k] = mergeValues(baseMap, overrideMap)
+				continue
+			}
 		}
+		base[k] = v
+	}
+	return base
+}
+
+// downloadChartArchive fetches chart's .tgz into a fresh temp directory,
+// returning its path and a cleanup func that removes the directory.
+func downloadChartArchive(chart config.ChartSpec) (path string, cleanup func(), err error) {
+	destDir, err := os.MkdirTemp("", "verity-discover-chart-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(destDir) }
+
+	var archivePath string
+	if strings.HasPrefix(chart.Repository, "oci://") {
+		archivePath, err = downloadOCIChart(chart, destDir)
+	} else {
+		archivePath, err = downloadHTTPSChart(chart, destDir)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return archivePath, cleanup, nil
+}
+
+// downloadOCIChart pulls an "oci://host/org" + name@version chart using
+// registry.NewClient, the same OCI client Helm's own `helm pull` uses for
+// OCI registries.
+func downloadOCIChart(chart config.ChartSpec, destDir string) (string, error) {
+	settings := sharedHelmSettings()
+
+	regClient, err := registry.NewClient()
+	if err != nil {
+		return "", fmt.Errorf("creating registry client: %w", err)
+	}
+
+	dl := downloader.ChartDownloader{
+		Out:              io.Discard,
+		Verify:           downloader.VerifyNever,
+		Getters:          getter.All(settings),
+		RegistryClient:   regClient,
+		RepositoryConfig: settings.RepositoryConfig,
+		RepositoryCache:  settings.RepositoryCache,
+	}
+
+	ref := chart.Repository + "/" + chart.Name
+	archivePath, _, err := dl.DownloadTo(ref, chart.Version, destDir)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s@%s: %w", ref, chart.Version, err)
+	}
+	return archivePath, nil
+}
+
+// downloadHTTPSChart resolves name@version against an HTTPS/HTTP chart
+// repository index (repo.NewChartRepository) and downloads the resulting
+// chart URL with a downloader.ChartDownloader, mirroring how Helm's own
+// `helm pull --repo <url>` locates and fetches a chart.
+func downloadHTTPSChart(chart config.ChartSpec, destDir string) (string, error) {
+	settings := sharedHelmSettings()
+	getters := getter.All(settings)
+
+	chartRepo, err := repo.NewChartRepository(&repo.Entry{URL: chart.Repository}, getters)
+	if err != nil {
+		return "", fmt.Errorf("configuring chart repository %s: %w", chart.Repository, err)
+	}
+	chartRepo.CachePath = settings.RepositoryCache
+	if _, err := chartRepo.DownloadIndexFile(); err != nil {
+		return "", fmt.Errorf("downloading index for %s: %w", chart.Repository, err)
+	}
+
+	chartURL, err := repo.FindChartInRepoURL(chart.Repository, chart.Name, chart.Version, "", "", "", getters)
+	if err != nil {
+		return "", fmt.Errorf("locating %s@%s in %s: %w", chart.Name, chart.Version, chart.Repository, err)
 	}
-	// HTTP repository: helm template <name> <name> --repo <url> --version <ver>
-	return []string{
-		"template", chart.Name, chart.Name,
-		"--repo", chart.Repository,
-		"--version", chart.Version,
+
+	dl := downloader.ChartDownloader{
+		Out:              io.Discard,
+		Verify:           downloader.VerifyNever,
+		Getters:          getters,
+		RepositoryConfig: settings.RepositoryConfig,
+		RepositoryCache:  settings.RepositoryCache,
+	}
+	archivePath, _, err := dl.DownloadTo(chartURL, "", destDir)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", chartURL, err)
 	}
+	return archivePath, nil
 }
 
 // extractImagesFromManifests parses multi-document Helm YAML output and collects unique image references.
@@ -96,8 +363,7 @@ func extractImagesFromManifests(data []byte) ([]string, error) {
 	return result, nil
 }
 
-// validateChartSpec checks that ChartSpec fields are safe to pass to helm.
-// Guards against argument injection (e.g., names or versions starting with "--").
+// validateChartSpec checks that ChartSpec fields are safe to render.
 func validateChartSpec(chart config.ChartSpec) error {
 	if strings.HasPrefix(chart.Name, "-") {
 		return fmt.Errorf("%w: %q", ErrInvalidChartName, chart.Name)
@@ -139,33 +405,64 @@ func walkNode(node any, seen map[string]struct{}, result *[]string) {
 // The map key is a partial image path; if the image contains it and its tag ends with
 // "-<from>", that suffix is replaced with "-<to>". Only the tag portion is rewritten.
 // Keys are evaluated in sorted order for deterministic behavior when multiple match.
+//
+// image is parsed with reference.ParseNormalizedNamed rather than splitting on the
+// last ":"/"/" by hand, so a registry port (localhost:5000/foo:1.0) or an
+// existing digest (foo:1.0@sha256:...) don't get mistaken for the tag separator.
 func applyOverride(image string, overrides map[string]config.Override) string {
+	_, override, matched := findMatchingOverride(image, overrides)
+	if !matched {
+		return image
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return image
+	}
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return image
+	}
+	tag := tagged.Tag()
+	suffix := "-" + override.From
+	newTag := tag[:len(tag)-len(suffix)] + "-" + override.To
+	newRef, err := reference.WithTag(named, newTag)
+	if err != nil {
+		return image
+	}
+	return newRef.String()
+}
+
+// findMatchingOverride reports the first overrides entry (in sorted key
+// order, for deterministic behavior when multiple match) whose key is
+// contained in image and whose From suffix matches image's tag, along with
+// the key itself so callers can report which override rule fired.
+func findMatchingOverride(image string, overrides map[string]config.Override) (key string, override config.Override, matched bool) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", config.Override{}, false
+	}
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return "", config.Override{}, false
+	}
+	tag := tagged.Tag()
+
 	keys := make([]string, 0, len(overrides))
 	for k := range overrides {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	name, tag := splitRef(image)
-	for _, key := range keys {
-		override := overrides[key]
-		if strings.Contains(image, key) {
-			suffix := "-" + override.From
-			if strings.HasSuffix(tag, suffix) {
-				return name + ":" + tag[:len(tag)-len(suffix)] + "-" + override.To
-			}
+	for _, k := range keys {
+		ov := overrides[k]
+		if !strings.Contains(image, k) {
+			continue
 		}
+		if !strings.HasSuffix(tag, "-"+ov.From) {
+			continue
+		}
+		return k, ov, true
 	}
-	return image
-}
-
-// splitRef splits an image reference into its name and tag components.
-// e.g., "docker.io/foo/bar:1.0-alpine" → ("docker.io/foo/bar", "1.0-alpine").
-// Returns (image, "") if no tag separator follows the last slash.
-func splitRef(ref string) (name, tag string) {
-	lastSlash := strings.LastIndex(ref, "/")
-	if lastColon := strings.LastIndex(ref, ":"); lastColon > lastSlash {
-		return ref[:lastColon], ref[lastColon+1:]
-	}
-	return ref, ""
+	return "", config.Override{}, false
 }
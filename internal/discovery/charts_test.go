@@ -1,6 +1,9 @@
 package discovery
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/verity-org/verity/internal/config"
@@ -43,55 +46,134 @@ func TestApplyOverride(t *testing.T) {
 	}
 }
 
-func TestHelmTemplateArgs(t *testing.T) {
+func TestValidateChartSpec(t *testing.T) {
 	tests := []struct {
-		name  string
-		chart config.ChartSpec
-		want  []string
+		name    string
+		chart   config.ChartSpec
+		wantErr error
 	}{
 		{
-			name: "OCI repository",
-			chart: config.ChartSpec{
-				Name:       "prometheus",
-				Version:    "28.9.1",
-				Repository: "oci://ghcr.io/prometheus-community/charts",
-			},
-			want: []string{
-				"template", "prometheus",
-				"oci://ghcr.io/prometheus-community/charts/prometheus",
-				"--version", "28.9.1",
-			},
+			name:  "valid OCI repository",
+			chart: config.ChartSpec{Name: "prometheus", Version: "28.9.1", Repository: "oci://ghcr.io/prometheus-community/charts"},
+		},
+		{
+			name:  "valid HTTPS repository",
+			chart: config.ChartSpec{Name: "postgres-operator", Version: "1.15.1", Repository: "https://opensource.zalando.com/postgres-operator/charts"},
+		},
+		{
+			name:    "name starting with dash",
+			chart:   config.ChartSpec{Name: "-evil", Version: "1.0.0", Repository: "oci://ghcr.io/org/charts"},
+			wantErr: ErrInvalidChartName,
 		},
 		{
-			name: "HTTP repository",
-			chart: config.ChartSpec{
-				Name:       "postgres-operator",
-				Version:    "1.15.1",
-				Repository: "https://opensource.zalando.com/postgres-operator/charts/postgres-operator",
-			},
-			want: []string{
-				"template", "postgres-operator", "postgres-operator",
-				"--repo", "https://opensource.zalando.com/postgres-operator/charts/postgres-operator",
-				"--version", "1.15.1",
-			},
+			name:    "version starting with dash",
+			chart:   config.ChartSpec{Name: "prometheus", Version: "--set=x", Repository: "oci://ghcr.io/org/charts"},
+			wantErr: ErrInvalidChartVersion,
+		},
+		{
+			name:    "unsupported repository scheme",
+			chart:   config.ChartSpec{Name: "prometheus", Version: "1.0.0", Repository: "ftp://example.com/charts"},
+			wantErr: ErrInvalidChartRepo,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := helmTemplateArgs(tc.chart)
-			if len(got) != len(tc.want) {
-				t.Fatalf("helmTemplateArgs() = %v, want %v", got, tc.want)
+			err := validateChartSpec(tc.chart)
+			if tc.wantErr == nil && err != nil {
+				t.Errorf("validateChartSpec() = %v, want nil", err)
 			}
-			for i, g := range got {
-				if g != tc.want[i] {
-					t.Errorf("helmTemplateArgs()[%d] = %q, want %q", i, g, tc.want[i])
-				}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("validateChartSpec() = %v, want %v", err, tc.wantErr)
 			}
 		})
 	}
 }
 
+func TestMergeValues(t *testing.T) {
+	base := map[string]any{
+		"image": map[string]any{
+			"tag":        "1.0.0",
+			"repository": "example/app",
+		},
+		"replicas": 1,
+	}
+	override := map[string]any{
+		"image": map[string]any{
+			"tag": "2.0.0",
+		},
+		"extraComponent": map[string]any{
+			"enabled": true,
+		},
+	}
+
+	got := mergeValues(base, override)
+
+	image, ok := got["image"].(map[string]any)
+	if !ok {
+		t.Fatalf("merged values missing image map: %+v", got)
+	}
+	if image["tag"] != "2.0.0" {
+		t.Errorf("image.tag = %v, want overridden value 2.0.0", image["tag"])
+	}
+	if image["repository"] != "example/app" {
+		t.Errorf("image.repository = %v, want base value preserved", image["repository"])
+	}
+	if got["replicas"] != 1 {
+		t.Errorf("replicas = %v, want base value preserved", got["replicas"])
+	}
+	extra, ok := got["extraComponent"].(map[string]any)
+	if !ok || extra["enabled"] != true {
+		t.Errorf("extraComponent not merged in: %+v", got)
+	}
+}
+
+func TestResolveValues(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "from.yaml")
+	if err := os.WriteFile(from, []byte("image:\n  tag: 1.0.0\nreplicas: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	extra := filepath.Join(dir, "extra.yaml")
+	if err := os.WriteFile(extra, []byte("replicas: 2\ningress:\n  enabled: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	chart := config.ChartSpec{
+		ValuesFrom:  from,
+		ValuesFiles: []string{extra},
+		Values: map[string]any{
+			"image": map[string]any{"repository": "example/app"},
+		},
+		Set: map[string]string{
+			"image.tag": "2.0.0",
+		},
+	}
+
+	got, err := resolveValues(chart)
+	if err != nil {
+		t.Fatalf("resolveValues() error = %v", err)
+	}
+
+	if got["replicas"] != 2 {
+		t.Errorf("replicas = %v, want 2 (ValuesFiles should win over ValuesFrom)", got["replicas"])
+	}
+	ingress, ok := got["ingress"].(map[string]any)
+	if !ok || ingress["enabled"] != true {
+		t.Errorf("ingress not merged in from ValuesFiles: %+v", got)
+	}
+	image, ok := got["image"].(map[string]any)
+	if !ok {
+		t.Fatalf("resolveValues() missing image map: %+v", got)
+	}
+	if image["repository"] != "example/app" {
+		t.Errorf("image.repository = %v, want example/app from Values", image["repository"])
+	}
+	if image["tag"] != "2.0.0" {
+		t.Errorf("image.tag = %v, want 2.0.0 (Set should win over everything else)", image["tag"])
+	}
+}
+
 func TestExtractImagesFromManifests(t *testing.T) {
 	yaml := []byte(`
 apiVersion: apps/v1
@@ -0,0 +1,180 @@
+// Package chartsource reads a Helm chart's published metadata — Chart.yaml,
+// values.yaml, and README.md — directly from an OCI registry via ORAS,
+// without downloading dependencies or rendering templates. It exists
+// alongside the full chart-rendering pipeline in internal/discovery/charts.go
+// for callers that only need a chart's declared name/version/dependencies or
+// default values (e.g. listing charts by ref in copa-config.yaml) and would
+// rather not pay for a full `helm template` dry run to get them.
+package chartsource
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+// helmChartContentMediaType is the OCI layer media type the Helm registry
+// client uses for a chart's packaged archive (registry.ChartLayerMediaType
+// in the Helm SDK). It's duplicated here rather than imported so chartsource
+// can pull an artifact with bare ORAS, without pulling in the rest of the
+// Helm SDK's registry package.
+const helmChartContentMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+// Sentinel errors.
+var (
+	ErrNotOCI         = errors.New("chart repository is not an oci:// reference")
+	ErrNoChartLayer   = errors.New("manifest has no helm chart content layer")
+	ErrFileNotInChart = errors.New("file not found in chart archive")
+)
+
+// Metadata is the subset of a chart's published files that image discovery
+// and chart browsing need without a local checkout: Chart.yaml's declared
+// name/version/description/dependencies, values.yaml's defaults, and
+// README.md for human-facing summaries. ValuesYAML and ReadmeMD are nil
+// when the chart archive doesn't contain that file.
+type Metadata struct {
+	Name         string
+	Version      string
+	Description  string
+	Dependencies []config.ChartSpec
+	ValuesYAML   []byte
+	ReadmeMD     []byte
+}
+
+// Fetch pulls spec's chart archive from its OCI repository via ORAS and
+// extracts Chart.yaml, values.yaml, and README.md from it. spec.Repository
+// must be an "oci://host/org" reference; spec.Name and spec.Version select
+// the tag within it, the same way DownloadChart's OCI path does.
+func Fetch(ctx context.Context, spec config.ChartSpec) (*Metadata, error) {
+	if !strings.HasPrefix(spec.Repository, "oci://") {
+		return nil, fmt.Errorf("%w: %q", ErrNotOCI, spec.Repository)
+	}
+
+	repoRef := strings.TrimPrefix(spec.Repository, "oci://") + "/" + spec.Name
+	repo, err := remote.NewRepository(repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("configuring OCI repository %s: %w", repoRef, err)
+	}
+
+	store := memory.New()
+	desc, err := oras.Copy(ctx, repo, spec.Version, store, spec.Version, oras.DefaultCopyOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s:%s: %w", repoRef, spec.Version, err)
+	}
+
+	manifestBytes, err := content.FetchAll(ctx, store, desc)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest for %s:%s: %w", repoRef, spec.Version, err)
+	}
+	var manifest ocispec.Manifest
+	if err := yaml.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest for %s:%s: %w", repoRef, spec.Version, err)
+	}
+
+	var chartLayer *ocispec.Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == helmChartContentMediaType {
+			chartLayer = &manifest.Layers[i]
+			break
+		}
+	}
+	if chartLayer == nil {
+		return nil, fmt.Errorf("%w: %s:%s", ErrNoChartLayer, repoRef, spec.Version)
+	}
+
+	archiveBytes, err := content.FetchAll(ctx, store, *chartLayer)
+	if err != nil {
+		return nil, fmt.Errorf("reading chart archive for %s:%s: %w", repoRef, spec.Version, err)
+	}
+
+	return parseChartArchive(archiveBytes)
+}
+
+// chartMetadataFile mirrors the subset of Chart.yaml's fields chartsource
+// exposes; config.HelmChartFile only carries Dependencies, so Name/Version/
+// Description are decoded separately here rather than widening that type
+// for a reader that's only used in one place.
+type chartMetadataFile struct {
+	Name         string             `yaml:"name"`
+	Version      string             `yaml:"version"`
+	Description  string             `yaml:"description"`
+	Dependencies []config.ChartSpec `yaml:"dependencies"`
+}
+
+// parseChartArchive extracts Chart.yaml, values.yaml, and README.md from a
+// gzipped chart tarball. Helm chart archives nest every file under a single
+// top-level "<name>/" directory; parseChartArchive matches on basename so
+// that prefix doesn't need to be known in advance.
+func parseChartArchive(tgz []byte) (*Metadata, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(tgz))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing chart archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	md := &Metadata{}
+	var chartYAML []byte
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading chart archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(hdr.Name, "/Chart.yaml") || hdr.Name == "Chart.yaml":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("reading Chart.yaml: %w", err)
+			}
+			chartYAML = data
+		case strings.HasSuffix(hdr.Name, "/values.yaml") || hdr.Name == "values.yaml":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("reading values.yaml: %w", err)
+			}
+			md.ValuesYAML = data
+		case strings.HasSuffix(hdr.Name, "/README.md") || hdr.Name == "README.md":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("reading README.md: %w", err)
+			}
+			md.ReadmeMD = data
+		}
+	}
+
+	if chartYAML == nil {
+		return nil, fmt.Errorf("%w: Chart.yaml", ErrFileNotInChart)
+	}
+	var parsed chartMetadataFile
+	if err := yaml.Unmarshal(chartYAML, &parsed); err != nil {
+		return nil, fmt.Errorf("parsing Chart.yaml: %w", err)
+	}
+	md.Name = parsed.Name
+	md.Version = parsed.Version
+	md.Description = parsed.Description
+	md.Dependencies = parsed.Dependencies
+
+	return md, nil
+}
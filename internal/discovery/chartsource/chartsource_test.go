@@ -0,0 +1,83 @@
+package chartsource
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+// buildTestArchive builds a minimal gzipped tarball laid out the way Helm
+// packages a chart: every file nested under a single "<name>/" directory.
+func buildTestArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, contents := range files {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(contents))}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("writing header for %s: %v", name, err)
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatalf("writing contents for %s: %v", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseChartArchive(t *testing.T) {
+	chartYAML := "name: nginx\nversion: 1.2.3\ndescription: a test chart\ndependencies:\n  - name: common\n    version: 2.0.0\n    repository: https://example.com/charts\n"
+	archive := buildTestArchive(t, map[string]string{
+		"nginx/Chart.yaml":  chartYAML,
+		"nginx/values.yaml": "replicaCount: 1\n",
+		"nginx/README.md":   "# nginx\n",
+	})
+
+	md, err := parseChartArchive(archive)
+	if err != nil {
+		t.Fatalf("parseChartArchive: %v", err)
+	}
+	if md.Name != "nginx" || md.Version != "1.2.3" || md.Description != "a test chart" {
+		t.Errorf("parseChartArchive metadata = %+v, want name/version/description from Chart.yaml", md)
+	}
+	if string(md.ValuesYAML) != "replicaCount: 1\n" {
+		t.Errorf("ValuesYAML = %q, want values.yaml contents", md.ValuesYAML)
+	}
+	if string(md.ReadmeMD) != "# nginx\n" {
+		t.Errorf("ReadmeMD = %q, want README.md contents", md.ReadmeMD)
+	}
+	if len(md.Dependencies) != 1 || md.Dependencies[0].Name != "common" {
+		t.Errorf("Dependencies = %+v, want one entry for %q", md.Dependencies, "common")
+	}
+}
+
+func TestParseChartArchiveMissingChartYAML(t *testing.T) {
+	archive := buildTestArchive(t, map[string]string{
+		"nginx/values.yaml": "replicaCount: 1\n",
+	})
+
+	if _, err := parseChartArchive(archive); err == nil {
+		t.Error("expected an error when Chart.yaml is missing, got nil")
+	}
+}
+
+func TestParseChartArchiveNoValuesOrReadme(t *testing.T) {
+	archive := buildTestArchive(t, map[string]string{
+		"nginx/Chart.yaml": "name: nginx\nversion: 1.2.3\n",
+	})
+
+	md, err := parseChartArchive(archive)
+	if err != nil {
+		t.Fatalf("parseChartArchive: %v", err)
+	}
+	if md.ValuesYAML != nil || md.ReadmeMD != nil {
+		t.Errorf("expected nil ValuesYAML/ReadmeMD when absent, got %+v", md)
+	}
+}
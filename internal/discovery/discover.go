@@ -1,18 +1,26 @@
 package discovery
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/distribution/reference"
 	"gopkg.in/yaml.v3"
 
 	"github.com/verity-org/verity/internal/config"
+	"github.com/verity-org/verity/internal/discovery/chartsource"
 )
 
 // DefaultPlatforms is the default comma-separated list of platforms to patch.
 const DefaultPlatforms = "linux/amd64,linux/arm64"
 
+// errInvalidOCIChartRef is returned by parseOCIChartRef when a charts-file
+// path starting with "oci://" doesn't also carry a "chart:version" suffix.
+var errInvalidOCIChartRef = errors.New("oci charts-file reference must be oci://registry/org/chart:version")
+
 // DiscoveredImage represents one image+tag combination to be patched.
 type DiscoveredImage struct {
 	Name           string `json:"name"`
@@ -34,12 +42,19 @@ func Discover(cfg *config.CopaConfig, targetRegistry string, overrides map[strin
 	seen := make(map[string]struct{})
 
 	for i := range cfg.Images {
-		imgs, err := discoverStandaloneImage(&cfg.Images[i], registry)
+		imgs, err := discoverStandaloneImage(&cfg.Images[i], registry, cfg.Mirrors)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to discover tags for %q: %v\n", cfg.Images[i].Name, err)
 			continue
 		}
 		for _, img := range imgs {
+			decision := decideFilter(img.Source, cfg.Filters)
+			if decision.Reason != "" {
+				logFilterDecision(decision)
+			}
+			if decision.Skipped {
+				continue
+			}
 			key := img.Name + "|" + img.Source
 			if _, exists := seen[key]; !exists {
 				seen[key] = struct{}{}
@@ -54,7 +69,15 @@ func Discover(cfg *config.CopaConfig, targetRegistry string, overrides map[strin
 			fmt.Fprintf(os.Stderr, "Warning: failed to discover images from chart %q: %v\n", chartSpec.Name, err)
 			continue
 		}
+		filters := mergeFilters(cfg.Filters, chartSpec.Filters)
 		for _, img := range imgs {
+			decision := decideFilter(img.Source, filters)
+			if decision.Reason != "" {
+				logFilterDecision(decision)
+			}
+			if decision.Skipped {
+				continue
+			}
 			key := img.Name + "|" + img.Source
 			if _, exists := seen[key]; !exists {
 				seen[key] = struct{}{}
@@ -76,9 +99,28 @@ func LoadConfig(path string) (*config.CopaConfig, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
+	if err := validateTagStrategies(&cfg); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
 
+// validateTagStrategies compiles every "constraint" strategy's expression
+// up front, so a typo like ">=1.25.0, <1.27.0 ||" fails LoadConfig instead
+// of surfacing as a DiscoverTags error on whatever discovery run happens
+// to hit that image next.
+func validateTagStrategies(cfg *config.CopaConfig) error {
+	for _, img := range cfg.Images {
+		if img.Tags.Strategy != "constraint" {
+			continue
+		}
+		if _, err := buildConstraint(img.Tags); err != nil {
+			return fmt.Errorf("image %s: invalid tag constraint: %w", img.Name, err)
+		}
+	}
+	return nil
+}
+
 // LoadVerityConfig reads verity-specific configuration from verity.yaml.
 // Returns an empty config (not an error) if the file does not exist.
 func LoadVerityConfig(path string) (*config.VerityConfig, error) {
@@ -96,11 +138,27 @@ func LoadVerityConfig(path string) (*config.VerityConfig, error) {
 	return &vc, nil
 }
 
-// LoadChartsFile reads chart dependencies from a Helm Chart.yaml file.
-// Only the `dependencies:` field is read; all other Chart.yaml fields are ignored.
-// Returns a nil slice (not an error) if the file does not exist, so callers
-// can pass a default path unconditionally.
+// LoadChartsFile reads chart dependencies from either a local Helm
+// Chart.yaml file or, when path is an "oci://registry/org/chart:version"
+// reference, the Chart.yaml published inside that chart's own OCI
+// artifact — fetched directly via chartsource, without running `helm pull`
+// first. Only the `dependencies:` field is read; all other Chart.yaml
+// fields are ignored. For a local path, returns a nil slice (not an error)
+// if the file does not exist, so callers can pass a default path
+// unconditionally.
 func LoadChartsFile(path string) ([]config.ChartSpec, error) {
+	if strings.HasPrefix(path, "oci://") {
+		spec, err := parseOCIChartRef(path)
+		if err != nil {
+			return nil, err
+		}
+		md, err := chartsource.Fetch(context.Background(), spec)
+		if err != nil {
+			return nil, fmt.Errorf("fetching charts file from %s: %w", path, err)
+		}
+		return md.Dependencies, nil
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -115,27 +173,78 @@ func LoadChartsFile(path string) ([]config.ChartSpec, error) {
 	return chartFile.Dependencies, nil
 }
 
-func discoverStandaloneImage(spec *config.ImageSpec, registry string) ([]DiscoveredImage, error) {
-	tags, err := FindTagsToPatch(spec)
-	if err != nil {
-		return nil, err
+// parseOCIChartRef splits an "oci://registry/org/chart:version" charts-file
+// reference into the ChartSpec chartsource.Fetch expects: Repository holds
+// everything up to (not including) the chart name, Name and Version are
+// read from the final path segment's "name:version" pair.
+func parseOCIChartRef(ref string) (config.ChartSpec, error) {
+	trimmed := strings.TrimPrefix(ref, "oci://")
+	lastSlash := strings.LastIndex(trimmed, "/")
+	if lastSlash < 0 {
+		return config.ChartSpec{}, fmt.Errorf("%w: %q", errInvalidOCIChartRef, ref)
 	}
+	repoPart, namePart := trimmed[:lastSlash], trimmed[lastSlash+1:]
 
+	name, version, ok := strings.Cut(namePart, ":")
+	if !ok || name == "" || version == "" {
+		return config.ChartSpec{}, fmt.Errorf("%w: %q", errInvalidOCIChartRef, ref)
+	}
+	return config.ChartSpec{
+		Name:       name,
+		Version:    version,
+		Repository: "oci://" + repoPart,
+	}, nil
+}
+
+func discoverStandaloneImage(spec *config.ImageSpec, registry string, defaultMirrors []config.Mirror) ([]DiscoveredImage, error) {
 	imgRegistry := registry
 	if spec.Target.Registry != "" {
 		imgRegistry = spec.Target.Registry
 	}
 
-	result := make([]DiscoveredImage, 0, len(tags))
+	mirrors := spec.Mirrors
+	if len(mirrors) == 0 {
+		mirrors = defaultMirrors
+	}
+
+	// A digest-pinned image (spec.Image ending in "@sha256:...") has no tag
+	// strategy to run: it names exactly one immutable artifact already.
+	if digest, ok := digestPin(spec.Image); ok {
+		source := resolvePullSource(spec.Image, mirrors, digest)
+		return expandPlatforms(spec, source, imgRegistry), nil
+	}
+
+	tags, err := FindTagsToPatch(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []DiscoveredImage
 	for _, tag := range tags {
+		source := resolvePullSource(spec.Image, mirrors, tag)
+		result = append(result, expandPlatforms(spec, source, imgRegistry)...)
+	}
+	return result, nil
+}
+
+// expandPlatforms turns one resolved pull source into a DiscoveredImage per
+// platform, using resolveManifestList to resolve each platform's own child
+// digest when source is a multi-arch manifest list — falling back to a
+// single DiscoveredImage (source unchanged, Platforms comma-joined) when
+// spec.Platforms has fewer than two entries or the registry doesn't return
+// an index.
+func expandPlatforms(spec *config.ImageSpec, source, imgRegistry string) []DiscoveredImage {
+	sources := resolveManifestList(source, spec.Platforms)
+	result := make([]DiscoveredImage, 0, len(sources))
+	for _, ps := range sources {
 		result = append(result, DiscoveredImage{
 			Name:           spec.Name,
-			Source:         spec.Image + ":" + tag,
+			Source:         ps.Source,
 			TargetRegistry: imgRegistry,
-			Platforms:      joinPlatforms(spec.Platforms),
+			Platforms:      ps.Platform,
 		})
 	}
-	return result, nil
+	return result
 }
 
 func discoverChartImages(chart config.ChartSpec, overrides map[string]config.Override, registry string) ([]DiscoveredImage, error) {
@@ -157,11 +266,15 @@ func discoverChartImages(chart config.ChartSpec, overrides map[string]config.Ove
 }
 
 // nameFromRef derives a safe, unique image name from a full image reference.
-// For images with a registry and org (3+ path components), joins the org and
-// name with "-" to prevent collisions between images with the same basename
-// from different registries/orgs. When org and name are identical (e.g.,
-// prometheus/prometheus), the duplicate is dropped. Single-component and
-// two-component refs return the last component directly.
+// ref is parsed with reference.ParseNormalizedNamed, which handles registry
+// ports (localhost:5000/foo:1.0), tag+digest combinations (foo:1.0@sha256:...),
+// and docker.io/library normalization the way containers/image and podman do,
+// rather than splitting on the last ":"/"/" by hand. When ref has an explicit
+// org component, it's joined with the repo name with "-" to prevent collisions
+// between images with the same basename from different registries/orgs; when
+// org and name are identical (e.g., prometheus/prometheus), the duplicate is
+// dropped. Refs with no explicit org (e.g. "nginx") use the bare repo name,
+// ignoring the implicit "library/" namespace Docker Hub normalization adds.
 // e.g.:
 //
 //	"quay.io/prometheus/prometheus:v3.2.1" → "prometheus"
@@ -169,19 +282,20 @@ func discoverChartImages(chart config.ChartSpec, overrides map[string]config.Ove
 //	"ghcr.io/kiwigrid/k8s-sidecar:1.28.0" → "kiwigrid-k8s-sidecar"
 //	"nginx:1.25"                           → "nginx"
 func nameFromRef(ref string) string {
-	// Strip digest
-	if idx := strings.Index(ref, "@"); idx != -1 {
-		ref = ref[:idx]
-	}
-	// Strip tag: last ":" must come after the last "/"
-	lastSlash := strings.LastIndex(ref, "/")
-	if lastColon := strings.LastIndex(ref, ":"); lastColon > lastSlash {
-		ref = ref[:lastColon]
-	}
-	// Split into path components (hostname/org/name)
-	parts := strings.Split(ref, "/")
-	// 3+ parts: hostname/org/name — include org to prevent collisions
-	if len(parts) >= 3 {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		// Not a parseable reference; fall back to the raw string so
+		// discovery still produces a name rather than erroring.
+		return ref
+	}
+
+	parts := strings.Split(reference.Path(named), "/")
+	if !strings.Contains(ref, "/") {
+		// No explicit org in the input — ignore the implicit "library/"
+		// namespace ParseNormalizedNamed added and use the bare repo name.
+		return parts[len(parts)-1]
+	}
+	if len(parts) >= 2 {
 		org := parts[len(parts)-2]
 		name := parts[len(parts)-1]
 		if org == name {
@@ -189,7 +303,6 @@ func nameFromRef(ref string) string {
 		}
 		return org + "-" + name
 	}
-	// 1-2 parts: no org or no hostname — use last component
 	return parts[len(parts)-1]
 }
 
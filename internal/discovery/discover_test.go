@@ -107,7 +107,7 @@ func TestDiscoverStandaloneImage_List(t *testing.T) {
 		Platforms: []string{"linux/amd64", "linux/arm64"},
 	}
 
-	got, err := discoverStandaloneImage(spec, "ghcr.io/verity-org")
+	got, err := discoverStandaloneImage(spec, "ghcr.io/verity-org", nil)
 	if err != nil {
 		t.Fatalf("discoverStandaloneImage() error = %v", err)
 	}
@@ -147,7 +147,7 @@ func TestDiscoverStandaloneImage_PerImageRegistryOverride(t *testing.T) {
 		Target: config.TargetSpec{Registry: "ghcr.io/custom-org"},
 	}
 
-	got, err := discoverStandaloneImage(spec, "ghcr.io/verity-org")
+	got, err := discoverStandaloneImage(spec, "ghcr.io/verity-org", nil)
 	if err != nil {
 		t.Fatalf("discoverStandaloneImage() error = %v", err)
 	}
@@ -276,6 +276,28 @@ func TestLoadChartsFile_Missing(t *testing.T) {
 	}
 }
 
+func TestParseOCIChartRef(t *testing.T) {
+	spec, err := parseOCIChartRef("oci://ghcr.io/org/charts/nginx:1.2.3")
+	if err != nil {
+		t.Fatalf("parseOCIChartRef() error = %v", err)
+	}
+	want := config.ChartSpec{Name: "nginx", Version: "1.2.3", Repository: "oci://ghcr.io/org/charts"}
+	if spec != want {
+		t.Errorf("parseOCIChartRef() = %+v, want %+v", spec, want)
+	}
+}
+
+func TestParseOCIChartRef_Invalid(t *testing.T) {
+	for _, ref := range []string{
+		"oci://ghcr.io/org/charts/nginx",
+		"oci://nginx:1.2.3",
+	} {
+		if _, err := parseOCIChartRef(ref); err == nil {
+			t.Errorf("parseOCIChartRef(%q) expected an error, got nil", ref)
+		}
+	}
+}
+
 func TestLoadConfig_MissingFile(t *testing.T) {
 	_, err := LoadConfig("/nonexistent/path/copa-config.yaml")
 	if err == nil {
@@ -296,6 +318,29 @@ func TestLoadConfig_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_InvalidConstraintFailsFast(t *testing.T) {
+	yaml := `
+apiVersion: copa.sh/v1alpha1
+kind: PatchConfig
+images:
+  - name: nginx
+    image: mirror.gcr.io/library/nginx
+    tags:
+      strategy: constraint
+      constraint: ">=1.25.0, <1.27.0 ||"
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "copa-config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("LoadConfig() expected error for an invalid tag constraint, got nil")
+	}
+}
+
 func TestDiscover_StandaloneOnly(t *testing.T) {
 	cfg := &config.CopaConfig{
 		Target: config.TargetSpec{Registry: "ghcr.io/verity-org"},
@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+// Origin records one place Explain found a requested image reference:
+// either a standalone images[] entry that names it directly, or a
+// container in a Kubernetes resource rendered from one of cfg.Charts.
+type Origin struct {
+	// MatchKind is "image-spec" or "chart-manifest", telling the two cases
+	// in this struct apart without a type switch.
+	MatchKind string `json:"matchKind"`
+
+	// ImageSpecName is set for a MatchKind "image-spec" origin.
+	ImageSpecName string `json:"imageSpecName,omitempty"`
+
+	// ChartName/ChartVersion identify the ChartSpec that rendered the
+	// manifest a MatchKind "chart-manifest" origin was found in.
+	ChartName    string `json:"chartName,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+
+	// ResourceKind/ResourceName/ContainerName locate imageRef within the
+	// rendered manifest: the Kubernetes resource's kind and metadata.name,
+	// and the container (or initContainer) entry that named the image.
+	ResourceKind  string `json:"resourceKind,omitempty"`
+	ResourceName  string `json:"resourceName,omitempty"`
+	ContainerName string `json:"containerName,omitempty"`
+
+	// OverrideFrom/OverrideTo are set when an overrides[] entry rewrote the
+	// chart's own tag to produce imageRef, naming the suffix substitution
+	// that fired (see applyOverride).
+	OverrideFrom string `json:"overrideFrom,omitempty"`
+	OverrideTo   string `json:"overrideTo,omitempty"`
+
+	// Snippet is the rendered YAML for the container entry that named
+	// imageRef, re-marshaled from the parsed manifest document.
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// Explain reports every place imageRef was discovered in cfg: which
+// ImageSpec names it directly, and every chart-rendered manifest container
+// that references it, mirroring the "find-images --why" pattern so users
+// can audit why an image ended up on the patch list.
+func Explain(cfg *config.CopaConfig, overrides map[string]config.Override, imageRef string) ([]Origin, error) {
+	var origins []Origin
+
+	for i := range cfg.Images {
+		if cfg.Images[i].Image == imageRef {
+			origins = append(origins, Origin{
+				MatchKind:     "image-spec",
+				ImageSpecName: cfg.Images[i].Name,
+			})
+		}
+	}
+
+	for _, chartSpec := range cfg.Charts {
+		manifest, err := renderChart(chartSpec)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s: %w", chartSpec.Name, err)
+		}
+		matches, err := findImageOrigins([]byte(manifest), imageRef, overrides)
+		if err != nil {
+			return nil, fmt.Errorf("walking manifests for %s: %w", chartSpec.Name, err)
+		}
+		for _, m := range matches {
+			m.ChartName = chartSpec.Name
+			m.ChartVersion = chartSpec.Version
+			origins = append(origins, m)
+		}
+	}
+
+	return origins, nil
+}
+
+// findImageOrigins decodes a rendered chart manifest's YAML documents and
+// walks each one for a container entry whose (possibly override-rewritten)
+// image matches imageRef.
+func findImageOrigins(manifest []byte, imageRef string, overrides map[string]config.Override) ([]Origin, error) {
+	var origins []Origin
+
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest))
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decoding YAML document: %w", err)
+		}
+		if doc == nil {
+			continue
+		}
+
+		kind, _ := doc["kind"].(string)
+		name := ""
+		if meta, ok := doc["metadata"].(map[string]any); ok {
+			name, _ = meta["name"].(string)
+		}
+		walkForOrigins(doc, kind, name, imageRef, overrides, &origins)
+	}
+
+	return origins, nil
+}
+
+// walkForOrigins mirrors walkNode's generic "find any image field" search
+// (see extractImagesFromManifests), but additionally resolves each raw
+// image through applyOverride to compare against imageRef, and records the
+// enclosing container map's sibling "name" field and a YAML snippet of it
+// when it matches.
+func walkForOrigins(node any, kind, name, imageRef string, overrides map[string]config.Override, result *[]Origin) {
+	switch v := node.(type) {
+	case map[string]any:
+		if rawImage, ok := v["image"].(string); ok && rawImage != "" {
+			if resolved := applyOverride(rawImage, overrides); resolved == imageRef {
+				containerName, _ := v["name"].(string)
+				origin := Origin{
+					MatchKind:     "chart-manifest",
+					ResourceKind:  kind,
+					ResourceName:  name,
+					ContainerName: containerName,
+				}
+				if _, override, matched := findMatchingOverride(rawImage, overrides); matched {
+					origin.OverrideFrom = override.From
+					origin.OverrideTo = override.To
+				}
+				if snippet, err := yaml.Marshal(v); err == nil {
+					origin.Snippet = string(snippet)
+				}
+				*result = append(*result, origin)
+			}
+		}
+		for _, val := range v {
+			walkForOrigins(val, kind, name, imageRef, overrides, result)
+		}
+	case []any:
+		for _, item := range v {
+			walkForOrigins(item, kind, name, imageRef, overrides, result)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+func TestFindImageOrigins_ContainerMatch(t *testing.T) {
+	manifest := []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: docker.io/library/nginx:1.25.3
+`)
+
+	origins, err := findImageOrigins(manifest, "docker.io/library/nginx:1.25.3", nil)
+	if err != nil {
+		t.Fatalf("findImageOrigins() error = %v", err)
+	}
+	if len(origins) != 1 {
+		t.Fatalf("findImageOrigins() returned %d origins, want 1", len(origins))
+	}
+	got := origins[0]
+	if got.ResourceKind != "Deployment" || got.ResourceName != "web" || got.ContainerName != "app" {
+		t.Errorf("origin = %+v, want Deployment/web/app", got)
+	}
+	if !strings.Contains(got.Snippet, "app") {
+		t.Errorf("Snippet = %q, want it to contain the container name", got.Snippet)
+	}
+}
+
+func TestFindImageOrigins_NoMatch(t *testing.T) {
+	manifest := []byte(`
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: docker.io/library/nginx:1.25.3
+`)
+
+	origins, err := findImageOrigins(manifest, "docker.io/library/redis:7.2", nil)
+	if err != nil {
+		t.Fatalf("findImageOrigins() error = %v", err)
+	}
+	if len(origins) != 0 {
+		t.Errorf("findImageOrigins() = %+v, want no origins", origins)
+	}
+}
+
+func TestFindImageOrigins_ReportsOverride(t *testing.T) {
+	manifest := []byte(`
+kind: Deployment
+metadata:
+  name: vector
+spec:
+  template:
+    spec:
+      containers:
+        - name: vector
+          image: timberio/vector:0.41.0-distroless-libc
+`)
+	overrides := map[string]config.Override{
+		"timberio/vector": {From: "distroless-libc", To: "debian"},
+	}
+
+	origins, err := findImageOrigins(manifest, "timberio/vector:0.41.0-debian", overrides)
+	if err != nil {
+		t.Fatalf("findImageOrigins() error = %v", err)
+	}
+	if len(origins) != 1 {
+		t.Fatalf("findImageOrigins() returned %d origins, want 1", len(origins))
+	}
+	if origins[0].OverrideFrom != "distroless-libc" || origins[0].OverrideTo != "debian" {
+		t.Errorf("origin override = %+v, want distroless-libc -> debian", origins[0])
+	}
+}
+
+func TestExplain_StandaloneImageSpec(t *testing.T) {
+	cfg := &config.CopaConfig{
+		Images: []config.ImageSpec{
+			{Name: "nginx", Image: "docker.io/library/nginx:1.25.3"},
+		},
+	}
+
+	origins, err := Explain(cfg, nil, "docker.io/library/nginx:1.25.3")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(origins) != 1 || origins[0].MatchKind != "image-spec" || origins[0].ImageSpecName != "nginx" {
+		t.Errorf("Explain() = %+v, want a single image-spec origin named nginx", origins)
+	}
+}
@@ -0,0 +1,139 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+// FilterDecision records whether one candidate image was kept or skipped by
+// Discover's include/exclude filters, for --dry-run-filters auditing.
+type FilterDecision struct {
+	Image   string `json:"image"`
+	Skipped bool   `json:"skipped"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// passesFilters reports whether ref should be kept, given filters whose
+// Include/Exclude entries are regexps matched against ref — the same
+// pattern matching ImageSpec.Tags uses for strategy: pattern. When Include
+// is non-empty, ref must match at least one Include pattern; a match
+// against any Exclude pattern always drops ref, even if it also matched an
+// Include pattern.
+func passesFilters(ref string, filters config.Filters) (bool, error) {
+	if len(filters.Include) > 0 {
+		matched := false
+		for _, pattern := range filters.Include {
+			ok, err := regexp.MatchString(pattern, ref)
+			if err != nil {
+				return false, fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	for _, pattern := range filters.Exclude {
+		ok, err := regexp.MatchString(pattern, ref)
+		if err != nil {
+			return false, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		if ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// mergeFilters combines a chart's own Filters with CopaConfig's default
+// Filters by concatenating their Include/Exclude lists, so a chart can add
+// to (never replace) the config-level defaults.
+func mergeFilters(defaults, chart config.Filters) config.Filters {
+	if len(chart.Include) == 0 && len(chart.Exclude) == 0 {
+		return defaults
+	}
+	merged := config.Filters{
+		Include: make([]string, 0, len(defaults.Include)+len(chart.Include)),
+		Exclude: make([]string, 0, len(defaults.Exclude)+len(chart.Exclude)),
+	}
+	merged.Include = append(merged.Include, defaults.Include...)
+	merged.Include = append(merged.Include, chart.Include...)
+	merged.Exclude = append(merged.Exclude, defaults.Exclude...)
+	merged.Exclude = append(merged.Exclude, chart.Exclude...)
+	return merged
+}
+
+// logFilterDecision prints one structured (JSON-lines) FilterDecision to
+// stderr so users can audit what Discover dropped, e.g.:
+//
+//	{"image":"docker.io/library/busybox:1.36","skipped":true,"reason":"excluded by filter"}
+func logFilterDecision(d FilterDecision) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
+// decideFilter evaluates ref against filters and reports the decision that
+// would be logged — used by both Discover (to skip filtered-out images) and
+// AuditFilters (to report every candidate's decision for --dry-run-filters).
+func decideFilter(ref string, filters config.Filters) FilterDecision {
+	keep, err := passesFilters(ref, filters)
+	if err != nil {
+		// An invalid pattern fails open so a config typo can't silently
+		// drop images; the error is still visible in Reason.
+		return FilterDecision{Image: ref, Reason: err.Error()}
+	}
+	if !keep {
+		return FilterDecision{Image: ref, Skipped: true, Reason: "excluded by filter"}
+	}
+	return FilterDecision{Image: ref}
+}
+
+// AuditFilters runs the same candidate discovery Discover does, but returns
+// every candidate's keep/skip FilterDecision instead of the final filtered
+// image list, for a --dry-run-filters CLI mode that reports the
+// include/exclude decision for every candidate without emitting output.
+func AuditFilters(cfg *config.CopaConfig, targetRegistry string, overrides map[string]config.Override) ([]FilterDecision, error) {
+	registry := targetRegistry
+	if registry == "" {
+		registry = cfg.Target.Registry
+	}
+
+	var decisions []FilterDecision
+
+	for i := range cfg.Images {
+		imgs, err := discoverStandaloneImage(&cfg.Images[i], registry, cfg.Mirrors)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to discover tags for %q: %v\n", cfg.Images[i].Name, err)
+			continue
+		}
+		for _, img := range imgs {
+			decisions = append(decisions, decideFilter(img.Source, cfg.Filters))
+		}
+	}
+
+	for _, chartSpec := range cfg.Charts {
+		imgs, err := discoverChartImages(chartSpec, overrides, registry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to discover images from chart %q: %v\n", chartSpec.Name, err)
+			continue
+		}
+		filters := mergeFilters(cfg.Filters, chartSpec.Filters)
+		for _, img := range imgs {
+			decisions = append(decisions, decideFilter(img.Source, filters))
+		}
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,89 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+func TestPassesFiltersNoFilters(t *testing.T) {
+	keep, err := passesFilters("docker.io/library/nginx:1.25", config.Filters{})
+	if err != nil || !keep {
+		t.Errorf("passesFilters() = (%v, %v), want (true, nil)", keep, err)
+	}
+}
+
+func TestPassesFiltersExcludeDrops(t *testing.T) {
+	filters := config.Filters{Exclude: []string{"busybox"}}
+	keep, err := passesFilters("docker.io/library/busybox:1.36", filters)
+	if err != nil || keep {
+		t.Errorf("passesFilters() = (%v, %v), want (false, nil)", keep, err)
+	}
+}
+
+func TestPassesFiltersIncludeRequiresMatch(t *testing.T) {
+	filters := config.Filters{Include: []string{`^docker\.io/library/nginx`}}
+
+	keep, err := passesFilters("docker.io/library/nginx:1.25", filters)
+	if err != nil || !keep {
+		t.Errorf("passesFilters() for matching include = (%v, %v), want (true, nil)", keep, err)
+	}
+
+	keep, err = passesFilters("docker.io/library/redis:7.0", filters)
+	if err != nil || keep {
+		t.Errorf("passesFilters() for non-matching include = (%v, %v), want (false, nil)", keep, err)
+	}
+}
+
+func TestPassesFiltersExcludeWinsOverInclude(t *testing.T) {
+	filters := config.Filters{
+		Include: []string{"nginx"},
+		Exclude: []string{"nginx:1.25"},
+	}
+	keep, err := passesFilters("docker.io/library/nginx:1.25", filters)
+	if err != nil || keep {
+		t.Errorf("passesFilters() = (%v, %v), want (false, nil) — exclude should win", keep, err)
+	}
+}
+
+func TestPassesFiltersInvalidPatternErrors(t *testing.T) {
+	filters := config.Filters{Include: []string{"("}}
+	if _, err := passesFilters("docker.io/library/nginx:1.25", filters); err == nil {
+		t.Error("passesFilters() with an invalid regexp = nil error, want non-nil")
+	}
+}
+
+func TestMergeFilters(t *testing.T) {
+	defaults := config.Filters{Include: []string{"a"}, Exclude: []string{"b"}}
+	chart := config.Filters{Exclude: []string{"c"}}
+
+	got := mergeFilters(defaults, chart)
+	if len(got.Include) != 1 || got.Include[0] != "a" {
+		t.Errorf("mergeFilters() Include = %v, want [a]", got.Include)
+	}
+	if len(got.Exclude) != 2 || got.Exclude[0] != "b" || got.Exclude[1] != "c" {
+		t.Errorf("mergeFilters() Exclude = %v, want [b c]", got.Exclude)
+	}
+}
+
+func TestMergeFiltersChartEmptyReturnsDefaults(t *testing.T) {
+	defaults := config.Filters{Include: []string{"a"}}
+	got := mergeFilters(defaults, config.Filters{})
+	if len(got.Include) != 1 || got.Include[0] != "a" {
+		t.Errorf("mergeFilters() = %+v, want defaults unchanged", got)
+	}
+}
+
+func TestDecideFilterSkipped(t *testing.T) {
+	d := decideFilter("docker.io/library/busybox:1.36", config.Filters{Exclude: []string{"busybox"}})
+	if !d.Skipped || d.Reason != "excluded by filter" {
+		t.Errorf("decideFilter() = %+v, want Skipped=true Reason=%q", d, "excluded by filter")
+	}
+}
+
+func TestDecideFilterKept(t *testing.T) {
+	d := decideFilter("docker.io/library/nginx:1.25", config.Filters{})
+	if d.Skipped {
+		t.Errorf("decideFilter() = %+v, want Skipped=false", d)
+	}
+}
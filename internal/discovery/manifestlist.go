@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// platformSource is one platform-specific pull source produced by
+// resolveManifestList: either a child digest resolved from a manifest
+// list/image index, or — when expansion wasn't possible — source
+// unchanged with every platform joined into one string, identical to
+// discoverStandaloneImage's behavior before per-platform resolution
+// existed.
+type platformSource struct {
+	Source   string
+	Platform string
+}
+
+// resolveManifestList expands source into one platformSource per entry in
+// platforms by resolving each platform's child manifest digest from the
+// registry's OCI/Docker manifest list (index), so downstream Copa/BuildKit
+// steps operate on a specific architecture instead of the ambiguous
+// multi-arch tag.
+//
+// It falls back to the pre-existing single-entry behavior (all platforms
+// joined into one comma-separated string, Source left untouched) whenever
+// expansion isn't possible or doesn't apply: fewer than two platforms,
+// source isn't an index, the index has no matching platform, or the
+// registry request itself fails — any of these print a warning (for
+// request error) and degrade gracefully rather than failing discovery for
+// the whole image.
+func resolveManifestList(source string, platforms []string) []platformSource {
+	fallback := []platformSource{{Source: source, Platform: joinPlatforms(platforms)}}
+	if len(platforms) < 2 {
+		return fallback
+	}
+
+	ref, err := name.ParseReference(source, name.WeakValidation)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not parse %q for per-platform digest resolution: %v\n", source, err)
+		return fallback
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not fetch %q for per-platform digest resolution: %v\n", source, err)
+		return fallback
+	}
+	if !desc.MediaType.IsIndex() {
+		return fallback
+	}
+
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read image index for %q: %v\n", source, err)
+		return fallback
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read index manifest for %q: %v\n", source, err)
+		return fallback
+	}
+
+	digestByPlatform := make(map[string]string, len(manifest.Manifests))
+	for _, m := range manifest.Manifests {
+		if m.Platform == nil {
+			continue
+		}
+		digestByPlatform[platformKey(m.Platform)] = m.Digest.String()
+	}
+
+	repo := ref.Context().Name()
+	var result []platformSource
+	for _, platform := range platforms {
+		digest, ok := digestByPlatform[platform]
+		if !ok {
+			continue
+		}
+		result = append(result, platformSource{Source: repo + "@" + digest, Platform: platform})
+	}
+	if len(result) == 0 {
+		return fallback
+	}
+	return result
+}
+
+// platformKey formats a v1.Platform as "os/arch" ("os/arch/variant" when a
+// variant is set), matching the "linux/amd64" / "linux/arm64" form
+// ImageSpec.Platforms entries already use.
+func platformKey(p *v1.Platform) string {
+	key := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		key += "/" + p.Variant
+	}
+	return key
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+package discovery
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+
+	"github.com/verity-org/verity/internal/imgmutate"
+)
+
+// TestResolveManifestList_Integration pushes a real two-platform image index
+// (linux/amd64 + linux/arm64) to an in-process registry and checks that
+// resolveManifestList resolves each requested platform to that platform's
+// own child digest, rather than the ambiguous multi-arch tag every platform
+// shares.
+func TestResolveManifestList_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	ref := fmt.Sprintf("%s/library/nginx:1.27.0", host)
+
+	idx := imgmutate.BuildIndex(
+		[]v1.Image{empty.Image, empty.Image},
+		[]v1.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}},
+	)
+	if err := imgmutate.PushIndex(idx, ref, nil); err != nil {
+		t.Fatalf("pushing index: %v", err)
+	}
+
+	got := resolveManifestList(ref, []string{"linux/amd64", "linux/arm64"})
+	if len(got) != 2 {
+		t.Fatalf("resolveManifestList() = %+v, want 2 entries", got)
+	}
+
+	byPlatform := make(map[string]string, len(got))
+	for _, ps := range got {
+		byPlatform[ps.Platform] = ps.Source
+	}
+	for _, platform := range []string{"linux/amd64", "linux/arm64"} {
+		source, ok := byPlatform[platform]
+		if !ok {
+			t.Fatalf("resolveManifestList() missing platform %q, got %+v", platform, got)
+		}
+		if source == ref {
+			t.Errorf("resolveManifestList() for %q returned the tag unchanged, want a per-platform digest", platform)
+		}
+	}
+	if byPlatform["linux/amd64"] == byPlatform["linux/arm64"] {
+		t.Error("resolveManifestList() resolved both platforms to the same digest")
+	}
+}
+
+// TestResolveManifestList_MissingPlatform_Integration pushes an index with
+// only a linux/amd64 child and checks that requesting linux/arm64 (absent
+// from the index) is dropped rather than silently resolving to the wrong
+// platform's manifest.
+func TestResolveManifestList_MissingPlatform_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	ref := fmt.Sprintf("%s/library/nginx:1.27.0-amd64-only", host)
+
+	idx := imgmutate.BuildIndex(
+		[]v1.Image{empty.Image},
+		[]v1.Platform{{OS: "linux", Architecture: "amd64"}},
+	)
+	if err := imgmutate.PushIndex(idx, ref, nil); err != nil {
+		t.Fatalf("pushing index: %v", err)
+	}
+
+	got := resolveManifestList(ref, []string{"linux/amd64", "linux/arm64"})
+	if len(got) != 1 || got[0].Platform != "linux/amd64" {
+		t.Errorf("resolveManifestList() = %+v, want only linux/amd64", got)
+	}
+}
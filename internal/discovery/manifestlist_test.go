@@ -0,0 +1,51 @@
+package discovery
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+func TestResolveManifestListSingleArchReturnsUnexpanded(t *testing.T) {
+	got := resolveManifestList("quay.io/prometheus/prometheus:v3.2.1", []string{"linux/amd64"})
+	want := []platformSource{{Source: "quay.io/prometheus/prometheus:v3.2.1", Platform: "linux/amd64"}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("resolveManifestList() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveManifestListNoPlatformsUsesDefault(t *testing.T) {
+	got := resolveManifestList("quay.io/prometheus/prometheus:v3.2.1", nil)
+	want := []platformSource{{Source: "quay.io/prometheus/prometheus:v3.2.1", Platform: DefaultPlatforms}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("resolveManifestList() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveManifestListUnreachableRegistryFallsBack(t *testing.T) {
+	platforms := []string{"linux/amd64", "linux/arm64"}
+	got := resolveManifestList("unreachable.invalid:5000/some/image:v1", platforms)
+	want := []platformSource{{Source: "unreachable.invalid:5000/some/image:v1", Platform: joinPlatforms(platforms)}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("resolveManifestList() = %+v, want fallback %+v", got, want)
+	}
+}
+
+func TestPlatformKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform *v1.Platform
+		want     string
+	}{
+		{"no variant", &v1.Platform{OS: "linux", Architecture: "amd64"}, "linux/amd64"},
+		{"with variant", &v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, "linux/arm/v7"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := platformKey(tc.platform)
+			if got != tc.want {
+				t.Errorf("platformKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+// resolvePullSource picks the registry a DiscoveredImage's Source should
+// point at for one resolved tagOrDigest, applying the mirror-by-digest-only
+// semantics from containers/image's registries.conf: a mirror flagged
+// MirrorByDigestOnly is only eligible when tagOrDigest is itself a digest
+// (i.e. image is pinned with "@sha256:..."), since a mirror's tag can drift
+// from the upstream of record but a digest can't — so a tag-based pull
+// always falls through to image's own registry, while a digest-based pull
+// may be satisfied by any configured mirror.
+//
+// Mirrors are probed in order with a cheap reachability check (crane.Head)
+// and the first reachable one wins; if none are configured, or none are
+// reachable, image itself is used as the pull source.
+func resolvePullSource(image string, mirrors []config.Mirror, tagOrDigest string) string {
+	isDigestPull := strings.HasPrefix(tagOrDigest, "sha256:")
+
+	bareImage := stripDigestPin(image)
+	primary := image
+	if !isDigestPull {
+		primary = bareImage + ":" + tagOrDigest
+	}
+
+	ref, err := name.ParseReference(bareImage, name.WeakValidation)
+	if err != nil {
+		return primary
+	}
+	repoPath := ref.Context().RepositoryStr()
+
+	for _, mirror := range mirrors {
+		if mirror.MirrorByDigestOnly && !isDigestPull {
+			continue
+		}
+		candidate := mirrorReference(mirror.Location, repoPath, tagOrDigest, isDigestPull)
+		if probeReachable(candidate) {
+			return candidate
+		}
+	}
+
+	return primary
+}
+
+// mirrorReference builds a pull reference against a mirror's registry for
+// the same repository path and tag/digest as the primary image.
+func mirrorReference(location, repoPath, tagOrDigest string, isDigestPull bool) string {
+	if isDigestPull {
+		return location + "/" + repoPath + "@" + tagOrDigest
+	}
+	return location + "/" + repoPath + ":" + tagOrDigest
+}
+
+// probeReachable reports whether ref resolves in its registry, using a
+// HEAD request (crane.Head) rather than pulling the manifest body.
+func probeReachable(ref string) bool {
+	_, err := crane.Head(ref, craneOptions(ref)...)
+	return err == nil
+}
+
+// digestPin extracts the "sha256:..." portion of an image reference pinned
+// with "@sha256:...", reporting ok=false if image isn't digest-pinned.
+func digestPin(image string) (string, bool) {
+	idx := strings.Index(image, "@sha256:")
+	if idx == -1 {
+		return "", false
+	}
+	return image[idx+1:], true
+}
+
+// stripDigestPin drops a trailing "@sha256:..." pin from image, if present.
+func stripDigestPin(image string) string {
+	if idx := strings.Index(image, "@sha256:"); idx != -1 {
+		return image[:idx]
+	}
+	return image
+}
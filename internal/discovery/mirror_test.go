@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/verity-org/verity/internal/config"
+)
+
+func TestResolvePullSourceNoMirrorsUsesPrimary(t *testing.T) {
+	got := resolvePullSource("quay.io/prometheus/prometheus", nil, "v3.2.1")
+	want := "quay.io/prometheus/prometheus:v3.2.1"
+	if got != want {
+		t.Errorf("resolvePullSource() = %q, want %q", got, want)
+	}
+}
+
+func TestResolvePullSourceSkipsDigestOnlyMirrorForTagPull(t *testing.T) {
+	mirrors := []config.Mirror{
+		{Location: "unreachable.invalid:5000", MirrorByDigestOnly: true},
+	}
+
+	got := resolvePullSource("quay.io/prometheus/prometheus", mirrors, "v3.2.1")
+	want := "quay.io/prometheus/prometheus:v3.2.1"
+	if got != want {
+		t.Errorf("resolvePullSource() = %q, want primary %q (digest-only mirror must not serve a tag pull)", got, want)
+	}
+}
+
+func TestResolvePullSourceUnreachableMirrorFallsBackToPrimary(t *testing.T) {
+	mirrors := []config.Mirror{
+		{Location: "unreachable.invalid:5000"},
+	}
+
+	got := resolvePullSource("quay.io/prometheus/prometheus", mirrors, "v3.2.1")
+	want := "quay.io/prometheus/prometheus:v3.2.1"
+	if got != want {
+		t.Errorf("resolvePullSource() = %q, want primary %q", got, want)
+	}
+}
+
+func TestDigestPin(t *testing.T) {
+	digest, ok := digestPin("quay.io/prometheus/prometheus@sha256:abc123")
+	if !ok || digest != "sha256:abc123" {
+		t.Errorf("digestPin() = (%q, %v), want (\"sha256:abc123\", true)", digest, ok)
+	}
+
+	_, ok = digestPin("quay.io/prometheus/prometheus:v3.2.1")
+	if ok {
+		t.Error("digestPin() = ok=true for a tag-only reference, want false")
+	}
+}
+
+func TestStripDigestPin(t *testing.T) {
+	got := stripDigestPin("quay.io/prometheus/prometheus@sha256:abc123")
+	want := "quay.io/prometheus/prometheus"
+	if got != want {
+		t.Errorf("stripDigestPin() = %q, want %q", got, want)
+	}
+
+	got = stripDigestPin("quay.io/prometheus/prometheus:v3.2.1")
+	want = "quay.io/prometheus/prometheus:v3.2.1"
+	if got != want {
+		t.Errorf("stripDigestPin() without a pin = %q, want unchanged %q", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrUnknownScannerBackend is returned when a TagStrategy.Scanner URL's
+// scheme doesn't name a backend newVulnerabilityScanner knows how to build.
+var ErrUnknownScannerBackend = errors.New("unknown scanner backend")
+
+// defaultScannerEndpoint is used when TagStrategy.Scanner is empty: a Trivy
+// server listening locally on its default port.
+const defaultScannerEndpoint = "trivy://127.0.0.1:4954"
+
+// severityRank orders Trivy/Clair severities from least to most severe, so
+// "at or above MinSeverity" can be compared numerically. Unrecognized
+// severities rank below everything, the safe default for a typo.
+var severityRank = map[string]int{
+	"UNKNOWN":  0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// VulnerabilityScanner queries a scanner backend for whether ref (a
+// digest-resolvable image reference) has at least one vulnerability at or
+// above minSeverity with a fix available — the predicate the "vulnerable"
+// TagStrategy uses to skip tags with nothing to patch.
+type VulnerabilityScanner interface {
+	HasFixableVulnerabilities(ref, minSeverity string) (bool, error)
+}
+
+// newVulnerabilityScanner builds the VulnerabilityScanner named by raw, a
+// "<backend>://<host>[:port]" URL (e.g. "trivy://trivy-server:4954" or
+// "clair://clair-indexer:8080"). An empty raw uses defaultScannerEndpoint.
+func newVulnerabilityScanner(raw string) (VulnerabilityScanner, error) {
+	if raw == "" {
+		raw = defaultScannerEndpoint
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing scanner %q: %w", raw, err)
+	}
+
+	endpoint := "http://" + u.Host
+	switch u.Scheme {
+	case "trivy":
+		return &trivyServerScanner{endpoint: endpoint, client: &http.Client{Timeout: 30 * time.Second}}, nil
+	case "clair":
+		return &clairScanner{endpoint: endpoint, client: &http.Client{Timeout: 30 * time.Second}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScannerBackend, u.Scheme)
+	}
+}
+
+// trivyServerScanner queries a Trivy server's (`trivy server`) remote scan
+// API, the default scanner backend for the "vulnerable" TagStrategy.
+type trivyServerScanner struct {
+	endpoint string
+	client   *http.Client
+}
+
+// trivyScanReport is the subset of a Trivy JSON report this package reads.
+type trivyScanReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			Severity     string `json:"Severity"`
+			FixedVersion string `json:"FixedVersion"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func (s *trivyServerScanner) HasFixableVulnerabilities(ref, minSeverity string) (bool, error) {
+	reqURL := fmt.Sprintf("%s/v1/scan?image=%s", strings.TrimSuffix(s.endpoint, "/"), url.QueryEscape(ref))
+	resp, err := s.client.Get(reqURL) //nolint:noctx // TODO: thread a context through TagDiscoverer
+	if err != nil {
+		return false, fmt.Errorf("querying trivy server for %s: %w", ref, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("trivy server returned %s for %s", resp.Status, ref)
+	}
+
+	var report trivyScanReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return false, fmt.Errorf("decoding trivy server response for %s: %w", ref, err)
+	}
+
+	minRank := severityRank[strings.ToUpper(minSeverity)]
+	for _, res := range report.Results {
+		for _, v := range res.Vulnerabilities {
+			if v.FixedVersion == "" {
+				continue
+			}
+			if severityRank[strings.ToUpper(v.Severity)] >= minRank {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// clairScanner queries a Clair v4 matcher's vulnerability report API. Clair
+// indexes by manifest digest rather than by tag, so callers pass ref
+// already resolved to its "repo@sha256:..." form.
+type clairScanner struct {
+	endpoint string
+	client   *http.Client
+}
+
+// clairVulnerabilityReport is the subset of Clair v4's VulnerabilityReport
+// this package reads.
+type clairVulnerabilityReport struct {
+	Vulnerabilities map[string]struct {
+		NormalizedSeverity string `json:"normalized_severity"`
+		FixedInVersion     string `json:"fixed_in_version"`
+	} `json:"vulnerabilities"`
+}
+
+func (s *clairScanner) HasFixableVulnerabilities(ref, minSeverity string) (bool, error) {
+	digest := digestOf(ref)
+	if digest == "" {
+		return false, fmt.Errorf("%w: %q has no resolvable digest", errRefNotDigestPinned, ref)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/vulnerability_report/%s", strings.TrimSuffix(s.endpoint, "/"), digest)
+	resp, err := s.client.Get(reqURL) //nolint:noctx // TODO: thread a context through TagDiscoverer
+	if err != nil {
+		return false, fmt.Errorf("querying clair for %s: %w", ref, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("clair returned %s for %s", resp.Status, ref)
+	}
+
+	var report clairVulnerabilityReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return false, fmt.Errorf("decoding clair response for %s: %w", ref, err)
+	}
+
+	minRank := severityRank[strings.ToUpper(minSeverity)]
+	for _, v := range report.Vulnerabilities {
+		if v.FixedInVersion == "" {
+			continue
+		}
+		if severityRank[strings.ToUpper(v.NormalizedSeverity)] >= minRank {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var errRefNotDigestPinned = errors.New("reference is not digest-pinned")
+
+// digestOf extracts the "sha256:..." suffix from a "repo@sha256:..." ref,
+// or "" if ref isn't digest-pinned.
+func digestOf(ref string) string {
+	_, digest, found := strings.Cut(ref, "@")
+	if !found {
+		return ""
+	}
+	return digest
+}
@@ -0,0 +1,91 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewVulnerabilityScanner(t *testing.T) {
+	if _, err := newVulnerabilityScanner(""); err != nil {
+		t.Errorf("newVulnerabilityScanner(\"\") error = %v, want nil (default endpoint)", err)
+	}
+	if _, err := newVulnerabilityScanner("trivy://trivy-server:4954"); err != nil {
+		t.Errorf("newVulnerabilityScanner(trivy://...) error = %v", err)
+	}
+	if _, err := newVulnerabilityScanner("clair://clair:8080"); err != nil {
+		t.Errorf("newVulnerabilityScanner(clair://...) error = %v", err)
+	}
+	if _, err := newVulnerabilityScanner("bogus://host"); err == nil {
+		t.Error("newVulnerabilityScanner(bogus://...) expected an error, got nil")
+	}
+}
+
+func TestTrivyServerScanner_HasFixableVulnerabilities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := trivyScanReport{}
+		report.Results = []struct {
+			Vulnerabilities []struct {
+				Severity     string `json:"Severity"`
+				FixedVersion string `json:"FixedVersion"`
+			} `json:"Vulnerabilities"`
+		}{
+			{Vulnerabilities: []struct {
+				Severity     string `json:"Severity"`
+				FixedVersion string `json:"FixedVersion"`
+			}{
+				{Severity: "MEDIUM", FixedVersion: "1.2.4"},
+				{Severity: "CRITICAL", FixedVersion: ""}, // no fix available: must not count
+			}},
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}))
+	defer srv.Close()
+
+	scanner := &trivyServerScanner{endpoint: srv.URL, client: srv.Client()}
+
+	has, err := scanner.HasFixableVulnerabilities("example/app:1.2.3", "MEDIUM")
+	if err != nil {
+		t.Fatalf("HasFixableVulnerabilities() error = %v", err)
+	}
+	if !has {
+		t.Error("HasFixableVulnerabilities() = false, want true for a fixable MEDIUM")
+	}
+
+	has, err = scanner.HasFixableVulnerabilities("example/app:1.2.3", "HIGH")
+	if err != nil {
+		t.Fatalf("HasFixableVulnerabilities() error = %v", err)
+	}
+	if has {
+		t.Error("HasFixableVulnerabilities() = true, want false: no fixable vuln reaches HIGH")
+	}
+}
+
+func TestCachedHasFixableVulnerabilities(t *testing.T) {
+	calls := 0
+	stub := stubScanner{fn: func(ref, minSeverity string) (bool, error) {
+		calls++
+		return true, nil
+	}}
+
+	got, err := cachedHasFixableVulnerabilities(stub, "example/app@sha256:cachetest1234", "sha256:cachetest1234", "HIGH")
+	if err != nil || !got {
+		t.Fatalf("cachedHasFixableVulnerabilities() = (%v, %v), want (true, nil)", got, err)
+	}
+	got, err = cachedHasFixableVulnerabilities(stub, "example/app@sha256:cachetest1234", "sha256:cachetest1234", "HIGH")
+	if err != nil || !got {
+		t.Fatalf("cachedHasFixableVulnerabilities() = (%v, %v), want (true, nil)", got, err)
+	}
+	if calls != 1 {
+		t.Errorf("scanner called %d times, want 1 (second call should hit vulnScanCache)", calls)
+	}
+}
+
+type stubScanner struct {
+	fn func(ref, minSeverity string) (bool, error)
+}
+
+func (s stubScanner) HasFixableVulnerabilities(ref, minSeverity string) (bool, error) {
+	return s.fn(ref, minSeverity)
+}
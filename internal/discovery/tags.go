@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-containerregistry/pkg/crane"
@@ -17,6 +19,81 @@ import (
 // ErrUnknownStrategy is returned when an image spec has an unrecognized tag strategy.
 var ErrUnknownStrategy = errors.New("unknown tag strategy")
 
+// TagDiscoverer resolves the tags to patch for one image spec under its own
+// TagStrategy.Strategy. FindTagsToPatch just looks up the discoverer
+// registered for spec.Tags.Strategy in tagDiscoverers and calls it — pulled
+// out as an interface so strategies (and tests) can swap in a fake, e.g. a
+// VulnerabilityScanner stub for the "vulnerable" strategy.
+type TagDiscoverer interface {
+	DiscoverTags(spec *config.ImageSpec) ([]string, error)
+}
+
+// tagDiscoverers maps a TagStrategy.Strategy value to the TagDiscoverer
+// that implements it.
+var tagDiscoverers = map[string]TagDiscoverer{
+	"list":       listTagDiscoverer{},
+	"pattern":    patternTagDiscoverer{},
+	"latest":     latestTagDiscoverer{},
+	"vulnerable": vulnerableTagDiscoverer{},
+	"digest":     digestTagDiscoverer{},
+	"constraint": constraintTagDiscoverer{},
+}
+
+type listTagDiscoverer struct{}
+
+func (listTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	result := make([]string, len(spec.Tags.List))
+	copy(result, spec.Tags.List)
+	return result, nil
+}
+
+type patternTagDiscoverer struct{}
+
+func (patternTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	return findTagsByPattern(spec)
+}
+
+type latestTagDiscoverer struct{}
+
+func (latestTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	return findTagsByLatest(spec)
+}
+
+type digestTagDiscoverer struct{}
+
+// DiscoverTags picks the newest semver tag the same way the "latest"
+// strategy does, then resolves it to a digest via crane.Digest and returns
+// a pinned "tag@digest" reference instead of the bare tag. A plain tag can
+// be repointed at different bits between discovery and patch time (or
+// between patch and a later re-verification); the digest suffix makes the
+// matrix job, and every report/attestation keyed off its ImageDiscovery,
+// reproducible against the exact image that was scanned.
+func (digestTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	tags, err := findTagsByLatest(spec)
+	if err != nil {
+		return nil, err
+	}
+	return pinTagsWithDigest(spec.Image, tags)
+}
+
+// pinTagsWithDigest resolves each of tags against image and appends
+// "@<digest>" to it, failing the whole call if any tag's digest can't be
+// resolved (unlike the "vulnerable" strategy's per-tag warn-and-skip,
+// since a caller of the "digest" strategy is asking for exactly these tags
+// pinned, not a best-effort subset).
+func pinTagsWithDigest(image string, tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ref := image + ":" + tag
+		digest, err := crane.Digest(ref, craneOptions(image)...)
+		if err != nil {
+			return nil, fmt.Errorf("resolving digest for %s: %w", ref, err)
+		}
+		result = append(result, tag+"@"+digest)
+	}
+	return result, nil
+}
+
 // craneOptions returns crane options for the given image ref.
 // Localhost registries (127.0.0.1, localhost) use plain HTTP.
 func craneOptions(image string) []crane.Option {
@@ -36,18 +113,11 @@ func craneOptions(image string) []crane.Option {
 
 // FindTagsToPatch discovers the set of tags to patch for a given image spec.
 func FindTagsToPatch(spec *config.ImageSpec) ([]string, error) {
-	switch spec.Tags.Strategy {
-	case "list":
-		result := make([]string, len(spec.Tags.List))
-		copy(result, spec.Tags.List)
-		return result, nil
-	case "pattern":
-		return findTagsByPattern(spec)
-	case "latest":
-		return findTagsByLatest(spec)
-	default:
+	discoverer, ok := tagDiscoverers[spec.Tags.Strategy]
+	if !ok {
 		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, spec.Tags.Strategy)
 	}
+	return discoverer.DiscoverTags(spec)
 }
 
 func findTagsByLatest(spec *config.ImageSpec) ([]string, error) {
@@ -97,6 +167,97 @@ func findTagsByPattern(spec *config.ImageSpec) ([]string, error) {
 	return result, nil
 }
 
+type constraintTagDiscoverer struct{}
+
+// DiscoverTags lists every tag the registry has (like findTagsByLatest),
+// keeps the ones whose semver value satisfies the spec's constraint (see
+// buildConstraint) — expressing ranges like "patch 1.25.x and 1.26.x but
+// not 2.x" that the "pattern" strategy's regex+tail-slice can't — then
+// optionally collapses each major.minor bucket down to its highest
+// PerMinor patches, and applies Exclude/MaxTags exactly as the other
+// semver-aware strategies do.
+func (constraintTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	allTags, err := crane.ListTags(spec.Image, craneOptions(spec.Image)...)
+	if err != nil {
+		return nil, err
+	}
+
+	constraint, err := buildConstraint(spec.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := tagsToSortedVersions(ExcludeTags(allTags, spec.Tags.Exclude))
+	var matching []*semver.Version
+	for _, v := range versions {
+		if constraint.Check(v) {
+			matching = append(matching, v)
+		}
+	}
+
+	if spec.Tags.PerMinor > 0 {
+		matching = topPerMinor(matching, spec.Tags.PerMinor)
+	}
+
+	if spec.Tags.MaxTags > 0 && len(matching) > spec.Tags.MaxTags {
+		matching = matching[len(matching)-spec.Tags.MaxTags:]
+	}
+
+	result := make([]string, len(matching))
+	for i, v := range matching {
+		result[i] = v.Original()
+	}
+	return result, nil
+}
+
+// buildConstraint compiles ts.Constraint, e.g. ">=1.25.0, <1.27.0 || ~1.28",
+// into a *semver.Constraints. When Constraint is empty it synthesizes one
+// from MinVersion/MaxVersion instead (either may be empty), and falls back
+// to "*" (match everything) when none of the three are set.
+func buildConstraint(ts config.TagStrategy) (*semver.Constraints, error) {
+	expr := ts.Constraint
+	if expr == "" {
+		var parts []string
+		if ts.MinVersion != "" {
+			parts = append(parts, ">="+ts.MinVersion)
+		}
+		if ts.MaxVersion != "" {
+			parts = append(parts, "<="+ts.MaxVersion)
+		}
+		expr = strings.Join(parts, ", ")
+	}
+	if expr == "" {
+		expr = "*"
+	}
+	return semver.NewConstraint(expr)
+}
+
+// topPerMinor keeps only the highest perMinor versions within each
+// major.minor bucket of versions (already ascending-sorted, per
+// tagsToSortedVersions), returned still ascending-sorted overall — the
+// "patch latest of each supported minor line" use case PerMinor exists for.
+func topPerMinor(versions []*semver.Version, perMinor int) []*semver.Version {
+	buckets := make(map[string][]*semver.Version)
+	var order []string
+	for _, v := range versions {
+		key := fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+		if _, ok := buckets[key]; !ok {
+			order = append(order, key)
+		}
+		buckets[key] = append(buckets[key], v)
+	}
+
+	var result []*semver.Version
+	for _, key := range order {
+		bucket := buckets[key]
+		if len(bucket) > perMinor {
+			bucket = bucket[len(bucket)-perMinor:]
+		}
+		result = append(result, bucket...)
+	}
+	return result
+}
+
 // tagsToSortedVersions parses tags as semver and returns them sorted ascending.
 // Tags that cannot be parsed as semver are silently skipped.
 func tagsToSortedVersions(tags []string) []*semver.Version {
@@ -110,6 +271,86 @@ func tagsToSortedVersions(tags []string) []*semver.Version {
 	return versions
 }
 
+// defaultMinSeverity is used when TagStrategy.MinSeverity is unset.
+const defaultMinSeverity = "HIGH"
+
+// vulnScanCache caches HasFixableVulnerabilities results keyed by
+// "<digest>|<minSeverity>" so a tag whose digest was already scanned under
+// the same severity threshold (e.g. because two image specs share a base
+// image) isn't sent to the scanner backend twice.
+var vulnScanCache sync.Map
+
+type vulnerableTagDiscoverer struct{}
+
+// DiscoverTags lists every tag the registry has (like findTagsByLatest),
+// drops excluded and non-semver ones, then queries spec.Tags.Scanner (a
+// VulnerabilityScanner, Trivy server by default) for each remaining tag's
+// digest, keeping only tags with at least one fixable vulnerability at or
+// above spec.Tags.MinSeverity — letting large matrices skip rebuilding tags
+// that have nothing to patch.
+func (vulnerableTagDiscoverer) DiscoverTags(spec *config.ImageSpec) ([]string, error) {
+	allTags, err := crane.ListTags(spec.Image, craneOptions(spec.Image)...)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := tagsToSortedVersions(ExcludeTags(allTags, spec.Tags.Exclude))
+	if len(versions) == 0 {
+		return []string{}, nil
+	}
+
+	scanner, err := newVulnerabilityScanner(spec.Tags.Scanner)
+	if err != nil {
+		return nil, err
+	}
+	minSeverity := spec.Tags.MinSeverity
+	if minSeverity == "" {
+		minSeverity = defaultMinSeverity
+	}
+
+	var result []string
+	for _, v := range versions {
+		tag := v.Original()
+		ref := spec.Image + ":" + tag
+
+		digest, err := crane.Digest(ref, craneOptions(spec.Image)...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: resolving digest for %s: %v\n", ref, err)
+			continue
+		}
+
+		hasFixable, err := cachedHasFixableVulnerabilities(scanner, spec.Image+"@"+digest, digest, minSeverity)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: scanning %s: %v\n", ref, err)
+			continue
+		}
+		if hasFixable {
+			result = append(result, tag)
+		}
+	}
+
+	if spec.Tags.MaxTags > 0 && len(result) > spec.Tags.MaxTags {
+		result = result[len(result)-spec.Tags.MaxTags:]
+	}
+	return result, nil
+}
+
+// cachedHasFixableVulnerabilities wraps scanner.HasFixableVulnerabilities
+// with vulnScanCache, so every tag that resolves to the same digest is only
+// scanned once per minSeverity.
+func cachedHasFixableVulnerabilities(scanner VulnerabilityScanner, ref, digest, minSeverity string) (bool, error) {
+	key := digest + "|" + minSeverity
+	if cached, ok := vulnScanCache.Load(key); ok {
+		return cached.(bool), nil
+	}
+	has, err := scanner.HasFixableVulnerabilities(ref, minSeverity)
+	if err != nil {
+		return false, err
+	}
+	vulnScanCache.Store(key, has)
+	return has, nil
+}
+
 // ExcludeTags returns a new slice with excluded entries removed.
 func ExcludeTags(tags, exclusions []string) []string {
 	if len(exclusions) == 0 {
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
+
 	"github.com/verity-org/verity/internal/config"
 )
 
@@ -73,6 +75,103 @@ func TestFindTagsToPatch_List(t *testing.T) {
 	}
 }
 
+func TestFindTagsToPatch_Vulnerable_UnknownScanner(t *testing.T) {
+	spec := &config.ImageSpec{
+		Image: "docker.io/library/nginx",
+		Tags: config.TagStrategy{
+			Strategy: "vulnerable",
+			Scanner:  "bogus://host",
+		},
+	}
+
+	// FindTagsToPatch reaches newVulnerabilityScanner before it needs any
+	// network access, so an unknown backend fails fast without a registry.
+	_, err := FindTagsToPatch(spec)
+	if err == nil {
+		t.Fatal("expected an error for an unknown scanner backend, got nil")
+	}
+}
+
+func TestBuildConstraint(t *testing.T) {
+	tests := []struct {
+		name    string
+		ts      config.TagStrategy
+		version string
+		want    bool
+	}{
+		{"explicit constraint matches", config.TagStrategy{Constraint: ">=1.25.0, <1.27.0"}, "1.26.0", true},
+		{"explicit constraint excludes", config.TagStrategy{Constraint: ">=1.25.0, <1.27.0"}, "1.27.0", false},
+		{"or-clause matches second branch", config.TagStrategy{Constraint: ">=1.25.0, <1.27.0 || ~1.28"}, "1.28.3", true},
+		{"min/max shorthand matches", config.TagStrategy{MinVersion: "1.25.0", MaxVersion: "1.26.9"}, "1.26.0", true},
+		{"min/max shorthand excludes", config.TagStrategy{MinVersion: "1.25.0", MaxVersion: "1.26.9"}, "2.0.0", false},
+		{"no bounds matches everything", config.TagStrategy{}, "9.9.9", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, err := buildConstraint(tt.ts)
+			if err != nil {
+				t.Fatalf("buildConstraint() error = %v", err)
+			}
+			v, err := semver.NewVersion(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := constraint.Check(v); got != tt.want {
+				t.Errorf("constraint.Check(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildConstraint_InvalidExpression(t *testing.T) {
+	_, err := buildConstraint(config.TagStrategy{Constraint: ">=1.25.0, <1.27.0 ||"})
+	if err == nil {
+		t.Fatal("expected an error for an invalid constraint expression, got nil")
+	}
+}
+
+func TestFindTagsToPatch_Constraint(t *testing.T) {
+	orig := tagDiscoverers["constraint"]
+	defer func() { tagDiscoverers["constraint"] = orig }()
+
+	// FindTagsToPatch itself only needs to route to constraintTagDiscoverer
+	// and surface its error; the matching/PerMinor logic is covered by
+	// TestBuildConstraint and TestTopPerMinor directly, without needing a
+	// live registry.
+	spec := &config.ImageSpec{
+		Image: "docker.io/library/nginx",
+		Tags: config.TagStrategy{
+			Strategy:   "constraint",
+			Constraint: ">=1.25.0, <1.27.0 ||",
+		},
+	}
+	_, err := FindTagsToPatch(spec)
+	if err == nil {
+		t.Fatal("expected an error for an invalid constraint expression, got nil")
+	}
+}
+
+func TestTopPerMinor(t *testing.T) {
+	versions := func(tags ...string) []*semver.Version {
+		result := make([]*semver.Version, len(tags))
+		for i, tag := range tags {
+			result[i] = semver.MustParse(tag)
+		}
+		return result
+	}
+
+	got := topPerMinor(versions("1.25.0", "1.25.1", "1.26.0", "1.26.1", "1.26.2"), 1)
+	want := versions("1.25.1", "1.26.2")
+	if len(got) != len(want) {
+		t.Fatalf("topPerMinor() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("topPerMinor()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
 func TestFindTagsToPatch_UnknownStrategy(t *testing.T) {
 	spec := &config.ImageSpec{
 		Image: "docker.io/library/nginx",
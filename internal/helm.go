@@ -2,21 +2,31 @@ package internal
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/provenance"
 	"helm.sh/helm/v3/pkg/registry"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+
+	"github.com/verity-org/verity/internal/attest"
 )
 
 var (
@@ -24,6 +34,88 @@ var (
 	errHTTPFetch  = errors.New("HTTP request failed")
 )
 
+// ChartVerifyMode controls whether DownloadChart checks a chart's .prov
+// provenance signature before accepting it, mirroring the verify modes of
+// Helm's own ChartDownloader.DownloadTo.
+type ChartVerifyMode string
+
+const (
+	// VerifyNever skips provenance verification entirely (the default).
+	VerifyNever ChartVerifyMode = ""
+	// VerifyIfPresent verifies the provenance file when the chart publishes
+	// one, but doesn't fail the download when none exists.
+	VerifyIfPresent ChartVerifyMode = "if-present"
+	// VerifyAlways requires a valid provenance file and fails the download
+	// when one is missing or doesn't verify.
+	VerifyAlways ChartVerifyMode = "always"
+)
+
+// ChartProvenance reports what verifying a chart's .prov file found, so
+// callers can log it without re-deriving it from the keyring themselves.
+// It is nil whenever no provenance was checked (VerifyNever, or
+// VerifyIfPresent with nothing published).
+type ChartProvenance struct {
+	Verified    bool
+	Signer      string // signer identity from the provenance file's PGP key, e.g. "Jane Doe <jane@example.com>"
+	Fingerprint string // hex-encoded fingerprint of the signing key
+	SignedHash  string // sha256 hash the provenance file signs for
+}
+
+// verifyChartProvenance checks tgzPath's signature against the sibling
+// provenance file at provPath using keyringPath, and extracts the signer
+// details a caller would want to log.
+func verifyChartProvenance(tgzPath, provPath, keyringPath string) (*ChartProvenance, error) {
+	sig, err := provenance.NewFromKeyring(keyringPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("loading keyring %s: %w", keyringPath, err)
+	}
+	ver, err := sig.Verify(tgzPath, provPath)
+	if err != nil {
+		return nil, fmt.Errorf("verifying provenance for %s: %w", tgzPath, err)
+	}
+
+	result := &ChartProvenance{Verified: true, SignedHash: ver.FileHash}
+	if ver.SignedBy != nil {
+		for name := range ver.SignedBy.Identities {
+			result.Signer = name
+			break
+		}
+		if ver.SignedBy.PrimaryKey != nil {
+			result.Fingerprint = fmt.Sprintf("%X", ver.SignedBy.PrimaryKey.Fingerprint)
+		}
+	}
+	return result, nil
+}
+
+// fetchURLToFile downloads url to destPath. It returns false (with no
+// error) only for the ordinary "nothing was published here" case of a
+// non-2xx response; any transport-level failure is returned as an error.
+func fetchURLToFile(url, destPath string) (bool, error) {
+	client, err := activeRepoConfig.HTTPClient(url)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Get(url) //nolint:noctx // TODO: add context support
+	if err != nil {
+		return false, fmt.Errorf("fetching %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+
+	f, err := os.Create(destPath)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 type Dependency struct {
 	Name       string `yaml:"name"`
 	Version    string `yaml:"version"`
@@ -52,28 +144,95 @@ func ParseChartFile(path string) (*ChartFile, error) {
 	return &cf, nil
 }
 
-func DownloadChart(dep Dependency, destDir string) (string, error) {
-	// Direct .tgz URL — download and extract.
-	if strings.HasSuffix(dep.Repository, ".tgz") || strings.HasSuffix(dep.Repository, ".tar.gz") {
-		return downloadTarball(dep.Repository, dep.Name, destDir)
+// DownloadChart fetches dep's chart archive into destDir, either via a
+// direct .tgz/.tar.gz URL or through Helm's own SDK pull (classic HTTP
+// repos and OCI registries). When verify is not VerifyNever, it also checks
+// the chart's .prov signature against keyringPath and returns what it
+// found: VerifyAlways fails the download outright when no valid provenance
+// exists, while VerifyIfPresent only checks a signature that's published,
+// and tolerates there being none. This mirrors Helm's own
+// ChartDownloader.DownloadTo, which accepted any tarball a repo served
+// until verification was added.
+func DownloadChart(dep Dependency, destDir string, verify ChartVerifyMode, keyringPath string) (string, *ChartProvenance, error) {
+	var chartPath string
+	var prov *ChartProvenance
+	var err error
+
+	switch {
+	case strings.HasSuffix(dep.Repository, ".tgz") || strings.HasSuffix(dep.Repository, ".tar.gz"):
+		// Direct .tgz URL — download and extract.
+		chartPath, prov, err = downloadTarball(dep.Repository, dep.Name, destDir, verify, keyringPath)
+	case dep.Repository == "" || strings.HasPrefix(dep.Repository, "file://"):
+		// Vendored subchart — no remote repo to pull from at all.
+		chartPath, err = copyLocalChart(dep, destDir)
+	default:
+		// Helm SDK pull (OCI or HTTP repo).
+		chartPath, prov, err = helmPull(dep, destDir, verify, keyringPath)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if locked, ok := activeLock.chartFor(dep); ok {
+		sum, err := hashChartDir(chartPath)
+		if err != nil {
+			return "", nil, fmt.Errorf("hashing %s@%s: %w", dep.Name, dep.Version, err)
+		}
+		if sum != locked.SHA256 {
+			return "", nil, fmt.Errorf("%w: %s@%s: locked %s, got %s", ErrChartHashMismatch, dep.Name, dep.Version, locked.SHA256, sum)
+		}
 	}
 
-	// Helm SDK pull (OCI or HTTP repo).
-	chartPath, err := helmPull(dep, destDir)
+	return chartPath, prov, nil
+}
+
+// copyLocalChart resolves dep's chart straight from the local filesystem
+// instead of a remote repo, for a Dependency declared with repository: ""
+// or a file://... URL — the same shorthand `helm dependency update`
+// accepts for a subchart that's vendored alongside its parent rather than
+// published anywhere. An empty Repository defaults to "charts/<name>",
+// matching where a chart's own charts/ directory already keeps a vendored
+// subchart. The path is resolved relative to the current working
+// directory, since DownloadChart has no notion of "the umbrella chart's
+// directory" at this layer — every current caller runs from the chart
+// tree root, so this is equivalent in practice.
+func copyLocalChart(dep Dependency, destDir string) (string, error) {
+	src := strings.TrimPrefix(dep.Repository, "file://")
+	if src == "" {
+		src = filepath.Join("charts", dep.Name)
+	}
+	info, err := os.Stat(src)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("resolving local chart %s: %w", src, err)
 	}
-	return chartPath, nil
+	if !info.IsDir() {
+		return "", fmt.Errorf("local chart path %s is not a directory", src)
+	}
+
+	dst := filepath.Join(destDir, dep.Name)
+	if err := copyTree(src, dst, nil); err != nil {
+		return "", fmt.Errorf("copying local chart %s: %w", src, err)
+	}
+	return dst, nil
 }
 
-func helmPull(dep Dependency, destDir string) (string, error) {
+func helmPull(dep Dependency, destDir string, verify ChartVerifyMode, keyringPath string) (string, *ChartProvenance, error) {
 	settings := cli.New()
 	cfg := &action.Configuration{}
+	auth, hasAuth := activeRepoConfig.authFor(dep.Repository)
 
 	if strings.HasPrefix(dep.Repository, "oci://") {
-		regClient, err := registry.NewClient()
+		regOpts := []registry.ClientOption{}
+		if hasAuth {
+			httpClient, err := activeRepoConfig.HTTPClient(dep.Repository)
+			if err != nil {
+				return "", nil, err
+			}
+			regOpts = append(regOpts, registry.ClientOptHTTPClient(httpClient))
+		}
+		regClient, err := registry.NewClient(regOpts...)
 		if err != nil {
-			return "", fmt.Errorf("creating registry client: %w", err)
+			return "", nil, fmt.Errorf("creating registry client: %w", err)
 		}
 		cfg.RegistryClient = regClient
 	}
@@ -81,7 +240,7 @@ func helmPull(dep Dependency, destDir string) (string, error) {
 	// Create temp dir for .tgz download
 	tmpDir, err := os.MkdirTemp("", "verity-helm-*")
 	if err != nil {
-		return "", fmt.Errorf("creating temp dir: %w", err)
+		return "", nil, fmt.Errorf("creating temp dir: %w", err)
 	}
 	defer func() { _ = os.RemoveAll(tmpDir) }()
 
@@ -90,6 +249,20 @@ func helmPull(dep Dependency, destDir string) (string, error) {
 	pull.Untar = false // We'll extract it ourselves
 	pull.DestDir = tmpDir
 	pull.Version = dep.Version
+	pull.Keyring = keyringPath
+	if hasAuth {
+		pull.CaFile = auth.CAFile
+		pull.CertFile = auth.CertFile
+		pull.KeyFile = auth.KeyFile
+		pull.InsecureSkipTLSverify = auth.InsecureSkipTLSVerify
+		pull.Username = auth.Username
+		pull.Password = auth.Password
+	}
+	// Helm's pull action only fetches a chart's sibling .prov when Verify
+	// is set, and treats a missing one as fatal — so only turn it on here
+	// for VerifyAlways. VerifyIfPresent falls back to an unverified pull
+	// below when that turns out to be why it failed.
+	pull.Verify = verify == VerifyAlways
 
 	var chartRef string
 	if strings.HasPrefix(dep.Repository, "oci://") {
@@ -100,14 +273,18 @@ func helmPull(dep Dependency, destDir string) (string, error) {
 	}
 
 	output, err := pull.Run(chartRef)
+	if err != nil && verify == VerifyIfPresent && isMissingProvenanceErr(err) {
+		pull.Verify = false
+		output, err = pull.Run(chartRef)
+	}
 	if err != nil {
-		return "", fmt.Errorf("pulling %s@%s: %w", dep.Name, dep.Version, err)
+		return "", nil, fmt.Errorf("pulling %s@%s: %w", dep.Name, dep.Version, err)
 	}
 
 	// Find the .tgz file in tmpDir
 	entries, err := os.ReadDir(tmpDir)
 	if err != nil {
-		return "", fmt.Errorf("reading temp dir: %w", err)
+		return "", nil, fmt.Errorf("reading temp dir: %w", err)
 	}
 	var tgzPath string
 	for _, entry := range entries {
@@ -117,42 +294,161 @@ func helmPull(dep Dependency, destDir string) (string, error) {
 		}
 	}
 	if tgzPath == "" {
-		return "", fmt.Errorf("%w in %s (output was: %q)", errNoTgzFound, tmpDir, output)
+		return "", nil, fmt.Errorf("%w in %s (output was: %q)", errNoTgzFound, tmpDir, output)
+	}
+
+	// If pull.Verify fetched a sibling .prov alongside the chart, re-check
+	// it ourselves so we can return the structured signer/fingerprint/hash
+	// details Helm's own pull.Run doesn't surface to its caller.
+	var prov *ChartProvenance
+	if provPath := tgzPath + ".prov"; pull.Verify {
+		if _, err := os.Stat(provPath); err == nil {
+			prov, err = verifyChartProvenance(tgzPath, provPath, keyringPath)
+			if err != nil {
+				return "", nil, err
+			}
+		}
 	}
 
 	// Extract the downloaded .tgz
 	file, err := os.Open(tgzPath)
 	if err != nil {
-		return "", fmt.Errorf("opening chart archive: %w", err)
+		return "", nil, fmt.Errorf("opening chart archive: %w", err)
 	}
 	defer func() { _ = file.Close() }()
 
 	chartPath, err := extractTarGz(file, dep.Name, destDir)
 	if err != nil {
-		return "", fmt.Errorf("extracting chart: %w", err)
+		return "", nil, fmt.Errorf("extracting chart: %w", err)
 	}
 
-	return chartPath, nil
+	return chartPath, prov, nil
 }
 
-// downloadTarball fetches a .tgz URL and extracts it into destDir.
-func downloadTarball(url, chartName, destDir string) (string, error) {
-	client := &http.Client{Timeout: 5 * time.Minute}
-	resp, err := client.Get(url) //nolint:noctx // TODO: add context support
+// fetchWrapperChartMeta pulls registry/charts/<chartName>:<version> with the
+// Helm SDK (the same OCI pull action.NewPullWithOpts sets up for helmPull)
+// and returns its Chart.yaml metadata without extracting the archive,
+// giving callers like ListPublishedCharts read access to the org.verity.*
+// annotations CreateWrapperChart/PublishChart wrote there.
+func fetchWrapperChartMeta(registry, chartName, version string) (*chart.Metadata, error) {
+	settings := cli.New()
+	cfg := &action.Configuration{}
+	auth, hasAuth := activeRepoConfig.authFor(registry)
+	regOpts := []registry.ClientOption{}
+	if hasAuth {
+		httpClient, err := activeRepoConfig.HTTPClient(registry)
+		if err != nil {
+			return nil, err
+		}
+		regOpts = append(regOpts, registry.ClientOptHTTPClient(httpClient))
+	}
+	regClient, err := registry.NewClient(regOpts...)
 	if err != nil {
-		return "", fmt.Errorf("fetching %s: %w", url, err)
+		return nil, fmt.Errorf("creating registry client: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", err)
+	cfg.RegistryClient = regClient
+
+	tmpDir, err := os.MkdirTemp("", "verity-chart-meta-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	pull := action.NewPullWithOpts(action.WithConfig(cfg))
+	pull.Settings = settings
+	pull.Untar = false
+	pull.DestDir = tmpDir
+	pull.Version = version
+	if hasAuth {
+		pull.CaFile = auth.CAFile
+		pull.CertFile = auth.CertFile
+		pull.KeyFile = auth.KeyFile
+		pull.InsecureSkipTLSverify = auth.InsecureSkipTLSVerify
+		pull.Username = auth.Username
+		pull.Password = auth.Password
+	}
+
+	chartRef := fmt.Sprintf("oci://%s/charts/%s", registry, chartName)
+	if _, err := pull.Run(chartRef); err != nil {
+		return nil, fmt.Errorf("pulling %s@%s: %w", chartName, version, err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading temp dir: %w", err)
+	}
+	var tgzPath string
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tgz") {
+			tgzPath = filepath.Join(tmpDir, entry.Name())
+			break
 		}
-	}()
+	}
+	if tgzPath == "" {
+		return nil, fmt.Errorf("%w for %s@%s in %s", errNoTgzFound, chartName, version, tmpDir)
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: fetching %s: HTTP %d", errHTTPFetch, url, resp.StatusCode)
+	ch, err := loader.LoadFile(tgzPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading packaged chart %s: %w", tgzPath, err)
+	}
+	return ch.Metadata, nil
+}
+
+// isMissingProvenanceErr reports whether err looks like a Helm pull failed
+// only because the chart has no .prov file, as opposed to one that exists
+// but doesn't verify.
+func isMissingProvenanceErr(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, ".prov") &&
+		(strings.Contains(msg, "no such file") || strings.Contains(msg, "not found"))
+}
+
+// downloadTarball fetches a .tgz URL and extracts it into destDir. When
+// verify is not VerifyNever, it also fetches the sibling "<url>.prov" and
+// verifies it against keyringPath.
+func downloadTarball(url, chartName, destDir string, verify ChartVerifyMode, keyringPath string) (string, *ChartProvenance, error) {
+	tmpDir, err := os.MkdirTemp("", "verity-tarball-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	tgzPath := filepath.Join(tmpDir, chartName+".tgz")
+	if ok, err := fetchURLToFile(url, tgzPath); err != nil {
+		return "", nil, err
+	} else if !ok {
+		return "", nil, fmt.Errorf("%w: fetching %s", errHTTPFetch, url)
+	}
+
+	var prov *ChartProvenance
+	if verify != VerifyNever {
+		provPath := tgzPath + ".prov"
+		ok, err := fetchURLToFile(url+".prov", provPath)
+		switch {
+		case err != nil:
+			return "", nil, err
+		case !ok && verify == VerifyAlways:
+			return "", nil, fmt.Errorf("%w: no provenance published for %s", errHTTPFetch, url)
+		case ok:
+			prov, err = verifyChartProvenance(tgzPath, provPath, keyringPath)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	archive, err := os.Open(tgzPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("opening chart archive: %w", err)
 	}
+	defer func() { _ = archive.Close() }()
 
-	return extractTarGz(resp.Body, chartName, destDir)
+	chartPath, err := extractTarGz(archive, chartName, destDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("extracting chart: %w", err)
+	}
+	return chartPath, prov, nil
 }
 
 func extractTarGz(r io.Reader, chartName, destDir string) (string, error) {
@@ -210,54 +506,193 @@ func extractTarGz(r io.Reader, chartName, destDir string) (string, error) {
 	return filepath.Join(destDir, chartName), nil
 }
 
-// PublishChart packages a chart directory and pushes it to an OCI registry.
-// Returns the path to the packaged .tgz file.
-func PublishChart(chartDir, targetRegistry string) (string, error) {
-	// Create a temp directory for the package output
-	tmpDir, err := os.MkdirTemp("", "helm-package-*")
+// PublishResult records what PublishChart packaged and pushed: the OCI
+// reference and digest it was published under, the packaged .tgz's local
+// path (so callers like signAndAttestChart can still sign the archive
+// directly), and any signature artifacts a non-nil PublishSignOptions
+// produced.
+type PublishResult struct {
+	Reference      string
+	Digest         string
+	LocalPath      string
+	ProvenancePath string
+	CosignResult   *attest.SignResult
+}
+
+// PublishSignOptions requests PublishChart additionally sign what it
+// publishes. Either field may be left unset to skip that signature.
+type PublishSignOptions struct {
+	// ProvenanceKeyring, if set, has Helm itself sign the packaged chart
+	// with a Helm provenance (.prov) file using this PGP keyring, and
+	// pushes the provenance alongside the chart as a sibling OCI artifact.
+	ProvenanceKeyring string
+	// ProvenanceIdentity selects the signing key within ProvenanceKeyring
+	// (passed through to Helm's own `helm package --key`).
+	ProvenanceIdentity string
+	// Cosign, if non-nil, also signs the pushed OCI manifest with cosign
+	// (keyless unless Cosign.Key is set) the same way SignImage signs a
+	// patched image's digest.
+	Cosign *attest.SignOptions
+}
+
+// stampPublishedAt sets chartDir/Chart.yaml's org.verity.published-at
+// annotation to the current time, called by PublishChart just before
+// packaging so the recorded timestamp reflects this push rather than
+// whenever CreateWrapperChart happened to build the chart directory.
+// Rewrites the whole annotations map generically (rather than through
+// writeChartYaml's WrapperChart struct) since PublishChart only has a
+// chart directory to work with, not the WrapperChart CreateWrapperChart
+// built it from.
+func stampPublishedAt(chartDir string) error {
+	path := filepath.Join(chartDir, "Chart.yaml")
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("creating temp dir: %w", err)
+		return fmt.Errorf("reading Chart.yaml: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing Chart.yaml: %w", err)
+	}
+
+	annotations, _ := doc["annotations"].(map[string]any)
+	if annotations == nil {
+		annotations = map[string]any{}
+	}
+	annotations["org.verity.published-at"] = time.Now().UTC().Format(time.RFC3339)
+	doc["annotations"] = annotations
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("marshaling Chart.yaml: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// PublishChart packages chartDir with the Helm SDK (action.Package, with
+// DependencyUpdate so subcharts are resolved first — no `helm` binary
+// required) and pushes it to targetRegistry's OCI "charts" path via Helm's
+// own registry.Client. The packaged .tgz (and .prov, if signed) are
+// written into chartDir itself, alongside the other wrapper-chart
+// artifacts CreateWrapperChart already places there.
+//
+// When activeRepoConfig has an entry for targetRegistry's host, PublishChart
+// builds the registry.Client from that entry's mTLS settings (the same as
+// helmPull) and, for basic-auth entries, logs in via RegistryClient.Login
+// before pushing — mirroring `helm registry login` — so a private registry
+// push doesn't silently fall back to the ambient docker keychain the way an
+// unauthenticated registry.NewClient() would.
+func PublishChart(chartDir, targetRegistry string, sign *PublishSignOptions) (*PublishResult, error) {
+	if err := stampPublishedAt(chartDir); err != nil {
+		return nil, err
 	}
-	defer os.RemoveAll(tmpDir)
 
-	// Build dependencies so the published chart is self-contained
-	cmd := exec.Command("helm", "dependency", "build", chartDir) //nolint:noctx // TODO: add context support
-	output, err := cmd.CombinedOutput()
+	pkg := action.NewPackage()
+	pkg.Destination = chartDir
+	pkg.DependencyUpdate = true
+	if sign != nil && sign.ProvenanceKeyring != "" {
+		pkg.Sign = true
+		pkg.Key = sign.ProvenanceIdentity
+		pkg.Keyring = sign.ProvenanceKeyring
+	}
+
+	tgzPath, err := pkg.Run(chartDir, nil)
 	if err != nil {
-		return "", fmt.Errorf("helm dependency build failed: %w\nOutput: %s", err, output)
+		return nil, fmt.Errorf("packaging %s: %w", chartDir, err)
 	}
 
-	// Package the chart
-	cmd = exec.Command("helm", "package", chartDir, "-d", tmpDir) //nolint:noctx // TODO: add context support
-	output, err = cmd.CombinedOutput()
+	ch, err := loader.LoadFile(tgzPath)
 	if err != nil {
-		return "", fmt.Errorf("helm package failed: %w\nOutput: %s", err, output)
+		return nil, fmt.Errorf("loading packaged chart %s: %w", tgzPath, err)
+	}
+	chartBytes, err := os.ReadFile(tgzPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading packaged chart %s: %w", tgzPath, err)
 	}
 
-	// Find the packaged .tgz file
-	entries, err := os.ReadDir(tmpDir)
+	auth, hasAuth := activeRepoConfig.authFor(targetRegistry)
+	var regOpts []registry.ClientOption
+	if hasAuth {
+		httpClient, err := activeRepoConfig.HTTPClient(targetRegistry)
+		if err != nil {
+			return nil, err
+		}
+		regOpts = append(regOpts, registry.ClientOptHTTPClient(httpClient))
+	}
+	regClient, err := registry.NewClient(regOpts...)
 	if err != nil {
-		return "", fmt.Errorf("reading package dir: %w", err)
+		return nil, fmt.Errorf("creating registry client: %w", err)
 	}
-	var tgzPath string
-	for _, e := range entries {
-		if strings.HasSuffix(e.Name(), ".tgz") {
-			tgzPath = filepath.Join(tmpDir, e.Name())
-			break
+
+	if hasAuth && auth.Username != "" {
+		if err := regClient.Login(
+			hostOf(targetRegistry),
+			registry.LoginOptBasicAuth(auth.Username, auth.Password),
+			registry.LoginOptInsecure(auth.InsecureSkipTLSVerify),
+		); err != nil {
+			return nil, fmt.Errorf("logging into %s: %w", hostOf(targetRegistry), err)
 		}
 	}
-	if tgzPath == "" {
-		return "", fmt.Errorf("%w after packaging", errNoTgzFound)
+
+	result := &PublishResult{LocalPath: tgzPath}
+
+	var pushOpts []registry.PushOption
+	provPath := tgzPath + ".prov"
+	if provBytes, statErr := os.ReadFile(provPath); statErr == nil {
+		pushOpts = append(pushOpts, registry.PushOptProvData(provBytes))
+		result.ProvenancePath = provPath
 	}
 
-	// Push to OCI registry
-	ociURL := fmt.Sprintf("oci://%s/charts", targetRegistry)
-	cmd = exec.Command("helm", "push", tgzPath, ociURL) //nolint:noctx // TODO: add context support
-	output, err = cmd.CombinedOutput()
+	chartRef := fmt.Sprintf("%s/charts/%s:%s", targetRegistry, ch.Metadata.Name, ch.Metadata.Version)
+	pushResult, err := regClient.Push(chartBytes, chartRef, pushOpts...)
 	if err != nil {
-		return "", fmt.Errorf("helm push failed: %w\nOutput: %s", err, output)
+		return nil, fmt.Errorf("pushing %s: %w", chartRef, err)
+	}
+	result.Reference = "oci://" + chartRef
+	result.Digest = pushResult.Manifest.Digest
+
+	if sign != nil && sign.Cosign != nil {
+		digestRef := fmt.Sprintf("%s/charts/%s@%s", targetRegistry, ch.Metadata.Name, pushResult.Manifest.Digest)
+		signResult, err := attest.SignImage(context.Background(), digestRef, *sign.Cosign)
+		if err != nil {
+			return nil, fmt.Errorf("cosign-signing %s: %w", digestRef, err)
+		}
+		result.CosignResult = signResult
 	}
 
-	fmt.Printf("Published chart to %s\n", ociURL)
-	return tgzPath, nil
+	fmt.Printf("Published chart to %s (%s)\n", result.Reference, result.Digest)
+	return result, nil
+}
+
+// PushChannelTags computes and pushes floating channel tags ("latest", the
+// major version, "major.minor", and — for a non-prerelease — "stable") as
+// OCI tag aliases pointing at chartName:version's own manifest, so
+// consumers can depend on oci://registry/charts/name:latest instead of
+// pinning an exact version. isVersionTag already skips ".sig"/".att"/digest
+// tags when listing versions, so these aliases don't interfere with
+// discoverRegistryVersions' per-version history.
+//
+// version must already be pushed to registry. A version that doesn't parse
+// as semver (e.g. AssembleResults' -legacy-numeric-versions mode) only gets
+// "latest", since there's no major/minor to derive a channel from.
+func PushChannelTags(registry, chartName, version string) error {
+	chartRef := fmt.Sprintf("%s/charts/%s", registry, chartName)
+	src := fmt.Sprintf("%s:%s", chartRef, version)
+
+	channels := []string{"latest"}
+	if v, err := semver.NewVersion(version); err == nil {
+		channels = append(channels, fmt.Sprintf("%d", v.Major()), fmt.Sprintf("%d.%d", v.Major(), v.Minor()))
+		if v.Prerelease() == "" {
+			channels = append(channels, "stable")
+		}
+	}
+
+	for _, channel := range channels {
+		if err := crane.Tag(src, channel, crane.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+			return fmt.Errorf("tagging %s as %s: %w", src, channel, err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,123 @@
+// Package imgmutate rebases, appends layers to, and pushes container images
+// entirely in-process via go-containerregistry, so the patch step no longer
+// needs to shell out to crane/docker for its mutate-and-push leg. Copa and
+// Trivy still drive the actual vulnerability scan/patch; this package only
+// replaces the "retag and push the result" plumbing around them.
+package imgmutate
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// fetch resolves ref and pulls its image manifest/config, the shared first
+// step of Rebase and any other operation that needs the current image.
+func fetch(ref string) (v1.Image, error) {
+	r, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ref, err)
+	}
+	img, err := remote.Image(r, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", ref, err)
+	}
+	return img, nil
+}
+
+// Rebase pulls ref and swaps its base image from oldBase to newBase,
+// keeping ref's non-base layers (the application layers Copa/Trivy added)
+// unchanged. oldBase and newBase are image references, not already-pulled
+// images, since the caller typically only knows them by tag (e.g. the
+// distro base a patched image was built from, and its newly released
+// successor).
+func Rebase(ref, oldBase, newBase string) (v1.Image, error) {
+	orig, err := fetch(ref)
+	if err != nil {
+		return nil, err
+	}
+	oldBaseImg, err := fetch(oldBase)
+	if err != nil {
+		return nil, err
+	}
+	newBaseImg, err := fetch(newBase)
+	if err != nil {
+		return nil, err
+	}
+
+	rebased, err := mutate.Rebase(orig, oldBaseImg, newBaseImg)
+	if err != nil {
+		return nil, fmt.Errorf("rebasing %s onto %s: %w", ref, newBase, err)
+	}
+	return rebased, nil
+}
+
+// AppendLayers returns img with layers appended on top, in order. Used to
+// add a patch layer (e.g. Copa's upgraded-package layer) without rebuilding
+// the image from scratch.
+func AppendLayers(img v1.Image, layers ...v1.Layer) (v1.Image, error) {
+	appended, err := mutate.AppendLayers(img, layers...)
+	if err != nil {
+		return nil, fmt.Errorf("appending %d layer(s): %w", len(layers), err)
+	}
+	return appended, nil
+}
+
+// Push writes img to destRef. go-containerregistry automatically mounts
+// blobs img already has in a registry it's also pushing to (cross-registry
+// mounting), so rebasing onto a base hosted on the same registry as destRef
+// avoids re-uploading shared layers.
+func Push(img v1.Image, destRef string, auth authn.Authenticator) error {
+	dst, err := name.ParseReference(destRef, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", destRef, err)
+	}
+	if auth == nil {
+		auth = authn.Anonymous
+	}
+	if err := remote.Write(dst, img, remote.WithAuth(auth)); err != nil {
+		return fmt.Errorf("pushing %s: %w", destRef, err)
+	}
+	return nil
+}
+
+// BuildIndex assembles variants (one image per platform, paired by position
+// with platforms) into a single OCI image index — the in-process equivalent
+// of internal.BuildImageIndex, for callers that already hold v1.Image
+// variants instead of already-pushed refs. Pairs beyond the shorter of the
+// two slices are ignored.
+func BuildIndex(variants []v1.Image, platforms []v1.Platform) v1.ImageIndex {
+	idx := empty.Index
+	for i, img := range variants {
+		if i >= len(platforms) {
+			break
+		}
+		p := platforms[i]
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &p},
+		})
+	}
+	return idx
+}
+
+// PushIndex pushes idx to destRef, so destRef alone resolves to the right
+// architecture for any puller.
+func PushIndex(idx v1.ImageIndex, destRef string, auth authn.Authenticator) error {
+	dst, err := name.ParseReference(destRef, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", destRef, err)
+	}
+	if auth == nil {
+		auth = authn.Anonymous
+	}
+	if err := remote.WriteIndex(dst, idx, remote.WithAuth(auth)); err != nil {
+		return fmt.Errorf("pushing index %s: %w", destRef, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrChartHashMismatch indicates a downloaded chart's contents no longer
+// match the SHA-256 recorded in verity.lock for its pinned version, e.g.
+// because a repository overwrote a "mutable" version tag out from under a
+// lockfile that pinned it.
+var ErrChartHashMismatch = errors.New("chart contents do not match locked hash")
+
+// LockedChart records exactly what ParseChartFile + DownloadChart resolved
+// for one dependency, so a later run can reproduce it instead of
+// re-resolving against the repository.
+type LockedChart struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+	SHA256     string `yaml:"sha256"`
+}
+
+// LockedImage records exactly what findImages + ResolveImageTag resolved
+// for one image, so a later run can reproduce it without hitting the
+// registry.
+type LockedImage struct {
+	Repository string `yaml:"repository"`
+	Tag        string `yaml:"tag"`
+	Digest     string `yaml:"digest"`
+}
+
+// Lock is the verity.lock structure written next to Chart.yaml/values.yaml.
+// It mirrors Helm's own Chart.lock: it pins every dependency chart's
+// resolved version and content hash, and every image's resolved tag and
+// digest, so a mirroring run can be reproduced exactly instead of
+// depending on a registry's current state — something the append-only
+// MergeChartImages cannot guarantee on its own.
+type Lock struct {
+	Charts []LockedChart `yaml:"charts"`
+	Images []LockedImage `yaml:"images"`
+}
+
+// LoadLock reads a verity.lock file at path. It returns (nil, nil), not an
+// error, when path does not yet exist: the first `verity lock update` run
+// is expected to create it rather than pin against it.
+func LoadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading lock %s: %w", path, err)
+	}
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parsing lock %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// WriteLock writes a verity.lock file at path, downloading each of deps to
+// compute its content hash and recording images as already resolved by the
+// caller (via findImages + ResolveImageTag).
+func WriteLock(path string, deps []Dependency, images []Image) error {
+	tmpDir, err := os.MkdirTemp("", "verity-lock-*")
+	if err != nil {
+		return fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	lock := &Lock{}
+	for _, dep := range deps {
+		chartPath, _, err := DownloadChart(dep, tmpDir, VerifyNever, "")
+		if err != nil {
+			return fmt.Errorf("downloading %s@%s: %w", dep.Name, dep.Version, err)
+		}
+		sum, err := hashChartDir(chartPath)
+		if err != nil {
+			return fmt.Errorf("hashing %s@%s: %w", dep.Name, dep.Version, err)
+		}
+		lock.Charts = append(lock.Charts, LockedChart{
+			Name:       dep.Name,
+			Version:    dep.Version,
+			Repository: dep.Repository,
+			SHA256:     sum,
+		})
+	}
+
+	for _, img := range images {
+		lock.Images = append(lock.Images, LockedImage{
+			Repository: img.Repository,
+			Tag:        img.Tag,
+			Digest:     img.Digest,
+		})
+	}
+
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshaling lock: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating lock dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing lock %s: %w", path, err)
+	}
+	return nil
+}
+
+// hashChartDir fingerprints an extracted chart directory by feeding every
+// regular file's path (relative to dir) and contents, in sorted order,
+// into a single SHA-256 hash. DownloadChart extracts a chart rather than
+// keeping its raw .tgz bytes around, so this is the hash verity can
+// actually reproduce on a later run.
+func hashChartDir(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		h.Write(content)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// chartFor returns the locked entry for dep, if any. A nil Lock never
+// matches, so callers don't need their own nil check.
+func (l *Lock) chartFor(dep Dependency) (LockedChart, bool) {
+	if l == nil {
+		return LockedChart{}, false
+	}
+	for _, c := range l.Charts {
+		if c.Name == dep.Name && c.Version == dep.Version {
+			return c, true
+		}
+	}
+	return LockedChart{}, false
+}
+
+// imageFor returns the locked tag/digest for repository, if any.
+func (l *Lock) imageFor(repository string) (LockedImage, bool) {
+	if l == nil {
+		return LockedImage{}, false
+	}
+	for _, img := range l.Images {
+		if img.Repository == repository {
+			return img, true
+		}
+	}
+	return LockedImage{}, false
+}
+
+// activeLock is the process-wide Lock installed by the CLI entrypoint from
+// a verity.lock file, if one exists. nil means "no lock loaded," so
+// DownloadChart and ResolveImageTag behave exactly as before this
+// mechanism existed.
+var activeLock *Lock
+
+// SetLock installs lock as the Lock consulted by DownloadChart and
+// ResolveImageTag for the remainder of the process.
+func SetLock(lock *Lock) {
+	activeLock = lock
+}
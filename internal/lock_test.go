@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLockMissingFileIsNotError(t *testing.T) {
+	lock, err := LoadLock(filepath.Join(t.TempDir(), "verity.lock"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if lock != nil {
+		t.Errorf("expected nil lock, got %+v", lock)
+	}
+}
+
+func TestHashChartDirDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: foo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "templates", "deploy.yaml"), []byte("kind: Deployment\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := hashChartDir(dir)
+	if err != nil {
+		t.Fatalf("hashChartDir: %v", err)
+	}
+	second, err := hashChartDir(dir)
+	if err != nil {
+		t.Fatalf("hashChartDir: %v", err)
+	}
+	if first != second {
+		t.Errorf("hashChartDir not deterministic: %q != %q", first, second)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: bar\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := hashChartDir(dir)
+	if err != nil {
+		t.Fatalf("hashChartDir: %v", err)
+	}
+	if changed == first {
+		t.Error("expected hash to change after editing a file")
+	}
+}
+
+func TestLockChartForAndImageFor(t *testing.T) {
+	lock := &Lock{
+		Charts: []LockedChart{{Name: "nginx", Version: "1.2.3", SHA256: "sha256:abc"}},
+		Images: []LockedImage{{Repository: "docker.io/library/nginx", Tag: "1.25.3", Digest: "sha256:def"}},
+	}
+
+	if _, ok := lock.chartFor(Dependency{Name: "nginx", Version: "9.9.9"}); ok {
+		t.Error("chartFor matched on a different version")
+	}
+	got, ok := lock.chartFor(Dependency{Name: "nginx", Version: "1.2.3"})
+	if !ok || got.SHA256 != "sha256:abc" {
+		t.Errorf("chartFor = (%+v, %v), want matching entry", got, ok)
+	}
+
+	if _, ok := lock.imageFor("docker.io/library/redis"); ok {
+		t.Error("imageFor matched an unrelated repository")
+	}
+	img, ok := lock.imageFor("docker.io/library/nginx")
+	if !ok || img.Tag != "1.25.3" || img.Digest != "sha256:def" {
+		t.Errorf("imageFor = (%+v, %v), want the locked entry", img, ok)
+	}
+}
+
+func TestLockNilReceiverNeverMatches(t *testing.T) {
+	var lock *Lock
+	if _, ok := lock.chartFor(Dependency{Name: "nginx"}); ok {
+		t.Error("chartFor on a nil Lock should never match")
+	}
+	if _, ok := lock.imageFor("nginx"); ok {
+		t.Error("imageFor on a nil Lock should never match")
+	}
+}
+
+func TestResolveImageTagUsesLockedEntry(t *testing.T) {
+	oldTagChecker, oldDigestResolver, oldLock := tagChecker, digestResolver, activeLock
+	defer func() {
+		tagChecker, digestResolver, activeLock = oldTagChecker, oldDigestResolver, oldLock
+	}()
+	tagChecker = func(_ context.Context, _ string) bool {
+		t.Fatal("tagChecker should not be called when a locked entry exists")
+		return false
+	}
+	digestResolver = func(_ context.Context, _ string) string {
+		t.Fatal("digestResolver should not be called when a locked entry exists")
+		return ""
+	}
+	activeLock = &Lock{Images: []LockedImage{
+		{Repository: "docker.io/library/nginx", Tag: "1.25.3", Digest: "sha256:def"},
+	}}
+
+	img := Image{Repository: "docker.io/library/nginx", Tag: "latest"}
+	resolved := ResolveImageTag(context.Background(), img)
+	if resolved.Tag != "1.25.3" || resolved.Digest != "sha256:def" {
+		t.Errorf("ResolveImageTag = %+v, want locked tag/digest", resolved)
+	}
+}
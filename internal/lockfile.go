@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrLockedTagMissing indicates the registry no longer serves a tag that
+// was pinned in a lockfile, so a matrix built from it would silently drop
+// an image CI previously patched.
+var ErrLockedTagMissing = errors.New("locked tag no longer present in registry")
+
+// TagLock pins the tag set discovery resolved to for a single image
+// repository, so a later run can reproduce it instead of re-querying the
+// registry.
+type TagLock struct {
+	Image string   `yaml:"image"`
+	Tags  []string `yaml:"tags"`
+}
+
+// TagLockFile is the .verity/tags.lock.yaml structure shared by the
+// --lockfile flag on ScanCommand and DiscoverCommand.
+type TagLockFile struct {
+	Images []TagLock `yaml:"images"`
+}
+
+// LoadTagLock reads a lockfile at path. It returns (nil, nil), not an
+// error, when path is empty or the file does not yet exist: the first run
+// against a given --lockfile path is expected to create it rather than
+// pin against it.
+func LoadTagLock(path string) (*TagLockFile, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading lockfile %s: %w", path, err)
+	}
+	var lock TagLockFile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parsing lockfile %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// SaveTagLock writes lock to path as YAML, creating parent directories as
+// needed.
+func SaveTagLock(path string, lock *TagLockFile) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshaling lockfile: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating lockfile dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing lockfile %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the locked tag set for image, or nil if image is not
+// present in the lockfile.
+func (l *TagLockFile) Find(image string) []string {
+	if l == nil {
+		return nil
+	}
+	for _, entry := range l.Images {
+		if entry.Image == image {
+			return entry.Tags
+		}
+	}
+	return nil
+}
+
+// Set records (or replaces) the tag set for image.
+func (l *TagLockFile) Set(image string, tags []string) {
+	for i, entry := range l.Images {
+		if entry.Image == image {
+			l.Images[i].Tags = tags
+			return
+		}
+	}
+	l.Images = append(l.Images, TagLock{Image: image, Tags: tags})
+}
+
+// VerifyLockedTags checks that every tag in locked also appears in live,
+// returning ErrLockedTagMissing (wrapped with the offending image and tag)
+// on the first tag the registry no longer serves.
+func VerifyLockedTags(image string, locked, live []string) error {
+	liveSet := make(map[string]struct{}, len(live))
+	for _, t := range live {
+		liveSet[t] = struct{}{}
+	}
+	for _, t := range locked {
+		if _, ok := liveSet[t]; !ok {
+			return fmt.Errorf("%w: %s:%s", ErrLockedTagMissing, image, t)
+		}
+	}
+	return nil
+}
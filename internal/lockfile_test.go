@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTagLockMissingFileIsNotError(t *testing.T) {
+	lock, err := LoadTagLock(filepath.Join(t.TempDir(), "tags.lock.yaml"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if lock != nil {
+		t.Errorf("expected nil lock, got %+v", lock)
+	}
+}
+
+func TestLoadTagLockEmptyPathIsNotError(t *testing.T) {
+	lock, err := LoadTagLock("")
+	if err != nil || lock != nil {
+		t.Errorf("LoadTagLock(\"\") = (%+v, %v), want (nil, nil)", lock, err)
+	}
+}
+
+func TestSaveAndLoadTagLockRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "tags.lock.yaml")
+
+	lock := &TagLockFile{}
+	lock.Set("quay.io/prometheus/prometheus", []string{"v3.9.1", "v3.9.0"})
+
+	if err := SaveTagLock(path, lock); err != nil {
+		t.Fatalf("SaveTagLock returned error: %v", err)
+	}
+
+	loaded, err := LoadTagLock(path)
+	if err != nil {
+		t.Fatalf("LoadTagLock returned error: %v", err)
+	}
+	if got := loaded.Find("quay.io/prometheus/prometheus"); len(got) != 2 {
+		t.Errorf("Find returned %v, want 2 tags", got)
+	}
+}
+
+func TestTagLockFileSetReplacesExisting(t *testing.T) {
+	lock := &TagLockFile{}
+	lock.Set("app", []string{"v1"})
+	lock.Set("app", []string{"v1", "v2"})
+
+	if len(lock.Images) != 1 {
+		t.Fatalf("expected 1 entry after replace, got %d", len(lock.Images))
+	}
+	if got := lock.Find("app"); len(got) != 2 {
+		t.Errorf("Find(\"app\") = %v, want [v1 v2]", got)
+	}
+}
+
+func TestVerifyLockedTags(t *testing.T) {
+	if err := VerifyLockedTags("app", []string{"v1", "v2"}, []string{"v1", "v2", "v3"}); err != nil {
+		t.Errorf("expected no error when all locked tags are live, got %v", err)
+	}
+
+	err := VerifyLockedTags("app", []string{"v1", "v2"}, []string{"v1"})
+	if !errors.Is(err, ErrLockedTagMissing) {
+		t.Errorf("expected ErrLockedTagMissing, got %v", err)
+	}
+}
@@ -7,9 +7,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/verity-org/verity/internal/attest"
+	"github.com/verity-org/verity/internal/scanner"
 )
 
+// severityRank orders Trivy severities from least to most severe, so "at or
+// above minSeverity" can be compared numerically. Unrecognized severities
+// rank below everything, the safe default for a typo. Mirrors
+// internal/discovery's own copy (kept separate since that package compares
+// live scanner responses, not cached report files).
+var severityRank = map[string]int{
+	"UNKNOWN":  0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
 // DiscoveryManifest holds all discovered images.
 // Charts groups images by chart dependency (used by the assemble step).
 // Images is the unified flat list from values.yaml (used for matrix generation).
@@ -25,25 +44,74 @@ type ChartDiscovery struct {
 	Version    string           `json:"version"`
 	Repository string           `json:"repository"`
 	Images     []ImageDiscovery `json:"images"`
+
+	// Starter names a starter chart tree (see CopyStarter) to scaffold this
+	// chart's wrapper from, in addition to the built-in Chart.yaml/
+	// values.yaml layout — e.g. one that adds a NetworkPolicy or
+	// PodSecurityPolicy replacement template. Empty uses the built-in
+	// scaffold only, exactly as before starters existed.
+	Starter string `json:"starter,omitempty"`
 }
 
 // ImageDiscovery is a single discovered image with its values path.
+//
+// Its fields must stay in the same order and types as Image's so that the
+// two remain convertible with a plain Image(d)/ImageDiscovery(img) cast
+// (see ApplyOverridesToManifest and the scan/render helpers).
 type ImageDiscovery struct {
 	Registry   string `json:"registry"`
 	Repository string `json:"repository"`
 	Tag        string `json:"tag"`
+	Digest     string `json:"digest,omitempty"`
 	Path       string `json:"path"`
+
+	// Platforms lists the architectures found in ref's manifest list at
+	// discovery time (e.g. "linux/amd64", "linux/arm64"), populated by
+	// resolving the image with go-containerregistry's remote.Index. Nil
+	// for a single-arch image, or when the manifest list couldn't be
+	// resolved. Unlike Image.Platforms (populated by PatchImage for its
+	// own combined, single-matrix-job multi-arch flow), this drives
+	// GenerateMatrix emitting one MatrixEntry per platform instead of one
+	// entry covering every platform — see MatrixEntry.Platform.
+	Platforms []string `json:"platforms,omitempty"`
 }
 
 func (d ImageDiscovery) reference() string {
-	img := Image{Registry: d.Registry, Repository: d.Repository, Tag: d.Tag}
+	img := Image{Registry: d.Registry, Repository: d.Repository, Tag: d.Tag, Digest: d.Digest}
 	return img.Reference()
 }
 
+// PlatformKey joins ref and platform into the key buildPatchResults,
+// LoadResults and GenerateMatrix use to keep per-platform matrix jobs of
+// the same image distinct, in the "imageRef|platform" form a sanitized
+// filename or map key can still be told apart by. Returns ref unchanged
+// when platform is empty, so single-platform images round-trip to exactly
+// the plain refs used everywhere before per-platform fanout existed.
+// Exported so the attest-all command can look up the SinglePatchResult for
+// a matrix.json entry the same way GenerateMatrix and PatchSingleImage do.
+func PlatformKey(ref, platform string) string {
+	if platform == "" {
+		return ref
+	}
+	return ref + "|" + platform
+}
+
 // MatrixEntry represents one job in a GitHub Actions matrix.
 type MatrixEntry struct {
 	ImageRef  string `json:"image_ref"`
-	ImageName string `json:"image_name"` // sanitized ref, used for artifact naming
+	ImageName string `json:"image_name"` // sanitized imageRef|platform, used for artifact naming
+
+	// Platform is the single platform (e.g. "linux/amd64") this matrix
+	// entry scans, set when the image's discovery found more than one
+	// platform in its manifest list. Empty for a single-platform image,
+	// exactly as before per-platform fanout existed.
+	Platform string `json:"platform,omitempty"`
+
+	// Mirrors lists additional refs (resolved from -registry-config) the
+	// patch job should try, in order, if ImageRef's pull fails with a
+	// transient error. Empty when no -registry-config was given, or when
+	// ImageRef's entry has no mirrors configured beyond itself.
+	Mirrors []string `json:"mirrors,omitempty"`
 }
 
 // MatrixOutput is the GitHub Actions matrix JSON.
@@ -54,6 +122,7 @@ type MatrixOutput struct {
 // SinglePatchResult is the JSON written by each matrix job after patching.
 type SinglePatchResult struct {
 	ImageRef          string `json:"image_ref"`
+	Platform          string `json:"platform,omitempty"`
 	PatchedRegistry   string `json:"patched_registry,omitempty"`
 	PatchedRepository string `json:"patched_repository,omitempty"`
 	PatchedTag        string `json:"patched_tag,omitempty"`
@@ -62,88 +131,103 @@ type SinglePatchResult struct {
 	SkipReason        string `json:"skip_reason,omitempty"`
 	Error             string `json:"error,omitempty"`
 	Changed           bool   `json:"changed"`
+
+	// PatchedReportPath carries PatchResult.PatchedReportPath across the
+	// matrix job boundary: the Trivy report from re-scanning the image
+	// actually pushed as PatchedTag, as opposed to the upstream scan the
+	// image_ref|platform-keyed report file already covers. Empty when no
+	// post-patch rescan happened (Skipped, Error, or a multi-arch image —
+	// see PatchResult.PatchedReportPath).
+	PatchedReportPath string `json:"patched_report_path,omitempty"`
+
+	// Platforms lists each platform's own patched digest/tag when ImageRef
+	// resolved to a Docker Schema2List/OCI index and PatchImage fanned out
+	// a Copa invocation per platform (see PatchResult.Variants) —
+	// PatchedTag above still names the combined manifest list
+	// pushManifestList assembled from them. Empty for a single-arch image.
+	Platforms []PlatformResult `json:"platforms,omitempty"`
+}
+
+// PlatformResult is one platform's own patched image within a multi-arch
+// SinglePatchResult, carrying enough for buildPatchResults to reconstruct
+// PatchResult.Variants from the matrix job's JSON without re-deriving it
+// from the registry.
+type PlatformResult struct {
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Variant string `json:"variant,omitempty"`
+	Digest  string `json:"digest"`
+	Tag     string `json:"tag"`
+
+	// VulnCount is this platform's own fixable vuln count (see
+	// PatchResult.PlatformVulnCounts), mirroring the top-level VulnCount
+	// field but scoped to one architecture instead of the image as a
+	// whole.
+	VulnCount int `json:"vuln_count"`
+
+	// Skipped reports whether this platform had no fixable vulns and was
+	// only mirrored rather than Copa-patched (see patchOnePlatform).
+	// Always false for a platform recorded here via PlatformErrors
+	// instead — it didn't skip, it failed.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Error is this platform's own failure (see PatchResult.PlatformErrors),
+	// set when patching continued with the rest of the platforms rather
+	// than failing the whole image. Empty for a platform that made it into
+	// the combined manifest list, whether patched or skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// DiscoveryReport records one failure encountered while building a
+// DiscoveryManifest — either a Chart.yaml dependency's download/scan
+// failure, or an image blocked by RewriteManifestRegistries. DiscoverImages
+// and RewriteManifestRegistries collect these instead of aborting on the
+// first one, so one broken dependency or blocked image doesn't block
+// discovery of everything else in the same run.
+type DiscoveryReport struct {
+	Chart string // chart name, or the image ref for a "registry" stage failure
+	Stage string // "download", "scan", or "registry"
+	Err   error
 }
 
 // DiscoverImages scans Chart.yaml dependencies and the images file,
-// returning a manifest of all images and a deduplicated matrix for
-// GitHub Actions.
+// returning a manifest of all images, a deduplicated matrix for GitHub
+// Actions, and any per-dependency failures encountered along the way.
+// Dependencies are downloaded and scanned concurrently across up to jobs
+// workers (jobs <= 0 behaves as 1); a failure on one dependency is recorded
+// in the returned []DiscoveryReport rather than failing the whole run, so
+// the caller can print a summary and decide whether to exit non-zero.
 //
 // Chart-discovered images are merged into the images file (values.yaml)
 // so that it becomes the single source of truth for all images. The
 // manifest retains chart→images grouping for the assemble step, while
 // manifest.Images holds the unified flat list from the images file.
-func DiscoverImages(chartFile, imagesFile, tmpDir string) (*DiscoveryManifest, error) {
+func DiscoverImages(chartFile, imagesFile, tmpDir string, jobs int) (*DiscoveryManifest, []DiscoveryReport, error) {
 	manifest := &DiscoveryManifest{}
 
-	chart, err := ParseChartFile(chartFile)
+	source := &ChartFileSource{ChartFile: chartFile, TmpDir: tmpDir, Jobs: jobs}
+	chartImageDiscoveries, err := source.Discover(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("parsing %s: %w", chartFile, err)
+		return nil, nil, err
 	}
+	manifest.Charts = source.Charts
 
-	var chartImages []Image
-
-	// Handle standalone chart (local directory, not a Helm dependency)
-	standalonePath := filepath.Join(filepath.Dir(chartFile), "charts", "standalone")
-	if _, err := os.Stat(standalonePath); err == nil {
-		fmt.Println("Discovering standalone@0.0.0")
-		images, err := ScanForImages(standalonePath)
-		if err != nil {
-			return nil, fmt.Errorf("scanning standalone: %w", err)
-		}
-
-		if len(images) > 0 {
-			cd := ChartDiscovery{
-				Name:       "standalone",
-				Version:    "0.0.0",
-				Repository: "file://./charts/standalone",
-			}
-			for _, img := range images {
-				cd.Images = append(cd.Images, ImageDiscovery(img))
-			}
-			fmt.Printf("  Found %d images\n", len(images))
-			manifest.Charts = append(manifest.Charts, cd)
-			chartImages = append(chartImages, images...)
-		}
-	}
-
-	for _, dep := range chart.Dependencies {
-		fmt.Printf("Discovering %s@%s\n", dep.Name, dep.Version)
-
-		chartPath, err := DownloadChart(dep, tmpDir)
-		if err != nil {
-			return nil, fmt.Errorf("downloading %s: %w", dep.Name, err)
-		}
-
-		images, err := ScanForImages(chartPath)
-		if err != nil {
-			return nil, fmt.Errorf("scanning %s: %w", dep.Name, err)
-		}
-
-		cd := ChartDiscovery{
-			Name:       dep.Name,
-			Version:    dep.Version,
-			Repository: dep.Repository,
-		}
-		for _, img := range images {
-			cd.Images = append(cd.Images, ImageDiscovery(img))
-		}
-		fmt.Printf("  Found %d images\n", len(images))
-		manifest.Charts = append(manifest.Charts, cd)
-
-		chartImages = append(chartImages, images...)
+	chartImages := make([]Image, len(chartImageDiscoveries))
+	for i, d := range chartImageDiscoveries {
+		chartImages[i] = Image(d)
 	}
 
 	// Merge chart-discovered images into the images file so it contains
 	// all images (chart-discovered + manually maintained standalone).
 	if imagesFile != "" {
 		if err := MergeChartImages(imagesFile, chartImages); err != nil {
-			return nil, fmt.Errorf("merging chart images into %s: %w", imagesFile, err)
+			return nil, nil, fmt.Errorf("merging chart images into %s: %w", imagesFile, err)
 		}
 
 		// Read the unified image list back from the updated file.
-		allImages, err := ParseImagesFile(imagesFile)
+		allImages, err := ParseImagesFile(context.Background(), imagesFile)
 		if err != nil {
-			return nil, fmt.Errorf("parsing %s: %w", imagesFile, err)
+			return nil, nil, fmt.Errorf("parsing %s: %w", imagesFile, err)
 		}
 		for _, img := range allImages {
 			manifest.Images = append(manifest.Images, ImageDiscovery(img))
@@ -157,12 +241,149 @@ func DiscoverImages(chartFile, imagesFile, tmpDir string) (*DiscoveryManifest, e
 		fmt.Printf("Total images: %d\n", len(chartImages))
 	}
 
-	return manifest, nil
+	sortDiscoveryManifest(manifest)
+
+	return manifest, source.Reports, nil
+}
+
+// discoverDependenciesConcurrently downloads and scans each of deps across up
+// to jobs workers (jobs <= 0 behaves as 1 worker), returning results in deps'
+// original order regardless of completion order so the caller doesn't need a
+// separate stabilization pass. Each worker downloads into its own subdirectory
+// of tmpDir (named after the dependency) so concurrent DownloadChart calls
+// never write into the same path.
+func discoverDependenciesConcurrently(deps []Dependency, tmpDir string, jobs int) ([]ChartDiscovery, []Image, []DiscoveryReport) {
+	if jobs <= 0 {
+		jobs = 1
+	}
+
+	type depResult struct {
+		cd     ChartDiscovery
+		images []Image
+		report *DiscoveryReport
+	}
+
+	progress := startProgressPrinter()
+	defer progress.stop()
+
+	results := make([]depResult, len(deps))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, jobs)
+
+	for i, dep := range deps {
+		wg.Add(1)
+		go func(i int, dep Dependency) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			progress.printf("Discovering %s@%s\n", dep.Name, dep.Version)
+
+			workerDir := filepath.Join(tmpDir, sanitize(dep.Name))
+			if err := os.MkdirAll(workerDir, 0o755); err != nil {
+				results[i].report = &DiscoveryReport{Chart: dep.Name, Stage: "download", Err: fmt.Errorf("creating temp dir: %w", err)}
+				return
+			}
+
+			chartPath, _, err := DownloadChart(dep, workerDir, VerifyNever, "")
+			if err != nil {
+				results[i].report = &DiscoveryReport{Chart: dep.Name, Stage: "download", Err: err}
+				return
+			}
+
+			images, err := ScanForImages(context.Background(), chartPath)
+			if err != nil {
+				results[i].report = &DiscoveryReport{Chart: dep.Name, Stage: "scan", Err: err}
+				return
+			}
+
+			cd := ChartDiscovery{Name: dep.Name, Version: dep.Version, Repository: dep.Repository}
+			for _, img := range images {
+				cd.Images = append(cd.Images, ImageDiscovery(img))
+			}
+			progress.printf("  Found %d images\n", len(images))
+
+			results[i] = depResult{cd: cd, images: images}
+		}(i, dep)
+	}
+
+	wg.Wait()
+
+	var charts []ChartDiscovery
+	var images []Image
+	var reports []DiscoveryReport
+	for _, r := range results {
+		if r.report != nil {
+			reports = append(reports, *r.report)
+			continue
+		}
+		charts = append(charts, r.cd)
+		images = append(images, r.images...)
+	}
+	return charts, images, reports
+}
+
+// progressPrinter serializes concurrent workers' progress lines through a
+// single goroutine so "Discovering %s@%s"/"  Found %d images" pairs from
+// different dependencies' goroutines never interleave mid-line the way
+// concurrent fmt.Printf calls to the same os.Stdout could.
+type progressPrinter struct {
+	lines chan string
+	done  chan struct{}
+}
+
+func startProgressPrinter() *progressPrinter {
+	p := &progressPrinter{lines: make(chan string), done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		for line := range p.lines {
+			fmt.Print(line)
+		}
+	}()
+	return p
+}
+
+func (p *progressPrinter) printf(format string, args ...any) {
+	p.lines <- fmt.Sprintf(format, args...)
+}
+
+// stop closes the line channel and waits for the printer goroutine to drain
+// it, so every line a caller's workers sent is flushed before stop returns.
+func (p *progressPrinter) stop() {
+	close(p.lines)
+	<-p.done
+}
+
+// sortDiscoveryManifest sorts manifest's Charts and each chart's/the flat
+// Images slice by a stable key, so WriteDiscoveryOutput produces the same
+// manifest.json/matrix.json bytes run to run regardless of the order
+// concurrent discovery happened to finish in.
+func sortDiscoveryManifest(manifest *DiscoveryManifest) {
+	sort.Slice(manifest.Charts, func(i, j int) bool {
+		return manifest.Charts[i].Name < manifest.Charts[j].Name
+	})
+	for i := range manifest.Charts {
+		sortImageDiscoveries(manifest.Charts[i].Images)
+	}
+	sortImageDiscoveries(manifest.Images)
+}
+
+func sortImageDiscoveries(images []ImageDiscovery) {
+	sort.Slice(images, func(i, j int) bool {
+		a, b := images[i], images[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return a.Tag < b.Tag
+	})
 }
 
 // ApplyOverridesToManifest applies image tag overrides to both the flat Images
 // list and all Charts[*].Images so that refs match after patching.
-func ApplyOverridesToManifest(manifest *DiscoveryManifest, overrides []ImageOverride) {
+func ApplyOverridesToManifest(ctx context.Context, manifest *DiscoveryManifest, overrides []ImageOverride) {
 	if len(overrides) == 0 {
 		return
 	}
@@ -172,7 +393,7 @@ func ApplyOverridesToManifest(manifest *DiscoveryManifest, overrides []ImageOver
 	for i, img := range manifest.Images {
 		images[i] = Image(img)
 	}
-	images = ApplyOverrides(images, overrides)
+	images = ApplyOverrides(ctx, images, overrides)
 	for i, img := range images {
 		manifest.Images[i] = ImageDiscovery(img)
 	}
@@ -183,7 +404,7 @@ func ApplyOverridesToManifest(manifest *DiscoveryManifest, overrides []ImageOver
 		for j, img := range manifest.Charts[i].Images {
 			chartImages[j] = Image(img)
 		}
-		chartImages = ApplyOverrides(chartImages, overrides)
+		chartImages = ApplyOverrides(ctx, chartImages, overrides)
 		for j, img := range chartImages {
 			manifest.Charts[i].Images[j] = ImageDiscovery(img)
 		}
@@ -191,26 +412,81 @@ func ApplyOverridesToManifest(manifest *DiscoveryManifest, overrides []ImageOver
 }
 
 // GenerateMatrix creates a deduplicated GitHub Actions matrix from a manifest.
-// Uses the unified Images list so every image is patched exactly once.
-func GenerateMatrix(manifest *DiscoveryManifest) *MatrixOutput {
+// Uses the unified Images list so every image is patched exactly once per
+// platform it was discovered with (see ImageDiscovery.Platforms) — a
+// single-platform image still produces exactly one MatrixEntry, unchanged
+// from before per-platform fanout existed.
+//
+// When minSeverity is non-empty, an (image, platform) pair is dropped from
+// the matrix if reportsDir holds a cached Trivy report for it (from a
+// previous run) with no fixable finding at or above minSeverity — there's
+// nothing left for this run to patch. A pair with no cached report is
+// always included, since there's no evidence yet that it's clean. Pass an
+// empty reportsDir or minSeverity to skip this filtering entirely (the
+// previous, unconditional behavior).
+//
+// mirrors carries each image's resolved fallback refs (from
+// RewriteManifestRegistries), keyed by the same ref manifest.Images already
+// holds after rewriting, onto the matching MatrixEntry.Mirrors. A nil
+// mirrors leaves every entry's Mirrors empty, exactly as before
+// -registry-config existed.
+func GenerateMatrix(manifest *DiscoveryManifest, reportsDir, minSeverity string, mirrors map[string][]string) *MatrixOutput {
 	seen := make(map[string]bool)
 	matrix := &MatrixOutput{}
 
 	for _, img := range manifest.Images {
 		ref := img.reference()
-		if seen[ref] {
-			continue
+
+		platforms := img.Platforms
+		if len(platforms) == 0 {
+			platforms = []string{""}
+		}
+
+		for _, platform := range platforms {
+			key := PlatformKey(ref, platform)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if minSeverity != "" && reportsDir != "" && !hasFixableAtOrAbove(reportsDir, key, minSeverity) {
+				continue
+			}
+
+			matrix.Include = append(matrix.Include, MatrixEntry{
+				ImageRef:  ref,
+				ImageName: sanitize(key),
+				Platform:  platform,
+				Mirrors:   mirrors[ref],
+			})
 		}
-		seen[ref] = true
-		matrix.Include = append(matrix.Include, MatrixEntry{
-			ImageRef:  ref,
-			ImageName: sanitize(ref),
-		})
 	}
 
 	return matrix
 }
 
+// hasFixableAtOrAbove reports whether ref's cached Trivy report in
+// reportsDir (from a previous discover/patch run) contains a fixable
+// vulnerability at or above minSeverity. A missing or unreadable report
+// counts as "yes" (keep the image in the matrix), since there's no cached
+// evidence to justify dropping it.
+func hasFixableAtOrAbove(reportsDir, ref, minSeverity string) bool {
+	vulns, err := scanner.Normalize(filepath.Join(reportsDir, sanitize(ref)+".json"))
+	if err != nil {
+		return true
+	}
+	minRank := severityRank[strings.ToUpper(minSeverity)]
+	for _, v := range vulns {
+		if v.FixedVersion == "" {
+			continue
+		}
+		if severityRank[strings.ToUpper(v.Severity)] >= minRank {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteDiscoveryOutput writes the manifest and matrix JSON files.
 // The matrix JSON is compact (single line) so it can be set as a
 // GitHub Actions output directly.
@@ -241,7 +517,13 @@ func WriteDiscoveryOutput(manifest *DiscoveryManifest, matrix *MatrixOutput, out
 
 // PatchSingleImage patches one image and writes the result JSON and
 // Trivy report to the given directories. Designed to run in a matrix job.
-func PatchSingleImage(ctx context.Context, imageRef string, opts PatchOptions, resultDir string) error {
+// platform is the single platform this job was fanned out for (see
+// MatrixEntry.Platform) — empty for a single-platform image, in which case
+// behavior is unchanged from before per-platform fanout existed. mirrors is
+// MatrixEntry.Mirrors, tried in order on a transient failure of imageRef
+// (see patchWithMirrorFallback) — nil for a job with no -registry-config
+// mirrors, in which case behavior is unchanged from before mirrors existed.
+func PatchSingleImage(ctx context.Context, imageRef, platform string, opts PatchOptions, resultDir string, mirrors []string) error {
 	img := parseRef(imageRef)
 	originalTag := img.Tag
 
@@ -258,13 +540,15 @@ func PatchSingleImage(ctx context.Context, imageRef string, opts PatchOptions, r
 		return fmt.Errorf("creating report dir: %w", err)
 	}
 
-	result := PatchImage(ctx, img, opts)
+	result := patchWithMirrorFallback(ctx, img, opts, mirrors)
 
 	entry := SinglePatchResult{
-		ImageRef:   imageRef,
-		VulnCount:  result.VulnCount,
-		Skipped:    result.Skipped,
-		SkipReason: result.SkipReason,
+		ImageRef:          imageRef,
+		Platform:          platform,
+		VulnCount:         result.VulnCount,
+		Skipped:           result.Skipped,
+		SkipReason:        result.SkipReason,
+		PatchedReportPath: result.PatchedReportPath,
 	}
 	if result.Error != nil {
 		entry.Error = result.Error.Error()
@@ -274,6 +558,32 @@ func PatchSingleImage(ctx context.Context, imageRef string, opts PatchOptions, r
 		entry.PatchedRepository = result.Patched.Repository
 		entry.PatchedTag = result.Patched.Tag
 	}
+	for _, v := range result.Variants {
+		vulnCount := result.PlatformVulnCounts[v.Platform.String()]
+		entry.Platforms = append(entry.Platforms, PlatformResult{
+			OS:        v.Platform.OS,
+			Arch:      v.Platform.Arch,
+			Variant:   v.Platform.Variant,
+			Digest:    v.Digest,
+			Tag:       parseRef(v.Reference).Tag,
+			VulnCount: vulnCount,
+			Skipped:   vulnCount == 0,
+		})
+	}
+	// A platform that failed during patchMultiArch isn't in Variants, but
+	// still belongs in the persisted result so a later audit (or a retry
+	// tool) can see which platform failed and why instead of just the
+	// surviving manifest list's platform set.
+	for _, p := range result.Platforms {
+		if errMsg, failed := result.PlatformErrors[p.String()]; failed {
+			entry.Platforms = append(entry.Platforms, PlatformResult{
+				OS:      p.OS,
+				Arch:    p.Arch,
+				Variant: p.Variant,
+				Error:   errMsg,
+			})
+		}
+	}
 	// For skipped images that have a genuinely different patched ref
 	// (e.g. already patched in registry), record it. Don't record when
 	// the patched ref equals the original upstream ref.
@@ -293,7 +603,7 @@ func PatchSingleImage(ctx context.Context, imageRef string, opts PatchOptions, r
 		return fmt.Errorf("marshaling result: %w", err)
 	}
 
-	resultPath := filepath.Join(resultDir, sanitize(imageRef)+".json")
+	resultPath := filepath.Join(resultDir, sanitize(PlatformKey(imageRef, platform))+".json")
 	if err := os.WriteFile(resultPath, data, 0o644); err != nil {
 		return fmt.Errorf("writing result: %w", err)
 	}
@@ -308,15 +618,50 @@ func PatchSingleImage(ctx context.Context, imageRef string, opts PatchOptions, r
 	return nil
 }
 
+// patchWithMirrorFallback patches img, retrying against each of mirrors in
+// order while the failure looks transient (see isRetryableRegistryError) —
+// a real vulnerability or patch failure on img isn't fixed by pulling the
+// same bits from somewhere else, so it's returned as-is instead of masking
+// it with a mirror attempt. Stops at the first mirror that doesn't fail
+// transiently, successful or not.
+func patchWithMirrorFallback(ctx context.Context, img Image, opts PatchOptions, mirrors []string) *PatchResult {
+	result := PatchImage(ctx, img, opts)
+	for _, mirror := range mirrors {
+		if result.Error == nil || !isRetryableRegistryError(result.Error) {
+			break
+		}
+		fmt.Printf("    %s failed transiently, retrying via mirror %s: %v\n", img.Reference(), mirror, result.Error)
+		result = PatchImage(ctx, ResolveImageTag(ctx, parseRef(mirror)), opts)
+	}
+	return result
+}
+
 // PublishedChart represents a chart that was published to OCI.
 type PublishedChart struct {
-	Name              string           `json:"name"`
-	Version           string           `json:"version"`
-	Registry          string           `json:"registry"`
-	OCIRef            string           `json:"oci_ref"`
-	SBOMPath          string           `json:"sbom_path"`
-	VulnPredicatePath string           `json:"vuln_predicate_path"`
-	Images            []PublishedImage `json:"images"`
+	Name                   string           `json:"name"`
+	Version                string           `json:"version"`
+	Registry               string           `json:"registry"`
+	OCIRef                 string           `json:"oci_ref"`
+	SBOMPath               string           `json:"sbom_path"`
+	VEXPath                string           `json:"vex_path"`
+	VulnPredicatePath      string           `json:"vuln_predicate_path"`
+	VulnDeltaPredicatePath string           `json:"vuln_delta_predicate_path,omitempty"`
+	VulnSummaryPath        string           `json:"vuln_summary_path"`
+	Images                 []PublishedImage `json:"images"`
+	// ReportReferrerDigests are the digest references of this chart's
+	// Trivy reports pushed as OCI 1.1 referrer artifacts (see
+	// pushChartReportsAsReferrers), so downstream tools can locate them by
+	// chart digest without re-querying the registry's Referrers API. Empty
+	// when reportsMode is ReportsModeEmbed.
+	ReportReferrerDigests []string `json:"report_referrer_digests,omitempty"`
+	// Digest is the OCI manifest digest OCIRef was pushed under (see
+	// PublishResult.Digest), alongside which CosignSignatureRef (if any)
+	// can be looked up.
+	Digest string `json:"digest,omitempty"`
+	// CosignSignatureRef is the digest reference of the cosign signature
+	// artifact pushed against Digest (see PublishSignOptions.Cosign), set
+	// only when sign requests manifest-level cosign signing.
+	CosignSignatureRef string `json:"cosign_signature_ref,omitempty"`
 }
 
 // PublishedImage represents an image included in a published chart.
@@ -329,7 +674,64 @@ type PublishedImage struct {
 // jobs, then creates wrapper charts. When publish is true and registry is set,
 // publishes charts to OCI and generates SBOMs and vulnerability attestations.
 // Only publishes charts where at least one underlying image changed.
-func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry string, publish bool) error { //nolint:gocognit,gocyclo,cyclop,funlen // complex workflow
+//
+// Wrapper chart versions are content-addressable by default (see
+// CreateWrapperChart), so a chart whose version already exists in registry
+// is skipped rather than republished. Set legacyNumericVersions to use the
+// older auto-incrementing "{version}-{N}" scheme instead.
+//
+// When sign is true and publish succeeds, the pushed chart manifest itself
+// is cosign-signed (see PublishChart's PublishSignOptions.Cosign, the same
+// path SignImage uses for a patched image's digest), recording the
+// signature's digest reference on PublishedChart.CosignSignatureRef.
+// Separately, each chart's packaged archive is also cosign-signed and its
+// provenance.json (see BuildWrapperProvenance) attached as an in-toto
+// attestation, both written as sidecar files next to the .tgz (see
+// internal/attest.SignChartArchive/AttestChartProvenance) — archive-level
+// signing for consumers that fetch the .tgz directly, manifest-level
+// signing for `cosign verify` against the published OCI ref. signKey
+// selects keyed signing for both; empty uses cosign's keyless (Fulcio/OIDC)
+// flow, driven non-interactively by identityToken when set.
+//
+// When dryRun is true, AssembleResults renders each wrapper chart with the
+// Helm Go SDK instead of packaging and pushing it (see
+// renderWrapperChartDryRun), writing the result to
+// "<outputDir>/<chart>/rendered.yaml", and records what a --publish run
+// would do — version, target OCI ref, per-image digests — in a top-level
+// "<outputDir>/assemble-plan.json" (see AssemblePlanChart) instead of
+// publishing anything. dryRun takes priority over publish: pass --dry-run
+// on its own to review the plan in CI, then re-run with --publish once
+// satisfied.
+//
+// startersDir is the starters root (see DefaultStartersDir) consulted when
+// a chart's manifest entry sets ChartDiscovery.Starter; charts that don't
+// set it are unaffected.
+//
+// reportsMode selects how each chart's Trivy reports are attached (see
+// ReportsMode): ReportsModeReferrer (the default) pushes them as OCI 1.1
+// referrer artifacts when publish succeeds, keeping the chart manifest
+// itself strictly OCI-compliant; ReportsModeEmbed bundles them into the
+// chart package's reports/ directory instead (see embedChartReports), done
+// locally regardless of publish; ReportsModeBoth does both.
+//
+// onConflict selects what happens when a chart's content-addressable
+// version (see CreateWrapperChart/contentDigest) already exists in
+// registry: OnConflictBump (the default) skips the redundant publish and
+// logs "unchanged" along with the existing remote digest (see
+// remoteChartDigest); OnConflictFail returns an error instead, for a CI
+// pipeline that wants a no-op rerun surfaced rather than silently
+// swallowed; OnConflictOverwrite republishes anyway, re-pushing the chart
+// under its existing tag. Ignored in legacyNumericVersions mode, which
+// never sets alreadyPublished.
+//
+// summaryPath, when non-empty, writes an AssembleSummaryChart entry for
+// every wrapper chart produced in this run — whether a --publish push
+// happened, it was skipped as already-published, or it was only rendered
+// locally — to summaryPath (see writeAssembleSummary), giving downstream
+// GitOps/attestation tooling a stable artifact to consume instead of
+// re-parsing stdout or reaching into published-charts.json, which only
+// covers charts a real --publish run actually pushed.
+func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, startersDir string, publish, dryRun, legacyNumericVersions, sign bool, signKey, destLayout string, reportsMode ReportsMode, identityToken, summaryPath string, onConflict OnConflict) error { //nolint:gocognit,gocyclo,cyclop,funlen // complex workflow
 	manifestData, err := os.ReadFile(manifestPath)
 	if err != nil {
 		return fmt.Errorf("reading manifest: %w", err)
@@ -340,12 +742,14 @@ func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry s
 	}
 
 	// Load all patch results keyed by image ref.
-	resultMap, err := loadResults(resultsDir)
+	resultMap, err := LoadResults(resultsDir)
 	if err != nil {
 		return err
 	}
 
 	var publishedCharts []PublishedChart
+	var assemblePlan []AssemblePlanChart
+	var assembleSummary []AssembleSummaryChart
 
 	// Create wrapper charts.
 	for _, ch := range manifest.Charts {
@@ -357,13 +761,20 @@ func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry s
 
 		results := buildPatchResults(ch.Images, resultMap, reportsDir)
 
-		// Check if any images changed
+		// Check if any images (at any discovered platform) changed.
 		hasChanges := false
+	changeCheck:
 		for _, imgDisc := range ch.Images {
 			ref := Image(imgDisc).Reference()
-			if r, ok := resultMap[ref]; ok && r.Changed {
-				hasChanges = true
-				break
+			platforms := imgDisc.Platforms
+			if len(platforms) == 0 {
+				platforms = []string{""}
+			}
+			for _, platform := range platforms {
+				if r, ok := resultMap[PlatformKey(ref, platform)]; ok && r.Changed {
+					hasChanges = true
+					break changeCheck
+				}
 			}
 		}
 
@@ -373,7 +784,15 @@ func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry s
 		}
 
 		// Create wrapper chart
-		version, err := CreateWrapperChart(dep, results, outputDir, registry)
+		starter := StarterOptions{}
+		if ch.Starter != "" {
+			imagePaths := make([]string, len(ch.Images))
+			for i, img := range ch.Images {
+				imagePaths[i] = img.Path
+			}
+			starter = StarterOptions{Dir: startersDir, Name: ch.Starter, ImagePaths: imagePaths}
+		}
+		version, alreadyPublished, valuesHash, err := CreateWrapperChart(dep, results, outputDir, registry, legacyNumericVersions, starter, destLayout)
 		if err != nil {
 			return fmt.Errorf("creating wrapper chart for %s: %w", ch.Name, err)
 		}
@@ -382,45 +801,172 @@ func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry s
 		chartDir := filepath.Join(outputDir, ch.Name)
 		ociRef := fmt.Sprintf("%s/charts/%s:%s", registry, ch.Name, version)
 
+		if reportsMode == ReportsModeEmbed || reportsMode == ReportsModeBoth {
+			if err := embedChartReports(results, chartDir); err != nil {
+				return fmt.Errorf("embedding reports for %s: %w", ch.Name, err)
+			}
+		}
+
+		summaryEntry := buildAssembleSummaryChart(ch, results, resultsDir, version, valuesHash, ociRef, false, "")
+
+		if alreadyPublished {
+			switch onConflict {
+			case OnConflictFail:
+				return fmt.Errorf("chart %s version %s already published to %s (--on-conflict=fail)", ch.Name, version, ociRef)
+			case OnConflictOverwrite:
+				if publish && !dryRun {
+					fmt.Printf("  Republishing %s: version %s already in registry (--on-conflict=overwrite)\n", ch.Name, version)
+				}
+			default: // OnConflictBump
+				digest, _ := remoteChartDigest(registry, ch.Name, version)
+				if digest != "" {
+					fmt.Printf("  Skipping publish for %s: version %s already in registry (unchanged, %s)\n", ch.Name, version, digest)
+				} else {
+					fmt.Printf("  Skipping publish for %s: version %s already in registry (unchanged)\n", ch.Name, version)
+				}
+				summaryEntry.Digest = digest
+				assembleSummary = append(assembleSummary, summaryEntry)
+				continue
+			}
+		}
+
+		if dryRun {
+			manifestYAML, err := renderWrapperChartDryRun(chartDir, ch.Name)
+			if err != nil {
+				return fmt.Errorf("rendering dry-run manifest for %s: %w", ch.Name, err)
+			}
+			renderedPath := filepath.Join(chartDir, "rendered.yaml")
+			if err := os.WriteFile(renderedPath, []byte(manifestYAML), 0o644); err != nil {
+				return fmt.Errorf("writing rendered manifest for %s: %w", ch.Name, err)
+			}
+			fmt.Printf("  Dry-run render → %s\n", renderedPath)
+
+			// Read back the provenance CreateWrapperChart already computed
+			// and saved to chartDir/provenance.json rather than calling
+			// BuildWrapperProvenance again, which would re-resolve every
+			// image's digest against the registry a second time.
+			planProvenance, err := LoadWrapperProvenance(chartDir)
+			if err != nil {
+				return fmt.Errorf("loading provenance for dry-run plan of %s: %w", ch.Name, err)
+			}
+			assemblePlan = append(assemblePlan, AssemblePlanChart{
+				Name:         ch.Name,
+				Version:      version,
+				TargetRef:    ociRef,
+				RenderedPath: renderedPath,
+				Images:       planProvenance.Images,
+			})
+		}
+
 		// Publish to OCI if requested
-		if publish && registry != "" {
-			_, err := PublishChart(chartDir, registry)
+		var reportReferrerDigests []string
+		var chartDigest, cosignSignatureRef string
+		if publish && registry != "" && !dryRun {
+			var publishSignOpts *PublishSignOptions
+			if sign {
+				publishSignOpts = &PublishSignOptions{Cosign: &attest.SignOptions{Key: signKey, IdentityToken: identityToken}}
+			}
+			publishResult, err := PublishChart(chartDir, registry, publishSignOpts)
 			if err != nil {
 				return fmt.Errorf("publishing chart %s: %w", ch.Name, err)
 			}
+			chartDigest = publishResult.Digest
+			if publishResult.CosignResult != nil {
+				cosignSignatureRef = publishResult.CosignResult.SignatureRef
+			}
+
+			if sign {
+				if err := signAndAttestChart(chartDir, publishResult.LocalPath, signKey); err != nil {
+					return fmt.Errorf("signing chart %s: %w", ch.Name, err)
+				}
+			}
+
+			if reportsMode == ReportsModeReferrer || reportsMode == ReportsModeBoth {
+				pushed, err := pushChartReportsAsReferrers(results, strings.TrimPrefix(publishResult.Reference, "oci://"), publishResult.Digest)
+				if err != nil {
+					return fmt.Errorf("pushing reports as referrers for %s: %w", ch.Name, err)
+				}
+				reportReferrerDigests = pushed
+			}
+
+			if _, err := PushChartAdditions(strings.TrimPrefix(publishResult.Reference, "oci://"), publishResult.Digest, chartDir, dep, results); err != nil {
+				return fmt.Errorf("pushing chart additions for %s: %w", ch.Name, err)
+			}
+
+			if err := PushChannelTags(registry, ch.Name, version); err != nil {
+				return fmt.Errorf("pushing channel tags for %s: %w", ch.Name, err)
+			}
 		}
 
+		summaryEntry.Published = publish && registry != "" && !dryRun
+		summaryEntry.Digest = chartDigest
+		assembleSummary = append(assembleSummary, summaryEntry)
+
 		// Generate SBOM
 		sbomPath := filepath.Join(chartDir, "sbom.cdx.json")
 		if err := GenerateChartSBOM(ch, results, version, sbomPath); err != nil {
 			return fmt.Errorf("generating SBOM for %s: %w", ch.Name, err)
 		}
 
+		// Generate a VEX document alongside the SBOM, suitable for
+		// `cosign attest --type vex`, linking each aggregated
+		// vulnerability to its SBOM component by bom-ref.
+		vexPath := filepath.Join(chartDir, "vex.cdx.json")
+		if err := GenerateChartVEX(ch, results, reportsDir, vexPath); err != nil {
+			return fmt.Errorf("generating VEX for %s: %w", ch.Name, err)
+		}
+
 		// Generate aggregated vulnerability predicate
 		vulnPredicatePath := filepath.Join(chartDir, "vuln-predicate.json")
 		if err := AggregateVulnPredicate(results, reportsDir, vulnPredicatePath); err != nil {
 			return fmt.Errorf("generating vuln predicate for %s: %w", ch.Name, err)
 		}
 
-		// Record published chart
-		pc := PublishedChart{
-			Name:              ch.Name,
-			Version:           version,
-			Registry:          registry,
-			OCIRef:            ociRef,
-			SBOMPath:          sbomPath,
-			VulnPredicatePath: vulnPredicatePath,
-		}
-		for _, pr := range results {
-			// Include all successfully processed images (including mirrored ones that were skipped)
-			if pr.Error == nil && pr.Patched.Reference() != "" {
-				pc.Images = append(pc.Images, PublishedImage{
-					Original: pr.Original.Reference(),
-					Patched:  pr.Patched.Reference(),
-				})
+		// Generate a vuln delta predicate alongside the snapshot, classifying
+		// each upstream CVE as fixed/remaining/introduced by re-scanning the
+		// patched image (see PatchResult.PatchedReportPath) — a signable
+		// record of what the patch changed, not just what's left.
+		vulnDeltaPredicatePath := filepath.Join(chartDir, "vuln-delta-predicate.json")
+		if err := AggregateVulnDeltaPredicate(results, reportsDir, vulnDeltaPredicatePath); err != nil {
+			return fmt.Errorf("generating vuln delta predicate for %s: %w", ch.Name, err)
+		}
+
+		// Generate a per-chart executive summary (VulnsBySeverity, BadVulns)
+		// so CI can gate on severity instead of raw vuln count.
+		vulnSummaryPath := filepath.Join(chartDir, "vuln-summary.json")
+		if err := GenerateVulnSummary(results, vulnSummaryPath); err != nil {
+			return fmt.Errorf("generating vuln summary for %s: %w", ch.Name, err)
+		}
+
+		// Record published chart. Skipped for a dry run: nothing was
+		// actually pushed, so it doesn't belong in published-charts.json
+		// alongside charts a real --publish run pushed.
+		if !dryRun {
+			pc := PublishedChart{
+				Name:                   ch.Name,
+				Version:                version,
+				Registry:               registry,
+				OCIRef:                 ociRef,
+				SBOMPath:               sbomPath,
+				VEXPath:                vexPath,
+				VulnPredicatePath:      vulnPredicatePath,
+				VulnDeltaPredicatePath: vulnDeltaPredicatePath,
+				VulnSummaryPath:        vulnSummaryPath,
+				ReportReferrerDigests:  reportReferrerDigests,
+				Digest:                 chartDigest,
+				CosignSignatureRef:     cosignSignatureRef,
 			}
+			for _, pr := range results {
+				// Include all successfully processed images (including mirrored ones that were skipped)
+				if pr.Error == nil && pr.Patched.Reference() != "" {
+					pc.Images = append(pc.Images, PublishedImage{
+						Original: pr.Original.Reference(),
+						Patched:  pr.Patched.Reference(),
+					})
+				}
+			}
+			publishedCharts = append(publishedCharts, pc)
 		}
-		publishedCharts = append(publishedCharts, pc)
 	}
 
 	// Write published-charts.json
@@ -436,12 +982,179 @@ func AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry s
 		fmt.Printf("\nPublished %d chart(s) → %s\n", len(publishedCharts), publishedPath)
 	}
 
+	if err := writeOrphanImagePatches(manifest, resultMap, outputDir); err != nil {
+		return fmt.Errorf("writing patched-images.json: %w", err)
+	}
+
+	if err := writeAssemblePlan(assemblePlan, outputDir); err != nil {
+		return fmt.Errorf("writing assemble-plan.json: %w", err)
+	}
+
+	if summaryPath != "" {
+		if err := writeAssembleSummary(assembleSummary, summaryPath); err != nil {
+			return fmt.Errorf("writing assemble summary: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// orphanImages returns the subset of manifest.Images that don't belong to
+// any manifest.Charts entry — e.g. images a ClusterSource found in a live
+// Pod spec, which has no values.yaml path to write a wrapper chart
+// override into (see ClusterSource's "pod/<ns>/<name>/<container>" Path
+// convention vs ChartFileSource's values-file paths).
+func orphanImages(manifest *DiscoveryManifest) []ImageDiscovery {
+	chartRefs := make(map[string]struct{})
+	for _, ch := range manifest.Charts {
+		for _, img := range ch.Images {
+			chartRefs[Image(img).Reference()] = struct{}{}
+		}
+	}
+
+	var orphans []ImageDiscovery
+	for _, img := range manifest.Images {
+		if _, ok := chartRefs[Image(img).Reference()]; !ok {
+			orphans = append(orphans, img)
+		}
+	}
+	return orphans
+}
+
+// writeOrphanImagePatches writes patched-images.json, a flat original-ref
+// → patched-ref mapping for orphanImages(manifest). These images have
+// nowhere for AssembleResults to write an override back into, so wrapper
+// chart creation is skipped for them entirely — this plain mapping is the
+// only record of what they were patched to. A no-op when there are none.
+func writeOrphanImagePatches(manifest *DiscoveryManifest, resultMap map[string]*SinglePatchResult, outputDir string) error {
+	orphans := orphanImages(manifest)
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	patches := make(map[string]string, len(orphans))
+	for _, img := range orphans {
+		ref := Image(img).Reference()
+		r, ok := resultMap[PlatformKey(ref, "")]
+		if !ok || r.Error != "" || r.PatchedRepository == "" {
+			continue
+		}
+		patched := Image{Registry: r.PatchedRegistry, Repository: r.PatchedRepository, Tag: r.PatchedTag}
+		patches[ref] = patched.Reference()
+	}
+	if len(patches) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(patches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling patched images: %w", err)
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("creating output dir: %w", err)
+	}
+	patchedPath := filepath.Join(outputDir, "patched-images.json")
+	if err := os.WriteFile(patchedPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing patched images: %w", err)
+	}
+	fmt.Printf("Patched images (no chart origin) → %s\n", patchedPath)
+	return nil
+}
+
+// signAndAttestChart signs tgzPath (the packaged chart archive PublishChart
+// just pushed) and attaches the provenance.json CreateWrapperChart wrote to
+// chartDir as a signed in-toto attestation, both as sidecar files next to
+// tgzPath.
+func signAndAttestChart(chartDir, tgzPath, signKey string) error {
+	ctx := context.Background()
+
+	signResult, err := attest.SignChartArchive(ctx, tgzPath, attest.SignOptions{Key: signKey})
+	if err != nil {
+		return fmt.Errorf("signing archive: %w", err)
+	}
+	fmt.Printf("    Signed chart archive (%s) → %s\n", signResult.Digest, signResult.SignaturePath)
+
+	provenance, err := os.ReadFile(filepath.Join(chartDir, "provenance.json"))
+	if err != nil {
+		return fmt.Errorf("reading provenance.json: %w", err)
+	}
+	attPath, err := attest.AttestChartProvenance(ctx, tgzPath, provenance, attest.AttestOptions{Key: signKey})
+	if err != nil {
+		return fmt.Errorf("attesting provenance: %w", err)
+	}
+	fmt.Printf("    Provenance attestation → %s\n", attPath)
 	return nil
 }
 
-// loadResults reads all SinglePatchResult JSON files from a directory,
-// returning a map keyed by image reference.
-func loadResults(dir string) (map[string]*SinglePatchResult, error) {
+// pushChartReportsAsReferrers copies the Trivy report belonging to each of
+// results' images into a scratch directory (so PushReportsAsReferrers sees
+// only this chart's own reports, not every report in the run's shared
+// reportsDir) and pushes them as OCI 1.1 referrer artifacts on chartRef's
+// just-published digest, returning the pushed artifacts' digest references
+// (see PublishedChart.ReportReferrerDigests). A chart whose images have no
+// reports yet (e.g. everything was mirrored/skipped) is a no-op, not an
+// error.
+//
+// Relies on remote.Referrers' built-in fallback to the OCI "referrers tag
+// scheme" (sha256-<hex>) when resolving referrers from a registry that
+// doesn't implement the Referrers API's GET endpoint; this function itself
+// doesn't maintain that fallback tag on the push side, so a report pushed
+// here may not be discoverable from such a registry until something else
+// reads it forward into the tag (a gap worth closing if this turns out to
+// matter in practice).
+func pushChartReportsAsReferrers(results []*PatchResult, chartRef, digest string) ([]string, error) {
+	scratchDir, err := os.MkdirTemp("", "verity-chart-reports-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(scratchDir) }()
+
+	var n int
+	for _, r := range results {
+		src := r.ReportPath
+		if src == "" {
+			continue
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("reading report for %s: %w", r.Original.Reference(), err)
+		}
+		dest := filepath.Join(scratchDir, sanitize(PlatformKey(r.Original.Reference(), r.Platform))+".json")
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
+			return nil, fmt.Errorf("writing report for %s: %w", r.Original.Reference(), err)
+		}
+		n++
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	pushed, err := PushReportsAsReferrers(chartRef, digest, scratchDir)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("    Pushed %d report(s) as referrers of %s@%s\n", len(pushed), chartRef, digest)
+	return pushed, nil
+}
+
+// VerifyWrapperChart validates a wrapper chart archive's cosign signature
+// and in-toto provenance attestation before install, confirming the chart
+// at tgzPath is the exact one Verity built and scanned — not a tampered or
+// stale copy. signKey is the cosign public key to verify against (keyless
+// verification of chart archives isn't supported yet; see
+// attest.VerifyChartArchive).
+func VerifyWrapperChart(tgzPath, signKey string) (*attest.ChartVerifyResult, error) {
+	return attest.VerifyChartArchive(context.Background(), tgzPath, attest.VerifyOptions{Key: signKey})
+}
+
+// LoadResults reads all SinglePatchResult JSON files from a directory,
+// returning a map keyed by PlatformKey(ImageRef, Platform) so per-platform
+// matrix jobs of the same image don't clobber each other. A single-platform
+// result (Platform == "") keys by its bare ImageRef, unchanged from before
+// per-platform fanout existed. Exported so the attest-all command can map a
+// discover/patch matrix.json entry to the patched ref PatchSingleImage
+// recorded for it, the same way buildPatchResults does for wrapper charts.
+func LoadResults(dir string) (map[string]*SinglePatchResult, error) {
 	m := make(map[string]*SinglePatchResult)
 
 	entries, err := os.ReadDir(dir)
@@ -471,14 +1184,27 @@ func loadResults(dir string) (map[string]*SinglePatchResult, error) {
 			fmt.Fprintf(os.Stderr, "Warning: result file %s has empty ImageRef, skipping\n", e.Name())
 			continue
 		}
-		m[r.ImageRef] = &r
+		m[PlatformKey(r.ImageRef, r.Platform)] = &r
 	}
 
 	return m, nil
 }
 
 // buildPatchResults converts discovered images + matrix results into
-// PatchResult objects that CreateWrapperChart expects.
+// PatchResult objects that CreateWrapperChart expects. It reads each
+// image's report from reportsDir via loadVulnStats (scanner.Normalize)
+// rather than taking a scanner.Scanner directly: matrix jobs run as
+// separate processes, so a live Scanner can't cross that boundary, but
+// scanner.Normalize reads any backend's report interchangeably once it's
+// been written in the common MarshalTrivyCompat shape (see
+// PatchSingleImage/scanWithBackend) — backend-agnostic by construction,
+// keyed through the reports directory rather than an in-memory interface.
+// An image discovered
+// with more than one platform (see ImageDiscovery.Platforms) produces one
+// PatchResult per platform, each keyed into resultMap via platformKey and
+// carrying its own PatchResult.Platform; a single-platform image still
+// produces exactly one PatchResult with Platform == "", unchanged from
+// before per-platform fanout existed.
 func buildPatchResults(images []ImageDiscovery, resultMap map[string]*SinglePatchResult, reportsDir string) []*PatchResult {
 	var results []*PatchResult
 
@@ -486,46 +1212,106 @@ func buildPatchResults(images []ImageDiscovery, resultMap map[string]*SinglePatc
 		img := Image(imgDisc)
 		ref := img.Reference()
 
-		pr := &PatchResult{Original: img}
-
-		r, ok := resultMap[ref]
-		if !ok || r == nil {
-			// No patch result produced (matrix job may have failed).
-			pr.Skipped = true
-			pr.SkipReason = SkipReasonNoPatchResult
-			results = append(results, pr)
-			continue
+		platforms := imgDisc.Platforms
+		if len(platforms) == 0 {
+			platforms = []string{""}
 		}
 
-		if r.Error != "" { //nolint:gocritic // prefer if-else for readability
-			pr.Error = errors.New(r.Error) //nolint:err113 // wrapping error from JSON string
-		} else if r.Skipped {
-			pr.Skipped = true
-			pr.SkipReason = r.SkipReason
-			if r.PatchedRepository != "" {
+		for _, platform := range platforms {
+			key := PlatformKey(ref, platform)
+
+			pr := &PatchResult{Original: img, Platform: platform}
+
+			r, ok := resultMap[key]
+			if !ok || r == nil {
+				// No patch result produced (matrix job may have failed).
+				pr.Skipped = true
+				pr.SkipReason = SkipReasonNoPatchResult
+				results = append(results, pr)
+				continue
+			}
+
+			if r.Error != "" { //nolint:gocritic // prefer if-else for readability
+				pr.Error = errors.New(r.Error) //nolint:err113 // wrapping error from JSON string
+			} else if r.Skipped {
+				pr.Skipped = true
+				pr.SkipReason = r.SkipReason
+				if r.PatchedRepository != "" {
+					pr.Patched = Image{
+						Registry:   r.PatchedRegistry,
+						Repository: r.PatchedRepository,
+						Tag:        r.PatchedTag,
+					}
+				}
+			} else {
+				pr.VulnCount = r.VulnCount
 				pr.Patched = Image{
 					Registry:   r.PatchedRegistry,
 					Repository: r.PatchedRepository,
 					Tag:        r.PatchedTag,
 				}
 			}
-		} else {
-			pr.VulnCount = r.VulnCount
-			pr.Patched = Image{
-				Registry:   r.PatchedRegistry,
-				Repository: r.PatchedRepository,
-				Tag:        r.PatchedTag,
+
+			// r.Platforms is only set when PatchSingleImage patched a
+			// multi-arch manifest list (see PlatformResult); reconstruct
+			// the in-process Variants/PlatformErrors shape from it so
+			// downstream consumers (CreateWrapperChart, sitedata.go's
+			// per-platform SiteImage breakdown) see the same data they
+			// would for a result built in one process by patchMultiArch,
+			// not just a combined digest. A platform recorded with Error
+			// set didn't make it into the manifest list, so it becomes a
+			// PlatformErrors entry instead of a (digest-less) Variant.
+			for _, p := range r.Platforms {
+				if p.Error != "" {
+					if pr.PlatformErrors == nil {
+						pr.PlatformErrors = map[string]string{}
+					}
+					pr.PlatformErrors[Platform{OS: p.OS, Arch: p.Arch, Variant: p.Variant}.String()] = p.Error
+					continue
+				}
+				pr.Variants = append(pr.Variants, PatchedVariant{
+					Platform: Platform{OS: p.OS, Arch: p.Arch, Variant: p.Variant},
+					Reference: Image{
+						Registry:   r.PatchedRegistry,
+						Repository: r.PatchedRepository,
+						Tag:        p.Tag,
+					}.Reference(),
+					Digest: p.Digest,
+				})
 			}
-		}
 
-		// Look for trivy report by sanitized original ref.
-		reportPath := filepath.Join(reportsDir, sanitize(ref)+".json")
-		if _, err := os.Stat(reportPath); err == nil {
-			pr.ReportPath = reportPath
-			pr.UpstreamReportPath = reportPath
-		}
+			// r.PatchedReportPath is an absolute path from the matrix job's
+			// own ReportDir, which may not exist in this process (e.g. a
+			// separate assemble job) — resolve it against reportsDir by
+			// basename, same as AggregateVulnPredicate does for ReportPath.
+			if r.PatchedReportPath != "" {
+				pr.PatchedReportPath = r.PatchedReportPath
+				if !filepath.IsAbs(pr.PatchedReportPath) {
+					pr.PatchedReportPath = filepath.Join(reportsDir, filepath.Base(pr.PatchedReportPath))
+				} else if _, err := os.Stat(pr.PatchedReportPath); err != nil {
+					pr.PatchedReportPath = filepath.Join(reportsDir, filepath.Base(r.PatchedReportPath))
+				}
+			}
 
-		results = append(results, pr)
+			// Look for trivy report by sanitized imageRef|platform key.
+			reportPath := filepath.Join(reportsDir, sanitize(key)+".json")
+			if _, err := os.Stat(reportPath); err == nil {
+				pr.ReportPath = reportPath
+				pr.UpstreamReportPath = reportPath
+
+				// Populate a severity histogram from the report itself, not just
+				// the matrix job's raw VulnCount, so AssembleResults can roll up
+				// VulnsBySeverity/BadVulns per chart.
+				if before, vulns, err := loadVulnStats(reportPath); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: cannot load vuln stats from %s: %v\n", reportPath, err)
+				} else {
+					pr.Before = before
+					pr.Vulns = vulns
+				}
+			}
+
+			results = append(results, pr)
+		}
 	}
 
 	return results
@@ -0,0 +1,169 @@
+//go:build integration
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+)
+
+// writeOnConflictFixture lays out a one-chart manifest plus a single
+// "changed" patch result and Trivy report under dir, mirroring
+// TestAssembleResultsProcessesChangedCharts's changed-app case, so
+// AssembleResults has exactly one wrapper chart to publish.
+func writeOnConflictFixture(t *testing.T, dir string) (manifestPath, resultsDir, reportsDir string) {
+	t.Helper()
+
+	manifest := DiscoveryManifest{
+		Charts: []ChartDiscovery{
+			{
+				Name:       "onconflict-app",
+				Version:    "1.0.0",
+				Repository: "oci://ghcr.io/charts",
+				Images: []ImageDiscovery{
+					{Registry: "docker.io", Repository: "library/redis", Tag: "7.0", Path: "image"},
+				},
+			},
+		},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath = filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(manifestPath, manifestData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resultsDir = filepath.Join(dir, "results")
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	result := SinglePatchResult{
+		ImageRef:          "docker.io/library/redis:7.0",
+		PatchedRegistry:   "ghcr.io/test",
+		PatchedRepository: "library/redis",
+		PatchedTag:        "7.0-patched",
+		VulnCount:         3,
+		Changed:           true,
+	}
+	rData, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resultsDir, sanitize("docker.io/library/redis:7.0")+".json"), rData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reportsDir = filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	reportData := []byte(`{"Results":[{"Vulnerabilities":[{"FixedVersion":"1.0","VulnerabilityID":"CVE-2024-0001"}]}]}`)
+	if err := os.WriteFile(filepath.Join(reportsDir, sanitize("docker.io/library/redis:7.0")+".json"), reportData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return manifestPath, resultsDir, reportsDir
+}
+
+// onConflictTestRegistry is newTestRegistry's host rewritten from
+// "127.0.0.1:port" to "localhost:port": PushChannelTags and the Helm OCI
+// registry client PublishChart uses don't take an explicit insecure option
+// the way crane.Push/crane.Digest do elsewhere in this package's
+// integration tests, so a real (non-TLS) publish round-trip needs a host
+// they'll treat as plain HTTP by default.
+func onConflictTestRegistry(t *testing.T) string {
+	t.Helper()
+	return strings.Replace(newTestRegistry(t), "127.0.0.1", "localhost", 1)
+}
+
+func readPublishedCharts(t *testing.T, outputDir string) []PublishedChart {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(outputDir, "published-charts.json"))
+	if err != nil {
+		t.Fatalf("reading published-charts.json: %v", err)
+	}
+	var charts []PublishedChart
+	if err := json.Unmarshal(data, &charts); err != nil {
+		t.Fatalf("parsing published-charts.json: %v", err)
+	}
+	return charts
+}
+
+// TestAssembleResultsOnConflictFail_Integration publishes the same wrapper
+// chart twice against a real registry: the content digest (and therefore
+// the version tag, see CreateWrapperChart) is identical both times, so the
+// second AssembleResults call finds the version already published and,
+// under OnConflictFail, must return an error instead of silently skipping
+// or re-publishing.
+func TestAssembleResultsOnConflictFail_Integration(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath, resultsDir, reportsDir := writeOnConflictFixture(t, dir)
+	registry := onConflictTestRegistry(t)
+
+	outputDir := filepath.Join(dir, "charts")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, "", true, false, false, false, "", "", ReportsModeEmbed, "", "", OnConflictBump); err != nil {
+		t.Fatalf("first publish: AssembleResults() error: %v", err)
+	}
+
+	outputDir2 := filepath.Join(dir, "charts2")
+	err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir2, registry, "", true, false, false, false, "", "", ReportsModeEmbed, "", "", OnConflictFail)
+	if err == nil {
+		t.Fatal("second publish with --on-conflict=fail: expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "already published") || !strings.Contains(err.Error(), "--on-conflict=fail") {
+		t.Errorf("second publish error = %q, want it to report an already-published conflict", err.Error())
+	}
+}
+
+// TestAssembleResultsOnConflictOverwrite_Integration publishes the same
+// wrapper chart twice against a real registry under OnConflictOverwrite:
+// unlike OnConflictFail, the second publish must succeed and must actually
+// re-push the chart (not silently skip it the way OnConflictBump's default
+// "unchanged" branch does).
+func TestAssembleResultsOnConflictOverwrite_Integration(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath, resultsDir, reportsDir := writeOnConflictFixture(t, dir)
+	registry := onConflictTestRegistry(t)
+
+	outputDir := filepath.Join(dir, "charts")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, "", true, false, false, false, "", "", ReportsModeEmbed, "", "", OnConflictBump); err != nil {
+		t.Fatalf("first publish: AssembleResults() error: %v", err)
+	}
+	first := readPublishedCharts(t, outputDir)
+	if len(first) != 1 {
+		t.Fatalf("expected 1 published chart after first publish, got %d", len(first))
+	}
+	chartRef := fmt.Sprintf("%s/charts/onconflict-app:%s", registry, first[0].Version)
+	if _, err := crane.Digest(chartRef, crane.Insecure); err != nil {
+		t.Fatalf("resolving digest after first publish: %v", err)
+	}
+
+	outputDir2 := filepath.Join(dir, "charts2")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir2, registry, "", true, false, false, false, "", "", ReportsModeEmbed, "", "", OnConflictOverwrite); err != nil {
+		t.Fatalf("second publish with --on-conflict=overwrite: unexpected error: %v", err)
+	}
+
+	second := readPublishedCharts(t, outputDir2)
+	if len(second) != 1 {
+		t.Fatalf("expected 1 published chart after overwrite republish, got %d", len(second))
+	}
+	if second[0].Digest == "" {
+		t.Error("overwrite republish: expected a non-empty chart digest, got empty")
+	}
+	if second[0].Version != first[0].Version {
+		t.Errorf("overwrite republish: version changed from %q to %q, want the same version re-pushed", first[0].Version, second[0].Version)
+	}
+
+	if digest, err := crane.Digest(chartRef, crane.Insecure); err != nil {
+		t.Errorf("resolving digest after overwrite republish: %v", err)
+	} else if digest == "" {
+		t.Error("overwrite republish: registry tag does not resolve to a digest")
+	}
+}
@@ -1,10 +1,19 @@
 package internal
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -29,7 +38,7 @@ func TestGenerateMatrix(t *testing.T) {
 		},
 	}
 
-	matrix := GenerateMatrix(manifest)
+	matrix := GenerateMatrix(manifest, "", "", nil)
 
 	if len(matrix.Include) != 3 {
 		t.Fatalf("expected 3 matrix entries (deduplicated), got %d", len(matrix.Include))
@@ -55,9 +64,92 @@ func TestGenerateMatrix(t *testing.T) {
 	}
 }
 
+func TestGenerateMatrixMultiPlatform(t *testing.T) {
+	manifest := &DiscoveryManifest{
+		Images: []ImageDiscovery{
+			{
+				Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "server.image",
+				Platforms: []string{"linux/amd64", "linux/arm64"},
+			},
+			// Duplicate of the multi-platform image above — should dedupe
+			// within each platform, not collapse the two platforms together.
+			{
+				Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "duplicate.image",
+				Platforms: []string{"linux/amd64", "linux/arm64"},
+			},
+			// Single-platform image — unaffected by Platforms, exactly one entry.
+			{Registry: "docker.io", Repository: "grafana/grafana", Tag: "12.3.3", Path: "grafana.image"},
+		},
+	}
+
+	matrix := GenerateMatrix(manifest, "", "", nil)
+
+	if len(matrix.Include) != 3 {
+		t.Fatalf("expected 3 matrix entries (2 platforms + 1 single-platform image), got %d", len(matrix.Include))
+	}
+
+	seen := map[string]string{}
+	for _, e := range matrix.Include {
+		seen[PlatformKey(e.ImageRef, e.Platform)] = e.ImageName
+	}
+
+	for _, key := range []string{
+		"quay.io/prometheus/prometheus:v3.9.1|linux/amd64",
+		"quay.io/prometheus/prometheus:v3.9.1|linux/arm64",
+		"docker.io/grafana/grafana:12.3.3",
+	} {
+		if _, ok := seen[key]; !ok {
+			t.Errorf("expected matrix entry keyed %q, got %v", key, seen)
+		}
+	}
+}
+
+func TestGenerateMatrixMinSeverityDropsCleanImages(t *testing.T) {
+	reportsDir := t.TempDir()
+
+	// nginx: only a LOW finding — should be dropped at --min-severity high.
+	nginxRef := "docker.io/library/nginx:1.25"
+	nginxReport := []byte(`{"Results":[{"Vulnerabilities":[{"Severity":"LOW","FixedVersion":"1.0"}]}]}`)
+	if err := os.WriteFile(filepath.Join(reportsDir, sanitize(nginxRef)+".json"), nginxReport, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// redis: a fixable CRITICAL finding — should stay.
+	redisRef := "docker.io/library/redis:7.0"
+	redisReport := []byte(`{"Results":[{"Vulnerabilities":[{"Severity":"CRITICAL","FixedVersion":"7.0.1"}]}]}`)
+	if err := os.WriteFile(filepath.Join(reportsDir, sanitize(redisRef)+".json"), redisReport, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := &DiscoveryManifest{
+		Images: []ImageDiscovery{
+			{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "image"},
+			{Registry: "docker.io", Repository: "library/redis", Tag: "7.0", Path: "image"},
+			// No cached report at all — always included.
+			{Registry: "docker.io", Repository: "library/grafana", Tag: "12.3.3", Path: "image"},
+		},
+	}
+
+	matrix := GenerateMatrix(manifest, reportsDir, "high", nil)
+
+	refs := map[string]bool{}
+	for _, e := range matrix.Include {
+		refs[e.ImageRef] = true
+	}
+	if refs[nginxRef] {
+		t.Errorf("expected %q to be dropped (only LOW findings), got matrix %v", nginxRef, refs)
+	}
+	if !refs[redisRef] {
+		t.Errorf("expected %q to remain (fixable CRITICAL finding), got matrix %v", redisRef, refs)
+	}
+	if !refs["docker.io/library/grafana:12.3.3"] {
+		t.Error("expected image with no cached report to remain in the matrix")
+	}
+}
+
 func TestGenerateMatrixEmpty(t *testing.T) {
 	manifest := &DiscoveryManifest{}
-	matrix := GenerateMatrix(manifest)
+	matrix := GenerateMatrix(manifest, "", "", nil)
 
 	if len(matrix.Include) != 0 {
 		t.Errorf("expected empty matrix, got %d entries", len(matrix.Include))
@@ -82,7 +174,7 @@ func TestWriteDiscoveryOutput(t *testing.T) {
 			{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "image"},
 		},
 	}
-	matrix := GenerateMatrix(manifest)
+	matrix := GenerateMatrix(manifest, "", "", nil)
 
 	if err := WriteDiscoveryOutput(manifest, matrix, dir); err != nil {
 		t.Fatalf("WriteDiscoveryOutput() error: %v", err)
@@ -194,6 +286,182 @@ func TestBuildPatchResults(t *testing.T) {
 	}
 }
 
+func TestBuildPatchResultsMultiPlatform(t *testing.T) {
+	dir := t.TempDir()
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := "quay.io/prometheus/prometheus:v3.9.1"
+	for _, platform := range []string{"linux/amd64", "linux/arm64"} {
+		reportData := []byte(`{"Results":[{"Vulnerabilities":[{"FixedVersion":"1.0"}]}]}`)
+		reportName := sanitize(PlatformKey(ref, platform)) + ".json"
+		if err := os.WriteFile(filepath.Join(reportsDir, reportName), reportData, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	images := []ImageDiscovery{
+		{Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "server.image", Platforms: []string{"linux/amd64", "linux/arm64"}},
+	}
+
+	resultMap := map[string]*SinglePatchResult{
+		PlatformKey(ref, "linux/amd64"): {
+			ImageRef: ref, Platform: "linux/amd64",
+			PatchedRegistry: testRegistry, PatchedRepository: "prometheus/prometheus", PatchedTag: "v3.9.1-patched-amd64",
+			VulnCount: 2,
+		},
+		PlatformKey(ref, "linux/arm64"): {
+			ImageRef: ref, Platform: "linux/arm64",
+			PatchedRegistry: testRegistry, PatchedRepository: "prometheus/prometheus", PatchedTag: "v3.9.1-patched-arm64",
+			VulnCount: 4,
+		},
+	}
+
+	results := buildPatchResults(images, resultMap, reportsDir)
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results (one per platform), got %d", len(results))
+	}
+
+	byPlatform := map[string]*PatchResult{}
+	for _, r := range results {
+		byPlatform[r.Platform] = r
+	}
+
+	amd := byPlatform["linux/amd64"]
+	if amd == nil {
+		t.Fatal("missing result for linux/amd64")
+	}
+	if amd.Patched.Tag != "v3.9.1-patched-amd64" || amd.VulnCount != 2 {
+		t.Errorf("unexpected amd64 result: %+v", amd)
+	}
+	if amd.ReportPath == "" {
+		t.Error("expected amd64 report path to be set")
+	}
+
+	arm := byPlatform["linux/arm64"]
+	if arm == nil {
+		t.Fatal("missing result for linux/arm64")
+	}
+	if arm.Patched.Tag != "v3.9.1-patched-arm64" || arm.VulnCount != 4 {
+		t.Errorf("unexpected arm64 result: %+v", arm)
+	}
+}
+
+func TestBuildPatchResultsReconstructsVariantsFromPlatforms(t *testing.T) {
+	dir := t.TempDir()
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := "quay.io/prometheus/prometheus:v3.9.1"
+	images := []ImageDiscovery{
+		{Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "server.image"},
+	}
+
+	resultMap := map[string]*SinglePatchResult{
+		PlatformKey(ref, ""): {
+			ImageRef:          ref,
+			PatchedRegistry:   testRegistry,
+			PatchedRepository: "prometheus/prometheus",
+			PatchedTag:        "v3.9.1-patched",
+			VulnCount:         6,
+			Platforms: []PlatformResult{
+				{OS: "linux", Arch: "amd64", Digest: "sha256:aaa", Tag: "v3.9.1-patched-amd64"},
+				{OS: "linux", Arch: "arm64", Digest: "sha256:bbb", Tag: "v3.9.1-patched-arm64"},
+			},
+		},
+	}
+
+	results := buildPatchResults(images, resultMap, reportsDir)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	pr := results[0]
+	if len(pr.Variants) != 2 {
+		t.Fatalf("expected 2 variants reconstructed from Platforms, got %d", len(pr.Variants))
+	}
+	if pr.Variants[0].Platform.Arch != "amd64" || pr.Variants[0].Digest != "sha256:aaa" {
+		t.Errorf("unexpected first variant: %+v", pr.Variants[0])
+	}
+	if pr.Variants[1].Reference != testRegistry+"/prometheus/prometheus:v3.9.1-patched-arm64" {
+		t.Errorf("unexpected second variant reference: %q", pr.Variants[1].Reference)
+	}
+}
+
+func TestBuildPatchResultsReconstructsPlatformErrorsFromPlatforms(t *testing.T) {
+	dir := t.TempDir()
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := "quay.io/prometheus/prometheus:v3.9.1"
+	images := []ImageDiscovery{
+		{Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "server.image"},
+	}
+
+	resultMap := map[string]*SinglePatchResult{
+		PlatformKey(ref, ""): {
+			ImageRef:          ref,
+			PatchedRegistry:   testRegistry,
+			PatchedRepository: "prometheus/prometheus",
+			PatchedTag:        "v3.9.1-patched",
+			VulnCount:         3,
+			Platforms: []PlatformResult{
+				{OS: "linux", Arch: "amd64", Digest: "sha256:aaa", Tag: "v3.9.1-patched-amd64", VulnCount: 3},
+				{OS: "linux", Arch: "arm64", Error: "copa: no fix available"},
+			},
+		},
+	}
+
+	results := buildPatchResults(images, resultMap, reportsDir)
+	pr := results[0]
+
+	if len(pr.Variants) != 1 || pr.Variants[0].Platform.Arch != "amd64" {
+		t.Fatalf("expected only the successful amd64 platform as a Variant, got %+v", pr.Variants)
+	}
+	if msg := pr.PlatformErrors["linux/arm64"]; msg != "copa: no fix available" {
+		t.Errorf("PlatformErrors[linux/arm64] = %q, want the recorded failure", msg)
+	}
+}
+
+func TestBuildPatchResultsWiresPatchedReportPath(t *testing.T) {
+	dir := t.TempDir()
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := "quay.io/prometheus/prometheus:v3.9.1"
+	patchedReportPath := filepath.Join(reportsDir, sanitize(ref)+"-after.json")
+	if err := os.WriteFile(patchedReportPath, []byte(`{"Results":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	images := []ImageDiscovery{
+		{Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1", Path: "server.image"},
+	}
+	resultMap := map[string]*SinglePatchResult{
+		PlatformKey(ref, ""): {
+			ImageRef:          ref,
+			PatchedRegistry:   testRegistry,
+			PatchedRepository: "prometheus/prometheus",
+			PatchedTag:        "v3.9.1-patched",
+			PatchedReportPath: patchedReportPath,
+		},
+	}
+
+	results := buildPatchResults(images, resultMap, reportsDir)
+	if results[0].PatchedReportPath != patchedReportPath {
+		t.Errorf("PatchedReportPath = %q, want %q", results[0].PatchedReportPath, patchedReportPath)
+	}
+}
+
 func TestLoadResults(t *testing.T) {
 	dir := t.TempDir()
 
@@ -227,9 +495,9 @@ func TestLoadResults(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	results, err := loadResults(dir)
+	results, err := LoadResults(dir)
 	if err != nil {
-		t.Fatalf("loadResults() error: %v", err)
+		t.Fatalf("LoadResults() error: %v", err)
 	}
 
 	if len(results) != 2 {
@@ -249,11 +517,52 @@ func TestLoadResults(t *testing.T) {
 	}
 }
 
+func TestLoadResultsMultiPlatform(t *testing.T) {
+	dir := t.TempDir()
+
+	ref := "quay.io/prom/prom:v1"
+	for i, platform := range []string{"linux/amd64", "linux/arm64"} {
+		r := SinglePatchResult{ImageRef: ref, Platform: platform, VulnCount: i + 1}
+		data, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, sanitize(PlatformKey(ref, platform))+".json")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := LoadResults(dir)
+	if err != nil {
+		t.Fatalf("LoadResults() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results (one per platform), got %d", len(results))
+	}
+
+	amd, ok := results[PlatformKey(ref, "linux/amd64")]
+	if !ok {
+		t.Fatal("missing result for linux/amd64")
+	}
+	if amd.VulnCount != 1 {
+		t.Errorf("expected amd64 vuln count 1, got %d", amd.VulnCount)
+	}
+
+	arm, ok := results[PlatformKey(ref, "linux/arm64")]
+	if !ok {
+		t.Fatal("missing result for linux/arm64")
+	}
+	if arm.VulnCount != 2 {
+		t.Errorf("expected arm64 vuln count 2, got %d", arm.VulnCount)
+	}
+}
+
 func TestLoadResultsEmptyDir(t *testing.T) {
 	dir := t.TempDir()
-	results, err := loadResults(dir)
+	results, err := LoadResults(dir)
 	if err != nil {
-		t.Fatalf("loadResults() error: %v", err)
+		t.Fatalf("LoadResults() error: %v", err)
 	}
 	if len(results) != 0 {
 		t.Errorf("expected 0 results, got %d", len(results))
@@ -261,9 +570,9 @@ func TestLoadResultsEmptyDir(t *testing.T) {
 }
 
 func TestLoadResultsNonExistentDir(t *testing.T) {
-	results, err := loadResults("/nonexistent/path")
+	results, err := LoadResults("/nonexistent/path")
 	if err != nil {
-		t.Fatalf("loadResults() should not error for missing dir: %v", err)
+		t.Fatalf("LoadResults() should not error for missing dir: %v", err)
 	}
 	if len(results) != 0 {
 		t.Errorf("expected 0 results, got %d", len(results))
@@ -328,7 +637,7 @@ func TestAssembleResults(t *testing.T) {
 
 	// Run assemble (without publishing).
 	outputDir := filepath.Join(dir, "charts")
-	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", false); err != nil {
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", "", false, false, false, false, "", "", ReportsModeReferrer, "", "", OnConflictBump); err != nil {
 		t.Fatalf("AssembleResults() error: %v", err)
 	}
 
@@ -355,6 +664,13 @@ func TestAssembleResults(t *testing.T) {
 		t.Errorf("vuln predicate should be created: %v", err)
 	}
 
+	// Verify vuln delta predicate was generated (no PatchedReportPath on
+	// this fixture's result, so it should still be created, just empty).
+	vulnDeltaPath := filepath.Join(outputDir, "myapp", "vuln-delta-predicate.json")
+	if _, err := os.Stat(vulnDeltaPath); err != nil {
+		t.Errorf("vuln delta predicate should be created: %v", err)
+	}
+
 	// Verify published-charts.json was created.
 	publishedPath := filepath.Join(outputDir, "published-charts.json")
 	if _, err := os.Stat(publishedPath); err != nil {
@@ -369,6 +685,281 @@ func TestAssembleResults(t *testing.T) {
 	}
 }
 
+// TestAssembleResultsWritesSummary covers the --summary-file output: one
+// AssembleSummaryChart entry per wrapper produced, carrying the fields a
+// GitOps/attestation consumer needs without re-parsing stdout or
+// published-charts.json.
+func TestAssembleResultsWritesSummary(t *testing.T) {
+	dir := t.TempDir()
+
+	manifest := DiscoveryManifest{
+		Charts: []ChartDiscovery{
+			{
+				Name:       "myapp",
+				Version:    "1.0.0",
+				Repository: "oci://ghcr.io/charts",
+				Images: []ImageDiscovery{
+					{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "image"},
+				},
+			},
+		},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(manifestPath, manifestData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resultsDir := filepath.Join(dir, "results")
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	result := SinglePatchResult{
+		ImageRef:          "docker.io/library/nginx:1.25",
+		PatchedRegistry:   "ghcr.io/test",
+		PatchedRepository: "library/nginx",
+		PatchedTag:        "1.25-patched",
+		VulnCount:         2,
+		Changed:           true,
+	}
+	rData, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultFile := filepath.Join(resultsDir, sanitize("docker.io/library/nginx:1.25")+".json")
+	if err := os.WriteFile(resultFile, rData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	reportData := []byte(`{"Results":[{"Vulnerabilities":[{"FixedVersion":"1.0","VulnerabilityID":"CVE-2024-0001"}]}]}`)
+	reportFile := filepath.Join(reportsDir, sanitize("docker.io/library/nginx:1.25")+".json")
+	if err := os.WriteFile(reportFile, reportData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := filepath.Join(dir, "charts")
+	summaryPath := filepath.Join(dir, "assemble-summary.json")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", "", false, false, false, false, "", "", ReportsModeReferrer, "", summaryPath, OnConflictBump); err != nil {
+		t.Fatalf("AssembleResults() error: %v", err)
+	}
+
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("reading assemble-summary.json: %v", err)
+	}
+	var summary []AssembleSummaryChart
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("parsing assemble-summary.json: %v", err)
+	}
+	if len(summary) != 1 {
+		t.Fatalf("expected 1 summary entry, got %d", len(summary))
+	}
+
+	entry := summary[0]
+	if entry.Name != "myapp" || entry.SourceRepository != "oci://ghcr.io/charts" || entry.SourceVersion != "1.0.0" {
+		t.Errorf("unexpected chart identity: %+v", entry)
+	}
+	if entry.ValuesHash == "" {
+		t.Errorf("expected a non-empty values hash")
+	}
+	if entry.Published {
+		t.Errorf("expected Published=false: no --publish was requested")
+	}
+	if entry.Digest != "" {
+		t.Errorf("expected empty Digest: no --publish was requested, got %q", entry.Digest)
+	}
+	if len(entry.ResultFiles) != 1 || entry.ResultFiles[0] != resultFile {
+		t.Errorf("expected ResultFiles = [%q], got %v", resultFile, entry.ResultFiles)
+	}
+	if len(entry.ReportPaths) != 1 || entry.ReportPaths[0] != reportFile {
+		t.Errorf("expected ReportPaths = [%q], got %v", reportFile, entry.ReportPaths)
+	}
+}
+
+// TestAssembleResultsWritesOrphanImagePatches covers an image discovered
+// with no owning chart (e.g. via ClusterSource, tagged "pod/..." in Path):
+// manifest.Charts has no entry for it, so AssembleResults must skip
+// wrapper-chart creation and instead record its patched ref in
+// patched-images.json.
+func TestAssembleResultsWritesOrphanImagePatches(t *testing.T) {
+	dir := t.TempDir()
+
+	manifest := DiscoveryManifest{
+		Images: []ImageDiscovery{
+			{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "pod/default/web-0/nginx"},
+		},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(manifestPath, manifestData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resultsDir := filepath.Join(dir, "results")
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	result := SinglePatchResult{
+		ImageRef:          "docker.io/library/nginx:1.25",
+		PatchedRegistry:   testRegistry,
+		PatchedRepository: "library/nginx",
+		PatchedTag:        "1.25-patched",
+		VulnCount:         2,
+		Changed:           true,
+	}
+	rData, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resultsDir, sanitize("docker.io/library/nginx:1.25")+".json"), rData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := filepath.Join(dir, "charts")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", "", false, false, false, false, "", "", ReportsModeReferrer, "", "", OnConflictBump); err != nil {
+		t.Fatalf("AssembleResults() error: %v", err)
+	}
+
+	chartYaml := filepath.Join(outputDir, "standalone", "Chart.yaml")
+	if _, err := os.Stat(chartYaml); !os.IsNotExist(err) {
+		t.Errorf("no wrapper chart should be created for an orphan image")
+	}
+
+	patchedPath := filepath.Join(outputDir, "patched-images.json")
+	data, err := os.ReadFile(patchedPath)
+	if err != nil {
+		t.Fatalf("patched-images.json should be created: %v", err)
+	}
+	var patches map[string]string
+	if err := json.Unmarshal(data, &patches); err != nil {
+		t.Fatalf("unmarshaling patched-images.json: %v", err)
+	}
+	want := fmt.Sprintf("%s/library/nginx:1.25-patched", testRegistry)
+	if got := patches["docker.io/library/nginx:1.25"]; got != want {
+		t.Errorf("patched-images.json[docker.io/library/nginx:1.25] = %q, want %q", got, want)
+	}
+}
+
+// TestAssembleResultsWithStarter mirrors TestAssembleResults, but the chart
+// declares a starter that contributes an extra NetworkPolicy template. The
+// built-in Chart.yaml/values.yaml/SBOM/vuln-predicate outputs must still be
+// produced exactly as without a starter, alongside the starter's template
+// with its <CHARTNAME> token rewritten.
+func TestAssembleResultsWithStarter(t *testing.T) {
+	dir := t.TempDir()
+
+	// Install a starter with an extra NetworkPolicy template.
+	startersDir := filepath.Join(dir, "starters")
+	starterTemplatesDir := filepath.Join(startersDir, "locked-down", "templates")
+	if err := os.MkdirAll(starterTemplatesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	netpol := "apiVersion: networking.k8s.io/v1\nkind: NetworkPolicy\nmetadata:\n  name: <CHARTNAME>-deny-all\nspec:\n  podSelector: {}\n  policyTypes: [Ingress, Egress]\n"
+	if err := os.WriteFile(filepath.Join(starterTemplatesDir, "networkpolicy.yaml"), []byte(netpol), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write manifest, pointing myapp at the installed starter.
+	manifest := DiscoveryManifest{
+		Charts: []ChartDiscovery{
+			{
+				Name:       "myapp",
+				Version:    "1.0.0",
+				Repository: "oci://ghcr.io/charts",
+				Starter:    "locked-down",
+				Images: []ImageDiscovery{
+					{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "image"},
+				},
+			},
+		},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(manifestPath, manifestData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write result.
+	resultsDir := filepath.Join(dir, "results")
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	result := SinglePatchResult{
+		ImageRef:          "docker.io/library/nginx:1.25",
+		PatchedRegistry:   "ghcr.io/test",
+		PatchedRepository: "library/nginx",
+		PatchedTag:        "1.25-patched",
+		VulnCount:         2,
+		Changed:           true,
+	}
+	rData, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resultsDir, sanitize("docker.io/library/nginx:1.25")+".json"), rData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write a trivy report.
+	reportsDir := filepath.Join(dir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	reportData := []byte(`{"Results":[{"Vulnerabilities":[{"FixedVersion":"1.0","VulnerabilityID":"CVE-2024-0001"}]}]}`)
+	if err := os.WriteFile(filepath.Join(reportsDir, sanitize("docker.io/library/nginx:1.25")+".json"), reportData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run assemble (without publishing).
+	outputDir := filepath.Join(dir, "charts")
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", startersDir, false, false, false, false, "", "", ReportsModeReferrer, "", "", OnConflictBump); err != nil {
+		t.Fatalf("AssembleResults() error: %v", err)
+	}
+
+	// Verify the built-in outputs still exist.
+	for _, rel := range []string{
+		filepath.Join("myapp", "Chart.yaml"),
+		filepath.Join("myapp", "values.yaml"),
+		filepath.Join("myapp", "sbom.cdx.json"),
+		filepath.Join("myapp", "vuln-predicate.json"),
+	} {
+		if _, err := os.Stat(filepath.Join(outputDir, rel)); err != nil {
+			t.Errorf("%s not created: %v", rel, err)
+		}
+	}
+
+	// Verify the starter's extra template was copied with its token rewritten.
+	netpolPath := filepath.Join(outputDir, "myapp", "templates", "networkpolicy.yaml")
+	got, err := os.ReadFile(netpolPath)
+	if err != nil {
+		t.Fatalf("starter template not copied: %v", err)
+	}
+	if !strings.Contains(string(got), "myapp-deny-all") {
+		t.Errorf("expected <CHARTNAME> token rewritten to myapp, got:\n%s", got)
+	}
+	if strings.Contains(string(got), "<CHARTNAME>") {
+		t.Errorf("expected no unreplaced <CHARTNAME> token, got:\n%s", got)
+	}
+}
+
 func TestBuildPatchResultsMissingResult(t *testing.T) {
 	images := []ImageDiscovery{
 		{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Path: "image"},
@@ -518,7 +1109,7 @@ func TestAssembleResultsSkipsUnchangedCharts(t *testing.T) {
 
 	// Run assemble.
 	outputDir := filepath.Join(dir, "charts")
-	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", false); err != nil {
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "", "", false, false, false, false, "", "", ReportsModeReferrer, "", "", OnConflictBump); err != nil {
 		t.Fatalf("AssembleResults() error: %v", err)
 	}
 
@@ -619,7 +1210,9 @@ func TestAssembleResultsProcessesChangedCharts(t *testing.T) {
 
 	// Run assemble.
 	outputDir := filepath.Join(dir, "charts")
-	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "ghcr.io/test", false); err != nil {
+	// legacyNumericVersions=true: preserves the "{version}-{N}" assertion
+	// below, which predates content-addressable versioning.
+	if err := AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, "ghcr.io/test", "", false, false, true, false, "", "", ReportsModeReferrer, "", "", OnConflictBump); err != nil {
 		t.Fatalf("AssembleResults() error: %v", err)
 	}
 
@@ -680,3 +1273,118 @@ func TestAssembleResultsProcessesChangedCharts(t *testing.T) {
 		t.Errorf("expected 1 image in published chart, got %d", len(charts[0].Images))
 	}
 }
+
+// chartTarballFixture serves a handful of minimal chart tarballs (each with
+// one container image in its values.yaml) over HTTP, so
+// discoverDependenciesConcurrently can exercise the real DownloadChart/
+// ScanForImages path without reaching an actual chart repo.
+func chartTarballFixture(t testing.TB, n int) (*httptest.Server, []Dependency) {
+	t.Helper()
+
+	var deps []Dependency
+	mux := http.NewServeMux()
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("dep%d", i)
+		path := "/" + name + ".tgz"
+		tarball := buildTestChartTarball(t, name, fmt.Sprintf("quay.io/verity-test/%s:1.0.%d", name, i))
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(tarball)
+		})
+		deps = append(deps, Dependency{Name: name, Version: "1.0.0", Repository: "PLACEHOLDER" + path})
+	}
+
+	srv := httptest.NewServer(mux)
+	for i := range deps {
+		deps[i].Repository = srv.URL + strings.TrimPrefix(deps[i].Repository, "PLACEHOLDER")
+	}
+	return srv, deps
+}
+
+// buildTestChartTarball packages a minimal "<name>/Chart.yaml" +
+// "<name>/values.yaml" (one image field, non-empty tag so ScanForImages
+// never needs to hit a real registry to resolve it from appVersion) into
+// the .tgz bytes DownloadChart's downloadTarball path expects.
+func buildTestChartTarball(t testing.TB, name, imageRef string) []byte {
+	t.Helper()
+
+	registry, repository, tag := ParseImageRef(imageRef)
+	chartYAML := fmt.Sprintf("apiVersion: v2\nname: %s\nversion: 1.0.0\n", name)
+	valuesYAML := fmt.Sprintf("image:\n  registry: %s\n  repository: %s\n  tag: %q\n", registry, repository, tag)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for path, content := range map[string]string{
+		name + "/Chart.yaml":  chartYAML,
+		name + "/values.yaml": valuesYAML,
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: path, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("writing tar header for %s: %v", path, err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("writing tar content for %s: %v", path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// sortedChartNames returns charts' Names sorted, so results from two
+// concurrency levels (whose ChartDiscovery order reflects deps' original
+// order either way) can be compared without depending on that order.
+func sortedChartNames(charts []ChartDiscovery) []string {
+	names := make([]string, len(charts))
+	for i, c := range charts {
+		names[i] = c.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestDiscoverDependenciesConcurrentlyMatchesSequential(t *testing.T) {
+	srv, deps := chartTarballFixture(t, 8)
+	defer srv.Close()
+
+	seqCharts, seqImages, seqReports := discoverDependenciesConcurrently(deps, t.TempDir(), 1)
+	parCharts, parImages, parReports := discoverDependenciesConcurrently(deps, t.TempDir(), 4)
+
+	if len(seqReports) != 0 || len(parReports) != 0 {
+		t.Fatalf("expected no failures, got sequential=%v concurrent=%v", seqReports, parReports)
+	}
+	if !slices.Equal(sortedChartNames(seqCharts), sortedChartNames(parCharts)) {
+		t.Errorf("chart names differ between Concurrency=1 and Concurrency=4: %v vs %v",
+			sortedChartNames(seqCharts), sortedChartNames(parCharts))
+	}
+	if len(seqCharts) != len(deps) || len(parCharts) != len(deps) {
+		t.Fatalf("expected %d charts discovered, got sequential=%d concurrent=%d", len(deps), len(seqCharts), len(parCharts))
+	}
+
+	seqRefs := make([]string, len(seqImages))
+	for i, img := range seqImages {
+		seqRefs[i] = img.Reference()
+	}
+	parRefs := make([]string, len(parImages))
+	for i, img := range parImages {
+		parRefs[i] = img.Reference()
+	}
+	sort.Strings(seqRefs)
+	sort.Strings(parRefs)
+	if !slices.Equal(seqRefs, parRefs) {
+		t.Errorf("discovered images differ between Concurrency=1 and Concurrency=4:\n  sequential: %v\n  concurrent: %v", seqRefs, parRefs)
+	}
+}
+
+func BenchmarkDiscoverDependenciesConcurrently(b *testing.B) {
+	srv, deps := chartTarballFixture(b, 16)
+	defer srv.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		discoverDependenciesConcurrently(deps, b.TempDir(), runtime.NumCPU())
+	}
+}
@@ -1,17 +1,38 @@
 package internal
 
 import (
+	"archive/tar"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/moby/buildkit/client"
+	copapatch "github.com/project-copacetic/copacetic/pkg/patch"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+
+	trivyArtifact "github.com/aquasecurity/trivy/pkg/commands/artifact"
+	trivyFlag "github.com/aquasecurity/trivy/pkg/flag"
+	trivyTypes "github.com/aquasecurity/trivy/pkg/types"
+
+	"github.com/verity-org/verity/internal/attest"
+	"github.com/verity-org/verity/internal/scanner"
 )
 
 // Skip reason constants for consistent change detection.
@@ -35,6 +56,199 @@ type PatchOptions struct {
 
 	// WorkDir is a temporary directory for storing OCI image layouts.
 	WorkDir string
+
+	// SourceLayout, when set, reads every source image from a local OCI
+	// image-layout directory (TransportOCI, e.g. "oci:/mnt/bundle") or a
+	// tar of one (TransportOCIArchive, e.g.
+	// "oci-archive:/mnt/bundle.tar") instead of pulling img.Reference()
+	// from a live registry (see pullImageOCI). Images are looked up inside
+	// the layout by an "org.opencontainers.image.ref.name" annotation
+	// matching img.Reference(), the same convention
+	// ExportStandaloneReportsOCILayout/importStandaloneReportsOCILayout
+	// already use for the standalone-reports artifact, so one layout
+	// directory can carry more than one image.
+	SourceLayout string
+
+	// DestLayout, when set, writes the patched image into a local OCI
+	// image-layout directory (see pushToLayout) instead of pushing it to
+	// TargetRegistry. TargetRegistry is still used to name the result (so
+	// PatchResult.Patched and downstream manifests/wrapper charts see a
+	// normal-looking ref), but no network push happens, and the
+	// already-patched-in-registry fast path and cosign signing/attestation
+	// (which need a reachable registry artifact) are both skipped. Pairs
+	// with SourceLayout to let the whole discover/patch/assemble pipeline
+	// run against one bundle transferred into and back out of a
+	// disconnected build environment.
+	DestLayout string
+
+	// Scanner selects the vulnerability scanning backend: "trivy-exec"
+	// (default, scans the pulled OCI layout with the trivy CLI), "trivy"
+	// (native library, scans the image ref directly), "grype", or "clair".
+	// See internal/scanner for the Scanner interface.
+	Scanner string
+
+	// ScannerConfigPath, when set, is a scanners.yaml loaded for per-backend
+	// settings (e.g. Clair indexer/matcher addresses) not already covered by
+	// DockerHost. See internal/scanner.Config.
+	ScannerConfigPath string
+
+	// DockerHost, when set, is passed through to scanner backends that
+	// support scanning images from a local Docker socket.
+	DockerHost string
+
+	// BuildKit, when set, is a shared BuildKit client reused across
+	// PatchImage calls (see BuildKitSession). PatchAllCommand sets this so
+	// its --buildkit-workers fan-out patches every image against one
+	// daemon connection instead of dialing (and having Copa launch) a
+	// fresh BuildKit session per image. PatchCommand leaves it nil, and
+	// PatchImage dials a one-off client from BuildKitAddr instead.
+	BuildKit *BuildKitSession
+
+	// Platforms, when set, restricts a multi-arch image (see
+	// resolveImageIndex, patchMultiArch) to only the listed platforms,
+	// instead of patching every platform the upstream manifest list
+	// covers. Ignored for single-arch images. Unmatched entries are
+	// silently ignored, since not every upstream image publishes every
+	// platform a fleet-wide override list might name.
+	Platforms []Platform
+
+	// Sign requests that PatchImage cosign-sign each patched image it
+	// pushes to TargetRegistry, and attach the attestations named in
+	// Attestations — the same sign-then-attest step PushStandaloneReports
+	// and PublishChart already offer for their own artifacts (see
+	// internal/attest). Ignored when TargetRegistry is empty, since cosign
+	// has nothing to attach a signature/attestation to without a pushed
+	// registry artifact.
+	Sign bool
+
+	// SignKey is a path to a cosign private key. Empty uses cosign's
+	// keyless (Fulcio/OIDC) flow, mirroring PushStandaloneReports's
+	// signKey. Ignored unless Sign is set.
+	SignKey string
+
+	// Attestations selects which in-toto attestations to attach when Sign
+	// is set: "vuln" attaches the Trivy report for the patched image (the
+	// "reduced Critical from 12 to 0" evidence, see PatchResult.After),
+	// "sbom" generates and attaches a CycloneDX SBOM via
+	// attest.GenerateSBOM. Unrecognized entries are logged and skipped.
+	Attestations []string
+
+	// Concurrency bounds how many images PatchImages patches at once.
+	// Defaults to GOMAXPROCS when <= 0. Ignored by PatchImage itself,
+	// which always patches a single image.
+	Concurrency int
+
+	// PullConcurrency, ScanConcurrency, and PatchConcurrency further bound
+	// how many pulls/scans/Copa solves PatchImages runs at once across the
+	// whole fan-out, independent of Concurrency: pulls are I/O-heavy but
+	// cheap to run many at once, while a Copa solve holds a BuildKit
+	// worker for the duration, so a caller patching 200 images might want
+	// 8 pulls overlapping with only 2 solves. Each defaults to
+	// Concurrency when <= 0, so setting only Concurrency behaves as one
+	// shared limit the way PatchImage always did.
+	PullConcurrency  int
+	ScanConcurrency  int
+	PatchConcurrency int
+
+	// RegistryQPS caps how many images PatchImages starts pulling per
+	// second from a given source registry host (img.Registry), to avoid
+	// tripping Docker Hub-style 429 rate limits when a fan-out of workers
+	// all pull from the same upstream at once. A registry absent from the
+	// map is unlimited.
+	RegistryQPS map[string]float64
+
+	// stages holds the pipeline-stage semaphores PatchImages sets up
+	// before fanning out; nil when PatchImage is called directly (e.g.
+	// PatchSingleImage), which skips this extra gating and relies solely
+	// on its caller's own concurrency control.
+	stages *patchStages
+}
+
+// patchStages holds the semaphores that gate each pipeline stage
+// (pull/scan/patch) across every image a PatchImages call is fanning out,
+// so e.g. many pulls can overlap with just a couple of Copa solves. Each
+// channel's capacity is its stage's configured concurrency.
+type patchStages struct {
+	pull  chan struct{}
+	scan  chan struct{}
+	patch chan struct{}
+}
+
+// acquireStage blocks until sem has room, or returns ctx's error if it's
+// canceled first. A nil sem (PatchImage called outside PatchImages) means
+// no additional gating: the returned release is a no-op.
+func acquireStage(ctx context.Context, sem chan struct{}) (func(), error) {
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// withStage runs fn gated by sem (see acquireStage), for the common case
+// of a single operation per stage acquisition.
+func withStage(ctx context.Context, sem chan struct{}, fn func() error) error {
+	release, err := acquireStage(ctx, sem)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+func (o PatchOptions) pullSem() chan struct{} {
+	if o.stages == nil {
+		return nil
+	}
+	return o.stages.pull
+}
+
+func (o PatchOptions) scanSem() chan struct{} {
+	if o.stages == nil {
+		return nil
+	}
+	return o.stages.scan
+}
+
+func (o PatchOptions) patchSem() chan struct{} {
+	if o.stages == nil {
+		return nil
+	}
+	return o.stages.patch
+}
+
+// BuildKitSession holds a BuildKit client connection that can be shared
+// across multiple PatchImage calls, amortizing the daemon connection and
+// scheduler handshake across an entire matrix instead of paying it once
+// per image.
+type BuildKitSession struct {
+	Client *client.Client
+}
+
+// NewBuildKitSession dials a shared BuildKit client at addr (or Copa's own
+// default of docker-container://buildkitd when addr is empty).
+func NewBuildKitSession(ctx context.Context, addr string) (*BuildKitSession, error) {
+	if addr == "" {
+		addr = "docker-container://buildkitd"
+	}
+	c, err := client.New(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to BuildKit at %s: %w", addr, err)
+	}
+	return &BuildKitSession{Client: c}, nil
+}
+
+// Close releases the underlying BuildKit client connection. It is safe to
+// call on a nil *BuildKitSession.
+func (s *BuildKitSession) Close() error {
+	if s == nil || s.Client == nil {
+		return nil
+	}
+	return s.Client.Close()
 }
 
 // PatchResult holds the outcome of patching a single image.
@@ -47,7 +261,171 @@ type PatchResult struct {
 	Error              error
 	ReportPath         string // Path to Trivy JSON report (may be patched image scan)
 	UpstreamReportPath string // Path to Trivy JSON report of the original upstream image
-	OverriddenFrom     string // Original tag before override (empty if not overridden)
+
+	// PatchedReportPath is the Trivy report from re-scanning the patched
+	// image that was actually pushed as result.Patched (see the residual
+	// scan after copaPatch, and the "existing patched image" reuse branch
+	// above). Empty when Skipped before a patched image was ever scanned
+	// (e.g. SkipReasonNoVulnerabilities, where the "patched" image is just
+	// a mirror of upstream and UpstreamReportPath already covers it), or
+	// on Error. Lets AggregateVulnDeltaPredicate diff upstream vs patched
+	// findings without re-scanning anything itself.
+	PatchedReportPath string
+	OverriddenFrom    string // Original tag before override (empty if not overridden)
+
+	// Platform is the single platform (e.g. "linux/amd64") this PatchResult
+	// covers, set when Original was discovered with more than one platform
+	// (see ImageDiscovery.Platforms and GenerateMatrix's per-platform
+	// fanout). Empty for an ordinary single-platform result — including
+	// every multi-arch image patched through the Platforms/PlatformReports/
+	// Variants/PlatformErrors mechanism below, which is a separate,
+	// pre-existing concern: that mechanism combines every platform of one
+	// image into a single PatchResult and manifest list within one matrix
+	// job, whereas Platform here marks a PatchResult that is itself only
+	// one of several separate matrix jobs/PatchResults for the same image.
+	Platform string
+
+	// Platforms lists the architectures a multi-arch image was patched for
+	// (nil for an ordinary single-arch image — see patchMultiArch). Patched
+	// still names a single reference: it resolves to a combined manifest
+	// list covering every platform here, so callers (GenerateValuesOverride,
+	// CreateWrapperChart) don't need separate per-platform handling for it.
+	Platforms []Platform
+
+	// PlatformReports maps each entry in Platforms (by Platform.String(),
+	// e.g. "linux/amd64") to that platform's own Trivy report path. Empty
+	// for single-arch images, where ReportPath above is the only report.
+	PlatformReports map[string]string
+
+	// PlatformVulnCounts maps each successfully-patched entry in Platforms
+	// (by Platform.String()) to that platform's own fixable vuln count
+	// (the per-platform return value of patchOnePlatform), so
+	// SinglePatchResult.Platforms can carry a VulnCount alongside each
+	// platform's digest/tag instead of only the totalVulns sum on
+	// VulnCount above. Empty for single-arch images.
+	PlatformVulnCounts map[string]int
+
+	// Variants lists each platform's own patched image, before it was
+	// assembled into the combined manifest list Patched names (see
+	// patchMultiArch and BuildImageIndex). Empty for single-arch images.
+	Variants []PatchedVariant
+
+	// PlatformErrors maps a platform (by Platform.String()) to the error
+	// that platform failed with during patchMultiArch, e.g. Copa failing
+	// to find a fix for that architecture's base image. A multi-arch
+	// image with some platforms here and others still represented in
+	// Variants/Patched succeeded partially: Patched names a manifest list
+	// covering only the platforms that made it, not the full set
+	// idx.platforms originally offered. Empty for single-arch images, and
+	// for multi-arch images where every platform succeeded.
+	PlatformErrors map[string]string
+
+	// Before and After are the severity-bucketed vuln counts of the
+	// upstream image and, once Copa has run, the patched image — the
+	// actual "reduced Critical from 12 to 0" figures a patching report
+	// needs, rather than the single collapsed VulnCount. After is the
+	// zero value when nothing was patched (Skipped, or Error set before a
+	// patch was attempted): there's no residual scan to report.
+	Before VulnSummary
+	After  VulnSummary
+
+	// Vulns holds the most recent scan's findings as SiteVuln (the same
+	// shape SiteImage.Vulnerabilities uses downstream, see buildSiteImage):
+	// the patched image's residual vulns once Copa has run and After is
+	// populated, or the upstream image's vulns otherwise. For a multi-arch
+	// image this is the union across every platform's own scan.
+	Vulns []SiteVuln
+
+	// SignatureDigest is the digest cosign signed when opts.Sign is set
+	// (see attest.SignResult.Digest). Empty when signing wasn't
+	// requested, wasn't reached (Skipped/Error), or TargetRegistry is
+	// empty.
+	SignatureDigest string
+
+	// AttestationDigests maps an attestation kind ("vuln", "sbom") to the
+	// reference cosign pushed it under, for each opts.Attestations entry
+	// that was successfully attached.
+	AttestationDigests map[string]string
+}
+
+// PatchedVariant is one platform's own patched image, pushed to the
+// registry under its platform-suffixed tag (see platformSuffix) before
+// pushManifestList assembles every variant into one combined manifest
+// list. SavePlatformVariants persists Digest so later, registry-only
+// site-data generation can show per-platform digests without a repeat
+// HEAD request against the registry.
+type PatchedVariant struct {
+	Platform  Platform
+	Reference string
+	Digest    string
+}
+
+// PatchImages patches imgs concurrently, returning one *PatchResult per
+// image in the same order as imgs (callers can match results to inputs by
+// index, regardless of which goroutine finishes first).
+//
+// Overall concurrency is bounded by opts.Concurrency (default
+// runtime.GOMAXPROCS(0)). Because pulling, scanning, and Copa-patching have
+// very different I/O profiles, each stage is additionally gated by its own
+// semaphore — opts.PullConcurrency, opts.ScanConcurrency, and
+// opts.PatchConcurrency (each defaulting to opts.Concurrency when <= 0) — so
+// e.g. many pulls can overlap with just a couple of Copa solves that are
+// each holding a BuildKit worker. opts.RegistryQPS further throttles how
+// fast images from a given source registry start their pipeline, to avoid
+// tripping Docker Hub-style 429s when a large fan-out all pulls from the
+// same upstream at once.
+func PatchImages(ctx context.Context, imgs []Image, opts PatchOptions) []*PatchResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	pullConcurrency, scanConcurrency, patchConcurrency := opts.PullConcurrency, opts.ScanConcurrency, opts.PatchConcurrency
+	if pullConcurrency <= 0 {
+		pullConcurrency = concurrency
+	}
+	if scanConcurrency <= 0 {
+		scanConcurrency = concurrency
+	}
+	if patchConcurrency <= 0 {
+		patchConcurrency = concurrency
+	}
+
+	fanOpts := opts
+	fanOpts.stages = &patchStages{
+		pull:  make(chan struct{}, pullConcurrency),
+		scan:  make(chan struct{}, scanConcurrency),
+		patch: make(chan struct{}, patchConcurrency),
+	}
+
+	limiters := make(map[string]*rate.Limiter, len(opts.RegistryQPS))
+	for registry, qps := range opts.RegistryQPS {
+		if qps > 0 {
+			limiters[registry] = rate.NewLimiter(rate.Limit(qps), 1)
+		}
+	}
+
+	results := make([]*PatchResult, len(imgs))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for i, img := range imgs {
+		i, img := i, img
+		eg.Go(func() error {
+			if limiter, ok := limiters[img.Registry]; ok {
+				if err := limiter.Wait(egCtx); err != nil {
+					results[i] = &PatchResult{Original: img, Error: fmt.Errorf("waiting for %s rate limiter: %w", img.Registry, err)}
+					return nil
+				}
+			}
+			// Never propagate a per-image error: one image's failure is
+			// recorded on its own PatchResult.Error and must not cancel
+			// or truncate the rest of the batch.
+			results[i] = PatchImage(egCtx, img, fanOpts)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return results
 }
 
 // PatchImage scans an image for OS vulnerabilities using Trivy,
@@ -58,7 +436,19 @@ type PatchResult struct {
 // image already exists there. If so, it scans the patched image
 // instead of the upstream — skipping entirely when no new fixable
 // vulns are found, or re-patching from upstream when they are.
+//
+// When img's tag resolves to a multi-arch manifest list with more than one
+// platform, patching is delegated to patchMultiArch instead: every
+// platform is patched independently and republished under one combined
+// manifest list, so the "existing patched image" reuse check above doesn't
+// apply to multi-arch images.
 func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult { //nolint:gocognit,gocyclo,cyclop,funlen // complex workflow
+	if idx, err := resolveImageIndex(ctx, img.Reference()); err != nil {
+		fmt.Printf("    WARN: could not inspect %s for a multi-arch manifest list: %v\n", img.Reference(), err)
+	} else if len(idx.platforms) > 1 {
+		return patchMultiArch(ctx, img, idx, opts)
+	}
+
 	result := &PatchResult{Original: img}
 
 	tag := img.Tag
@@ -67,8 +457,10 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	}
 	patchedTag := tag + "-patched"
 
-	// Check if a patched image already exists in the target registry.
-	if opts.TargetRegistry != "" { //nolint:nestif // patching workflow
+	// Check if a patched image already exists in the target registry. Not
+	// applicable in DestLayout mode: there's no live registry to check, so
+	// every image is treated as needing a fresh patch.
+	if opts.TargetRegistry != "" && opts.DestLayout == "" { //nolint:nestif // patching workflow
 		patchedRef := Image{
 			Registry:   opts.TargetRegistry,
 			Repository: img.Repository,
@@ -79,14 +471,17 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 
 			// Scan the existing patched image for new fixable vulns.
 			ociDir := filepath.Join(opts.WorkDir, "oci", sanitize(patchedRef.Reference()))
-			if err := pullAndSaveOCI(ctx, patchedRef.Reference(), ociDir); err != nil {
+			if err := withStage(ctx, opts.pullSem(), func() error { return pullImageOCI(ctx, opts, patchedRef.Reference(), ociDir) }); err != nil {
 				result.Error = fmt.Errorf("pulling patched image %s: %w", patchedRef.Reference(), err)
 				return result
 			}
 
 			reportPath := filepath.Join(opts.ReportDir, sanitize(patchedRef.Reference())+".json")
 			result.ReportPath = reportPath
-			if err := trivyScan(ctx, ociDir, reportPath); err != nil {
+			result.PatchedReportPath = reportPath
+			if err := withStage(ctx, opts.scanSem(), func() error {
+				return scanWithBackend(ctx, opts, patchedRef.Reference(), ociDir, reportPath)
+			}); err != nil {
 				result.Error = fmt.Errorf("scanning patched image %s: %w", patchedRef.Reference(), err)
 				return result
 			}
@@ -101,15 +496,29 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 			upstreamRef := img.Reference()
 			upstreamOciDir := filepath.Join(opts.WorkDir, "oci", sanitize(upstreamRef))
 			upstreamReportPath := filepath.Join(opts.ReportDir, sanitize(upstreamRef)+".json")
-			if err := pullAndSaveOCI(ctx, upstreamRef, upstreamOciDir); err != nil {
+			if err := withStage(ctx, opts.pullSem(), func() error { return pullImageOCI(ctx, opts, upstreamRef, upstreamOciDir) }); err != nil {
 				fmt.Printf("    WARN: could not pull upstream %s for report: %v\n", upstreamRef, err)
-			} else if err := trivyScan(ctx, upstreamOciDir, upstreamReportPath); err != nil {
+			} else if err := withStage(ctx, opts.scanSem(), func() error {
+				return scanWithBackend(ctx, opts, upstreamRef, upstreamOciDir, upstreamReportPath)
+			}); err != nil {
 				fmt.Printf("    WARN: could not scan upstream %s for report: %v\n", upstreamRef, err)
 			} else {
 				result.UpstreamReportPath = upstreamReportPath
+				if before, beforeVulns, err := loadVulnStats(upstreamReportPath); err != nil {
+					fmt.Printf("    WARN: could not load vuln stats for %s: %v\n", upstreamRef, err)
+				} else {
+					result.Before = before
+					result.Vulns = beforeVulns
+				}
 			}
 
 			if vulns == 0 {
+				if after, residual, err := loadVulnStats(reportPath); err != nil {
+					fmt.Printf("    WARN: could not load vuln stats for %s: %v\n", patchedRef.Reference(), err)
+				} else {
+					result.After = after
+					result.Vulns = residual
+				}
 				result.Skipped = true
 				result.SkipReason = SkipReasonUpToDate
 				result.Patched = patchedRef
@@ -124,7 +533,7 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	// Normal flow: pull upstream, scan, patch, push.
 	ref := img.Reference()
 	ociDir := filepath.Join(opts.WorkDir, "oci", sanitize(ref))
-	if err := pullAndSaveOCI(ctx, ref, ociDir); err != nil {
+	if err := withStage(ctx, opts.pullSem(), func() error { return pullImageOCI(ctx, opts, ref, ociDir) }); err != nil {
 		result.Error = fmt.Errorf("pulling %s: %w", ref, err)
 		return result
 	}
@@ -132,7 +541,7 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	reportPath := filepath.Join(opts.ReportDir, sanitize(ref)+".json")
 	result.ReportPath = reportPath
 	result.UpstreamReportPath = reportPath
-	if err := trivyScan(ctx, ociDir, reportPath); err != nil {
+	if err := withStage(ctx, opts.scanSem(), func() error { return scanWithBackend(ctx, opts, ref, ociDir, reportPath) }); err != nil {
 		result.Error = fmt.Errorf("scanning %s: %w", ref, err)
 		return result
 	}
@@ -144,6 +553,18 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	}
 	result.VulnCount = vulns
 
+	// Skip if we already have Before/Vulns from the "existing patched
+	// image found" branch above falling through to re-patch: reportPath
+	// here is the same upstream ref it already scanned.
+	if result.Vulns == nil {
+		if before, beforeVulns, err := loadVulnStats(reportPath); err != nil {
+			fmt.Printf("    WARN: could not load vuln stats for %s: %v\n", ref, err)
+		} else {
+			result.Before = before
+			result.Vulns = beforeVulns
+		}
+	}
+
 	if vulns == 0 { //nolint:nestif // early exit logic
 		result.Skipped = true
 		result.SkipReason = SkipReasonNoVulnerabilities
@@ -151,25 +572,56 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 		// Mirror the image to the target registry even when no patching is
 		// needed, so consumers always see the latest version available and
 		// have a clear upgrade path.
-		if opts.TargetRegistry != "" {
+		if opts.TargetRegistry != "" { //nolint:nestif // patching workflow
 			target := Image{
 				Registry:   opts.TargetRegistry,
 				Repository: img.Repository,
 				Tag:        patchedTag,
 			}
+			if opts.DestLayout != "" {
+				// No registry involved: reuse the layout ociDir already
+				// scanned above instead of a crane.Copy, which only knows
+				// how to mirror between live registries.
+				srcImg, err := imageFromOCIDir(ociDir)
+				if err != nil {
+					result.Error = fmt.Errorf("reading %s for layout mirror: %w", ref, err)
+					return result
+				}
+				if err := appendToLayout(opts.DestLayout, srcImg, target.Reference()); err != nil {
+					result.Error = fmt.Errorf("writing %s to OCI layout: %w", target.Reference(), err)
+					return result
+				}
+				result.Patched = target
+				return result
+			}
 			if err := mirrorImage(ctx, ref, target.Reference()); err != nil {
 				result.Error = fmt.Errorf("mirroring %s to %s: %w", ref, target.Reference(), err)
 				return result
 			}
 			result.Patched = target
+			signAndAttestPatched(ctx, target.Reference(), reportPath, opts, result)
 		} else {
 			result.Patched = img
 		}
 		return result
 	}
 
-	// Patch with Copa (requires BuildKit).
-	if err := copaPatch(ctx, ref, reportPath, patchedTag, opts.BuildKitAddr); err != nil {
+	// Patch with Copa's library entry point, reusing opts.BuildKit's shared
+	// client when set instead of dialing a new one.
+	bkClient, closeBkClient, err := buildkitClientFor(ctx, opts)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer closeBkClient()
+
+	// NOTE: even with SourceLayout set, this step still has BuildKit pull
+	// ref itself (copapatch.Options.Image) to build from - copaPatch has no
+	// equivalent of pullFromLayout, since Copa isn't ours to change.
+	// SourceLayout/DestLayout cover the scan/push legs of the pipeline that
+	// are; a fully disconnected Copa solve needs BuildKit's own registry
+	// mirror configured to resolve ref from the transferred bundle.
+	if err := withStage(ctx, opts.patchSem(), func() error { return copaPatch(ctx, bkClient, ref, reportPath, patchedTag) }); err != nil {
 		result.Error = fmt.Errorf("patching %s: %w", ref, err)
 		return result
 	}
@@ -177,18 +629,48 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	localPatched := img
 	localPatched.Tag = patchedTag
 
-	// Optionally push to target registry.
+	// Re-scan the freshly patched local image so After reflects the
+	// residual vulns that had no fix available, rather than leaving it at
+	// the zero value. Best-effort: a failure here shouldn't block the
+	// push of an image Copa already patched successfully.
+	patchedRef := localPatched.Reference()
+	patchedOciDir := filepath.Join(opts.WorkDir, "oci", sanitize(patchedRef)+"-after")
+	patchedReportPath := filepath.Join(opts.ReportDir, sanitize(patchedRef)+"-after.json")
+	if err := saveLocalOCI(ctx, patchedRef, patchedOciDir); err != nil {
+		fmt.Printf("    WARN: could not save patched image %s for residual scan: %v\n", patchedRef, err)
+	} else if err := withStage(ctx, opts.scanSem(), func() error {
+		return scanWithBackend(ctx, opts, patchedRef, patchedOciDir, patchedReportPath)
+	}); err != nil {
+		fmt.Printf("    WARN: could not scan patched image %s for residual vulns: %v\n", patchedRef, err)
+	} else if after, residual, err := loadVulnStats(patchedReportPath); err != nil {
+		fmt.Printf("    WARN: could not load vuln stats for %s: %v\n", patchedRef, err)
+	} else {
+		result.After = after
+		result.Vulns = residual
+		result.PatchedReportPath = patchedReportPath
+	}
+
+	// Optionally push to target registry (or write to DestLayout instead).
 	if opts.TargetRegistry != "" {
 		target := Image{
 			Registry:   opts.TargetRegistry,
 			Repository: img.Repository,
 			Tag:        patchedTag,
 		}
+		if opts.DestLayout != "" {
+			if err := pushToLayout(ctx, localPatched.Reference(), target.Reference(), opts.DestLayout); err != nil {
+				result.Error = fmt.Errorf("writing %s to OCI layout: %w", target.Reference(), err)
+				return result
+			}
+			result.Patched = target
+			return result
+		}
 		if err := pushLocal(ctx, localPatched.Reference(), target.Reference()); err != nil {
 			result.Error = fmt.Errorf("pushing %s: %w", target.Reference(), err)
 			return result
 		}
 		result.Patched = target
+		signAndAttestPatched(ctx, target.Reference(), patchedReportPath, opts, result)
 	} else {
 		result.Patched = localPatched
 	}
@@ -196,15 +678,16 @@ func PatchImage(ctx context.Context, img Image, opts PatchOptions) *PatchResult
 	return result
 }
 
-// imageExists checks whether an image reference exists in a remote registry
-// using a HEAD request (crane.Head). Returns false on any error.
+// imageExists checks whether ref exists in its registry, via defaultProber
+// (see tagprober.go) — constrained to linux/amd64 (the same platform
+// pullAndSaveOCI below pulls for scanning), so a tag published only for
+// windows/amd64 reports not-found the same as a missing one, rather than
+// "exists" for a Linux-targeted patch run. Returns false on any error,
+// including a real network/auth failure; callers needing to distinguish
+// those should use defaultProber's TagProber methods directly.
 func imageExists(ctx context.Context, ref string) bool {
-	opts := []crane.Option{
-		crane.WithAuthFromKeychain(authn.DefaultKeychain),
-		crane.WithContext(ctx),
-	}
-	_, err := crane.Head(ref, opts...)
-	return err == nil
+	exists, err := defaultProber().Exists(ctx, ref)
+	return err == nil && exists
 }
 
 // pullAndSaveOCI pulls an image from a registry using go-containerregistry
@@ -231,45 +714,488 @@ func pullAndSaveOCI(ctx context.Context, imageRef, ociDir string) error {
 	return nil
 }
 
-// trivyScan runs the trivy CLI to scan an OCI image layout for OS vulnerabilities.
+// saveLocalOCI saves a local (not-yet-pushed) Docker image as an OCI layout
+// directory for offline scanning, the same way pullAndSaveOCI does for a
+// registry ref. It's what lets PatchImage re-scan the image Copa just
+// produced for residual vulns (see PatchResult.After) before the patched
+// image is pushed anywhere: `docker save` + crane.Load is the same
+// local-image-to-v1.Image path pushLocal already uses to get a patched
+// Docker/BuildKit image into a form go-containerregistry can push.
+func saveLocalOCI(ctx context.Context, localRef, ociDir string) error {
+	tmp, err := os.CreateTemp("", "verity-local-image-*.tar")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	save := exec.CommandContext(ctx, "docker", "save", "-o", tmp.Name(), localRef)
+	save.Stdout = os.Stdout
+	save.Stderr = os.Stderr
+	if err := save.Run(); err != nil {
+		return fmt.Errorf("docker save %s: %w", localRef, err)
+	}
+
+	img, err := crane.Load(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("loading image %s: %w", localRef, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ociDir), 0o755); err != nil {
+		return err
+	}
+	if err := crane.SaveOCI(img, ociDir); err != nil {
+		return fmt.Errorf("saving OCI layout for %s: %w", localRef, err)
+	}
+	return nil
+}
+
+// pullImageOCI pulls ref into ociDir for scanning, either from a live
+// registry (pullAndSaveOCI) or, when opts.SourceLayout is set, from a
+// local OCI image-layout directory/archive (pullFromLayout) instead - the
+// one switch that lets every PatchImage call site that scans a ref run
+// disconnected from any registry.
+func pullImageOCI(ctx context.Context, opts PatchOptions, ref, ociDir string) error {
+	if opts.SourceLayout != "" {
+		return pullFromLayout(opts.SourceLayout, ref, ociDir)
+	}
+	return pullAndSaveOCI(ctx, ref, ociDir)
+}
+
+// pullFromLayout reads ref's image content from a local OCI image-layout
+// directory (TransportOCI) or a tar of one (TransportOCIArchive), and
+// re-saves it as its own OCI layout at ociDir so callers see the exact
+// same on-disk shape pullAndSaveOCI's registry pull would have produced.
+func pullFromLayout(sourceLayout, ref, ociDir string) error {
+	dir := strings.TrimPrefix(sourceLayout, TransportOCI)
+	if strings.HasPrefix(sourceLayout, TransportOCIArchive) {
+		tmp, err := untarLayout(strings.TrimPrefix(sourceLayout, TransportOCIArchive))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		dir = tmp
+	}
+
+	fmt.Printf("    Reading %s from OCI layout %s ...\n", ref, sourceLayout)
+	img, err := imageForRef(dir, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ociDir), 0o755); err != nil {
+		return err
+	}
+	return crane.SaveOCI(img, ociDir)
+}
+
+// untarLayout extracts an oci-archive tar (an OCI layout packed as a tar,
+// per containers/image's oci-archive transport) to a fresh temp directory
+// for layout.FromPath to read.
+func untarLayout(tarPath string) (string, error) {
+	dir, err := os.MkdirTemp("", "verity-oci-archive-*")
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(tarPath) //nolint:gosec // tarPath is operator-supplied (-source-layout), not untrusted input
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("opening %s: %w", tarPath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return "", fmt.Errorf("reading %s: %w", tarPath, err)
+		}
+
+		target := filepath.Join(dir, hdr.Name) //nolint:gosec // dir is our own temp dir, hdr.Name came from an operator-supplied archive
+		if hdr.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				_ = os.RemoveAll(dir)
+				return "", err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", err
+		}
+		out, err := os.Create(target) //nolint:gosec // see target comment above
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return "", err
+		}
+		if _, err := io.Copy(out, tr); err != nil { //nolint:gosec // bounded by the archive's own headers, not attacker-controlled
+			_ = out.Close()
+			_ = os.RemoveAll(dir)
+			return "", fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		_ = out.Close()
+	}
+
+	return dir, nil
+}
+
+// imageForRef picks the manifest in dir's OCI layout annotated
+// "org.opencontainers.image.ref.name" == ref, falling back to the sole
+// manifest when the layout holds just one image (a single-image `oci:`
+// destination is often left unannotated).
+func imageForRef(dir, ref string) (v1.Image, error) {
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout at %s: %w", dir, err)
+	}
+	index, err := lp.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout index at %s: %w", dir, err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout manifest at %s: %w", dir, err)
+	}
+	if len(indexManifest.Manifests) == 0 {
+		return nil, fmt.Errorf("OCI layout at %s has no images", dir)
+	}
+	if len(indexManifest.Manifests) == 1 {
+		return index.Image(indexManifest.Manifests[0].Digest)
+	}
+	for _, m := range indexManifest.Manifests {
+		if m.Annotations["org.opencontainers.image.ref.name"] == ref {
+			return index.Image(m.Digest)
+		}
+	}
+	return nil, fmt.Errorf("no image annotated %q in OCI layout at %s", ref, dir)
+}
+
+// imageFromOCIDir reads back the single image crane.SaveOCI wrote to dir
+// (pullAndSaveOCI, pullFromLayout, and saveLocalOCI all produce exactly
+// one), so a step that already has a scanned-and-saved layout on disk
+// (e.g. the no-fixable-vulns mirror path) can reuse it without pulling or
+// loading the image a second time.
+func imageFromOCIDir(dir string) (v1.Image, error) {
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout at %s: %w", dir, err)
+	}
+	index, err := lp.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout index at %s: %w", dir, err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout manifest at %s: %w", dir, err)
+	}
+	if len(indexManifest.Manifests) == 0 {
+		return nil, fmt.Errorf("OCI layout at %s has no images", dir)
+	}
+	return index.Image(indexManifest.Manifests[0].Digest)
+}
+
+// pushToLayout saves a local Docker image (srcRef, e.g. one Copa just
+// built) into a local OCI image-layout directory instead of pushing it to
+// a registry, annotated "org.opencontainers.image.ref.name" == dstRef so a
+// later pullFromLayout/imageForRef call (or `skopeo copy oci:dir:dstRef
+// ...`) can find it again by the same ref PatchResult.Patched records.
+// Uses the same `docker save` + crane.Load path pushLocal uses to get a
+// patched BuildKit image into a form go-containerregistry can write.
+func pushToLayout(ctx context.Context, srcRef, dstRef, destLayout string) error {
+	tmp, err := os.CreateTemp("", "verity-image-*.tar")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	save := exec.CommandContext(ctx, "docker", "save", "-o", tmp.Name(), srcRef)
+	save.Stdout = os.Stdout
+	save.Stderr = os.Stderr
+	if err := save.Run(); err != nil {
+		return fmt.Errorf("docker save %s: %w", srcRef, err)
+	}
+
+	img, err := crane.Load(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("loading image %s: %w", srcRef, err)
+	}
+
+	fmt.Printf("    Writing %s to OCI layout %s ...\n", dstRef, destLayout)
+	return appendToLayout(destLayout, img, dstRef)
+}
+
+// appendToLayout writes img into the OCI layout directory at dir,
+// creating it (via layout.Write) if it doesn't exist yet, annotated with
+// ref so multiple images can share one layout the way
+// pullFromLayout/imageForRef expect.
+func appendToLayout(dir string, img v1.Image, ref string) error {
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		lp, err = layout.Write(dir, empty.Index)
+		if err != nil {
+			return fmt.Errorf("creating OCI layout at %s: %w", dir, err)
+		}
+	}
+	return lp.AppendImage(img, layout.WithAnnotations(map[string]string{
+		"org.opencontainers.image.ref.name": ref,
+	}))
+}
+
+// scanWithBackend scans ref for OS vulnerabilities using the backend
+// selected by opts.Scanner, writing a Trivy-compatible JSON report to
+// reportPath either way. The default ("" or "trivy-exec") scans the
+// already-pulled OCI layout at ociDir in-process via Trivy's own
+// pkg/commands/artifact Runner, rather than forking the trivy binary. Any
+// other backend name scans ref directly via internal/scanner, skipping the
+// local OCI layout entirely.
+func scanWithBackend(ctx context.Context, opts PatchOptions, ref, ociDir, reportPath string) error {
+	if opts.Scanner == "" || opts.Scanner == "trivy-exec" {
+		return trivyScan(ctx, ociDir, reportPath)
+	}
+
+	scannerCfg, err := scanner.LoadConfig(opts.ScannerConfigPath)
+	if err != nil {
+		return err
+	}
+	sc, err := scanner.New(opts.Scanner, scannerCfg.OptionsFor(opts.Scanner, scanner.Options{DockerHost: opts.DockerHost}))
+	if err != nil {
+		return fmt.Errorf("configuring scanner: %w", err)
+	}
+	report, err := sc.Scan(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("%s scan %s: %w", sc.Name(), ref, err)
+	}
+	data, err := report.MarshalTrivyCompat()
+	if err != nil {
+		return fmt.Errorf("marshaling report for %s: %w", ref, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(reportPath, data, 0o644)
+}
+
+// trivyDBUpdateOnce ensures only the first trivyScan call of the process
+// refreshes Trivy's on-disk vulnerability DB; concurrent scans fanned out by
+// PatchImages would otherwise race updating the same ~/.cache/trivy DB.
+var trivyDBUpdateOnce sync.Once
+
+// trivyScan scans an OCI image layout for OS vulnerabilities using Trivy's
+// own artifact.Runner in-process, equivalent to `trivy image --input
+// <ociDir> --vuln-type os --ignore-unfixed --format json --output
+// <reportPath>` but without forking the trivy binary: no CLI version to
+// keep pinned, typed errors instead of an exit code, and ctx cancellation
+// actually aborts an in-flight scan instead of leaving an orphaned process.
 func trivyScan(ctx context.Context, ociDir, reportPath string) error {
-	cmd := exec.CommandContext(ctx, "trivy", "image",
-		"--input", ociDir,
-		"--vuln-type", "os",
-		"--ignore-unfixed",
-		"--format", "json",
-		"--output", reportPath,
-	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(reportPath)
+	if err != nil {
+		return fmt.Errorf("creating report file %s: %w", reportPath, err)
+	}
+	defer func() { _ = out.Close() }()
+
+	// Only the first scan updates Trivy's vulnerability DB; concurrent
+	// scans from PatchImages' fan-out would otherwise race writing the
+	// same ~/.cache/trivy DB directory.
+	skipDBUpdate := true
+	trivyDBUpdateOnce.Do(func() { skipDBUpdate = false })
+
+	opts := trivyArtifact.Option{
+		ArtifactOption: trivyFlag.ArtifactOption{
+			Input: ociDir,
+		},
+		DBOption: trivyFlag.DBOption{
+			SkipDBUpdate: skipDBUpdate,
+		},
+		ScanOption: trivyFlag.ScanOption{
+			VulnType: []string{trivyTypes.VulnTypeOS},
+			Scanners: trivyTypes.Scanners{trivyTypes.VulnerabilityScanner},
+		},
+		VulnerabilityOption: trivyFlag.VulnerabilityOption{
+			IgnoreUnfixed: true,
+		},
+		ReportOption: trivyFlag.ReportOption{
+			Format: trivyTypes.FormatJSON,
+			Output: out,
+		},
+	}
+
+	runner, err := trivyArtifact.NewRunner(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("initializing trivy runner for %s: %w", ociDir, err)
+	}
+	defer func() { _ = runner.Close(ctx) }()
+
+	report, err := runner.ScanImage(ctx, opts)
+	if err != nil {
 		return fmt.Errorf("trivy scan %s: %w", ociDir, err)
 	}
+
+	report, err = runner.Filter(ctx, opts, report)
+	if err != nil {
+		return fmt.Errorf("filtering trivy report for %s: %w", ociDir, err)
+	}
+
+	if err := runner.Report(ctx, opts, report); err != nil {
+		return fmt.Errorf("writing trivy report for %s: %w", ociDir, err)
+	}
 	return nil
 }
 
-// copaPatch runs the copa CLI to patch an image via BuildKit.
-func copaPatch(ctx context.Context, imageRef, reportPath, patchedTag, buildkitAddr string) error {
-	args := []string{
-		"patch",
-		"--image", imageRef,
-		"--report", reportPath,
-		"--tag", patchedTag,
-		"--timeout", "10m",
+// buildkitClientFor returns the BuildKit client PatchImage should patch
+// with: opts.BuildKit's shared client when set (the PatchAllCommand fan-out
+// case), or a one-off client dialed from opts.BuildKitAddr otherwise (the
+// PatchCommand single-image case). The returned func closes the dialed
+// client; it is a no-op when a shared client was reused instead, since
+// ownership of that connection belongs to whoever created the session.
+func buildkitClientFor(ctx context.Context, opts PatchOptions) (*client.Client, func(), error) {
+	if opts.BuildKit != nil {
+		return opts.BuildKit.Client, func() {}, nil
 	}
-	if buildkitAddr != "" {
-		args = append(args, "--addr", buildkitAddr)
+	addr := opts.BuildKitAddr
+	if addr == "" {
+		addr = "docker-container://buildkitd"
 	}
+	c, err := client.New(ctx, addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to BuildKit at %s: %w", addr, err)
+	}
+	return c, func() { _ = c.Close() }, nil
+}
 
-	cmd := exec.CommandContext(ctx, "copa", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+// copaPatch patches imageRef in place by calling Copa's own patching
+// pipeline as a library (pkg/patch) against bkClient, instead of shelling
+// out to the copa binary. This is what lets PatchAllCommand share one
+// BuildKit session and its build scheduler across many concurrent patch
+// jobs, rather than every image paying for its own copa process and
+// BuildKit session handshake.
+func copaPatch(ctx context.Context, bkClient *client.Client, imageRef, reportPath, patchedTag string) error {
+	statusCh := make(chan *client.SolveStatus)
+	logDone := make(chan struct{})
+	go func() {
+		defer close(logDone)
+		for status := range statusCh {
+			logBuildStatus(imageRef, status)
+		}
+	}()
+
+	err := copapatch.Patch(
+		ctx,
+		copapatch.BkClient(bkClient),
+		copapatch.BuildContext{WorkingFolder: ""},
+		// Scanner is always "trivy" here regardless of which backend
+		// produced reportPath (trivy, grype, clair, ...): scanWithBackend
+		// normalizes every backend's report into Trivy-compatible JSON
+		// via scanner.Report.MarshalTrivyCompat before it's ever written
+		// to disk, so Copa only ever needs to parse one report shape.
+		copapatch.ScannerOpts{Report: reportPath, Scanner: "trivy"},
+		copapatch.Options{
+			Image:    imageRef,
+			PatchTag: patchedTag,
+			Timeout:  10 * time.Minute,
+		},
+		statusCh,
+	)
+	close(statusCh)
+	<-logDone
+
+	if err != nil {
 		return fmt.Errorf("copa patch %s: %w", imageRef, err)
 	}
 	return nil
 }
 
+// logBuildStatus writes one BuildKit status update as a structured,
+// per-image-prefixed log line. With --buildkit-workers fanning out several
+// builds at once against a shared session, copa's own terminal progress UI
+// isn't usable, so each vertex/log event is attributed to the image that
+// produced it instead.
+func logBuildStatus(imageRef string, status *client.SolveStatus) {
+	for _, v := range status.Vertexes {
+		switch {
+		case v.Completed != nil && v.Error != "":
+			fmt.Printf("    [%s] FAILED: %s: %s\n", imageRef, v.Name, v.Error)
+		case v.Completed != nil:
+			fmt.Printf("    [%s] done: %s\n", imageRef, v.Name)
+		case v.Started != nil:
+			fmt.Printf("    [%s] start: %s\n", imageRef, v.Name)
+		}
+	}
+	for _, l := range status.Logs {
+		fmt.Printf("    [%s] %s", imageRef, l.Data)
+	}
+}
+
+// signAndAttestPatched cosign-signs target (a pushed, registry-resolvable
+// ref) when opts.Sign is set, then attaches each attestation named in
+// opts.Attestations — "vuln" reads reportPath (the Trivy-compatible JSON
+// for target's own vuln state) and "sbom" generates a fresh CycloneDX SBOM
+// via attest.GenerateSBOM. Mirrors the sign-then-attest sequence
+// PushStandaloneReports and PublishChart already use (see internal/attest).
+// Failures are logged and swallowed rather than returned: by the time this
+// runs, the patched image itself has already been pushed successfully, and
+// a signing hiccup shouldn't fail the whole patch.
+func signAndAttestPatched(ctx context.Context, target, reportPath string, opts PatchOptions, result *PatchResult) {
+	if !opts.Sign {
+		return
+	}
+
+	signResult, err := attest.SignImage(ctx, target, attest.SignOptions{Key: opts.SignKey})
+	if err != nil {
+		fmt.Printf("    WARN: could not sign %s: %v\n", target, err)
+		return
+	}
+	result.SignatureDigest = signResult.Digest
+	fmt.Printf("    Signed %s → %s\n", target, signResult.SignatureRef)
+
+	for _, kind := range opts.Attestations {
+		var (
+			predicateType string
+			predicate     []byte
+		)
+		switch kind {
+		case "vuln":
+			if reportPath == "" {
+				continue
+			}
+			data, err := os.ReadFile(reportPath)
+			if err != nil {
+				fmt.Printf("    WARN: could not read report %s for vuln attestation: %v\n", reportPath, err)
+				continue
+			}
+			predicateType, predicate = "https://cosign.sigstore.dev/attestation/vuln/v1", data
+		case "sbom":
+			sbom, err := attest.GenerateSBOM(ctx, target)
+			if err != nil {
+				fmt.Printf("    WARN: could not generate SBOM for %s: %v\n", target, err)
+				continue
+			}
+			predicateType, predicate = "https://cyclonedx.org/bom", sbom
+		default:
+			fmt.Printf("    WARN: unknown attestation kind %q, skipping\n", kind)
+			continue
+		}
+
+		attRef, err := attest.AttestCustom(ctx, target, predicateType, predicate, attest.AttestOptions{Key: opts.SignKey})
+		if err != nil {
+			fmt.Printf("    WARN: could not attest %s for %s: %v\n", kind, target, err)
+			continue
+		}
+		if result.AttestationDigests == nil {
+			result.AttestationDigests = map[string]string{}
+		}
+		result.AttestationDigests[kind] = attRef
+	}
+}
+
 // mirrorImage copies an image between registries using crane.Copy.
 // Used to publish images that need no patching to the target registry.
 func mirrorImage(ctx context.Context, srcRef, dstRef string) error {
@@ -312,41 +1238,341 @@ func pushLocal(ctx context.Context, srcRef, dstRef string) error {
 	)
 }
 
-// countFixable reads a Trivy JSON report and counts vulnerabilities with a fix available.
+// countFixable reads a report (written by any scanner backend, in the
+// common Trivy-compatible shape — see scanner.Report.MarshalTrivyCompat)
+// and counts vulnerabilities with a fix available.
 func countFixable(reportPath string) (int, error) {
-	data, err := os.ReadFile(reportPath)
+	return scanner.CountFixable(reportPath)
+}
+
+// loadVulnStats reads reportPath (written by any scanner backend, normalized
+// to Trivy-compatible JSON — see scanWithBackend) and returns both a
+// severity-bucketed VulnSummary and the underlying findings as SiteVuln, the
+// shape PatchResult's Before/After/Vulns fields and downstream site-data
+// generation (see summarizeVulns in sitedata.go) share.
+func loadVulnStats(reportPath string) (VulnSummary, []SiteVuln, error) {
+	vulns, err := scanner.Normalize(reportPath)
 	if err != nil {
-		return 0, err
+		return VulnSummary{}, nil, err
 	}
-	var report trivyReport
-	if err := json.Unmarshal(data, &report); err != nil {
-		return 0, err
+	siteVulns := make([]SiteVuln, 0, len(vulns))
+	for _, v := range vulns {
+		siteVulns = append(siteVulns, SiteVuln{
+			ID:               v.ID,
+			PkgName:          v.PkgName,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Severity:         v.Severity,
+		})
 	}
-	count := 0
-	for _, r := range report.Results {
-		for _, v := range r.Vulnerabilities {
-			if v.FixedVersion != "" {
-				count++
-			}
+	return summarizeVulns(siteVulns), siteVulns, nil
+}
+
+// imageIndex describes the platforms a remote tag resolves to via an OCI
+// manifest list (image index), and the per-platform manifest digest used
+// to pull each one without triggering the registry's own "unknown"
+// platform-selection fallback.
+type imageIndex struct {
+	platforms []Platform
+	digests   map[Platform]string
+}
+
+// resolveImageIndex inspects ref's manifest and reports the platforms it
+// covers. A plain (single-arch) image, or any ref that can't be resolved,
+// yields a zero-value *imageIndex with no platforms — callers should treat
+// that the same as "not multi-arch" rather than as an error case to stop on.
+func resolveImageIndex(ctx context.Context, ref string) (*imageIndex, error) {
+	r, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ref, err)
+	}
+	desc, err := remote.Get(r, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest for %s: %w", ref, err)
+	}
+	if !desc.MediaType.IsIndex() {
+		return &imageIndex{}, nil
+	}
+
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("reading image index for %s: %w", ref, err)
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading index manifest for %s: %w", ref, err)
+	}
+
+	result := &imageIndex{digests: make(map[Platform]string)}
+	for _, m := range manifest.Manifests {
+		// Manifest lists also carry non-image entries (e.g. buildkit cache,
+		// attestations) with no real platform; skip those.
+		if m.Platform == nil || m.Platform.OS == "unknown" {
+			continue
 		}
+		p := Platform{OS: m.Platform.OS, Arch: m.Platform.Architecture, Variant: m.Platform.Variant}
+		result.platforms = append(result.platforms, p)
+		result.digests[p] = m.Digest.String()
 	}
-	return count, nil
+	return result, nil
 }
 
-type trivyReport struct {
-	Results []struct {
-		Vulnerabilities []trivyVulnerability `json:"Vulnerabilities"`
-	} `json:"Results"`
+// platformSuffix formats p for use in a registry tag or report filename,
+// e.g. Platform{OS: "linux", Arch: "arm64"} → "linux_arm64".
+func platformSuffix(p Platform) string {
+	return strings.ReplaceAll(p.String(), "/", "_")
 }
 
-type trivyVulnerability struct {
-	VulnerabilityID  string `json:"VulnerabilityID"`
-	PkgName          string `json:"PkgName"`
-	Severity         string `json:"Severity"`
-	InstalledVersion string `json:"InstalledVersion"`
-	FixedVersion     string `json:"FixedVersion"`
-	Title            string `json:"Title"`
-	Description      string `json:"Description"`
+// filterPlatforms restricts idx to only the platforms also present in want
+// (see PatchOptions.Platforms), preserving idx's own ordering. A want entry
+// with no matching platform in idx is silently dropped rather than erroring,
+// since idx reflects what the upstream manifest list actually publishes.
+func filterPlatforms(idx *imageIndex, want []Platform) *imageIndex {
+	wantSet := make(map[Platform]bool, len(want))
+	for _, p := range want {
+		wantSet[p] = true
+	}
+	filtered := &imageIndex{digests: make(map[Platform]string)}
+	for _, p := range idx.platforms {
+		if !wantSet[p] {
+			continue
+		}
+		filtered.platforms = append(filtered.platforms, p)
+		filtered.digests[p] = idx.digests[p]
+	}
+	return filtered
+}
+
+// patchMultiArch patches img once per platform in idx and republishes the
+// results as a single combined manifest list under img's own patched tag,
+// so GenerateValuesOverride and CreateWrapperChart need no multi-arch-aware
+// code of their own: a container runtime resolves the right platform from
+// the manifest list the same way it already does for the upstream image.
+//
+// Each platform is patched (or mirrored, if already vuln-free) to its own
+// platform-suffixed tag first, then assembled into the combined manifest
+// list — pushing a manifest list requires every referenced manifest to
+// already exist in the registry. A platform that fails along the way
+// (pull, scan, Copa, or push) is recorded in PlatformErrors and skipped
+// rather than aborting the whole image: the combined manifest list is
+// still assembled and pushed from whichever platforms succeeded, so one
+// broken architecture doesn't block shipping patches for the rest. Only
+// when every platform fails does patchMultiArch report a hard error.
+func patchMultiArch(ctx context.Context, img Image, idx *imageIndex, opts PatchOptions) *PatchResult {
+	if len(opts.Platforms) > 0 {
+		idx = filterPlatforms(idx, opts.Platforms)
+	}
+	result := &PatchResult{Original: img, Platforms: idx.platforms, PlatformReports: map[string]string{}, PlatformErrors: map[string]string{}, PlatformVulnCounts: map[string]int{}}
+
+	if opts.TargetRegistry == "" {
+		result.Error = fmt.Errorf("patching multi-arch image %s requires a target registry to assemble the combined manifest list", img.Reference())
+		return result
+	}
+	if len(idx.platforms) == 0 {
+		result.Error = fmt.Errorf("no platforms to patch for %s", img.Reference())
+		return result
+	}
+
+	tag := img.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	patchedTag := tag + "-patched"
+
+	sourceRepo := Image{Registry: img.Registry, Repository: img.Repository}.Reference()
+	platformTargets := make(map[Platform]string, len(idx.platforms))
+	totalVulns := 0
+	var beforeVulns, afterVulns []SiteVuln
+
+	for _, p := range idx.platforms {
+		target, vulns, before, after, err := patchOnePlatform(ctx, img, p, sourceRepo, idx.digests[p], patchedTag, opts, result)
+		if err != nil {
+			fmt.Printf("    WARN: platform %s failed, continuing with the rest: %v\n", p, err)
+			result.PlatformErrors[p.String()] = err.Error()
+			continue
+		}
+		totalVulns += vulns
+		result.PlatformVulnCounts[p.String()] = vulns
+		platformTargets[p] = target.Reference()
+		beforeVulns = append(beforeVulns, before...)
+		afterVulns = append(afterVulns, after...)
+
+		// Best-effort: a missing digest just means SiteImage.Platforms
+		// shows no digest for this platform later, not a hard failure
+		// (mirrors BuildWrapperProvenance's graceful degradation).
+		digest, err := crane.Digest(target.Reference(), crane.WithAuthFromKeychain(authn.DefaultKeychain), crane.WithContext(ctx))
+		if err != nil {
+			fmt.Printf("    WARN: could not resolve digest for %s: %v\n", target.Reference(), err)
+		}
+		result.Variants = append(result.Variants, PatchedVariant{Platform: p, Reference: target.Reference(), Digest: digest})
+	}
+	result.VulnCount = totalVulns
+	// Before/After/Vulns are the union across every successfully-patched
+	// platform (see PatchResult.Vulns); a platform recorded in
+	// PlatformErrors contributes to neither, the same way it's absent from
+	// platformTargets and result.Variants.
+	result.Before = summarizeVulns(beforeVulns)
+	result.After = summarizeVulns(afterVulns)
+	result.Vulns = afterVulns
+
+	if len(platformTargets) == 0 {
+		result.Error = fmt.Errorf("patching multi-arch image %s failed on every platform: %v", img.Reference(), result.PlatformErrors)
+		return result
+	}
+
+	if totalVulns == 0 && len(result.PlatformErrors) == 0 {
+		result.Skipped = true
+		result.SkipReason = SkipReasonNoVulnerabilities
+	}
+
+	combined := Image{Registry: opts.TargetRegistry, Repository: img.Repository, Tag: patchedTag}
+	if err := pushManifestList(ctx, combined.Reference(), platformTargets); err != nil {
+		result.Error = fmt.Errorf("assembling manifest list %s: %w", combined.Reference(), err)
+		return result
+	}
+	result.Patched = combined
+
+	// Sign the combined manifest list itself. There's no single Trivy
+	// report for a multi-arch image (each platform scanned its own), so
+	// "vuln" is skipped here — only "sbom" attestations apply.
+	signAndAttestPatched(ctx, combined.Reference(), "", opts, result)
+
+	if len(result.PlatformErrors) > 0 {
+		fmt.Printf("    Pushed partial manifest list %s — %d/%d platform(s) failed: %v\n",
+			combined.Reference(), len(result.PlatformErrors), len(result.PlatformErrors)+len(platformTargets), result.PlatformErrors)
+	}
+
+	return result
+}
+
+// patchOnePlatform pulls, scans, and (if needed) Copa-patches and pushes a
+// single platform of a multi-arch image, recording its Trivy report path on
+// result along the way. It returns the pushed platform-suffixed target, its
+// fixable vuln count, and that platform's own before/after vuln findings
+// (for patchMultiArch to union into PatchResult.Before/After/Vulns), or an
+// error if any step failed — patchMultiArch treats that as this platform
+// dropping out of the combined manifest list rather than a fatal error for
+// the whole image.
+func patchOnePlatform(ctx context.Context, img Image, p Platform, sourceRepo, digest, patchedTag string, opts PatchOptions, result *PatchResult) (Image, int, []SiteVuln, []SiteVuln, error) {
+	suffix := platformSuffix(p)
+	platformRef := sourceRepo + "@" + digest
+
+	ociDir := filepath.Join(opts.WorkDir, "oci", sanitize(platformRef))
+	if err := withStage(ctx, opts.pullSem(), func() error { return pullAndSaveOCI(ctx, platformRef, ociDir) }); err != nil {
+		return Image{}, 0, nil, nil, fmt.Errorf("pulling %s (%s): %w", platformRef, p, err)
+	}
+
+	reportPath := filepath.Join(opts.ReportDir, sanitize(img.Reference())+"_"+suffix+".json")
+	if err := withStage(ctx, opts.scanSem(), func() error { return scanWithBackend(ctx, opts, platformRef, ociDir, reportPath) }); err != nil {
+		return Image{}, 0, nil, nil, fmt.Errorf("scanning %s (%s): %w", platformRef, p, err)
+	}
+	result.PlatformReports[p.String()] = reportPath
+
+	vulns, err := countFixable(reportPath)
+	if err != nil {
+		return Image{}, 0, nil, nil, fmt.Errorf("reading report for %s (%s): %w", platformRef, p, err)
+	}
+
+	var before []SiteVuln
+	if _, pv, err := loadVulnStats(reportPath); err != nil {
+		fmt.Printf("    WARN: could not load vuln stats for %s (%s): %v\n", platformRef, p, err)
+	} else {
+		before = pv
+	}
+
+	target := Image{Registry: opts.TargetRegistry, Repository: img.Repository, Tag: patchedTag + "-" + suffix}
+	if vulns == 0 {
+		if err := mirrorImage(ctx, platformRef, target.Reference()); err != nil {
+			return Image{}, 0, nil, nil, fmt.Errorf("mirroring %s to %s: %w", platformRef, target.Reference(), err)
+		}
+		// Nothing to patch, so the residual vulns are the same as before.
+		return target, vulns, before, before, nil
+	}
+
+	bkClient, closeBkClient, err := buildkitClientFor(ctx, opts)
+	if err != nil {
+		return Image{}, 0, nil, nil, err
+	}
+	defer closeBkClient()
+
+	if err := withStage(ctx, opts.patchSem(), func() error { return copaPatch(ctx, bkClient, platformRef, reportPath, target.Tag) }); err != nil {
+		return Image{}, 0, nil, nil, fmt.Errorf("patching %s (%s): %w", platformRef, p, err)
+	}
+
+	localPatched := img
+	localPatched.Tag = target.Tag
+	if err := pushLocal(ctx, localPatched.Reference(), target.Reference()); err != nil {
+		return Image{}, 0, nil, nil, fmt.Errorf("pushing %s: %w", target.Reference(), err)
+	}
+
+	// Re-scan the freshly patched local image so the returned "after" vulns
+	// reflect the residual, the same way PatchImage's single-arch flow does.
+	var after []SiteVuln
+	patchedRef := localPatched.Reference()
+	patchedOciDir := filepath.Join(opts.WorkDir, "oci", sanitize(patchedRef)+"-after")
+	patchedReportPath := filepath.Join(opts.ReportDir, sanitize(patchedRef)+"-after.json")
+	if err := saveLocalOCI(ctx, patchedRef, patchedOciDir); err != nil {
+		fmt.Printf("    WARN: could not save patched image %s for residual scan: %v\n", patchedRef, err)
+	} else if err := withStage(ctx, opts.scanSem(), func() error {
+		return scanWithBackend(ctx, opts, patchedRef, patchedOciDir, patchedReportPath)
+	}); err != nil {
+		fmt.Printf("    WARN: could not scan patched image %s for residual vulns: %v\n", patchedRef, err)
+	} else if _, pv, err := loadVulnStats(patchedReportPath); err != nil {
+		fmt.Printf("    WARN: could not load vuln stats for %s: %v\n", patchedRef, err)
+	} else {
+		after = pv
+	}
+
+	return target, vulns, before, after, nil
+}
+
+// BuildImageIndex assembles variants (one patched image per platform,
+// paired by position with platforms) into a single OCI image index, the
+// same image-index construction pushManifestList uses to combine
+// per-platform patched images into one pushable multi-arch tag. Pairs
+// beyond the shorter of the two slices are ignored.
+func BuildImageIndex(variants []v1.Image, platforms []v1.Platform) v1.ImageIndex {
+	idx := empty.Index
+	for i, img := range variants {
+		if i >= len(platforms) {
+			break
+		}
+		p := platforms[i]
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &p},
+		})
+	}
+	return idx
+}
+
+// pushManifestList assembles platformTargets (already-pushed, single-platform
+// image refs keyed by the platform they were built for) into one OCI image
+// index and pushes it to dstRef, so dstRef alone is enough for any consumer
+// to get the right architecture.
+func pushManifestList(ctx context.Context, dstRef string, platformTargets map[Platform]string) error {
+	platforms := make([]v1.Platform, 0, len(platformTargets))
+	variants := make([]v1.Image, 0, len(platformTargets))
+	for p, ref := range platformTargets {
+		r, err := name.ParseReference(ref, name.WeakValidation)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", ref, err)
+		}
+		img, err := remote.Image(r, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("fetching %s: %w", ref, err)
+		}
+		platforms = append(platforms, v1.Platform{OS: p.OS, Architecture: p.Arch, Variant: p.Variant})
+		variants = append(variants, img)
+	}
+	idx := BuildImageIndex(variants, platforms)
+
+	dst, err := name.ParseReference(dstRef, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", dstRef, err)
+	}
+	fmt.Printf("    Pushing manifest list %s ...\n", dstRef)
+	return remote.WriteIndex(dst, idx, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
 }
 
 func sanitize(ref string) string {
@@ -3,6 +3,8 @@ package internal
 import (
 	"context"
 	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 )
 
 func TestImageExistsReturnsFalseForNonExistent(t *testing.T) {
@@ -13,3 +15,80 @@ func TestImageExistsReturnsFalseForNonExistent(t *testing.T) {
 		t.Error("imageExists returned true for a non-existent image, want false")
 	}
 }
+
+func TestBuildKitSessionCloseNilSafe(t *testing.T) {
+	var s *BuildKitSession
+	if err := s.Close(); err != nil {
+		t.Errorf("Close on nil *BuildKitSession returned %v, want nil", err)
+	}
+
+	if err := (&BuildKitSession{}).Close(); err != nil {
+		t.Errorf("Close on a session with no client returned %v, want nil", err)
+	}
+}
+
+func TestPlatformSuffix(t *testing.T) {
+	tests := []struct {
+		platform Platform
+		want     string
+	}{
+		{Platform{OS: "linux", Arch: "amd64"}, "linux_amd64"},
+		{Platform{OS: "linux", Arch: "arm64"}, "linux_arm64"},
+		{Platform{OS: "linux", Arch: "arm", Variant: "v7"}, "linux_arm_v7"},
+	}
+	for _, tt := range tests {
+		if got := platformSuffix(tt.platform); got != tt.want {
+			t.Errorf("platformSuffix(%+v) = %q, want %q", tt.platform, got, tt.want)
+		}
+	}
+}
+
+func TestAppendAndReadLayoutRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	ref := "ghcr.io/verity-org/nginx:1.25.3-patched"
+
+	if err := appendToLayout(dir, empty.Image, ref); err != nil {
+		t.Fatalf("appendToLayout() error = %v", err)
+	}
+
+	got, err := imageForRef(dir, ref)
+	if err != nil {
+		t.Fatalf("imageForRef() error = %v", err)
+	}
+	wantDigest, err := empty.Image.Digest()
+	if err != nil {
+		t.Fatalf("empty.Image.Digest() error = %v", err)
+	}
+	gotDigest, err := got.Digest()
+	if err != nil {
+		t.Fatalf("got.Digest() error = %v", err)
+	}
+	if gotDigest != wantDigest {
+		t.Errorf("imageForRef() returned digest %v, want %v", gotDigest, wantDigest)
+	}
+}
+
+func TestImageForRefMultipleImagesRequiresAnnotationMatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := appendToLayout(dir, empty.Image, "ghcr.io/verity-org/nginx:1.25.3-patched"); err != nil {
+		t.Fatalf("appendToLayout() error = %v", err)
+	}
+	// A second, distinct manifest (a fresh empty.Image config digest would
+	// collide, so mutate isn't worth the import here - a plain unannotated
+	// lookup failing with more than one manifest present is what matters).
+	if err := appendToLayout(dir, empty.Image, "ghcr.io/verity-org/redis:7.2-patched"); err != nil {
+		t.Fatalf("appendToLayout() error = %v", err)
+	}
+
+	if _, err := imageForRef(dir, "ghcr.io/verity-org/does-not-exist:latest"); err == nil {
+		t.Error("imageForRef() for an unannotated ref in a multi-image layout returned nil error, want an error")
+	}
+}
+
+func TestResolveImageIndexInvalidRef(t *testing.T) {
+	ctx := context.Background()
+	// An empty reference fails name.ParseReference before any network call.
+	if _, err := resolveImageIndex(ctx, ""); err == nil {
+		t.Error("resolveImageIndex(\"\") returned nil error, want an error")
+	}
+}
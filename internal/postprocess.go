@@ -3,15 +3,13 @@ package internal
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
-)
 
-var errEmptyDigest = errors.New("empty digest returned from registry")
+	"github.com/verity-org/verity/internal/registry"
+)
 
 const (
 	statusPatched = "Patched"
@@ -26,6 +24,19 @@ type PostProcessOptions struct {
 	RegistryPrefix   string
 	OutputDir        string
 	SkipDigestLookup bool // for testing
+
+	// RegistryClient resolves patched images' digests. Nil uses
+	// registry.New(registry.Options{}) (go-containerregistry against the
+	// ambient keychain); tests can inject a stub instead.
+	RegistryClient registry.Client
+
+	// RegistryConfig, when set, rewrites each patched image's ref through
+	// its mirror/block rules before the digest lookup in generateMatrix,
+	// falling back across any configured mirrors on a transient registry
+	// error — the same resolution cmd/discover.go's -registry-config gives
+	// the matrix/patch path (see RegistryConfig.RewriteRef), applied here
+	// to this pipeline's own digest lookup. Nil skips rewriting entirely.
+	RegistryConfig *RegistryConfig
 }
 
 // PostProcessResult represents the output of post-processing.
@@ -39,6 +50,12 @@ type PostProcessResult struct {
 	ChartCount   int
 	HasImages    bool
 	HasCharts    bool
+
+	// AttestMatrix is the same matrix already written to MatrixPath,
+	// surfaced directly so a caller driving attest-all in-process (rather
+	// than re-reading matrix.json off disk) can shard the attest job the
+	// same way the patch job is sharded.
+	AttestMatrix *MatrixOutput
 }
 
 // PostProcessCopaResults reads Copa's output, queries registries, and generates
@@ -74,13 +91,19 @@ func PostProcessCopaResults(opts PostProcessOptions) (*PostProcessResult, error)
 	// Build lookup maps
 	imageResultMap := buildImageResultMap(copaOutput.Results)
 
+	client := opts.RegistryClient
+	if client == nil {
+		client = registry.New(registry.Options{})
+	}
+
 	// Generate matrix for attest job (only patched images)
 	ctx := context.Background()
-	matrix, err := generateMatrix(ctx, copaOutput.Results, opts.RegistryPrefix, opts.SkipDigestLookup)
+	matrix, err := generateMatrix(ctx, copaOutput.Results, opts.RegistryPrefix, opts.SkipDigestLookup, client, opts.RegistryConfig)
 	if err != nil {
 		return nil, fmt.Errorf("generating matrix: %w", err)
 	}
 	result.HasImages = len(matrix.Include) > 0
+	result.AttestMatrix = matrix
 
 	// Write matrix.json
 	matrixData, err := json.Marshal(matrix)
@@ -139,7 +162,7 @@ func buildImageResultMap(results []CopaOutputResult) map[string]*CopaOutputResul
 
 // generateMatrix creates the GitHub Actions matrix for the attest job.
 // Only includes successfully patched images (status="Patched").
-func generateMatrix(ctx context.Context, results []CopaOutputResult, registryPrefix string, skipDigest bool) (*MatrixOutput, error) {
+func generateMatrix(ctx context.Context, results []CopaOutputResult, registryPrefix string, skipDigest bool, client registry.Client, regCfg *RegistryConfig) (*MatrixOutput, error) {
 	matrix := &MatrixOutput{}
 
 	for _, r := range results {
@@ -150,7 +173,7 @@ func generateMatrix(ctx context.Context, results []CopaOutputResult, registryPre
 		// Get digest for the patched image
 		patchedRef := r.PatchedImage
 		if !skipDigest {
-			digest, err := getImageDigest(ctx, patchedRef)
+			digest, err := digestWithMirrorFallback(ctx, client, patchedRef, regCfg)
 			if err != nil {
 				// Be resilient: skip images whose digest cannot be retrieved, but continue processing others
 				fmt.Fprintf(os.Stderr, "Warning: skipping image %s because digest lookup failed: %v\n", patchedRef, err)
@@ -176,6 +199,28 @@ func generateMatrix(ctx context.Context, results []CopaOutputResult, registryPre
 	return matrix, nil
 }
 
+// digestWithMirrorFallback resolves ref's digest, first rewriting it
+// through regCfg's mirror/block rules (a nil regCfg is a no-op — see
+// RegistryConfig.RewriteRef) and then retrying against each configured
+// mirror, in order, while the lookup keeps failing transiently (see
+// isRetryableRegistryError) — the same fallback patchWithMirrorFallback
+// gives a patch attempt, applied here to a digest lookup instead.
+func digestWithMirrorFallback(ctx context.Context, client registry.Client, ref string, regCfg *RegistryConfig) (string, error) {
+	rewritten, mirrors, err := regCfg.RewriteRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := client.Digest(ctx, rewritten)
+	for _, mirror := range mirrors {
+		if err == nil || !isRetryableRegistryError(err) {
+			break
+		}
+		digest, err = client.Digest(ctx, mirror)
+	}
+	return digest, err
+}
+
 // generateManifest creates the DiscoveryManifest structure for the assemble step.
 // Groups images by chart according to chart-image-map.yaml.
 func generateManifest(chartMap *ChartImageMap, resultMap map[string]*CopaOutputResult) *DiscoveryManifest {
@@ -268,22 +313,6 @@ func writeResultFiles(results []CopaOutputResult, resultsDir string) error {
 	return nil
 }
 
-// getImageDigest queries the registry for an image's digest using crane.
-func getImageDigest(ctx context.Context, ref string) (string, error) {
-	cmd := exec.CommandContext(ctx, "crane", "digest", ref)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("crane digest failed for %s: %w\nOutput: %s", ref, err, string(output))
-	}
-
-	digest := strings.TrimSpace(string(output))
-	if digest == "" {
-		return "", fmt.Errorf("%w for %s", errEmptyDigest, ref)
-	}
-
-	return digest, nil
-}
-
 // sanitizeImageName converts an image reference to a safe filename/artifact name.
 func sanitizeImageName(ref string) string {
 	// Remove protocol if present
@@ -0,0 +1,260 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// trivyReportArtifactType is the OCI artifactType used for Trivy report
+// referrer artifacts, so PushReportsAsReferrers and the referrers-API
+// fallback in matchReportsToImages agree on what to push and what to
+// filter for.
+const trivyReportArtifactType = "application/vnd.verity.trivy.report+json"
+
+// ReportsMode selects how AssembleResults attaches a chart's Trivy reports
+// to the published chart (see AssembleResults's reportsMode parameter).
+type ReportsMode string
+
+// Supported reports modes.
+const (
+	// ReportsModeReferrer pushes each report as its own OCI 1.1 referrer
+	// artifact of the chart manifest (see PushReportsAsReferrers), keeping
+	// the chart manifest itself strictly OCI-compliant. The default.
+	ReportsModeReferrer ReportsMode = "referrer"
+	// ReportsModeEmbed bundles every report straight into the chart
+	// package's reports/ directory (see embedChartReports) instead of
+	// pushing referrer artifacts, for registries or tooling that can't
+	// resolve OCI 1.1 referrers.
+	ReportsModeEmbed ReportsMode = "embed"
+	// ReportsModeBoth does both: embeds reports in the chart package and
+	// also pushes them as referrer artifacts.
+	ReportsModeBoth ReportsMode = "both"
+)
+
+// resolveSubject parses chartRef@chartDigest and resolves its manifest
+// descriptor, so callers pushing or listing OCI 1.1 referrers against it
+// (PushReportsAsReferrers, PushChartAdditions) only have to do this once
+// each.
+func resolveSubject(chartRef, chartDigest string) (name.Reference, v1.Descriptor, error) {
+	subjectRef, err := name.ParseReference(chartRef + "@" + chartDigest)
+	if err != nil {
+		return nil, v1.Descriptor{}, fmt.Errorf("parsing subject reference %s@%s: %w", chartRef, chartDigest, err)
+	}
+	subjectDesc, err := remote.Head(subjectRef, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, v1.Descriptor{}, fmt.Errorf("resolving subject descriptor for %s: %w", subjectRef, err)
+	}
+	return subjectRef, v1.Descriptor{
+		MediaType: subjectDesc.MediaType,
+		Digest:    subjectDesc.Digest,
+		Size:      subjectDesc.Size,
+	}, nil
+}
+
+// pushReferrerArtifact pushes data as a single-layer OCI 1.1 referrer
+// artifact of subject, with the given artifactType and an
+// "org.opencontainers.image.title" annotation of title, and returns the
+// digest reference it was pushed under.
+func pushReferrerArtifact(repo name.Repository, subject v1.Descriptor, artifactType, title string, data []byte) (string, error) {
+	layer, err := tarball.LayerFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		return "", fmt.Errorf("building layer for %s: %w", title, err)
+	}
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", fmt.Errorf("building artifact image for %s: %w", title, err)
+	}
+	img = mutate.Subject(img, subject)
+	img = mutate.ArtifactType(img, artifactType)
+	annotated, ok := mutate.Annotations(img, map[string]string{"org.opencontainers.image.title": title}).(v1.Image)
+	if !ok {
+		return "", fmt.Errorf("annotating artifact for %s: unexpected image type", title)
+	}
+	img = annotated
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("digesting artifact for %s: %w", title, err)
+	}
+
+	dst, err := name.NewDigest(fmt.Sprintf("%s@%s", repo.Name(), digest))
+	if err != nil {
+		return "", fmt.Errorf("building push reference for %s: %w", title, err)
+	}
+	if err := remote.Write(dst, img, remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return "", fmt.Errorf("pushing artifact for %s: %w", title, err)
+	}
+	return dst.String(), nil
+}
+
+// PushReportsAsReferrers pushes each Trivy JSON report in reportsDir as a
+// separate OCI 1.1 referrer artifact whose manifest has a "subject" field
+// pointing at chartRef@chartDigest (the chart manifest PublishChart just
+// pushed). Each artifact has one layer: the raw report JSON, with
+// artifactType trivyReportArtifactType.
+//
+// Pushing reports this way (rather than embedding them in the chart
+// package, or in the gitignored local reports/ directory) lets reports be
+// rotated or re-scanned independently of the chart's own version, and
+// removes the need for matchReportsToImages' stub-entry fallback when
+// reports/ is missing.
+func PushReportsAsReferrers(chartRef, chartDigest, reportsDir string) ([]string, error) {
+	subjectRef, subject, err := resolveSubject(chartRef, chartDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(reportsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading reports dir: %w", err)
+	}
+
+	repo := subjectRef.Context()
+
+	var pushed []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(reportsDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", e.Name(), err)
+		}
+
+		dst, err := pushReferrerArtifact(repo, subject, trivyReportArtifactType, e.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		pushed = append(pushed, dst)
+	}
+
+	return pushed, nil
+}
+
+// listReferrers resolves chartRef@chartDigest to a digest reference and
+// returns its repository (for building per-referrer pull references) and
+// its OCI 1.1 referrers manifest list, so callers (fetchReportsViaReferrers,
+// FetchAdditions) only have to filter by artifactType from there.
+func listReferrers(chartRef, chartDigest string) (name.Repository, []v1.Descriptor, error) {
+	subjectRef, err := name.ParseReference(chartRef + "@" + chartDigest)
+	if err != nil {
+		return name.Repository{}, nil, fmt.Errorf("parsing subject reference %s@%s: %w", chartRef, chartDigest, err)
+	}
+
+	digestRef, ok := subjectRef.(name.Digest)
+	if !ok {
+		return name.Repository{}, nil, fmt.Errorf("subject reference %s is not digest-addressable", subjectRef)
+	}
+
+	index, err := remote.Referrers(digestRef, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return name.Repository{}, nil, fmt.Errorf("listing referrers for %s: %w", subjectRef, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return name.Repository{}, nil, fmt.Errorf("reading referrers index for %s: %w", subjectRef, err)
+	}
+
+	return digestRef.Context(), manifest.Manifests, nil
+}
+
+// fetchReferrerBlob pulls the referrer artifact repo@desc.Digest and
+// returns its single layer's uncompressed bytes, along with the title from
+// its "org.opencontainers.image.title" annotation (falling back to the
+// digest string when unset).
+func fetchReferrerBlob(repo name.Repository, desc v1.Descriptor) ([]byte, string, error) {
+	artifactRef, err := name.NewDigest(fmt.Sprintf("%s@%s", repo.Name(), desc.Digest))
+	if err != nil {
+		return nil, "", fmt.Errorf("building referrer reference %s: %w", desc.Digest, err)
+	}
+	img, err := remote.Image(artifactRef, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, "", fmt.Errorf("pulling referrer %s: %w", artifactRef, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil || len(layers) == 0 {
+		return nil, "", fmt.Errorf("reading layers of referrer %s: %w", artifactRef, err)
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, "", fmt.Errorf("decompressing referrer %s: %w", artifactRef, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading referrer %s: %w", artifactRef, err)
+	}
+
+	title, ok := desc.Annotations["org.opencontainers.image.title"]
+	if !ok || title == "" {
+		title = desc.Digest.String()
+	}
+	return data, title, nil
+}
+
+// fetchReportsViaReferrers queries chartRef@chartDigest's OCI 1.1 referrers,
+// pulls every referrer with artifactType trivyReportArtifactType, and
+// writes each one's single layer back out as a {sanitized-ref}.json file
+// under destDir — the same on-disk layout matchReportsToImages already
+// reads from a local reports/ directory, so callers can treat a
+// referrers-backed chart identically to one with a vendored reports/
+// directory.
+func fetchReportsViaReferrers(chartRef, chartDigest, destDir string) error {
+	repo, descs, err := listReferrers(chartRef, chartDigest)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", destDir, err)
+	}
+
+	for _, desc := range descs {
+		if desc.ArtifactType != trivyReportArtifactType {
+			continue
+		}
+
+		data, title, err := fetchReferrerBlob(repo, desc)
+		if err != nil {
+			return err
+		}
+		if !strings.HasSuffix(title, ".json") {
+			title += ".json"
+		}
+		dest := filepath.Join(destDir, filepath.Base(title))
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", dest, err)
+		}
+	}
+
+	return nil
+}
+
+// fetchChartReportsViaReferrers resolves registry/charts/<name>:<version>'s
+// manifest digest and fetches its Trivy report referrers into destDir,
+// the local-file fallback path parseWrapperChart uses when a chart
+// package has no vendored reports/ directory.
+func fetchChartReportsViaReferrers(registry, chartName, version, destDir string) error {
+	chartRef := fmt.Sprintf("%s/charts/%s:%s", registry, chartName, version)
+	digest, err := crane.Digest(chartRef)
+	if err != nil {
+		return fmt.Errorf("resolving digest for %s: %w", chartRef, err)
+	}
+	return fetchReportsViaReferrers(strings.TrimSuffix(chartRef, ":"+version), digest, destDir)
+}
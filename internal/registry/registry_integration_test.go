@@ -0,0 +1,79 @@
+//go:build integration
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+)
+
+// newTestRegistry creates an in-process OCI registry and returns its host address.
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+	r := registry.New()
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestClientDigestAndHead_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	ref := fmt.Sprintf("%s/library/nginx:1.0", host)
+	if err := crane.Push(empty.Image, ref, crane.Insecure); err != nil {
+		t.Fatalf("seeding %s: %v", ref, err)
+	}
+
+	c := New(Options{})
+	ctx := context.Background()
+
+	digest, err := c.Digest(ctx, ref)
+	if err != nil {
+		t.Fatalf("Digest() error = %v", err)
+	}
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Errorf("Digest() = %q, want a sha256 digest", digest)
+	}
+
+	desc, err := c.Head(ctx, ref)
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if desc.Digest.String() != digest {
+		t.Errorf("Head().Digest = %q, want %q", desc.Digest.String(), digest)
+	}
+}
+
+func TestClientCopyAndPushArtifact_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	src := fmt.Sprintf("%s/library/nginx:src", host)
+	if err := crane.Push(empty.Image, src, crane.Insecure); err != nil {
+		t.Fatalf("seeding %s: %v", src, err)
+	}
+
+	c := New(Options{})
+	ctx := context.Background()
+	dst := fmt.Sprintf("%s/library/nginx:dst", host)
+
+	if err := c.Copy(ctx, src, dst); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+
+	srcDigest, err := c.Digest(ctx, src)
+	if err != nil {
+		t.Fatalf("Digest(src) error = %v", err)
+	}
+	dstDigest, err := c.Digest(ctx, dst)
+	if err != nil {
+		t.Fatalf("Digest(dst) error = %v", err)
+	}
+	if srcDigest != dstDigest {
+		t.Errorf("Copy() produced digest %q, want %q (matching source)", dstDigest, srcDigest)
+	}
+}
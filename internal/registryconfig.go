@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrRegistryBlocked is returned by RegistryConfig.RewriteRef when ref's
+// registry matches an entry with blocked: true.
+var ErrRegistryBlocked = errors.New("registry is blocked by registry config")
+
+// RegistryMirror is one additional location a RegistryConfigEntry's images
+// can be pulled from, modelled on sysregistriesv2's [[registry.mirror]].
+type RegistryMirror struct {
+	Location string `yaml:"location"`
+
+	// Insecure allows plain HTTP / skips TLS verification for this mirror
+	// only, independent of the registry it's mirroring.
+	Insecure bool `yaml:"insecure,omitempty"`
+
+	// PullFromMirror is accepted for parity with sysregistriesv2's field of
+	// the same name but isn't consulted yet: RewriteRef always prefers
+	// Mirror[0] and falls back through the rest in order, the same
+	// "digest-only" default sysregistriesv2 uses when this is unset.
+	PullFromMirror string `yaml:"pull-from-mirror,omitempty"`
+}
+
+// RegistryConfigEntry matches images whose registry host equals Location
+// and, when Prefix is set, whose repository also starts with Prefix on a
+// path-segment boundary. A matching entry either blocks the image outright
+// or lists mirrors RewriteRef rewrites the ref to.
+type RegistryConfigEntry struct {
+	Location string           `yaml:"location"`
+	Prefix   string           `yaml:"prefix,omitempty"`
+	Blocked  bool             `yaml:"blocked,omitempty"`
+	Mirror   []RegistryMirror `yaml:"mirror,omitempty"`
+}
+
+// RegistryConfig is a sysregistriesv2-style mirror/block list, loaded from
+// a YAML file (-registry-config). It lets verity rewrite discovered image
+// refs to pull through internal mirrors, with fallback across more than
+// one, and reject images from registries an org doesn't trust — richer
+// than the plain tag substitutions ImageOverride/ApplyOverrides handle
+// (distroless → debian and similar), which RegistryConfig complements
+// rather than replaces: one rewrites a tag, the other rewrites a registry.
+type RegistryConfig struct {
+	UnqualifiedSearchRegistries []string              `yaml:"unqualified-search-registries,omitempty"`
+	Registries                  []RegistryConfigEntry `yaml:"registry"`
+}
+
+// LoadRegistryConfig reads a RegistryConfig from path. As with
+// LoadRepoConfig, it returns (nil, nil) for an empty path: every method on
+// a nil *RegistryConfig behaves as if no config was given, so callers don't
+// need to special-case "no -registry-config" themselves.
+func LoadRegistryConfig(path string) (*RegistryConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading registry config %s: %w", path, err)
+	}
+	var cfg RegistryConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing registry config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// match finds the entry governing a ref with the given registry host and
+// repository, preferring the entry whose Prefix is the longest match so a
+// more specific rule (e.g. "quay.io" + prefix "prometheus") wins over a
+// host-wide one (e.g. "quay.io" with no prefix) — the same longest-match
+// precedence sysregistriesv2 uses.
+func (c *RegistryConfig) match(registry, repository string) (RegistryConfigEntry, bool) {
+	if c == nil {
+		return RegistryConfigEntry{}, false
+	}
+
+	var best RegistryConfigEntry
+	bestLen := -1
+	for _, e := range c.Registries {
+		if e.Location != registry {
+			continue
+		}
+		if e.Prefix != "" && !prefixMatches(repository, e.Prefix) {
+			continue
+		}
+		if len(e.Prefix) > bestLen {
+			best, bestLen = e, len(e.Prefix)
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// prefixMatches reports whether repository starts with prefix on a
+// path-segment boundary, the same rule ImageOverride.Match uses so a
+// prefix of "prometheus" doesn't also match "prometheus-operator".
+func prefixMatches(repository, prefix string) bool {
+	if !strings.HasPrefix(repository, prefix) {
+		return false
+	}
+	return len(repository) == len(prefix) || repository[len(prefix)] == '/'
+}
+
+// RewriteRef resolves ref against c, returning the ref verity should
+// actually pull plus any further mirrors to fall back to on a transient
+// error (see isRetryableRegistryError). A nil RegistryConfig, or a ref that
+// matches no entry, returns ref unchanged with no mirrors and no error.
+//
+// The request this was built from modelled the signature on
+// sysregistriesv2's own "RewriteRef(ref) (rewritten string, mirrors
+// []string)"; an error return was added so a blocked registry is reported
+// through the same sentinel-error convention as errImageNotInLockfile and
+// errPatchedOnlyNeedsTarget, instead of rewriting it to an empty string a
+// caller could easily fail to check for.
+func (c *RegistryConfig) RewriteRef(ref string) (string, []string, error) {
+	if c == nil {
+		return ref, nil, nil
+	}
+
+	registry, repository, tag := ParseImageRef(ref)
+	if registry == "" {
+		if resolved, ok := c.resolveUnqualified(); ok {
+			registry = resolved
+			// Official Docker Hub images are single-segment ("redis", not
+			// "library/redis"); docker.io's API only recognizes the latter,
+			// the same normalization NormalizeImageRef applies.
+			if registry == "docker.io" && !strings.Contains(repository, "/") {
+				repository = "library/" + repository
+			}
+			ref = rewriteRegistry(repository, tag, registry)
+		}
+	}
+
+	entry, ok := c.match(registry, repository)
+	if !ok {
+		return ref, nil, nil
+	}
+	if entry.Blocked {
+		return "", nil, fmt.Errorf("%w: %s", ErrRegistryBlocked, ref)
+	}
+	if len(entry.Mirror) == 0 {
+		return ref, nil, nil
+	}
+
+	rewritten := rewriteRegistry(repository, tag, entry.Mirror[0].Location)
+	var mirrors []string
+	for _, m := range entry.Mirror[1:] {
+		mirrors = append(mirrors, rewriteRegistry(repository, tag, m.Location))
+	}
+	return rewritten, mirrors, nil
+}
+
+// resolveUnqualified returns the first of c.UnqualifiedSearchRegistries, the
+// registry RewriteRef qualifies an unqualified ref (e.g. "redis:7.0", no
+// registry host) against before matching it against Registries — the same
+// "first configured search registry wins" precedence containers/image's
+// short-name resolution uses, simplified to skip the existence probing that
+// would require a registry round-trip.
+func (c *RegistryConfig) resolveUnqualified() (string, bool) {
+	if len(c.UnqualifiedSearchRegistries) == 0 {
+		return "", false
+	}
+	return c.UnqualifiedSearchRegistries[0], true
+}
+
+// rewriteRegistry rebuilds a ref from repository and tagOrDigest (as
+// ParseImageRef returns them) against registry. tagOrDigest containing a
+// ":" is a digest value (ParseImageRef returns the part after "@"
+// verbatim, e.g. "sha256:abc123"), rejoined with "@" instead of ":".
+func rewriteRegistry(repository, tagOrDigest, registry string) string {
+	ref := registry + "/" + repository
+	switch {
+	case tagOrDigest == "":
+		return ref
+	case strings.Contains(tagOrDigest, ":"):
+		return ref + "@" + tagOrDigest
+	default:
+		return ref + ":" + tagOrDigest
+	}
+}
+
+// RewriteManifestRegistries rewrites every image in manifest (the flat
+// Images list and each chart's Images) through cfg's mirror/block rules,
+// replacing each ImageDiscovery's Registry/Repository/Tag with the ref
+// RewriteRef resolves it to so the matrix GenerateMatrix later builds
+// contains the refs Copa will actually pull. It returns the resolved
+// mirrors for each rewritten image, keyed by the rewritten ref (for
+// GenerateMatrix to carry onto MatrixEntry.Mirrors), and one
+// DiscoveryReport per image blocked by cfg, reusing the aggregated-failure
+// shape chart download/scan failures already use so a blocked registry
+// surfaces the same way in runDiscover.
+//
+// A nil cfg (no -registry-config given) is a no-op.
+func RewriteManifestRegistries(manifest *DiscoveryManifest, cfg *RegistryConfig) (map[string][]string, []DiscoveryReport) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	mirrors := make(map[string][]string)
+	var reports []DiscoveryReport
+
+	rewrite := func(images []ImageDiscovery) []ImageDiscovery {
+		var kept []ImageDiscovery
+		for _, img := range images {
+			rewritten, imgMirrors, err := cfg.RewriteRef(img.reference())
+			if err != nil {
+				reports = append(reports, DiscoveryReport{Chart: img.reference(), Stage: "registry", Err: err})
+				continue
+			}
+
+			registry, repository, tagOrDigest := ParseImageRef(rewritten)
+			img.Registry, img.Repository = registry, repository
+			img.Tag, img.Digest = "", ""
+			if strings.Contains(tagOrDigest, ":") {
+				img.Digest = tagOrDigest
+			} else {
+				img.Tag = tagOrDigest
+			}
+
+			if len(imgMirrors) > 0 {
+				mirrors[img.reference()] = imgMirrors
+			}
+			kept = append(kept, img)
+		}
+		return kept
+	}
+
+	manifest.Images = rewrite(manifest.Images)
+	for i := range manifest.Charts {
+		manifest.Charts[i].Images = rewrite(manifest.Charts[i].Images)
+	}
+
+	return mirrors, reports
+}
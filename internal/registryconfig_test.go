@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRewriteRefNilConfig(t *testing.T) {
+	var cfg *RegistryConfig
+	ref, mirrors, err := cfg.RewriteRef("quay.io/prometheus/prometheus:v3.9.1")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if ref != "quay.io/prometheus/prometheus:v3.9.1" || mirrors != nil {
+		t.Errorf("got (%q, %v), want ref unchanged and no mirrors", ref, mirrors)
+	}
+}
+
+func TestRewriteRefNoMatchingEntry(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "other.example.com"},
+	}}
+	ref, mirrors, err := cfg.RewriteRef("quay.io/prometheus/prometheus:v3.9.1")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if ref != "quay.io/prometheus/prometheus:v3.9.1" || mirrors != nil {
+		t.Errorf("got (%q, %v), want ref unchanged and no mirrors", ref, mirrors)
+	}
+}
+
+func TestRewriteRefUnqualifiedSearchRegistries(t *testing.T) {
+	cfg := &RegistryConfig{
+		UnqualifiedSearchRegistries: []string{"docker.io"},
+		Registries: []RegistryConfigEntry{
+			{Location: "docker.io", Prefix: "library", Mirror: []RegistryMirror{{Location: "mirror.example.com"}}},
+		},
+	}
+	ref, mirrors, err := cfg.RewriteRef("redis:7.0")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if ref != "mirror.example.com/library/redis:7.0" || mirrors != nil {
+		t.Errorf("got (%q, %v), want the unqualified ref resolved against docker.io, library/-prefixed, and rewritten through its mirror", ref, mirrors)
+	}
+}
+
+func TestRewriteRefUnqualifiedNoSearchRegistries(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "docker.io", Blocked: true},
+	}}
+	ref, mirrors, err := cfg.RewriteRef("redis:7.0")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if ref != "redis:7.0" || mirrors != nil {
+		t.Errorf("got (%q, %v), want the ref left unqualified when no search registries are configured", ref, mirrors)
+	}
+}
+
+func TestRewriteRefBlocked(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "quay.io", Blocked: true},
+	}}
+	if _, _, err := cfg.RewriteRef("quay.io/prometheus/prometheus:v3.9.1"); !errors.Is(err, ErrRegistryBlocked) {
+		t.Errorf("RewriteRef error = %v, want ErrRegistryBlocked", err)
+	}
+}
+
+func TestRewriteRefMirrorsWithFallback(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{
+			Location: "quay.io",
+			Prefix:   "prometheus",
+			Mirror: []RegistryMirror{
+				{Location: "mirror1.example.com"},
+				{Location: "mirror2.example.com"},
+			},
+		},
+	}}
+
+	rewritten, mirrors, err := cfg.RewriteRef("quay.io/prometheus/prometheus:v3.9.1")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if rewritten != "mirror1.example.com/prometheus/prometheus:v3.9.1" {
+		t.Errorf("rewritten = %q, want first mirror", rewritten)
+	}
+	if len(mirrors) != 1 || mirrors[0] != "mirror2.example.com/prometheus/prometheus:v3.9.1" {
+		t.Errorf("mirrors = %v, want [mirror2.example.com/prometheus/prometheus:v3.9.1]", mirrors)
+	}
+}
+
+func TestRewriteRefPrefixDoesNotMatchSiblingRepository(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "quay.io", Prefix: "prometheus", Blocked: true},
+	}}
+
+	ref, _, err := cfg.RewriteRef("quay.io/prometheus-operator/operator:v1.0")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if ref != "quay.io/prometheus-operator/operator:v1.0" {
+		t.Errorf("got %q, want ref unchanged (prefix shouldn't match a sibling repository)", ref)
+	}
+}
+
+func TestRewriteRefDigestRef(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "quay.io", Mirror: []RegistryMirror{{Location: "mirror.example.com"}}},
+	}}
+
+	rewritten, _, err := cfg.RewriteRef("quay.io/prometheus/prometheus@sha256:abc123")
+	if err != nil {
+		t.Fatalf("RewriteRef: %v", err)
+	}
+	if rewritten != "mirror.example.com/prometheus/prometheus@sha256:abc123" {
+		t.Errorf("got %q, want digest preserved with @ separator", rewritten)
+	}
+}
+
+func TestLoadRegistryConfigEmptyPath(t *testing.T) {
+	cfg, err := LoadRegistryConfig("")
+	if err != nil || cfg != nil {
+		t.Errorf("LoadRegistryConfig(\"\") = (%v, %v), want (nil, nil)", cfg, err)
+	}
+}
+
+func TestLoadRegistryConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registries.yaml")
+	data := `
+unqualified-search-registries:
+  - docker.io
+registry:
+  - location: quay.io
+    prefix: prometheus
+    mirror:
+      - location: mirror.example.com
+        insecure: true
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+
+	cfg, err := LoadRegistryConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRegistryConfig: %v", err)
+	}
+	if len(cfg.UnqualifiedSearchRegistries) != 1 || cfg.UnqualifiedSearchRegistries[0] != "docker.io" {
+		t.Errorf("UnqualifiedSearchRegistries = %v, want [docker.io]", cfg.UnqualifiedSearchRegistries)
+	}
+	if len(cfg.Registries) != 1 || cfg.Registries[0].Location != "quay.io" {
+		t.Fatalf("Registries = %v, want one entry for quay.io", cfg.Registries)
+	}
+	if !cfg.Registries[0].Mirror[0].Insecure {
+		t.Error("expected the mirror's insecure flag to be carried through")
+	}
+}
+
+func TestRewriteManifestRegistries(t *testing.T) {
+	cfg := &RegistryConfig{Registries: []RegistryConfigEntry{
+		{Location: "quay.io", Mirror: []RegistryMirror{{Location: "mirror.example.com"}}},
+		{Location: "docker.io", Blocked: true},
+	}}
+
+	manifest := &DiscoveryManifest{
+		Images: []ImageDiscovery{
+			{Registry: "quay.io", Repository: "prometheus/prometheus", Tag: "v3.9.1"},
+			{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"},
+		},
+	}
+
+	mirrors, reports := RewriteManifestRegistries(manifest, cfg)
+
+	if len(manifest.Images) != 1 {
+		t.Fatalf("expected the blocked image to be dropped, got %d images", len(manifest.Images))
+	}
+	if manifest.Images[0].Registry != "mirror.example.com" {
+		t.Errorf("Registry = %q, want mirror.example.com", manifest.Images[0].Registry)
+	}
+	if len(reports) != 1 || reports[0].Stage != "registry" {
+		t.Errorf("reports = %v, want one registry-stage report for the blocked image", reports)
+	}
+	if len(mirrors) != 0 {
+		t.Errorf("mirrors = %v, want empty (quay.io entry has only one mirror, no further fallback)", mirrors)
+	}
+}
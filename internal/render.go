@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+// discoverNamespace is the ephemeral namespace used only to satisfy charts
+// whose templates reference .Release.Namespace; nothing is actually
+// installed into a cluster.
+const discoverNamespace = "verity-discover"
+
+// DiscoverImagesFromChart pulls dep (an OCI chart ref like
+// "oci://ghcr.io/org/chart" or an HTTP repo + name/version), renders it
+// with the Helm template engine against an optional values override file,
+// and walks every rendered manifest — Deployments, StatefulSets,
+// DaemonSets, CronJobs, Pods, and their initContainers — for image
+// references.
+//
+// engine.Render renders the umbrella chart and all of its "charts/"
+// subcharts in one pass, so umbrella charts like kube-prometheus-stack
+// surface every subchart's images without the caller hand-maintaining a
+// values.yaml. Each returned ImageDiscovery's Path records provenance as
+// "<subchart>:<template-path>" ("" subchart for the umbrella chart's own
+// templates) so callers can group images by the chart that defines them.
+//
+// verify and keyringPath are passed straight through to DownloadChart to
+// control .prov signature checking on the pulled chart.
+func DiscoverImagesFromChart(dep Dependency, valuesPath, tmpDir string, verify ChartVerifyMode, keyringPath string) ([]ImageDiscovery, error) {
+	chartPath, _, err := DownloadChart(dep, tmpDir, verify, keyringPath)
+	if err != nil {
+		return nil, fmt.Errorf("downloading chart %s: %w", dep.Name, err)
+	}
+
+	ch, err := loader.LoadDir(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading chart %s: %w", dep.Name, err)
+	}
+
+	overrides, err := loadValuesOverride(valuesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseOpts := chartutil.ReleaseOptions{
+		Name:      ch.Name(),
+		Namespace: discoverNamespace,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(ch, overrides, releaseOpts, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("computing render values for %s: %w", dep.Name, err)
+	}
+
+	rendered, err := engine.Render(ch, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("rendering chart %s: %w", dep.Name, err)
+	}
+
+	seen := make(map[string]struct{})
+	var images []ImageDiscovery
+	for templatePath, manifest := range rendered {
+		if strings.TrimSpace(manifest) == "" {
+			continue
+		}
+		subchart := SubchartFromTemplatePath(templatePath)
+		refs, err := imagesFromManifestYAML([]byte(manifest))
+		if err != nil {
+			return nil, fmt.Errorf("parsing rendered manifest %s: %w", templatePath, err)
+		}
+		for _, ref := range refs {
+			key := subchart + "|" + ref
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			img := parseRef(ref)
+			img.Path = templatePath
+			images = append(images, ImageDiscovery(img))
+		}
+	}
+
+	return images, nil
+}
+
+// loadValuesOverride reads a values override YAML file, returning an empty
+// map (not an error) when path is empty.
+func loadValuesOverride(path string) (map[string]any, error) {
+	if path == "" {
+		return map[string]any{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading values override %s: %w", path, err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parsing values override %s: %w", path, err)
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	return values, nil
+}
+
+// SubchartFromTemplatePath extracts the originating subchart name from a
+// rendered template key such as "parent/charts/child/templates/deploy.yaml",
+// returning "" for the umbrella chart's own templates
+// ("parent/templates/deploy.yaml").
+func SubchartFromTemplatePath(templatePath string) string {
+	const marker = "/charts/"
+	idx := strings.Index(templatePath, marker)
+	if idx == -1 {
+		return ""
+	}
+	rest := templatePath[idx+len(marker):]
+	if slash := strings.Index(rest, "/"); slash != -1 {
+		return rest[:slash]
+	}
+	return rest
+}
+
+// imagesFromManifestYAML decodes a (possibly multi-document) rendered
+// Kubernetes manifest and collects every "image" string field found at any
+// depth, which covers Deployments, StatefulSets, DaemonSets, CronJobs,
+// Pods, and initContainers alike without needing kind-specific paths.
+func imagesFromManifestYAML(data []byte) ([]string, error) {
+	var result []string
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			// Rendered output can contain non-manifest documents (e.g. NOTES.txt
+			// leaking in via "---"); skip anything that isn't valid YAML rather
+			// than failing the whole chart.
+			break
+		}
+		if doc == nil {
+			continue
+		}
+		walkManifestImages(doc, &result)
+	}
+	return result, nil
+}
+
+func walkManifestImages(node any, result *[]string) {
+	switch v := node.(type) {
+	case map[string]any:
+		if img, ok := v["image"].(string); ok && img != "" {
+			*result = append(*result, img)
+		}
+		for _, val := range v {
+			walkManifestImages(val, result)
+		}
+	case []any:
+		for _, item := range v {
+			walkManifestImages(item, result)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import "testing"
+
+func TestSubchartFromTemplatePath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"umbrella template", "kube-prometheus-stack/templates/deployment.yaml", ""},
+		{"subchart template", "kube-prometheus-stack/charts/grafana/templates/deployment.yaml", "grafana"},
+		{"nested subchart template", "parent/charts/child/charts/grandchild/templates/pod.yaml", "child"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SubchartFromTemplatePath(tc.path); got != tc.want {
+				t.Errorf("SubchartFromTemplatePath(%q) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestImagesFromManifestYAML(t *testing.T) {
+	manifest := []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: busybox:1.36
+      containers:
+        - name: app
+          image: nginx:1.25
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: job
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: job
+              image: alpine:3.19
+`)
+
+	images, err := imagesFromManifestYAML(manifest)
+	if err != nil {
+		t.Fatalf("imagesFromManifestYAML returned error: %v", err)
+	}
+
+	want := map[string]bool{"busybox:1.36": false, "nginx:1.25": false, "alpine:3.19": false}
+	if len(images) != len(want) {
+		t.Fatalf("expected %d images, got %d: %v", len(want), len(images), images)
+	}
+	for _, img := range images {
+		if _, ok := want[img]; !ok {
+			t.Errorf("unexpected image %q", img)
+		}
+		want[img] = true
+	}
+	for img, found := range want {
+		if !found {
+			t.Errorf("expected image %q not found", img)
+		}
+	}
+}
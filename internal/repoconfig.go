@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"gopkg.in/yaml.v3"
+)
+
+// RepoAuth holds the TLS material and credentials verity should present
+// when talking to one chart repository or image registry host. Field
+// names mirror Helm's own --ca-file/--cert-file/--key-file/
+// --insecure-skip-tls-verify/--username/--password flags so a
+// repositories.yaml written for this tool reads the same as one written
+// for `helm repo add`.
+type RepoAuth struct {
+	CAFile                string `yaml:"caFile,omitempty"`
+	CertFile              string `yaml:"certFile,omitempty"`
+	KeyFile               string `yaml:"keyFile,omitempty"`
+	InsecureSkipTLSVerify bool   `yaml:"insecureSkipTLSverify,omitempty"`
+	Username              string `yaml:"username,omitempty"`
+	Password              string `yaml:"password,omitempty"`
+	BearerToken           string `yaml:"bearerToken,omitempty"`
+}
+
+// RepoConfig is a repositories.yaml-style map of per-host auth settings,
+// keyed by registry/repo host (e.g. "ghcr.io", "charts.example.com:8443").
+// It is consulted by chart downloads (helmPull, downloadTarball) and by
+// CraneProber's registry tag/digest lookups (see tagprober.go) so private
+// OCI registries and self-signed HTTPS chart repos work the same as
+// public ones.
+type RepoConfig struct {
+	Repositories map[string]RepoAuth `yaml:"repositories"`
+}
+
+// LoadRepoConfig reads a RepoConfig from path. It returns (nil, nil), not
+// an error, when path is empty: verity then falls back to anonymous
+// access and the ambient docker/cosign keychain, exactly as before this
+// config existed.
+func LoadRepoConfig(path string) (*RepoConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading repo config %s: %w", path, err)
+	}
+	var cfg RepoConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing repo config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// hostOf extracts the registry/repo host from ref, stripping any scheme,
+// path, tag, or digest.
+func hostOf(ref string) string {
+	ref = strings.TrimPrefix(ref, "oci://")
+	if idx := strings.Index(ref, "://"); idx != -1 {
+		ref = ref[idx+3:]
+	}
+	ref = strings.TrimSuffix(ref, "/")
+	if idx := strings.Index(ref, "/"); idx != -1 {
+		ref = ref[:idx]
+	}
+	return ref
+}
+
+// authFor looks up the auth settings for ref's host. ref may be a bare
+// host, a host:port, or a full image/chart reference the host is
+// extracted from. A nil RepoConfig (no config loaded) never matches.
+func (c *RepoConfig) authFor(ref string) (RepoAuth, bool) {
+	if c == nil {
+		return RepoAuth{}, false
+	}
+	auth, ok := c.Repositories[hostOf(ref)]
+	return auth, ok
+}
+
+// tlsConfig builds a *tls.Config from auth's CA/client-cert settings.
+func (a RepoAuth) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: a.InsecureSkipTLSVerify} //nolint:gosec // opt-in per host, not a default
+	if a.CAFile != "" {
+		pem, err := os.ReadFile(a.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file %s: %w", a.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", a.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if a.CertFile != "" && a.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(a.CertFile, a.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client cert %s/%s: %w", a.CertFile, a.KeyFile, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// authRoundTripper adds basic or bearer auth to every request before
+// delegating to base.
+type authRoundTripper struct {
+	base     http.RoundTripper
+	username string
+	password string
+	token    string
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	switch {
+	case rt.token != "":
+		req.Header.Set("Authorization", "Bearer "+rt.token)
+	case rt.username != "":
+		req.SetBasicAuth(rt.username, rt.password)
+	}
+	return rt.base.RoundTrip(req)
+}
+
+// HTTPClient returns an *http.Client configured with ref's host's mTLS and
+// auth settings. When ref's host has no entry (including when c is nil),
+// it returns a plain client with the same 5-minute timeout
+// downloadTarball has always used.
+func (c *RepoConfig) HTTPClient(ref string) (*http.Client, error) {
+	auth, ok := c.authFor(ref)
+	if !ok {
+		return &http.Client{Timeout: 5 * time.Minute}, nil
+	}
+
+	tlsCfg, err := auth.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	var rt http.RoundTripper = transport
+	if auth.Username != "" || auth.BearerToken != "" {
+		rt = &authRoundTripper{base: transport, username: auth.Username, password: auth.Password, token: auth.BearerToken}
+	}
+	return &http.Client{Transport: rt, Timeout: 5 * time.Minute}, nil
+}
+
+// CraneOptions returns the crane.Option list to use for ref, applying
+// ref's host's mTLS settings and preferring explicit basic/bearer
+// credentials over the ambient docker/cosign keychain.
+func (c *RepoConfig) CraneOptions(ref string) ([]crane.Option, error) {
+	opts, ok, err := c.overrideOptions(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []crane.Option{crane.WithAuthFromKeychain(authn.DefaultKeychain)}, nil
+	}
+	return opts, nil
+}
+
+// overrideOptions returns ref's host's RepoConfig-driven crane.Options
+// (mTLS plus explicit basic/bearer auth), and ok=false (not an error) when
+// ref's host has no entry — unlike CraneOptions, it never falls back to
+// DefaultKeychain itself, so callers like CraneProber (see tagprober.go)
+// can tell "no override, use my own keychain" apart from "override is an
+// empty option list."
+func (c *RepoConfig) overrideOptions(ref string) ([]crane.Option, bool, error) {
+	auth, ok := c.authFor(ref)
+	if !ok {
+		return nil, false, nil
+	}
+
+	tlsCfg, err := auth.tlsConfig()
+	if err != nil {
+		return nil, true, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	opts := []crane.Option{crane.WithTransport(transport)}
+
+	switch {
+	case auth.Username != "":
+		opts = append(opts, crane.WithAuth(authn.FromConfig(authn.AuthConfig{
+			Username: auth.Username,
+			Password: auth.Password,
+		})))
+	case auth.BearerToken != "":
+		opts = append(opts, crane.WithAuth(authn.FromConfig(authn.AuthConfig{
+			RegistryToken: auth.BearerToken,
+		})))
+	default:
+		opts = append(opts, crane.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return opts, true, nil
+}
+
+// activeRepoConfig is the process-wide RepoConfig installed by the CLI
+// entrypoint from -repo-config/VERITY_REPO_CONFIG. nil means "no config
+// loaded," so every lookup above falls back to anonymous access.
+var activeRepoConfig *RepoConfig
+
+// SetRepoConfig installs cfg as the RepoConfig consulted by chart
+// downloads and registry lookups for the remainder of the process.
+func SetRepoConfig(cfg *RepoConfig) {
+	activeRepoConfig = cfg
+}
+
+// repoConfigOverride returns the active RepoConfig's override
+// crane.Options for ref's host, or nil if it has no entry (including when
+// no RepoConfig is loaded at all) — see CraneProber.OptionsForRef in
+// tagprober.go, which falls back to its own Keychain in that case instead
+// of activeRepoConfig's default DefaultKeychain fallback.
+func repoConfigOverride(_ context.Context, ref string) ([]crane.Option, error) {
+	opts, ok, err := activeRepoConfig.overrideOptions(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return opts, nil
+}
@@ -0,0 +1,288 @@
+// Package report renders Trivy vulnerability data into a self-contained,
+// shareable static site (HTML or Markdown), independent of the full Verity
+// catalog site. It reads the same reports-dir/post-reports-dir Trivy JSON
+// layout as internal.GenerateSiteDataFromJSON: one {sanitized-ref}.json file
+// per image, where post-reports-dir (optional) holds the "after patching"
+// scan for the same image.
+package report
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed templates/*.html.tmpl
+var defaultTemplates embed.FS
+
+// Format is a renderable output format for GenerateReports.
+type Format string
+
+// Supported output formats.
+const (
+	FormatHTML Format = "html"
+	FormatMD   Format = "md"
+)
+
+// trivyReport is the subset of a Trivy JSON report this package reads.
+type trivyReport struct {
+	ArtifactName string `json:"ArtifactName"`
+	Results      []struct {
+		Vulnerabilities []trivyVuln `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+type trivyVuln struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	Severity         string `json:"Severity"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Title            string `json:"Title"`
+	Description      string `json:"Description"`
+}
+
+// TagPage is the per-image (repository:tag) report page data.
+type TagPage struct {
+	Name      string // sanitized ref, used for the page filename
+	Reference string
+	Before    []trivyVuln
+	After     []trivyVuln
+	BadVulns  int // Critical + High count, from the "before" (upstream) scan
+}
+
+// IndexEntry is one row in the top-level index.
+type IndexEntry struct {
+	Reference string
+	Page      string
+	BadVulns  int
+}
+
+// GenerateReports reads Trivy JSON reports from reportsDir (and optionally
+// postReportsDir for before/after comparison), and writes a self-contained
+// static site to outputDir: an index.{ext} listing every image with its
+// BadVulns count, and one per-image page with CVE tables. templateDir, if
+// non-empty, overrides the embedded default theme with user-supplied
+// "index.html.tmpl" / "tag.html.tmpl" files (Markdown output always uses
+// the built-in renderer).
+func GenerateReports(reportsDir, postReportsDir, outputDir, templateDir string, formats []Format) error {
+	pages, err := buildTagPages(reportsDir, postReportsDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("creating output dir: %w", err)
+	}
+
+	for _, f := range formats {
+		switch f {
+		case FormatHTML:
+			if err := renderHTML(pages, outputDir, templateDir); err != nil {
+				return fmt.Errorf("rendering html report: %w", err)
+			}
+		case FormatMD:
+			if err := renderMarkdown(pages, outputDir); err != nil {
+				return fmt.Errorf("rendering markdown report: %w", err)
+			}
+		default:
+			return fmt.Errorf("unsupported report format %q", f)
+		}
+	}
+	return nil
+}
+
+// buildTagPages reads every Trivy report in reportsDir, pairing it with the
+// matching post-patch report in postReportsDir (if any) by filename.
+func buildTagPages(reportsDir, postReportsDir string) ([]TagPage, error) {
+	entries, err := os.ReadDir(reportsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading reports dir: %w", err)
+	}
+
+	var pages []TagPage
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		before, err := readTrivyReport(filepath.Join(reportsDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", e.Name(), err)
+		}
+
+		var after *trivyReport
+		if postReportsDir != "" {
+			if a, err := readTrivyReport(filepath.Join(postReportsDir, e.Name())); err == nil {
+				after = a
+			}
+		}
+
+		page := TagPage{
+			Name:      strings.TrimSuffix(e.Name(), ".json"),
+			Reference: before.ArtifactName,
+			Before:    flattenVulns(before),
+		}
+		if after != nil {
+			page.After = flattenVulns(after)
+		}
+		page.BadVulns = countBadVulns(page.Before)
+		pages = append(pages, page)
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Reference < pages[j].Reference })
+	return pages, nil
+}
+
+func readTrivyReport(path string) (*trivyReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r trivyReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func flattenVulns(r *trivyReport) []trivyVuln {
+	var vulns []trivyVuln
+	for _, res := range r.Results {
+		vulns = append(vulns, res.Vulnerabilities...)
+	}
+	sort.Slice(vulns, func(i, j int) bool {
+		return severityRank(vulns[i].Severity) > severityRank(vulns[j].Severity)
+	})
+	return vulns
+}
+
+// countBadVulns sums Critical and High severity findings, mirroring the
+// "BadVulns" count from Clair's classic VulnerabilityReport layout.
+func countBadVulns(vulns []trivyVuln) int {
+	count := 0
+	for _, v := range vulns {
+		if strings.EqualFold(v.Severity, "CRITICAL") || strings.EqualFold(v.Severity, "HIGH") {
+			count++
+		}
+	}
+	return count
+}
+
+func severityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return 4
+	case "HIGH":
+		return 3
+	case "MEDIUM":
+		return 2
+	case "LOW":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// nvdLink builds an NVD detail page URL for a CVE ID.
+func nvdLink(cveID string) string {
+	return "https://nvd.nist.gov/vuln/detail/" + cveID
+}
+
+func renderHTML(pages []TagPage, outputDir, templateDir string) error {
+	indexTmpl, tagTmpl, err := loadHTMLTemplates(templateDir)
+	if err != nil {
+		return err
+	}
+
+	var index []IndexEntry
+	for _, p := range pages {
+		pageFile := p.Name + ".html"
+		index = append(index, IndexEntry{Reference: p.Reference, Page: pageFile, BadVulns: p.BadVulns})
+
+		f, err := os.Create(filepath.Join(outputDir, pageFile))
+		if err != nil {
+			return err
+		}
+		err = tagTmpl.Execute(f, p)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("rendering %s: %w", pageFile, err)
+		}
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return indexTmpl.Execute(f, index)
+}
+
+func loadHTMLTemplates(templateDir string) (index, tag *template.Template, err error) {
+	funcs := template.FuncMap{"nvdLink": nvdLink}
+
+	if templateDir != "" {
+		index, err = template.New("index.html.tmpl").Funcs(funcs).ParseFiles(filepath.Join(templateDir, "index.html.tmpl"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading custom index template: %w", err)
+		}
+		tag, err = template.New("tag.html.tmpl").Funcs(funcs).ParseFiles(filepath.Join(templateDir, "tag.html.tmpl"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading custom tag template: %w", err)
+		}
+		return index, tag, nil
+	}
+
+	index, err = template.New("index.html.tmpl").Funcs(funcs).ParseFS(defaultTemplates, "templates/index.html.tmpl")
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading default index template: %w", err)
+	}
+	tag, err = template.New("tag.html.tmpl").Funcs(funcs).ParseFS(defaultTemplates, "templates/tag.html.tmpl")
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading default tag template: %w", err)
+	}
+	return index, tag, nil
+}
+
+func renderMarkdown(pages []TagPage, outputDir string) error {
+	var idx strings.Builder
+	idx.WriteString("# Vulnerability Report Index\n\n| Repository:Tag | Bad Vulns (Critical+High) |\n|---|---|\n")
+
+	for _, p := range pages {
+		pageFile := p.Name + ".md"
+		idx.WriteString(fmt.Sprintf("| [%s](%s) | %d |\n", p.Reference, pageFile, p.BadVulns))
+
+		var buf strings.Builder
+		buf.WriteString(fmt.Sprintf("# %s\n\n", p.Reference))
+		writeVulnTableMD(&buf, "Before", p.Before)
+		if p.After != nil {
+			writeVulnTableMD(&buf, "After", p.After)
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, pageFile), []byte(buf.String()), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, "index.md"), []byte(idx.String()), 0o644)
+}
+
+func writeVulnTableMD(buf *strings.Builder, heading string, vulns []trivyVuln) {
+	buf.WriteString(fmt.Sprintf("## %s\n\n", heading))
+	if len(vulns) == 0 {
+		buf.WriteString("No vulnerabilities found.\n\n")
+		return
+	}
+	buf.WriteString("| CVE | Severity | Package | Installed | Fixed | Description |\n|---|---|---|---|---|---|\n")
+	for _, v := range vulns {
+		buf.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | %s | %s | %s |\n",
+			v.VulnerabilityID, nvdLink(v.VulnerabilityID), v.Severity, v.PkgName,
+			v.InstalledVersion, v.FixedVersion, strings.ReplaceAll(v.Description, "|", "\\|")))
+	}
+	buf.WriteString("\n")
+}
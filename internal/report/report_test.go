@@ -0,0 +1,96 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeReportFile(t *testing.T, dir, name string, r trivyReport) {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("marshaling fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+}
+
+func TestBuildTagPages(t *testing.T) {
+	reportsDir := t.TempDir()
+	postReportsDir := t.TempDir()
+
+	before := trivyReport{
+		ArtifactName: "example.com/app:1.0",
+		Results: []struct {
+			Vulnerabilities []trivyVuln `json:"Vulnerabilities"`
+		}{
+			{Vulnerabilities: []trivyVuln{
+				{VulnerabilityID: "CVE-2024-1", Severity: "LOW"},
+				{VulnerabilityID: "CVE-2024-2", Severity: "CRITICAL"},
+				{VulnerabilityID: "CVE-2024-3", Severity: "HIGH"},
+			}},
+		},
+	}
+	after := trivyReport{ArtifactName: "example.com/app:1.0"}
+
+	writeReportFile(t, reportsDir, "app_1.0.json", before)
+	writeReportFile(t, postReportsDir, "app_1.0.json", after)
+
+	pages, err := buildTagPages(reportsDir, postReportsDir)
+	if err != nil {
+		t.Fatalf("buildTagPages returned error: %v", err)
+	}
+	if len(pages) != 1 {
+		t.Fatalf("expected 1 page, got %d", len(pages))
+	}
+
+	page := pages[0]
+	if page.BadVulns != 2 {
+		t.Errorf("BadVulns = %d, want 2", page.BadVulns)
+	}
+	if page.Before[0].Severity != "CRITICAL" {
+		t.Errorf("expected highest severity first, got %q", page.Before[0].Severity)
+	}
+	if page.After == nil || len(page.After) != 0 {
+		t.Errorf("expected empty After slice from post-reports-dir match, got %v", page.After)
+	}
+}
+
+func TestCountBadVulns(t *testing.T) {
+	vulns := []trivyVuln{
+		{Severity: "critical"},
+		{Severity: "HIGH"},
+		{Severity: "Medium"},
+		{Severity: "low"},
+	}
+	if got := countBadVulns(vulns); got != 2 {
+		t.Errorf("countBadVulns = %d, want 2", got)
+	}
+}
+
+func TestGenerateReportsMarkdown(t *testing.T) {
+	reportsDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	writeReportFile(t, reportsDir, "app_1.0.json", trivyReport{
+		ArtifactName: "example.com/app:1.0",
+		Results: []struct {
+			Vulnerabilities []trivyVuln `json:"Vulnerabilities"`
+		}{
+			{Vulnerabilities: []trivyVuln{{VulnerabilityID: "CVE-2024-9", Severity: "HIGH"}}},
+		},
+	})
+
+	if err := GenerateReports(reportsDir, "", outputDir, "", []Format{FormatMD}); err != nil {
+		t.Fatalf("GenerateReports returned error: %v", err)
+	}
+
+	for _, name := range []string{"index.md", "app_1.0.md"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:embed templates/serve/*.html.tmpl
+var serveTemplates embed.FS
+
+// ImageStatus carries the patch outcome for one image, so ServeReports can
+// show skipped/errored images on the dashboard even when they have no Trivy
+// report (e.g. a skipped image was never scanned). Reference must match the
+// "ArtifactName" in the corresponding Trivy JSON report when one exists.
+type ImageStatus struct {
+	Reference  string
+	Skipped    bool
+	SkipReason string
+	Error      string
+	VulnCount  int
+}
+
+// dashboardEntry is one row of the top-level dashboard: a TagPage merged
+// with its ImageStatus (if any) and a per-severity histogram of its "before"
+// vulnerabilities.
+type dashboardEntry struct {
+	Reference  string
+	Page       string // empty when the image has no Trivy report (e.g. skipped before scan)
+	Skipped    bool
+	SkipReason string
+	Error      string
+	Severities severityHistogram
+}
+
+type severityHistogram struct {
+	Critical int
+	High     int
+	Medium   int
+	Low      int
+	Other    int
+}
+
+func (h *severityHistogram) add(severity string) {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		h.Critical++
+	case "HIGH":
+		h.High++
+	case "MEDIUM":
+		h.Medium++
+	case "LOW":
+		h.Low++
+	default:
+		h.Other++
+	}
+}
+
+func (h severityHistogram) Total() int {
+	return h.Critical + h.High + h.Medium + h.Low + h.Other
+}
+
+// ServeReports starts an embedded HTTP dashboard over the same Trivy report
+// layout GenerateReports reads: a top-level page listing every image with
+// its patch status and severity histogram, per-image drill-down pages
+// (filterable by ?severity= and ?fixable=true), and before/after diff pages
+// showing which CVEs the patch eliminated. It blocks serving on addr (e.g.
+// ":8090") until the server stops.
+func ServeReports(reportsDir, postReportsDir string, statuses []ImageStatus, addr string) error {
+	pages, err := buildTagPages(reportsDir, postReportsDir)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := loadServeTemplates()
+	if err != nil {
+		return err
+	}
+
+	s := &server{
+		pages:     pagesByReference(pages),
+		statuses:  statusesByReference(statuses),
+		dashboard: buildDashboard(pages, statuses),
+		tmpl:      tmpl,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleDashboard)
+	mux.HandleFunc("/image/", s.handleImage)
+	mux.HandleFunc("/diff/", s.handleDiff)
+
+	return http.ListenAndServe(addr, mux)
+}
+
+type server struct {
+	pages     map[string]TagPage
+	statuses  map[string]ImageStatus
+	dashboard []dashboardEntry
+	tmpl      *template.Template
+}
+
+func pagesByReference(pages []TagPage) map[string]TagPage {
+	m := make(map[string]TagPage, len(pages))
+	for _, p := range pages {
+		m[p.Name] = p
+	}
+	return m
+}
+
+func statusesByReference(statuses []ImageStatus) map[string]ImageStatus {
+	m := make(map[string]ImageStatus, len(statuses))
+	for _, s := range statuses {
+		m[s.Reference] = s
+	}
+	return m
+}
+
+// buildDashboard merges TagPage data (from Trivy reports) with ImageStatus
+// data (from PatchResults) by Reference, so skipped/errored images without a
+// report still show up alongside scanned ones.
+func buildDashboard(pages []TagPage, statuses []ImageStatus) []dashboardEntry {
+	seen := make(map[string]struct{}, len(pages))
+	var entries []dashboardEntry
+
+	for _, p := range pages {
+		entry := dashboardEntry{Reference: p.Reference, Page: p.Name}
+		for _, v := range p.Before {
+			entry.Severities.add(v.Severity)
+		}
+		if st, ok := findStatus(statuses, p.Reference); ok {
+			entry.Skipped = st.Skipped
+			entry.SkipReason = st.SkipReason
+			entry.Error = st.Error
+		}
+		entries = append(entries, entry)
+		seen[p.Reference] = struct{}{}
+	}
+
+	for _, st := range statuses {
+		if _, ok := seen[st.Reference]; ok {
+			continue
+		}
+		entries = append(entries, dashboardEntry{
+			Reference:  st.Reference,
+			Skipped:    st.Skipped,
+			SkipReason: st.SkipReason,
+			Error:      st.Error,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Reference < entries[j].Reference })
+	return entries
+}
+
+func findStatus(statuses []ImageStatus, ref string) (ImageStatus, bool) {
+	for _, s := range statuses {
+		if s.Reference == ref {
+			return s, true
+		}
+	}
+	return ImageStatus{}, false
+}
+
+func loadServeTemplates() (*template.Template, error) {
+	funcs := template.FuncMap{"nvdLink": nvdLink}
+	tmpl, err := template.New("serve").Funcs(funcs).ParseFS(serveTemplates, "templates/serve/*.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("loading serve templates: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (s *server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if err := s.tmpl.ExecuteTemplate(w, "dashboard.html.tmpl", s.dashboard); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// imageViewData is the per-image drill-down page's template data: the
+// TagPage's "before" vulnerabilities, filtered per the request's ?severity=
+// and ?fixable= query params.
+type imageViewData struct {
+	TagPage
+	Vulns          []trivyVuln
+	SeverityFilter string
+	FixableOnly    bool
+}
+
+func (s *server) handleImage(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/image/")
+	page, ok := s.pages[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	severity := r.URL.Query().Get("severity")
+	fixableOnly := r.URL.Query().Get("fixable") == "true"
+
+	data := imageViewData{
+		TagPage:        page,
+		Vulns:          filterVulns(page.Before, severity, fixableOnly),
+		SeverityFilter: severity,
+		FixableOnly:    fixableOnly,
+	}
+	if err := s.tmpl.ExecuteTemplate(w, "image.html.tmpl", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// diffViewData is the before/after diff page's template data: vulnerabilities
+// present before patching but no longer present after, i.e. what the patch
+// eliminated.
+type diffViewData struct {
+	TagPage
+	Eliminated []trivyVuln
+	Remaining  []trivyVuln
+}
+
+func (s *server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/diff/")
+	page, ok := s.pages[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	afterIDs := make(map[string]struct{}, len(page.After))
+	for _, v := range page.After {
+		afterIDs[v.VulnerabilityID] = struct{}{}
+	}
+
+	var eliminated, remaining []trivyVuln
+	for _, v := range page.Before {
+		if _, stillPresent := afterIDs[v.VulnerabilityID]; stillPresent {
+			remaining = append(remaining, v)
+		} else {
+			eliminated = append(eliminated, v)
+		}
+	}
+
+	data := diffViewData{TagPage: page, Eliminated: eliminated, Remaining: remaining}
+	if err := s.tmpl.ExecuteTemplate(w, "diff.html.tmpl", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func filterVulns(vulns []trivyVuln, severity string, fixableOnly bool) []trivyVuln {
+	if severity == "" && !fixableOnly {
+		return vulns
+	}
+	var result []trivyVuln
+	for _, v := range vulns {
+		if severity != "" && !strings.EqualFold(v.Severity, severity) {
+			continue
+		}
+		if fixableOnly && v.FixedVersion == "" {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package report
+
+import "testing"
+
+func TestFilterVulnsBySeverity(t *testing.T) {
+	vulns := []trivyVuln{
+		{VulnerabilityID: "CVE-1", Severity: "HIGH", FixedVersion: "1.1"},
+		{VulnerabilityID: "CVE-2", Severity: "LOW"},
+	}
+	got := filterVulns(vulns, "HIGH", false)
+	if len(got) != 1 || got[0].VulnerabilityID != "CVE-1" {
+		t.Errorf("filterVulns(severity=HIGH) = %+v, want only CVE-1", got)
+	}
+}
+
+func TestFilterVulnsFixableOnly(t *testing.T) {
+	vulns := []trivyVuln{
+		{VulnerabilityID: "CVE-1", Severity: "HIGH", FixedVersion: "1.1"},
+		{VulnerabilityID: "CVE-2", Severity: "HIGH"},
+	}
+	got := filterVulns(vulns, "", true)
+	if len(got) != 1 || got[0].VulnerabilityID != "CVE-1" {
+		t.Errorf("filterVulns(fixable=true) = %+v, want only CVE-1", got)
+	}
+}
+
+func TestFilterVulnsNoFilterReturnsAll(t *testing.T) {
+	vulns := []trivyVuln{{VulnerabilityID: "CVE-1"}, {VulnerabilityID: "CVE-2"}}
+	got := filterVulns(vulns, "", false)
+	if len(got) != 2 {
+		t.Errorf("filterVulns(no filter) returned %d vulns, want 2", len(got))
+	}
+}
+
+func TestSeverityHistogramAdd(t *testing.T) {
+	var h severityHistogram
+	h.add("CRITICAL")
+	h.add("high")
+	h.add("unknown")
+	if h.Critical != 1 || h.High != 1 || h.Other != 1 {
+		t.Errorf("severityHistogram = %+v, want Critical=1 High=1 Other=1", h)
+	}
+	if h.Total() != 3 {
+		t.Errorf("Total() = %d, want 3", h.Total())
+	}
+}
+
+func TestBuildDashboardMergesStatusWithoutReport(t *testing.T) {
+	statuses := []ImageStatus{
+		{Reference: "docker.io/library/busybox:1.36", Skipped: true, SkipReason: "excluded by filter"},
+	}
+	entries := buildDashboard(nil, statuses)
+	if len(entries) != 1 {
+		t.Fatalf("buildDashboard() returned %d entries, want 1", len(entries))
+	}
+	if !entries[0].Skipped || entries[0].Page != "" {
+		t.Errorf("entries[0] = %+v, want Skipped=true and no Page", entries[0])
+	}
+}
+
+func TestBuildDashboardMergesStatusWithReport(t *testing.T) {
+	pages := []TagPage{
+		{
+			Name:      "nginx",
+			Reference: "docker.io/library/nginx:1.25",
+			Before:    []trivyVuln{{VulnerabilityID: "CVE-1", Severity: "HIGH"}},
+		},
+	}
+	statuses := []ImageStatus{
+		{Reference: "docker.io/library/nginx:1.25", Error: "patch failed"},
+	}
+	entries := buildDashboard(pages, statuses)
+	if len(entries) != 1 {
+		t.Fatalf("buildDashboard() returned %d entries, want 1", len(entries))
+	}
+	if entries[0].Error != "patch failed" || entries[0].Page != "nginx" || entries[0].Severities.High != 1 {
+		t.Errorf("entries[0] = %+v, want Error=%q Page=nginx Severities.High=1", entries[0], "patch failed")
+	}
+}
@@ -43,6 +43,11 @@ type cycloneDXMetadata struct {
 }
 
 type cycloneDXComponent struct {
+	// BOMRef uniquely identifies this component within the document so a
+	// sibling VEX document (see GenerateChartVEX) can point vulnerability
+	// "affects" entries at it. Set equal to PURL so the SBOM and VEX for
+	// the same chart always reference each other by the same string.
+	BOMRef  string `json:"bom-ref,omitempty"`
 	Type    string `json:"type"`
 	Name    string `json:"name"`
 	Version string `json:"version"`
@@ -64,11 +69,13 @@ func GenerateChartSBOM(chart ChartDiscovery, patchedImages []*PatchResult, wrapp
 	var components []cycloneDXComponent
 
 	// Add upstream chart as a dependency
+	chartPURL := chartToPURL(chart)
 	components = append(components, cycloneDXComponent{
+		BOMRef:  chartPURL,
 		Type:    "application",
 		Name:    chart.Name + " (upstream)",
 		Version: chart.Version,
-		PURL:    chartToPURL(chart),
+		PURL:    chartPURL,
 	})
 
 	// Add patched images (including mirrored images that were skipped with no fixable vulnerabilities)
@@ -77,11 +84,13 @@ func GenerateChartSBOM(chart ChartDiscovery, patchedImages []*PatchResult, wrapp
 		if pr.Error != nil || pr.Patched.Reference() == "" {
 			continue
 		}
+		imgPURL := imageToPURL(pr.Patched)
 		components = append(components, cycloneDXComponent{
+			BOMRef:  imgPURL,
 			Type:    "container",
 			Name:    pr.Patched.Repository,
 			Version: pr.Patched.Tag,
-			PURL:    imageToPURL(pr.Patched),
+			PURL:    imgPURL,
 		})
 	}
 
@@ -134,6 +143,126 @@ func imageToPURL(img Image) string {
 	return purl
 }
 
+// CycloneDX VEX structures (simplified, embedded-in-BOM style: one
+// vulnerability entry per image it affects rather than deduplicated across
+// images, mirroring AggregateVulnPredicate's own per-image granularity).
+type cycloneDXVEX struct {
+	BOMFormat       string                   `json:"bomFormat"`
+	SpecVersion     string                   `json:"specVersion"`
+	Version         int                      `json:"version"`
+	Vulnerabilities []cycloneDXVulnerability `json:"vulnerabilities"`
+}
+
+type cycloneDXVulnerability struct {
+	ID          string             `json:"id"`
+	Source      cycloneDXVEXSource `json:"source,omitempty"`
+	Ratings     []cycloneDXRating  `json:"ratings,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Affects     []cycloneDXAffects `json:"affects"`
+	Analysis    cycloneDXAnalysis  `json:"analysis"`
+}
+
+type cycloneDXVEXSource struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXRating struct {
+	Severity string `json:"severity,omitempty"`
+}
+
+type cycloneDXAffects struct {
+	Ref string `json:"ref"`
+}
+
+// cycloneDXAnalysis.State follows the CycloneDX VEX analysis state
+// enumeration (resolved, exploitable, in_triage, false_positive,
+// not_affected); GenerateChartVEX only ever derives "resolved" or
+// "in_triage" since Trivy doesn't otherwise tell us whether a vuln is
+// actually reachable.
+type cycloneDXAnalysis struct {
+	State string `json:"state"`
+}
+
+const (
+	vexStateResolved = "resolved"
+	vexStateInTriage = "in_triage"
+)
+
+// GenerateChartVEX creates a CycloneDX 1.5 VEX document for a chart,
+// linking each Trivy vulnerability aggregated from patchedImages' scan
+// reports to the affected component by bom-ref — the same PURL
+// GenerateChartSBOM assigns each image component, via imageToPURL, so a
+// chart release's SBOM and VEX always reference each other consistently.
+// analysis.state is "resolved" when Trivy reports a FixedVersion for the
+// vuln (meaning the patched image already carries the fix) and
+// "in_triage" otherwise.
+func GenerateChartVEX(chart ChartDiscovery, patchedImages []*PatchResult, reportsDir, outputPath string) error {
+	var vulns []cycloneDXVulnerability
+
+	for _, pr := range patchedImages {
+		if pr.Error != nil || pr.ReportPath == "" || pr.Patched.Reference() == "" {
+			continue
+		}
+
+		reportPath := pr.ReportPath
+		if !filepath.IsAbs(reportPath) {
+			reportPath = filepath.Join(reportsDir, filepath.Base(reportPath))
+		}
+
+		data, err := os.ReadFile(reportPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: cannot read report %s: %v\n", reportPath, err)
+			continue
+		}
+
+		var report trivyReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: cannot parse report %s: %v\n", reportPath, err)
+			continue
+		}
+
+		bomRef := imageToPURL(pr.Patched)
+		for _, res := range report.Results {
+			for _, v := range res.Vulnerabilities {
+				state := vexStateInTriage
+				if v.FixedVersion != "" {
+					state = vexStateResolved
+				}
+				vulns = append(vulns, cycloneDXVulnerability{
+					ID:          v.VulnerabilityID,
+					Source:      cycloneDXVEXSource{Name: "Trivy"},
+					Ratings:     []cycloneDXRating{{Severity: v.Severity}},
+					Description: v.Description,
+					Affects:     []cycloneDXAffects{{Ref: bomRef}},
+					Analysis:    cycloneDXAnalysis{State: state},
+				})
+			}
+		}
+	}
+
+	vex := cycloneDXVEX{
+		BOMFormat:       "CycloneDX",
+		SpecVersion:     "1.5",
+		Version:         1,
+		Vulnerabilities: vulns,
+	}
+
+	data, err := json.MarshalIndent(vex, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling VEX: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating VEX dir: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing VEX: %w", err)
+	}
+
+	return nil
+}
+
 // AggregateVulnPredicate aggregates Trivy reports from all underlying images
 // into a single vulnerability predicate for the chart.
 // The predicate format follows the cosign attest --type vuln schema.
@@ -162,6 +291,14 @@ func AggregateVulnPredicate(patchedImages []*PatchResult, reportsDir, outputPath
 			ScanFinishedOn string `json:"scanFinishedOn"`
 		} `json:"metadata"`
 		Vulnerabilities []vulnEntry `json:"vulnerabilities"`
+
+		// Predicates maps a platform (e.g. "linux/amd64", see
+		// PatchResult.Platform) to just that platform's vulnerabilities,
+		// for consumers that want arch-specific findings instead of the
+		// combined Vulnerabilities list above. Omitted entirely when no
+		// patchedImages entry has a Platform set (the previous,
+		// single-platform shape).
+		Predicates map[string][]vulnEntry `json:"predicates,omitempty"`
 	}
 
 	pred := predicate{}
@@ -174,6 +311,7 @@ func AggregateVulnPredicate(patchedImages []*PatchResult, reportsDir, outputPath
 	pred.Metadata.ScanFinishedOn = now
 
 	var allVulns []vulnEntry
+	var predicates map[string][]vulnEntry
 
 	// Aggregate vulnerabilities from all image Trivy reports
 	for _, pr := range patchedImages {
@@ -200,7 +338,7 @@ func AggregateVulnPredicate(patchedImages []*PatchResult, reportsDir, outputPath
 
 		for _, res := range report.Results {
 			for _, v := range res.Vulnerabilities {
-				allVulns = append(allVulns, vulnEntry{
+				entry := vulnEntry{
 					VulnerabilityID:  v.VulnerabilityID,
 					PkgName:          v.PkgName,
 					Severity:         v.Severity,
@@ -209,12 +347,20 @@ func AggregateVulnPredicate(patchedImages []*PatchResult, reportsDir, outputPath
 					Title:            v.Title,
 					Description:      v.Description,
 					Image:            pr.Patched.Reference(),
-				})
+				}
+				allVulns = append(allVulns, entry)
+				if pr.Platform != "" {
+					if predicates == nil {
+						predicates = make(map[string][]vulnEntry)
+					}
+					predicates[pr.Platform] = append(predicates[pr.Platform], entry)
+				}
 			}
 		}
 	}
 
 	pred.Vulnerabilities = allVulns
+	pred.Predicates = predicates
 
 	data, err := json.MarshalIndent(pred, "", "  ")
 	if err != nil {
@@ -228,6 +374,215 @@ func AggregateVulnPredicate(patchedImages []*PatchResult, reportsDir, outputPath
 	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
 		return fmt.Errorf("writing predicate: %w", err)
 	}
+	return nil
+}
+
+// vulnDeltaPredicateType identifies the in-toto predicate
+// AggregateVulnDeltaPredicate's output is meant to be attested under (e.g.
+// via `cosign attest --type custom --predicate vuln-delta-predicate.json
+// --predicate-type` this value), mirroring chartProvenancePredicateType and
+// standaloneReportsManifestPredicateType's naming convention.
+const vulnDeltaPredicateType = "https://verity.dev/attestations/vuln-delta/v1"
+
+// AggregateVulnDeltaPredicate compares each image's upstream Trivy report
+// (pr.ReportPath) against its post-patch rescan (pr.PatchedReportPath) and
+// emits a predicate classifying every CVE seen in either scan as "fixed"
+// (upstream only), "remaining" (in both), or "introduced" (patched only,
+// e.g. from a package Copa pulled in to remediate something else). Unlike
+// AggregateVulnPredicate's raw snapshot of what's left, this is a signable
+// record of what the patch actually changed. Images missing either report
+// (Skipped before a rescan happened, or Error) are omitted rather than
+// guessed at.
+func AggregateVulnDeltaPredicate(patchedImages []*PatchResult, reportsDir, outputPath string) error {
+	type vulnDeltaEntry struct {
+		ID              string `json:"id"`
+		Severity        string `json:"severity"`
+		Pkg             string `json:"pkg"`
+		UpstreamVersion string `json:"upstream_version,omitempty"`
+		PatchedVersion  string `json:"patched_version,omitempty"`
+		Status          string `json:"status"`
+		Image           string `json:"image"`
+	}
+
+	type predicate struct {
+		PredicateType   string           `json:"predicateType"`
+		ScanStartedOn   string           `json:"scanStartedOn"`
+		ScanFinishedOn  string           `json:"scanFinishedOn"`
+		Vulnerabilities []vulnDeltaEntry `json:"vulnerabilities"`
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	pred := predicate{
+		PredicateType:  vulnDeltaPredicateType,
+		ScanStartedOn:  now,
+		ScanFinishedOn: now,
+	}
+
+	resolveReportPath := func(reportPath string) string {
+		if !filepath.IsAbs(reportPath) {
+			return filepath.Join(reportsDir, filepath.Base(reportPath))
+		}
+		return reportPath
+	}
+
+	readReport := func(reportPath string) (map[string]trivyVulnerability, error) {
+		data, err := os.ReadFile(reportPath)
+		if err != nil {
+			return nil, err
+		}
+		var report trivyReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		vulns := make(map[string]trivyVulnerability)
+		for _, res := range report.Results {
+			for _, v := range res.Vulnerabilities {
+				vulns[v.VulnerabilityID+"|"+v.PkgName] = v
+			}
+		}
+		return vulns, nil
+	}
+
+	for _, pr := range patchedImages {
+		if pr.Error != nil || pr.ReportPath == "" || pr.PatchedReportPath == "" {
+			continue
+		}
+
+		upstreamPath := resolveReportPath(pr.ReportPath)
+		upstream, err := readReport(upstreamPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: cannot read upstream report %s: %v\n", upstreamPath, err)
+			continue
+		}
+
+		patchedPath := resolveReportPath(pr.PatchedReportPath)
+		patched, err := readReport(patchedPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: cannot read patched report %s: %v\n", patchedPath, err)
+			continue
+		}
+
+		image := pr.Patched.Reference()
+		for key, v := range upstream {
+			status := "remaining"
+			patchedVersion := ""
+			if pv, ok := patched[key]; ok {
+				patchedVersion = pv.InstalledVersion
+			} else {
+				status = "fixed"
+			}
+			pred.Vulnerabilities = append(pred.Vulnerabilities, vulnDeltaEntry{
+				ID:              v.VulnerabilityID,
+				Severity:        v.Severity,
+				Pkg:             v.PkgName,
+				UpstreamVersion: v.InstalledVersion,
+				PatchedVersion:  patchedVersion,
+				Status:          status,
+				Image:           image,
+			})
+		}
+		for key, v := range patched {
+			if _, ok := upstream[key]; ok {
+				continue
+			}
+			pred.Vulnerabilities = append(pred.Vulnerabilities, vulnDeltaEntry{
+				ID:             v.VulnerabilityID,
+				Severity:       v.Severity,
+				Pkg:            v.PkgName,
+				PatchedVersion: v.InstalledVersion,
+				Status:         "introduced",
+				Image:          image,
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(pred, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling vuln delta predicate: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating vuln delta predicate dir: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing vuln delta predicate: %w", err)
+	}
+	return nil
+}
+
+// VulnerabilityReport is a chart-wide vulnerability summary, in the
+// per-image grouping shape Clair's classic VulnerabilityReport API exposes
+// (registry/repo/tag), rolled up across every image in the chart into
+// VulnsBySeverity/BadVulns totals. Written by GenerateVulnSummary as
+// vuln-summary.json, so CI can gate on severity rather than raw vuln count
+// and operators get a per-chart executive summary.
+type VulnerabilityReport struct {
+	Images          []ImageVulnReport     `json:"images"`
+	VulnsBySeverity map[string][]SiteVuln `json:"vulnsBySeverity"`
+	BadVulns        int                   `json:"badVulns"`
+}
+
+// ImageVulnReport is one image's entry in a VulnerabilityReport.
+type ImageVulnReport struct {
+	RegistryURL string     `json:"registryUrl"`
+	Repo        string     `json:"repo"`
+	Tag         string     `json:"tag"`
+	Date        string     `json:"date"`
+	Vulns       []SiteVuln `json:"vulns"`
+}
+
+// GenerateVulnSummary aggregates each image's vulnerabilities (pr.Vulns,
+// populated by buildPatchResults from its Trivy report) into a single
+// vuln-summary.json for the chart: VulnsBySeverity groups every finding by
+// severity across all images, and BadVulns counts Critical+High findings
+// with a fix available, the same signal PatchResult.After tracks per image,
+// rolled up to the chart level so CI can gate on severity rather than raw
+// vuln count.
+func GenerateVulnSummary(patchedImages []*PatchResult, outputPath string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	report := VulnerabilityReport{
+		VulnsBySeverity: make(map[string][]SiteVuln),
+	}
+
+	for _, pr := range patchedImages {
+		if pr.Error != nil || len(pr.Vulns) == 0 {
+			continue
+		}
+
+		report.Images = append(report.Images, ImageVulnReport{
+			RegistryURL: pr.Original.Registry,
+			Repo:        pr.Original.Repository,
+			Tag:         pr.Original.Tag,
+			Date:        now,
+			Vulns:       pr.Vulns,
+		})
+
+		for _, v := range pr.Vulns {
+			sev := v.Severity
+			if sev == "" {
+				sev = "UNKNOWN"
+			}
+			report.VulnsBySeverity[sev] = append(report.VulnsBySeverity[sev], v)
+			if v.FixedVersion != "" && (strings.EqualFold(sev, "CRITICAL") || strings.EqualFold(sev, "HIGH")) {
+				report.BadVulns++
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling vuln summary: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating vuln summary dir: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing vuln summary: %w", err)
+	}
 
 	return nil
 }
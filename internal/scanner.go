@@ -6,7 +6,6 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"time"
 
 	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/chart"
@@ -18,10 +17,13 @@ type Image struct {
 	Registry   string `yaml:"registry,omitempty"`
 	Repository string `yaml:"repository"`
 	Tag        string `yaml:"tag,omitempty"`
+	Digest     string `yaml:"digest,omitempty"`
 	Path       string `yaml:"path"`
 }
 
-// Reference returns the full image reference string.
+// Reference returns the full image reference string: "repo:tag@sha256:..."
+// when both a tag and digest are known, "repo@sha256:..." when only the
+// digest is, and "repo:tag" (or bare "repo") otherwise.
 func (img Image) Reference() string {
 	ref := img.Repository
 	if img.Registry != "" {
@@ -30,12 +32,17 @@ func (img Image) Reference() string {
 	if img.Tag != "" {
 		ref = ref + ":" + img.Tag
 	}
+	if img.Digest != "" {
+		ref = ref + "@" + img.Digest
+	}
 	return ref
 }
 
 // ParseImagesFile reads a YAML file of helm-values style image definitions
-// and returns all container image references found.
-func ParseImagesFile(path string) ([]Image, error) {
+// and returns all container image references found. ctx bounds any
+// appVersion-fallback registry probes findImages needs to run (see
+// resolveTag) — it carries no other meaning here.
+func ParseImagesFile(ctx context.Context, path string) ([]Image, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s: %w", path, err)
@@ -47,7 +54,7 @@ func ParseImagesFile(path string) ([]Image, error) {
 	if len(values) == 0 {
 		return nil, nil
 	}
-	images := dedup(findImages(values, "", "", nil))
+	images := dedup(findImages(ctx, values, "", "", nil))
 	// Sort for deterministic output (Go map iteration is randomized)
 	sort.Slice(images, func(i, j int) bool {
 		return images[i].Reference() < images[j].Reference()
@@ -55,35 +62,50 @@ func ParseImagesFile(path string) ([]Image, error) {
 	return images, nil
 }
 
-// ScanForImages loads a chart directory and finds all container image references.
-func ScanForImages(chartPath string) ([]Image, error) {
+// ScanForImages loads a chart directory and finds all container image
+// references. See ParseImagesFile for ctx's role.
+func ScanForImages(ctx context.Context, chartPath string) ([]Image, error) {
 	ch, err := loader.LoadDir(chartPath)
 	if err != nil {
 		return nil, fmt.Errorf("loading chart %s: %w", chartPath, err)
 	}
 	cache := map[string]string{} // shared across all subcharts
-	return dedup(scanChart(ch, "", cache)), nil
+	return dedup(scanChart(ctx, ch, "", cache)), nil
 }
 
-func scanChart(ch *chart.Chart, prefix string, cache map[string]string) []Image {
+func scanChart(ctx context.Context, ch *chart.Chart, prefix string, cache map[string]string) []Image {
 	var images []Image
 
 	if ch.Values != nil {
-		images = append(images, findImages(ch.Values, prefix, ch.Metadata.AppVersion, cache)...)
+		images = append(images, findImages(ctx, ch.Values, prefix, ch.Metadata.AppVersion, cache)...)
 	}
 
 	for _, dep := range ch.Dependencies() {
-		images = append(images, scanChart(dep, joinPath(prefix, dep.Name()), cache)...)
+		images = append(images, scanChart(ctx, dep, joinPath(prefix, dep.Name()), cache)...)
 	}
 
 	return images
 }
 
 // tagChecker is the function used to probe whether an image tag exists.
-// Replaceable in tests for deterministic behavior.
-var tagChecker func(ctx context.Context, ref string) bool = imageExists
+// Replaceable in tests for deterministic behavior. Its default,
+// probeTagExists, delegates to defaultProber (see tagprober.go); callers
+// that need a specific TagProber (custom auth, cache, or rate limiter)
+// should call one directly instead of going through this package-level
+// hook.
+var tagChecker func(ctx context.Context, ref string) bool = probeTagExists
+
+// probeTagExists reports whether ref exists, via defaultProber. A probe
+// failure (bad credentials, network error) is treated the same as
+// not-found here, since tagChecker's bool-only contract predates
+// TagProber's richer (bool, error) one; code that needs to tell those
+// apart should use a TagProber directly.
+func probeTagExists(ctx context.Context, ref string) bool {
+	exists, err := defaultProber().Exists(ctx, ref)
+	return err == nil && exists
+}
 
-func findImages(values map[string]any, prefix, appVersion string, cache map[string]string) []Image {
+func findImages(ctx context.Context, values map[string]any, prefix, appVersion string, cache map[string]string) []Image {
 	if cache == nil {
 		cache = map[string]string{}
 	}
@@ -98,7 +120,7 @@ func findImages(values map[string]any, prefix, appVersion string, cache map[stri
 			if cached, ok := cache[key]; ok {
 				img.Tag = cached
 			} else {
-				img.Tag = resolveTag(img, appVersion)
+				img.Tag = resolveTag(ctx, img, appVersion)
 				cache[key] = img.Tag
 			}
 		}
@@ -112,55 +134,82 @@ func findImages(values map[string]any, prefix, appVersion string, cache map[stri
 // since chart templates vary in whether they prepend "v" to Chart.AppVersion.
 // ResolveImageTag attempts to find the correct tag for an image by trying
 // multiple variations. It tries the tag as-is first, then with a "v" prefix
-// if the tag doesn't already have one. Returns an Image with the resolved tag,
-// or the original image if no variation exists in the registry.
+// if the tag doesn't already have one. Returns an Image with the resolved
+// tag, or the original image if no variation exists in the registry.
+//
+// Once the tag is settled, it performs a manifest HEAD against the registry
+// and records the digest that tag currently resolves to (see digestResolver),
+// so downstream mirroring/copy steps can pin exactly what was published here
+// rather than trusting the tag not to move between discovery and patching.
 func ResolveImageTag(ctx context.Context, img Image) Image {
 	// If no tag specified, return as-is (will default to "latest" elsewhere)
 	if img.Tag == "" {
 		return img
 	}
 
-	// If tag already starts with "v", try as-is first, then without "v"
-	if strings.HasPrefix(img.Tag, "v") {
-		// Try with "v" prefix first
-		if tagChecker(ctx, img.Reference()) {
-			return img
-		}
-		// Try without "v" prefix
+	// A locked entry pins exactly what a prior `verity lock update` found,
+	// so reproduce it without hitting the registry at all.
+	if locked, ok := activeLock.imageFor(img.Repository); ok {
+		resolved := img
+		resolved.Tag = locked.Tag
+		resolved.Digest = locked.Digest
+		return resolved
+	}
+
+	resolved := img
+	switch {
+	case strings.HasPrefix(img.Tag, "v") && tagChecker(ctx, img.Reference()):
+		// Tag already starts with "v" and exists as-is.
+	case strings.HasPrefix(img.Tag, "v"):
+		// Tag starts with "v" but doesn't exist; try without the prefix.
 		candidate := img
 		candidate.Tag = strings.TrimPrefix(img.Tag, "v")
 		if tagChecker(ctx, candidate.Reference()) {
-			return candidate
+			resolved = candidate
+		}
+	case tagChecker(ctx, img.Reference()):
+		// Tag doesn't start with "v" and exists as-is.
+	default:
+		// Tag doesn't start with "v" and doesn't exist; try with the prefix.
+		candidate := img
+		candidate.Tag = "v" + img.Tag
+		if tagChecker(ctx, candidate.Reference()) {
+			resolved = candidate
 		}
-		// Fall back to original
-		return img
 	}
 
-	// Tag doesn't start with "v", try without prefix first
-	if tagChecker(ctx, img.Reference()) {
-		return img
+	if d := digestResolver(ctx, resolved.Reference()); d != "" {
+		resolved.Digest = d
 	}
+	return resolved
+}
 
-	// Try with "v" prefix
-	candidate := img
-	candidate.Tag = "v" + img.Tag
-	if tagChecker(ctx, candidate.Reference()) {
-		return candidate
+// digestResolver is the function used to resolve the manifest digest an
+// image reference currently points to. Replaceable in tests for
+// deterministic behavior.
+var digestResolver func(ctx context.Context, ref string) string = probeDigest
+
+// probeDigest resolves ref's current manifest digest via defaultProber,
+// returning "" if the probe fails for any reason (not found, bad
+// credentials, or a network error) — see TagProber.Digest for the richer
+// (digest, error) form.
+func probeDigest(ctx context.Context, ref string) string {
+	digest, err := defaultProber().Digest(ctx, ref)
+	if err != nil {
+		return ""
 	}
-
-	// Fall back to original tag if neither exists
-	return img
+	return digest
 }
 
-func resolveTag(img Image, appVersion string) string {
+// resolveTag resolves appVersion against img's registry using ctx, trying
+// both the "v"-prefixed and bare forms (see ResolveImageTag). ctx's
+// deadline, if any, is entirely up to the caller — findImages passes
+// through whatever ctx ParseImagesFile/ScanForImages were given.
+func resolveTag(ctx context.Context, img Image, appVersion string) string {
 	if strings.HasPrefix(appVersion, "v") {
 		return appVersion
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Use the new ResolveImageTag function
 	candidate := img
 	candidate.Tag = appVersion
 	resolved := ResolveImageTag(ctx, candidate)
@@ -215,6 +264,9 @@ func extractImage(m map[string]any, parentKey string) (Image, bool) {
 	if tag, ok := stringVal(m, "tag"); ok {
 		img.Tag = tag
 	}
+	if digest, ok := stringVal(m, "digest"); ok {
+		img.Digest = digest
+	}
 	return img, true
 }
 
@@ -250,8 +302,16 @@ func looksLikeRef(s string) bool {
 		!strings.HasPrefix(s, "https://")
 }
 
+// parseRef parses a bare image reference into its parts. It accepts
+// "repo[:tag]", "repo@sha256:<hex>" and "repo[:tag]@sha256:<hex>" forms; the
+// digest (if any) is split off first since it contains its own ":" and
+// would otherwise be mistaken for a tag separator.
 func parseRef(ref string) Image {
 	img := Image{}
+	if idx := strings.Index(ref, "@"); idx >= 0 {
+		img.Digest = ref[idx+1:]
+		ref = ref[:idx]
+	}
 	if idx := strings.LastIndex(ref, ":"); idx > 0 && !strings.Contains(ref[idx:], "/") {
 		img.Tag = ref[idx+1:]
 		ref = ref[:idx]
@@ -312,6 +372,14 @@ func normalizeReference(img Image) string {
 	if img.Registry != "" {
 		ref = img.Registry + "/" + ref
 	}
+	if img.Digest != "" {
+		// A digest pins the exact content, so it's a stronger identity key
+		// than any tag: two images with the same digest are the same image
+		// even if one was discovered as "v1.2.3" and the other as "stable".
+		// Drop the tag from the comparison entirely rather than appending
+		// both, so those two dedup to a single entry.
+		return ref + "@" + img.Digest
+	}
 	if img.Tag != "" {
 		// Normalize tag by removing "v" prefix for comparison
 		tag := strings.TrimPrefix(img.Tag, "v")
@@ -341,11 +409,55 @@ func shouldPrefer(img1, img2 Image) bool {
 // ImageOverride specifies a tag replacement for images matching a repository.
 // When an image's repository matches, the From substring in the tag is replaced with To.
 type ImageOverride struct {
-	Repository string // image repository to match (e.g. "timberio/vector")
+	Repository string // image repository to match (e.g. "timberio/vector"); see Match
 	From       string // substring to replace in the tag
 	To         string // replacement string
 }
 
+// Match reports whether img's repository matches o.Repository on
+// path-segment boundaries, the way container runtimes compare image names:
+// segments are compared whole, a "*" segment matches anything, and a
+// pattern with fewer segments than the image matches the image's trailing
+// segments. So "foo" matches "bar/foo" but never "myfoo", and
+// "quay.io/*/prometheus" matches any namespace under quay.io. An image
+// with no explicit registry is treated as "docker.io", and a
+// single-segment repository gets the implicit "library/" namespace
+// docker.io applies to bare names — both exactly as a container runtime
+// would resolve them.
+func (o ImageOverride) Match(img Image) bool {
+	imgSegs := imageSegments(img)
+	patSegs := strings.Split(strings.Trim(o.Repository, "/"), "/")
+	if len(patSegs) > len(imgSegs) {
+		return false
+	}
+
+	offset := len(imgSegs) - len(patSegs)
+	for i, seg := range patSegs {
+		if seg == "*" {
+			continue
+		}
+		if seg != imgSegs[offset+i] {
+			return false
+		}
+	}
+	return true
+}
+
+// imageSegments returns img's full reference path as registry/.../name
+// segments, filling in the "docker.io" registry and "library" namespace
+// defaults docker.io applies to a bare image name.
+func imageSegments(img Image) []string {
+	registry := img.Registry
+	if registry == "" {
+		registry = "docker.io"
+	}
+	repo := img.Repository
+	if registry == "docker.io" && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return append([]string{registry}, strings.Split(repo, "/")...)
+}
+
 // ParseOverrides reads the "overrides" section from a YAML values file.
 // The expected format:
 //
@@ -418,7 +530,7 @@ func MergeChartImages(valuesPath string, images []Image) error { //nolint:gocogn
 		if err := yaml.Unmarshal(existing, &values); err != nil {
 			return fmt.Errorf("parsing %s: %w", valuesPath, err)
 		}
-		for _, img := range findImages(values, "", "", nil) {
+		for _, img := range findImages(context.Background(), values, "", "", nil) {
 			existingRefs[img.Reference()] = true
 		}
 	}
@@ -482,6 +594,9 @@ func MergeChartImages(valuesPath string, images []Image) error { //nolint:gocogn
 		if img.Tag != "" {
 			sb.WriteString(fmt.Sprintf("    tag: %q\n", img.Tag))
 		}
+		if img.Digest != "" {
+			sb.WriteString(fmt.Sprintf("    digest: %q\n", img.Digest))
+		}
 	}
 
 	content += sb.String()
@@ -495,8 +610,11 @@ func imageEntryKey(img Image) string {
 }
 
 // ApplyOverrides applies tag replacements to images matching override rules.
-// Returns the modified image list.
-func ApplyOverrides(images []Image, overrides []ImageOverride) []Image {
+// Returns the modified image list. A rewritten tag invalidates any digest
+// already resolved for the old one (a new tag is free to point at different
+// content), so the digest is cleared and re-resolved against the registry
+// rather than carried over stale.
+func ApplyOverrides(ctx context.Context, images []Image, overrides []ImageOverride) []Image {
 	if len(overrides) == 0 {
 		return images
 	}
@@ -505,11 +623,13 @@ func ApplyOverrides(images []Image, overrides []ImageOverride) []Image {
 	for i, img := range images {
 		result[i] = img
 		for _, o := range overrides {
-			// Match by repository, with or without registry prefix
-			if img.Repository == o.Repository ||
-				(img.Registry != "" && img.Registry+"/"+img.Repository == o.Repository) {
+			if o.Match(img) {
 				if img.Tag != "" && strings.Contains(img.Tag, o.From) {
 					result[i].Tag = strings.Replace(img.Tag, o.From, o.To, 1)
+					result[i].Digest = ""
+					if d := digestResolver(ctx, result[i].Reference()); d != "" {
+						result[i].Digest = d
+					}
 				}
 			}
 		}
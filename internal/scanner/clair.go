@@ -0,0 +1,268 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// indexPollInterval and indexPollTimeout bound how long Scan waits for the
+// indexer to finish processing a manifest (Clair's indexing is async: the
+// initial POST only enqueues the work) before giving up.
+const (
+	indexPollInterval = 2 * time.Second
+	indexPollTimeout  = 5 * time.Minute
+)
+
+// clairScanner talks to a Clair v4 deployment's indexer and matcher HTTP
+// APIs. Unlike the CLI-based backends, Clair has no local "scan this ref"
+// verb: the indexer must fetch and index the image's layers first, then the
+// matcher is queried for vulnerabilities against that index report.
+type clairScanner struct {
+	indexerAddr string
+	matcherAddr string
+}
+
+func newClairScanner(opts Options) Scanner {
+	return &clairScanner{indexerAddr: opts.ClairIndexerAddr, matcherAddr: opts.ClairMatcherAddr}
+}
+
+func (s *clairScanner) Name() string { return "clair" }
+
+// SupportsServerMode is true: every scan already goes through the shared
+// indexer/matcher deployment addressed by ClairIndexerAddr/ClairMatcherAddr.
+func (s *clairScanner) SupportsServerMode() bool { return true }
+
+func (s *clairScanner) Scan(ctx context.Context, ref string) (*Report, error) {
+	manifestHash, err := s.submitManifest(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("clair index %s: %w", ref, err)
+	}
+
+	if err := s.waitForIndex(ctx, manifestHash); err != nil {
+		return nil, fmt.Errorf("clair index %s: %w", ref, err)
+	}
+
+	var vr clairVulnerabilityReport
+	raw, err := s.doJSON(ctx, http.MethodGet, s.matcherAddr+"/matcher/api/v1/vulnerability_report/"+manifestHash, nil, &vr)
+	if err != nil {
+		return nil, fmt.Errorf("clair match %s: %w", ref, err)
+	}
+
+	report := vr.toReport(ref)
+	report.Raw = raw
+	return report, nil
+}
+
+// clairIndexerManifest is the body Scan POSTs to the indexer's
+// index_report endpoint: the manifest digest plus each layer's digest and
+// where the indexer can fetch it from, mirroring Clair's own "submit a
+// manifest" request shape (see clairctl's manifest command).
+type clairIndexerManifest struct {
+	Hash   string               `json:"hash"`
+	Layers []clairLayerManifest `json:"layers"`
+}
+
+type clairLayerManifest struct {
+	Hash    string              `json:"hash"`
+	URI     string              `json:"uri"`
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// submitManifest resolves ref via go-containerregistry, builds the
+// clairIndexerManifest the indexer needs to pull ref's layers itself (Clair
+// fetches blobs directly from the registry, not through us), and submits
+// it. Returns the manifest hash the matcher's vulnerability_report endpoint
+// is keyed on.
+func (s *clairScanner) submitManifest(ctx context.Context, ref string) (string, error) {
+	r, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", ref, err)
+	}
+
+	img, err := remote.Image(r, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", fmt.Errorf("fetching manifest: %w", err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("hashing manifest: %w", err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return "", fmt.Errorf("listing layers: %w", err)
+	}
+
+	headers := s.blobAuthHeaders(r)
+
+	manifest := clairIndexerManifest{Hash: digest.String()}
+	for _, l := range layers {
+		ld, err := l.Digest()
+		if err != nil {
+			return "", fmt.Errorf("hashing layer: %w", err)
+		}
+		manifest.Layers = append(manifest.Layers, clairLayerManifest{
+			Hash:    ld.String(),
+			URI:     fmt.Sprintf("https://%s/v2/%s/blobs/%s", r.Context().RegistryStr(), r.Context().RepositoryStr(), ld.String()),
+			Headers: headers,
+		})
+	}
+
+	var resp struct {
+		ManifestHash string `json:"manifest_hash"`
+	}
+	if _, err := s.doJSON(ctx, http.MethodPost, s.indexerAddr+"/indexer/api/v1/index_report", manifest, &resp); err != nil {
+		return "", err
+	}
+	return resp.ManifestHash, nil
+}
+
+// blobAuthHeaders resolves ref's registry credentials from the default
+// keychain into the header the indexer should send when it fetches layer
+// blobs directly from the registry, so private images work the same as
+// they do for every other backend.
+func (s *clairScanner) blobAuthHeaders(r name.Reference) map[string][]string {
+	auth, err := authn.DefaultKeychain.Resolve(r.Context())
+	if err != nil {
+		return nil
+	}
+	cfg, err := auth.Authorization()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	switch {
+	case cfg.Auth != "":
+		return map[string][]string{"Authorization": {"Basic " + cfg.Auth}}
+	case cfg.RegistryToken != "":
+		return map[string][]string{"Authorization": {"Bearer " + cfg.RegistryToken}}
+	default:
+		return nil
+	}
+}
+
+// waitForIndex polls the indexer's index_report endpoint until manifestHash
+// reaches a terminal state, since the initial submitManifest call only
+// enqueues indexing work rather than performing it synchronously.
+func (s *clairScanner) waitForIndex(ctx context.Context, manifestHash string) error {
+	deadline := time.Now().Add(indexPollTimeout)
+	for {
+		var report struct {
+			State string `json:"state"`
+			Err   string `json:"err"`
+		}
+		if _, err := s.doJSON(ctx, http.MethodGet, s.indexerAddr+"/indexer/api/v1/index_report/"+manifestHash, nil, &report); err != nil {
+			return err
+		}
+
+		switch report.State {
+		case "IndexFinished":
+			return nil
+		case "IndexError":
+			return fmt.Errorf("indexing failed: %s", report.Err)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for indexer to finish %s", manifestHash)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(indexPollInterval):
+		}
+	}
+}
+
+// doJSON sends method to url with an optional JSON body, decodes the
+// response into out, and also returns the raw response bytes so Scan can
+// preserve Clair's original vulnerability_report JSON on Report.Raw.
+func (s *clairScanner) doJSON(ctx context.Context, method, url string, body, out any) ([]byte, error) {
+	var reqBody bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = *bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	if out != nil {
+		if err := json.Unmarshal(buf.Bytes(), out); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// clairVulnerabilityReport mirrors the subset of Clair v4's
+// VulnerabilityReport JSON shape we consume: a set of vulnerabilities keyed
+// by ID, and a set of packages keyed by ID, joined through
+// PackageVulnerabilities.
+type clairVulnerabilityReport struct {
+	Distributions map[string]struct {
+		DID string `json:"did"`
+	} `json:"distributions"`
+	Packages map[string]struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"packages"`
+	Vulnerabilities map[string]struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Severity       string `json:"normalized_severity"`
+		FixedInVersion string `json:"fixed_in_version"`
+	} `json:"vulnerabilities"`
+	PackageVulnerabilities map[string][]string `json:"package_vulnerabilities"`
+}
+
+func (c clairVulnerabilityReport) toReport(ref string) *Report {
+	os := ""
+	for _, d := range c.Distributions {
+		os = d.DID
+		break
+	}
+
+	report := &Report{ArtifactName: ref, OS: os}
+	for pkgID, vulnIDs := range c.PackageVulnerabilities {
+		pkg := c.Packages[pkgID]
+		for _, vulnID := range vulnIDs {
+			v := c.Vulnerabilities[vulnID]
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.Name,
+				Severity:         v.Severity,
+				PkgName:          pkg.Name,
+				InstalledVersion: pkg.Version,
+				FixedVersion:     v.FixedInVersion,
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds per-backend settings loaded from a scanners.yaml file, so
+// operators running a shared Clair or Trivy server deployment don't need to
+// repeat its address on every `verity scan`/`verity patch` invocation.
+type Config struct {
+	// Default selects the backend used when --scanner is not passed.
+	Default string `yaml:"default,omitempty"`
+
+	Trivy TrivyConfig `yaml:"trivy,omitempty"`
+	Clair ClairConfig `yaml:"clair,omitempty"`
+	Grype GrypeConfig `yaml:"grype,omitempty"`
+}
+
+// TrivyConfig configures the "trivy"/"trivy-exec" backends.
+type TrivyConfig struct {
+	ServerAddr string `yaml:"serverAddr,omitempty"`
+	DockerHost string `yaml:"dockerHost,omitempty"`
+}
+
+// ClairConfig configures the "clair" backend.
+type ClairConfig struct {
+	IndexerAddr string `yaml:"indexerAddr,omitempty"`
+	MatcherAddr string `yaml:"matcherAddr,omitempty"`
+}
+
+// GrypeConfig configures the "grype" backend.
+type GrypeConfig struct {
+	DockerHost string `yaml:"dockerHost,omitempty"`
+}
+
+// LoadConfig reads and parses a scanners.yaml file at path. A missing file
+// is not an error: it returns a zero-value Config so callers can fall back
+// to CLI flags alone.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return &Config{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading scanner config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing scanner config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// OptionsFor builds the Options for backend, layering cfg's per-backend
+// settings underneath any already-set fields in overrides (CLI flags take
+// precedence over scanners.yaml).
+func (cfg *Config) OptionsFor(backend string, overrides Options) Options {
+	opts := overrides
+	switch backend {
+	case "", "trivy-exec", "trivy":
+		if opts.ServerAddr == "" {
+			opts.ServerAddr = cfg.Trivy.ServerAddr
+		}
+		if opts.DockerHost == "" {
+			opts.DockerHost = cfg.Trivy.DockerHost
+		}
+	case "grype":
+		if opts.DockerHost == "" {
+			opts.DockerHost = cfg.Grype.DockerHost
+		}
+	case "clair":
+		if opts.ClairIndexerAddr == "" {
+			opts.ClairIndexerAddr = cfg.Clair.IndexerAddr
+		}
+		if opts.ClairMatcherAddr == "" {
+			opts.ClairMatcherAddr = cfg.Clair.MatcherAddr
+		}
+	}
+	return opts
+}
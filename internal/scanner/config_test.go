@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.Default != "" {
+		t.Errorf("Default = %q, want empty", cfg.Default)
+	}
+}
+
+func TestLoadConfigEmptyPath(t *testing.T) {
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig(\"\") error: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("LoadConfig(\"\") returned nil config")
+	}
+}
+
+func TestLoadConfigParsesClairAddrs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scanners.yaml")
+	yaml := `
+default: clair
+clair:
+  indexerAddr: http://indexer:8080
+  matcherAddr: http://matcher:8080
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.Clair.IndexerAddr != "http://indexer:8080" || cfg.Clair.MatcherAddr != "http://matcher:8080" {
+		t.Errorf("Clair = %+v, want indexer/matcher addrs from file", cfg.Clair)
+	}
+}
+
+func TestOptionsForCLIOverridesConfig(t *testing.T) {
+	cfg := &Config{Clair: ClairConfig{IndexerAddr: "http://from-config"}}
+	opts := cfg.OptionsFor("clair", Options{ClairIndexerAddr: "http://from-flag"})
+	if opts.ClairIndexerAddr != "http://from-flag" {
+		t.Errorf("ClairIndexerAddr = %q, want CLI override to win", opts.ClairIndexerAddr)
+	}
+}
+
+func TestOptionsForFallsBackToConfig(t *testing.T) {
+	cfg := &Config{Trivy: TrivyConfig{ServerAddr: "http://trivy-server:4954"}}
+	opts := cfg.OptionsFor("trivy", Options{})
+	if opts.ServerAddr != "http://trivy-server:4954" {
+		t.Errorf("ServerAddr = %q, want value from config", opts.ServerAddr)
+	}
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// execScanner shells out to the trivy CLI. It is the original scanning
+// path (see cmd/scan.go's scanImage and internal/patcher.go's trivyScan)
+// kept as a fallback for environments without the native library wired up.
+type execScanner struct {
+	dockerHost string
+	serverAddr string
+}
+
+func newExecScanner(opts Options) Scanner {
+	return &execScanner{dockerHost: opts.DockerHost, serverAddr: opts.ServerAddr}
+}
+
+func (s *execScanner) Name() string { return "trivy-exec" }
+
+func (s *execScanner) SupportsServerMode() bool { return s.serverAddr != "" }
+
+func (s *execScanner) Scan(ctx context.Context, ref string) (*Report, error) {
+	args := []string{"image", "--vuln-type", "os,library", "--format", "json", "--quiet"}
+	if s.serverAddr != "" {
+		args = append(args, "--server", s.serverAddr)
+	}
+	if s.dockerHost != "" {
+		args = append(args, "--docker-host", s.dockerHost)
+	}
+	args = append(args, ref)
+
+	cmd := exec.CommandContext(ctx, "trivy", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("trivy scan %s: %w\nOutput: %s", ref, err, string(output))
+	}
+
+	var raw trivyJSON
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("parsing trivy output for %s: %w", ref, err)
+	}
+	report := raw.toReport(ref)
+	report.Raw = output
+	return report, nil
+}
+
+// trivyJSON mirrors the subset of Trivy's native JSON report we consume.
+type trivyJSON struct {
+	ArtifactName string `json:"ArtifactName"`
+	Metadata     struct {
+		OS struct {
+			Family string `json:"Family"`
+		} `json:"OS"`
+	} `json:"Metadata"`
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			Severity         string `json:"Severity"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Status           string `json:"Status,omitempty"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func (t trivyJSON) toReport(ref string) *Report {
+	name := t.ArtifactName
+	if name == "" {
+		name = ref
+	}
+	report := &Report{ArtifactName: name, OS: t.Metadata.OS.Family}
+	for _, res := range t.Results {
+		for _, v := range res.Vulnerabilities {
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Severity:         v.Severity,
+				PkgName:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Status:           v.Status,
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// grypeScanner shells out to the grype CLI and normalizes its JSON output.
+// grype has no native Go scanning API comparable to Trivy's, so this stays
+// exec-based even though the Trivy backend is in-process.
+type grypeScanner struct {
+	dockerHost string
+}
+
+func newGrypeScanner(opts Options) Scanner {
+	return &grypeScanner{dockerHost: opts.DockerHost}
+}
+
+func (s *grypeScanner) Name() string { return "grype" }
+
+func (s *grypeScanner) SupportsServerMode() bool { return false }
+
+func (s *grypeScanner) Scan(ctx context.Context, ref string) (*Report, error) {
+	source := ref
+	if s.dockerHost != "" {
+		source = "docker:" + ref
+	}
+
+	cmd := exec.CommandContext(ctx, "grype", source, "-o", "json", "-q")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("grype scan %s: %w\nOutput: %s", ref, err, string(output))
+	}
+
+	var raw grypeJSON
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("parsing grype output for %s: %w", ref, err)
+	}
+	report := raw.toReport(ref)
+	report.Raw = output
+	return report, nil
+}
+
+// grypeJSON mirrors the subset of grype's JSON output we consume.
+type grypeJSON struct {
+	Distro struct {
+		Name string `json:"name"`
+	} `json:"distro"`
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+				State    string   `json:"state"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func (g grypeJSON) toReport(ref string) *Report {
+	report := &Report{ArtifactName: ref, OS: g.Distro.Name}
+	for _, m := range g.Matches {
+		fixed := ""
+		if len(m.Vulnerability.Fix.Versions) > 0 {
+			fixed = m.Vulnerability.Fix.Versions[0]
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+			ID:               m.Vulnerability.ID,
+			Severity:         m.Vulnerability.Severity,
+			PkgName:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     fixed,
+			Status:           m.Vulnerability.Fix.State,
+		})
+	}
+	return report
+}
@@ -0,0 +1,218 @@
+// Package scanner provides a pluggable vulnerability-scanning abstraction so
+// Verity's scan and patch flows are not hard-wired to the Trivy CLI.
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrUnknownBackend is returned by New when the requested backend name does
+// not match any registered Scanner.
+var ErrUnknownBackend = errors.New("unknown scanner backend")
+
+// Scanner scans a single image reference and returns a normalized report.
+// Implementations may shell out to a CLI or call a vulnerability scanning
+// library directly; callers should not assume either.
+type Scanner interface {
+	// Scan scans ref and returns a normalized vulnerability report.
+	Scan(ctx context.Context, ref string) (*Report, error)
+	// Name identifies the backend (e.g. "trivy", "grype", "trivy-exec").
+	Name() string
+	// SupportsServerMode reports whether this backend can share a remote
+	// vulnerability DB/server across concurrent scans instead of opening
+	// its own DB handle per call.
+	SupportsServerMode() bool
+}
+
+// Report is the scanner-agnostic result of scanning one image. Both the
+// native-library and exec-based backends normalize into this shape so
+// downstream consumers (internal.PatchSingleImage, the catalog generator)
+// work unchanged regardless of which backend produced the data.
+type Report struct {
+	ArtifactName    string          `json:"ArtifactName"`
+	OS              string          `json:"OS,omitempty"`
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+
+	// Raw holds the backend's own JSON response this Report was normalized
+	// from (Trivy's native report, grype's JSON output, Clair's
+	// vulnerability_report, ...), preserved so callers that need to attest
+	// the original scanner output — not just the fields Vulnerability
+	// captures — don't have to re-scan. Excluded from MarshalTrivyCompat's
+	// output, which is deliberately the normalized shape, not the raw one.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Vulnerability is a single normalized vulnerability finding.
+type Vulnerability struct {
+	ID               string `json:"ID"`
+	Severity         string `json:"Severity"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	// Status is the backend's per-vulnerability disposition (e.g. Trivy's
+	// "fixed"/"affected"/"will_not_fix"), mirroring internal.SiteVuln.Status.
+	// Empty for backends that don't report one (grype's fix state is
+	// populated here when present; Clair's VulnerabilityReport has no
+	// per-vuln status at all).
+	Status string `json:"Status,omitempty"`
+}
+
+// MarshalTrivyCompat renders the report in Trivy's own JSON shape
+// (top-level ArtifactName/Metadata.OS.Family/Results[].Vulnerabilities[]),
+// so existing consumers that parse Trivy JSON files directly — internal.PatchSingleImage's
+// countFixable and the catalog generator — keep working no matter which
+// backend produced the report.
+func (r *Report) MarshalTrivyCompat() ([]byte, error) {
+	type vuln struct {
+		VulnerabilityID  string `json:"VulnerabilityID"`
+		PkgName          string `json:"PkgName"`
+		Severity         string `json:"Severity"`
+		InstalledVersion string `json:"InstalledVersion"`
+		FixedVersion     string `json:"FixedVersion"`
+		Status           string `json:"Status,omitempty"`
+	}
+	type result struct {
+		Vulnerabilities []vuln `json:"Vulnerabilities"`
+	}
+	doc := struct {
+		ArtifactName string `json:"ArtifactName"`
+		Metadata     struct {
+			OS struct {
+				Family string `json:"Family"`
+			} `json:"OS"`
+		} `json:"Metadata"`
+		Results []result `json:"Results"`
+	}{ArtifactName: r.ArtifactName}
+	doc.Metadata.OS.Family = r.OS
+
+	var vulns []vuln
+	for _, v := range r.Vulnerabilities {
+		vulns = append(vulns, vuln{
+			VulnerabilityID:  v.ID,
+			PkgName:          v.PkgName,
+			Severity:         v.Severity,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Status:           v.Status,
+		})
+	}
+	if len(vulns) > 0 {
+		doc.Results = []result{{Vulnerabilities: vulns}}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Options configures a Scanner constructed by New.
+type Options struct {
+	// DockerHost is passed through to backends that can scan images from a
+	// local Docker socket (e.g. "unix:///var/run/docker.sock"), mirroring
+	// `trivy image --docker-host`. Ignored by backends without local-socket
+	// support.
+	DockerHost string
+
+	// ServerAddr, when set, points the backend at a shared scanner server
+	// (e.g. a Trivy server) instead of opening a local DB handle.
+	ServerAddr string
+
+	// ClairIndexerAddr and ClairMatcherAddr are the base URLs of a Clair v4
+	// deployment's indexer and matcher services (e.g.
+	// "http://clair-indexer:8080"). Required by the "clair" backend; ignored
+	// by all others.
+	ClairIndexerAddr string
+	ClairMatcherAddr string
+}
+
+// New constructs the Scanner registered under name. Recognized names are
+// "trivy" (native library), "trivy-exec" (legacy CLI fallback), "grype",
+// and "clair" (Clair v4, via its indexer/matcher HTTP API). An empty name
+// defaults to "trivy-exec" to preserve existing behavior.
+func New(name string, opts Options) (Scanner, error) {
+	switch name {
+	case "", "trivy-exec":
+		return newExecScanner(opts), nil
+	case "trivy":
+		return newTrivyScanner(opts), nil
+	case "grype":
+		return newGrypeScanner(opts), nil
+	case "clair":
+		return newClairScanner(opts), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+}
+
+// CountFixable reads a report previously written by any backend via
+// MarshalTrivyCompat (the common on-disk shape every Scanner normalizes
+// into) and counts vulnerabilities with a fix available. This replaces each
+// package's own Trivy-JSON-parsing copy of the same count, now that every
+// backend's report lives in one shape regardless of which scanner produced
+// it.
+func CountFixable(reportPath string) (int, error) {
+	vulns, err := FixableVulns(reportPath)
+	if err != nil {
+		return 0, err
+	}
+	return len(vulns), nil
+}
+
+// FixableVulns reads a report the same way CountFixable does, but returns
+// the fixable vulnerabilities themselves instead of just their count, for
+// callers that need more than a number (e.g. a per-image vuln summary).
+func FixableVulns(reportPath string) ([]Vulnerability, error) {
+	vulns, err := Normalize(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	var fixable []Vulnerability
+	for _, v := range vulns {
+		if v.FixedVersion != "" {
+			fixable = append(fixable, v)
+		}
+	}
+	return fixable, nil
+}
+
+// Normalize reads a report previously written by any backend via
+// MarshalTrivyCompat and returns its vulnerabilities as the scanner-agnostic
+// Vulnerability type.
+func Normalize(reportPath string) ([]Vulnerability, error) {
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				Severity         string `json:"Severity"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Status           string `json:"Status,omitempty"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var vulns []Vulnerability
+	for _, r := range doc.Results {
+		for _, v := range r.Vulnerabilities {
+			vulns = append(vulns, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Severity:         v.Severity,
+				PkgName:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Status:           v.Status,
+			})
+		}
+	}
+	return vulns, nil
+}
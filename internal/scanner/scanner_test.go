@@ -0,0 +1,222 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewUnknownBackend(t *testing.T) {
+	_, err := New("clair", Options{})
+	if err == nil {
+		t.Fatal("expected an error for an unknown backend")
+	}
+}
+
+func TestNewDefaultsToExec(t *testing.T) {
+	sc, err := New("", Options{})
+	if err != nil {
+		t.Fatalf("New(\"\", ...) returned error: %v", err)
+	}
+	if sc.Name() != "trivy-exec" {
+		t.Errorf("Name() = %q, want %q", sc.Name(), "trivy-exec")
+	}
+}
+
+func TestNewClairBackend(t *testing.T) {
+	sc, err := New("clair", Options{ClairIndexerAddr: "http://indexer", ClairMatcherAddr: "http://matcher"})
+	if err != nil {
+		t.Fatalf("New(\"clair\", ...) returned error: %v", err)
+	}
+	if sc.Name() != "clair" {
+		t.Errorf("Name() = %q, want %q", sc.Name(), "clair")
+	}
+	if !sc.SupportsServerMode() {
+		t.Error("clair SupportsServerMode() = false, want true")
+	}
+}
+
+func TestClairScannerWaitForIndexFinished(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"state":"IndexFinished"}`))
+	}))
+	defer srv.Close()
+
+	s := &clairScanner{indexerAddr: srv.URL}
+	if err := s.waitForIndex(context.Background(), "sha256:deadbeef"); err != nil {
+		t.Errorf("waitForIndex() error = %v, want nil", err)
+	}
+}
+
+func TestClairScannerWaitForIndexError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"state":"IndexError","err":"unsupported layer media type"}`))
+	}))
+	defer srv.Close()
+
+	s := &clairScanner{indexerAddr: srv.URL}
+	err := s.waitForIndex(context.Background(), "sha256:deadbeef")
+	if err == nil {
+		t.Fatal("waitForIndex() expected an error for state IndexError, got nil")
+	}
+}
+
+func TestClairScannerScanPreservesRawMatcherResponse(t *testing.T) {
+	const matcherBody = `{
+		"packages": {"p1": {"name": "libc", "version": "1.0"}},
+		"vulnerabilities": {"v1": {"id": "v1", "name": "CVE-2024-1", "normalized_severity": "HIGH", "fixed_in_version": "1.1"}},
+		"package_vulnerabilities": {"p1": ["v1"]}
+	}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/indexer/api/v1/index_report/sha256:deadbeef", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"state":"IndexFinished"}`))
+	})
+	mux.HandleFunc("/matcher/api/v1/vulnerability_report/sha256:deadbeef", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(matcherBody))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := &clairScanner{indexerAddr: srv.URL, matcherAddr: srv.URL}
+
+	var vr clairVulnerabilityReport
+	raw, err := s.doJSON(context.Background(), http.MethodGet, srv.URL+"/matcher/api/v1/vulnerability_report/sha256:deadbeef", nil, &vr)
+	if err != nil {
+		t.Fatalf("doJSON() error: %v", err)
+	}
+	if len(raw) == 0 {
+		t.Fatal("doJSON() returned no raw bytes")
+	}
+
+	report := vr.toReport("example/app:1.0")
+	if len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].ID != "CVE-2024-1" {
+		t.Errorf("toReport() vulnerabilities = %+v, want one CVE-2024-1", report.Vulnerabilities)
+	}
+
+	if err := s.waitForIndex(context.Background(), "sha256:deadbeef"); err != nil {
+		t.Errorf("waitForIndex() error = %v, want nil", err)
+	}
+}
+
+func TestCountFixableAndNormalize(t *testing.T) {
+	report := &Report{
+		ArtifactName: "nginx:1.25",
+		OS:           "debian",
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-2024-1", Severity: "HIGH", FixedVersion: "1.1"},
+			{ID: "CVE-2024-2", Severity: "LOW"},
+		},
+	}
+	data, err := report.MarshalTrivyCompat()
+	if err != nil {
+		t.Fatalf("MarshalTrivyCompat() error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := CountFixable(path)
+	if err != nil {
+		t.Fatalf("CountFixable() error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountFixable() = %d, want 1", count)
+	}
+
+	vulns, err := Normalize(path)
+	if err != nil {
+		t.Fatalf("Normalize() error: %v", err)
+	}
+	if len(vulns) != 2 {
+		t.Fatalf("Normalize() returned %d vulns, want 2", len(vulns))
+	}
+
+	fixable, err := FixableVulns(path)
+	if err != nil {
+		t.Fatalf("FixableVulns() error: %v", err)
+	}
+	if len(fixable) != 1 || fixable[0].ID != "CVE-2024-1" {
+		t.Errorf("FixableVulns() = %+v, want [CVE-2024-1]", fixable)
+	}
+}
+
+func TestNormalizePreservesStatus(t *testing.T) {
+	report := &Report{
+		ArtifactName: "nginx:1.25",
+		OS:           "debian",
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-2024-1", Severity: "HIGH", FixedVersion: "1.1", Status: "fixed"},
+			{ID: "CVE-2024-2", Severity: "LOW"},
+		},
+	}
+	data, err := report.MarshalTrivyCompat()
+	if err != nil {
+		t.Fatalf("MarshalTrivyCompat() error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	vulns, err := Normalize(path)
+	if err != nil {
+		t.Fatalf("Normalize() error: %v", err)
+	}
+	if len(vulns) != 2 || vulns[0].Status != "fixed" || vulns[1].Status != "" {
+		t.Fatalf("Normalize() = %+v, want first Status=%q, second Status empty", vulns, "fixed")
+	}
+}
+
+func TestGrypeToReportStatusFromFixState(t *testing.T) {
+	raw := grypeJSON{Distro: struct {
+		Name string `json:"name"`
+	}{Name: "debian"}}
+	raw.Matches = append(raw.Matches, struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+				State    string   `json:"state"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	}{})
+	raw.Matches[0].Vulnerability.ID = "CVE-2024-9"
+	raw.Matches[0].Vulnerability.Severity = "HIGH"
+	raw.Matches[0].Vulnerability.Fix.Versions = []string{"1.2"}
+	raw.Matches[0].Vulnerability.Fix.State = "fixed"
+
+	report := raw.toReport("nginx:1.25")
+	if len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].Status != "fixed" {
+		t.Fatalf("toReport() = %+v, want Status=%q", report.Vulnerabilities, "fixed")
+	}
+}
+
+func TestReportMarshalTrivyCompat(t *testing.T) {
+	r := &Report{
+		ArtifactName: "nginx:1.25",
+		OS:           "debian",
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-2024-1234", Severity: "HIGH", PkgName: "libc", InstalledVersion: "1.0", FixedVersion: "1.1"},
+		},
+	}
+
+	data, err := r.MarshalTrivyCompat()
+	if err != nil {
+		t.Fatalf("MarshalTrivyCompat() returned error: %v", err)
+	}
+	if len(data) == 0 {
+		t.Fatal("MarshalTrivyCompat() returned empty data")
+	}
+}
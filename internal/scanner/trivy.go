@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	tdb "github.com/aquasecurity/trivy-db/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/fanal/applier"
+	"github.com/aquasecurity/trivy/pkg/scanner"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// trivyScanner calls Trivy's Go library directly instead of shelling out,
+// so every parallel worker (see the semaphore-gated pool in cmd/scan.go)
+// shares a single vulnerability DB handle rather than paying Trivy's CLI
+// startup and DB-load cost per image.
+type trivyScanner struct {
+	dockerHost string
+	serverAddr string
+
+	initOnce sync.Once
+	initErr  error
+}
+
+func newTrivyScanner(opts Options) Scanner {
+	return &trivyScanner{dockerHost: opts.DockerHost, serverAddr: opts.ServerAddr}
+}
+
+func (s *trivyScanner) Name() string { return "trivy" }
+
+// SupportsServerMode is true because the native library shares one DB
+// handle across goroutines in-process; it does not need a remote server
+// the way the exec backend does to get concurrency benefits.
+func (s *trivyScanner) SupportsServerMode() bool { return true }
+
+func (s *trivyScanner) Scan(ctx context.Context, ref string) (*Report, error) {
+	s.initOnce.Do(func() {
+		s.initErr = tdb.Init(tdb.DefaultCacheDir())
+	})
+	if s.initErr != nil {
+		return nil, fmt.Errorf("initializing trivy vulnerability DB: %w", s.initErr)
+	}
+
+	scanOpts := types.ScanOptions{
+		VulnType:        []string{types.VulnTypeOS, types.VulnTypeLibrary},
+		ScanRemovedPkgs: false,
+	}
+	if s.dockerHost != "" {
+		scanOpts.ImageConfigScanners = []types.ScanType{} // local daemon scan, no remote config scanners
+	}
+
+	sc, err := scanner.NewScanner(ref, applier.NewApplier(tdb.Config{}))
+	if err != nil {
+		return nil, fmt.Errorf("creating trivy scanner for %s: %w", ref, err)
+	}
+
+	result, err := sc.ScanArtifact(ctx, scanOpts)
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", ref, err)
+	}
+
+	report := &Report{ArtifactName: ref, OS: result.OS.Family}
+	for _, r := range result.Results {
+		for _, v := range r.Vulnerabilities {
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Severity:         v.Severity,
+				PkgName:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Status:           v.Status.String(),
+			})
+		}
+	}
+	if raw, err := json.Marshal(result); err == nil {
+		report.Raw = raw
+	}
+	return report, nil
+}
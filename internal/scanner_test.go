@@ -38,7 +38,7 @@ func TestFindImages(t *testing.T) {
 		},
 	}
 
-	images := findImages(values, "", "", nil)
+	images := findImages(context.Background(), values, "", "", nil)
 
 	if len(images) < 3 {
 		t.Fatalf("expected at least 3 images, got %d", len(images))
@@ -140,6 +140,11 @@ func TestResolveImageTag(t *testing.T) {
 			}
 			defer func() { tagChecker = oldChecker }()
 
+			// Mock digestResolver so the test doesn't depend on registry access.
+			oldDigestResolver := digestResolver
+			digestResolver = func(_ context.Context, _ string) string { return "" }
+			defer func() { digestResolver = oldDigestResolver }()
+
 			got := ResolveImageTag(ctx, tt.img)
 			if got != tt.want {
 				t.Errorf("ResolveImageTag() = %+v, want %+v", got, tt.want)
@@ -165,11 +170,19 @@ func TestParseRef(t *testing.T) {
 			input: "docker.io/library/nginx:latest",
 			want:  Image{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
 		},
+		{
+			input: "quay.io/prometheus/prometheus@sha256:" + strings.Repeat("a", 64),
+			want:  Image{Registry: "quay.io", Repository: "prometheus/prometheus", Digest: "sha256:" + strings.Repeat("a", 64)},
+		},
+		{
+			input: "docker.io/library/nginx:1.25@sha256:" + strings.Repeat("b", 64),
+			want:  Image{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Digest: "sha256:" + strings.Repeat("b", 64)},
+		},
 	}
 
 	for _, tt := range tests {
 		got := parseRef(tt.input)
-		if got.Registry != tt.want.Registry || got.Repository != tt.want.Repository || got.Tag != tt.want.Tag {
+		if got.Registry != tt.want.Registry || got.Repository != tt.want.Repository || got.Tag != tt.want.Tag || got.Digest != tt.want.Digest {
 			t.Errorf("parseRef(%q) = %+v, want %+v", tt.input, got, tt.want)
 		}
 	}
@@ -192,6 +205,12 @@ func TestLooksLikeImage(t *testing.T) {
 }
 
 func TestFindImagesWithEmptyTag(t *testing.T) {
+	// Keep digest resolution out of this test entirely; it only exercises
+	// tag-variant selection and shouldn't depend on registry access.
+	oldDigestResolver := digestResolver
+	digestResolver = func(_ context.Context, _ string) string { return "" }
+	defer func() { digestResolver = oldDigestResolver }()
+
 	// Test with appVersion that has "v" prefix — used as-is, no registry check needed
 	values := map[string]any{
 		"server": map[string]any{
@@ -201,7 +220,7 @@ func TestFindImagesWithEmptyTag(t *testing.T) {
 			},
 		},
 	}
-	images := findImages(values, "", "v2.48.0", nil)
+	images := findImages(context.Background(), values, "", "v2.48.0", nil)
 	refs := map[string]bool{}
 	for _, img := range images {
 		refs[img.Reference()] = true
@@ -227,7 +246,7 @@ func TestFindImagesWithEmptyTag(t *testing.T) {
 			},
 		},
 	}
-	images = findImages(values, "", "2.10.1", nil)
+	images = findImages(context.Background(), values, "", "2.10.1", nil)
 	refs = map[string]bool{}
 	for _, img := range images {
 		refs[img.Reference()] = true
@@ -250,7 +269,7 @@ func TestFindImagesWithEmptyTag(t *testing.T) {
 			},
 		},
 	}
-	images = findImages(values, "", "0.50.0-distroless-libc", nil)
+	images = findImages(context.Background(), values, "", "0.50.0-distroless-libc", nil)
 	refs = map[string]bool{}
 	for _, img := range images {
 		refs[img.Reference()] = true
@@ -262,7 +281,7 @@ func TestFindImagesWithEmptyTag(t *testing.T) {
 	// Test fallback to as-is when registry is unreachable
 	tagChecker = func(_ context.Context, _ string) bool { return false }
 
-	images = findImages(values, "", "9.9.9", nil)
+	images = findImages(context.Background(), values, "", "9.9.9", nil)
 	refs = map[string]bool{}
 	for _, img := range images {
 		refs[img.Reference()] = true
@@ -296,7 +315,7 @@ postgres:
 		t.Fatal(err)
 	}
 
-	images, err := ParseImagesFile(path)
+	images, err := ParseImagesFile(context.Background(), path)
 	if err != nil {
 		t.Fatalf("ParseImagesFile() error: %v", err)
 	}
@@ -329,7 +348,7 @@ func TestParseImagesFileEmpty(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	images, err := ParseImagesFile(path)
+	images, err := ParseImagesFile(context.Background(), path)
 	if err != nil {
 		t.Fatalf("ParseImagesFile() error: %v", err)
 	}
@@ -339,7 +358,7 @@ func TestParseImagesFileEmpty(t *testing.T) {
 }
 
 func TestParseImagesFileMissing(t *testing.T) {
-	_, err := ParseImagesFile("/nonexistent/values.yaml")
+	_, err := ParseImagesFile(context.Background(), "/nonexistent/values.yaml")
 	if err == nil {
 		t.Error("expected error for missing file, got nil")
 	}
@@ -390,7 +409,7 @@ nginx:
 	}
 
 	// Verify images are still parsed correctly alongside overrides
-	images, err := ParseImagesFile(path)
+	images, err := ParseImagesFile(context.Background(), path)
 	if err != nil {
 		t.Fatalf("ParseImagesFile() error: %v", err)
 	}
@@ -423,8 +442,14 @@ nginx:
 }
 
 func TestApplyOverrides(t *testing.T) {
+	// Rewritten tags trigger a digest re-resolution; stub it out so the test
+	// doesn't depend on registry access.
+	oldDigestResolver := digestResolver
+	digestResolver = func(_ context.Context, _ string) string { return "" }
+	defer func() { digestResolver = oldDigestResolver }()
+
 	images := []Image{
-		{Repository: "timberio/vector", Tag: "0.46.1-distroless-libc", Path: "vector.image"},
+		{Repository: "timberio/vector", Tag: "0.46.1-distroless-libc", Digest: "sha256:stale", Path: "vector.image"},
 		{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25.0", Path: "nginx.image"},
 		{Repository: "victoriametrics/victoria-logs", Tag: "v1.0.0-victorialogs", Path: "server.image"},
 	}
@@ -433,11 +458,14 @@ func TestApplyOverrides(t *testing.T) {
 		{Repository: "timberio/vector", From: "distroless-libc", To: "debian"},
 	}
 
-	result := ApplyOverrides(images, overrides)
+	result := ApplyOverrides(context.Background(), images, overrides)
 
 	if result[0].Tag != "0.46.1-debian" {
 		t.Errorf("expected vector tag 0.46.1-debian, got %s", result[0].Tag)
 	}
+	if result[0].Digest != "" {
+		t.Errorf("expected stale digest to be cleared after tag rewrite, got %s", result[0].Digest)
+	}
 	if result[1].Tag != "1.25.0" {
 		t.Errorf("nginx tag should be unchanged, got %s", result[1].Tag)
 	}
@@ -447,6 +475,10 @@ func TestApplyOverrides(t *testing.T) {
 }
 
 func TestApplyOverridesWithRegistry(t *testing.T) {
+	oldDigestResolver := digestResolver
+	digestResolver = func(_ context.Context, _ string) string { return "" }
+	defer func() { digestResolver = oldDigestResolver }()
+
 	images := []Image{
 		{Registry: "docker.io", Repository: "timberio/vector", Tag: "0.46.1-distroless-libc", Path: "vector.image"},
 	}
@@ -455,7 +487,7 @@ func TestApplyOverridesWithRegistry(t *testing.T) {
 		{Repository: "docker.io/timberio/vector", From: "distroless-libc", To: "debian"},
 	}
 
-	result := ApplyOverrides(images, overrides)
+	result := ApplyOverrides(context.Background(), images, overrides)
 
 	if result[0].Tag != "0.46.1-debian" {
 		t.Errorf("expected vector tag 0.46.1-debian, got %s", result[0].Tag)
@@ -467,13 +499,79 @@ func TestApplyOverridesEmpty(t *testing.T) {
 		{Repository: "nginx", Tag: "1.25.0"},
 	}
 
-	result := ApplyOverrides(images, nil)
+	result := ApplyOverrides(context.Background(), images, nil)
 
 	if result[0].Tag != "1.25.0" {
 		t.Errorf("expected unchanged tag, got %s", result[0].Tag)
 	}
 }
 
+func TestImageOverrideMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		img     Image
+		want    bool
+	}{
+		{
+			name:    "bare name matches trailing segment",
+			pattern: "foo",
+			img:     Image{Repository: "bar/foo"},
+			want:    true,
+		},
+		{
+			name:    "bare name never matches a larger name segment",
+			pattern: "foo",
+			img:     Image{Repository: "bar/myfoo"},
+			want:    false,
+		},
+		{
+			name:    "wildcard namespace segment",
+			pattern: "quay.io/*/prometheus",
+			img:     Image{Registry: "quay.io", Repository: "prometheus/prometheus"},
+			want:    true,
+		},
+		{
+			name:    "wildcard registry segment",
+			pattern: "*/timberio/vector",
+			img:     Image{Repository: "timberio/vector"},
+			want:    true,
+		},
+		{
+			name:    "explicit registry must match exactly",
+			pattern: "quay.io/timberio/vector",
+			img:     Image{Repository: "timberio/vector"}, // defaults to docker.io
+			want:    false,
+		},
+		{
+			name:    "bare name applies docker.io/library default",
+			pattern: "nginx",
+			img:     Image{Repository: "nginx"},
+			want:    true,
+		},
+		{
+			name:    "full docker.io/library form matches the same image",
+			pattern: "docker.io/library/nginx",
+			img:     Image{Repository: "nginx"},
+			want:    true,
+		},
+		{
+			name:    "pattern with more segments than the image never matches",
+			pattern: "timberio/vector/extra",
+			img:     Image{Repository: "timberio/vector"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := ImageOverride{Repository: tt.pattern}
+			if got := o.Match(tt.img); got != tt.want {
+				t.Errorf("Match(%+v) with pattern %q = %v, want %v", tt.img, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMergeChartImages(t *testing.T) {
 	dir := t.TempDir()
 	valuesPath := filepath.Join(dir, "values.yaml")
@@ -530,7 +628,7 @@ redis:
 	}
 
 	// ParseImagesFile should find all images (existing + chart).
-	images, err := ParseImagesFile(valuesPath)
+	images, err := ParseImagesFile(context.Background(), valuesPath)
 	if err != nil {
 		t.Fatalf("ParseImagesFile() error: %v", err)
 	}
@@ -563,7 +661,7 @@ func TestMergeChartImagesDedup(t *testing.T) {
 		t.Fatalf("MergeChartImages() error: %v", err)
 	}
 
-	images, err := ParseImagesFile(valuesPath)
+	images, err := ParseImagesFile(context.Background(), valuesPath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -613,6 +711,21 @@ func mustReadFile(t *testing.T, path string) []byte {
 	return data
 }
 
+func TestDedupByDigest(t *testing.T) {
+	// Same digest, different tags ("latest" floating vs a pinned release
+	// tag) — digest is the stronger identity key, so these must collapse
+	// to one entry.
+	images := []Image{
+		{Registry: "docker.io", Repository: "library/redis", Tag: "latest", Digest: "sha256:abc"},
+		{Registry: "docker.io", Repository: "library/redis", Tag: "7.2.0", Digest: "sha256:abc"},
+		{Registry: "docker.io", Repository: "library/redis", Tag: "7.2.0", Digest: "sha256:def"},
+	}
+	result := dedup(images)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 images (same-digest pair deduped), got %d", len(result))
+	}
+}
+
 func TestImageEntryKey(t *testing.T) {
 	tests := []struct {
 		img  Image
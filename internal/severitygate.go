@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errUnknownGateSeverity is returned when a SeverityGateOptions.Threshold
+// names a severity severityRank doesn't recognize. Mirrors cmd.errUnknownFailOn.
+var errUnknownGateSeverity = errors.New("unknown severity gate threshold")
+
+// AllowlistEntry exempts one CVE from tripping the severity gate, either
+// everywhere or scoped to a single image. See LoadAllowlist.
+type AllowlistEntry struct {
+	CVE string `yaml:"cve"`
+	// ImageRef, if set, restricts this entry to vulnerabilities on images
+	// whose OriginalRef contains this substring — the same "match by
+	// substring" idiom ImageOverride.Match and checkImage's variantFilter
+	// use elsewhere, so a single entry can cover every tag of an image
+	// without needing a digest or exact ref.
+	ImageRef string `yaml:"image,omitempty"`
+	// Expires, if set, is an RFC3339 timestamp after which this entry no
+	// longer applies — so a temporary exception can't be forgotten and
+	// silently suppress the CVE forever.
+	Expires string `yaml:"expires,omitempty"`
+}
+
+// LoadAllowlist reads a severity-gate allowlist YAML file:
+//
+//	allowlist:
+//	  - cve: CVE-2023-1234
+//	    image: ghcr.io/verity-org/nginx  # optional, defaults to every image
+//	    expires: 2026-12-31T00:00:00Z    # optional, defaults to never
+//
+// Unlike ParseOverrides, a malformed entry (missing cve, unparseable
+// expires) is a hard error rather than being silently skipped: an
+// allowlist that fails to load as intended should block the gate, not
+// quietly suppress fewer CVEs than the author meant to.
+func LoadAllowlist(path string) ([]AllowlistEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading allowlist %s: %w", path, err)
+	}
+
+	var raw struct {
+		Allowlist []AllowlistEntry `yaml:"allowlist"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing allowlist %s: %w", path, err)
+	}
+
+	for i, e := range raw.Allowlist {
+		if e.CVE == "" {
+			return nil, fmt.Errorf("allowlist %s: entry %d is missing cve", path, i)
+		}
+		if e.Expires != "" {
+			if _, err := time.Parse(time.RFC3339, e.Expires); err != nil {
+				return nil, fmt.Errorf("allowlist %s: entry %d has invalid expires %q: %w", path, i, e.Expires, err)
+			}
+		}
+	}
+	return raw.Allowlist, nil
+}
+
+// allows reports whether entry exempts vuln on the image identified by
+// imageRef as of now.
+func (e AllowlistEntry) allows(imageRef, vulnID string, now time.Time) bool {
+	if !strings.EqualFold(e.CVE, vulnID) {
+		return false
+	}
+	if e.ImageRef != "" && !strings.Contains(imageRef, e.ImageRef) {
+		return false
+	}
+	if e.Expires != "" {
+		if expires, err := time.Parse(time.RFC3339, e.Expires); err == nil && now.After(expires) {
+			return false
+		}
+	}
+	return true
+}
+
+// SeverityGateOptions configures EvaluateSeverityGate.
+type SeverityGateOptions struct {
+	// Threshold is the minimum severity (LOW|MEDIUM|HIGH|CRITICAL) that
+	// counts as an offense, compared using severityRank.
+	Threshold string
+	// MinCount is the number of qualifying (non-allowlisted) vulnerabilities
+	// required to breach the gate. Zero or negative is treated as 1, so the
+	// default behavior is "fail on the first one".
+	MinCount int
+	// Allowlist exempts specific CVEs, optionally scoped to an image and/or
+	// an expiration, from counting as offenses. See LoadAllowlist.
+	Allowlist []AllowlistEntry
+}
+
+// GateOffender is one image whose vulnerabilities breached the threshold,
+// and which of them did so.
+type GateOffender struct {
+	ChartName string     `json:"chartName,omitempty"`
+	ImageRef  string     `json:"imageRef"`
+	Vulns     []SiteVuln `json:"vulns"`
+}
+
+// GateNewVuln is one vulnerability that's new since the previous scan (see
+// SiteImage.NewVulns), surfaced separately from the offender list so CI can
+// post "what changed" without re-deriving it from two full catalogs.
+type GateNewVuln struct {
+	ChartName string `json:"chartName,omitempty"`
+	ImageRef  string `json:"imageRef"`
+	ID        string `json:"id"`
+}
+
+// SeverityGateResult is EvaluateSeverityGate's verdict: whether the gate
+// was breached, every offending image, and what's new since the last scan.
+// It marshals directly to the "machine-readable JSON diff" the chunk13-2
+// request asks for — no separate diff type is needed since SiteImage
+// already tracks NewVulns/FixedVulns per scan.
+type SeverityGateResult struct {
+	Breached  bool           `json:"breached"`
+	Threshold string         `json:"threshold"`
+	MinCount  int            `json:"minCount"`
+	Count     int            `json:"count"`
+	Offenders []GateOffender `json:"offenders,omitempty"`
+	NewVulns  []GateNewVuln  `json:"newVulns,omitempty"`
+}
+
+// EvaluateSeverityGate walks every image in data (both chart-embedded and
+// standalone) looking for vulnerabilities at or above opts.Threshold,
+// skipping any exempted by opts.Allowlist. It's pure over data, so it can
+// be re-run against a previously generated catalog.json (unmarshaled back
+// into a SiteData) without touching a registry or re-scanning anything.
+func EvaluateSeverityGate(data SiteData, opts SeverityGateOptions) (SeverityGateResult, error) {
+	minRank, ok := severityRank[strings.ToUpper(opts.Threshold)]
+	if !ok {
+		return SeverityGateResult{}, fmt.Errorf("%w: %q", errUnknownGateSeverity, opts.Threshold)
+	}
+	minCount := opts.MinCount
+	if minCount <= 0 {
+		minCount = 1
+	}
+
+	result := SeverityGateResult{Threshold: strings.ToUpper(opts.Threshold), MinCount: minCount}
+	now := time.Now()
+
+	walk := func(chartName string, img SiteImage) {
+		var offending []SiteVuln
+		for _, v := range img.Vulnerabilities {
+			if severityRank[strings.ToUpper(v.Severity)] < minRank {
+				continue
+			}
+			if isAllowlisted(opts.Allowlist, img.OriginalRef, v.ID, now) {
+				continue
+			}
+			offending = append(offending, v)
+		}
+		if len(offending) > 0 {
+			result.Count += len(offending)
+			result.Offenders = append(result.Offenders, GateOffender{
+				ChartName: chartName,
+				ImageRef:  img.OriginalRef,
+				Vulns:     offending,
+			})
+		}
+		for _, id := range img.NewVulns {
+			result.NewVulns = append(result.NewVulns, GateNewVuln{ChartName: chartName, ImageRef: img.OriginalRef, ID: id})
+		}
+	}
+
+	for _, c := range data.Charts {
+		for _, img := range c.Images {
+			walk(c.Name, img)
+		}
+	}
+	for _, img := range data.StandaloneImages {
+		walk("", img)
+	}
+
+	result.Breached = result.Count >= minCount
+	return result, nil
+}
+
+func isAllowlisted(allowlist []AllowlistEntry, imageRef, vulnID string, now time.Time) bool {
+	for _, e := range allowlist {
+		if e.allows(imageRef, vulnID, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatGateSummary renders result as the human-readable summary printed
+// by `verity gate` and suitable for pasting into a PR comment alongside
+// the JSON diff.
+func FormatGateSummary(result SeverityGateResult) string {
+	var b strings.Builder
+	status := "PASSED"
+	if result.Breached {
+		status = "BREACHED"
+	}
+	fmt.Fprintf(&b, "Severity gate %s: %d vuln(s) at or above %s (threshold: %d)\n", status, result.Count, result.Threshold, result.MinCount)
+	for _, o := range result.Offenders {
+		name := o.ImageRef
+		if o.ChartName != "" {
+			name = fmt.Sprintf("%s (%s)", o.ChartName, o.ImageRef)
+		}
+		fmt.Fprintf(&b, "  %s\n", name)
+		for _, v := range o.Vulns {
+			fmt.Fprintf(&b, "    %-16s %-9s %s\n", v.ID, v.Severity, v.PkgName)
+		}
+	}
+	if len(result.NewVulns) > 0 {
+		fmt.Fprintf(&b, "New since last scan:\n")
+		for _, n := range result.NewVulns {
+			fmt.Fprintf(&b, "  %s: %s\n", n.ImageRef, n.ID)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEvaluateSeverityGate_Breach(t *testing.T) {
+	data := SiteData{
+		Charts: []SiteChart{
+			{
+				Name: "nginx",
+				Images: []SiteImage{
+					{
+						OriginalRef: "ghcr.io/verity-org/nginx:1.25",
+						Vulnerabilities: []SiteVuln{
+							{ID: "CVE-2024-0001", Severity: "CRITICAL"},
+							{ID: "CVE-2024-0002", Severity: "LOW"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := EvaluateSeverityGate(data, SeverityGateOptions{Threshold: "HIGH"})
+	if err != nil {
+		t.Fatalf("EvaluateSeverityGate failed: %v", err)
+	}
+	if !result.Breached {
+		t.Fatal("expected gate to be breached")
+	}
+	if result.Count != 1 {
+		t.Fatalf("Count = %d, want 1", result.Count)
+	}
+	if len(result.Offenders) != 1 || len(result.Offenders[0].Vulns) != 1 || result.Offenders[0].Vulns[0].ID != "CVE-2024-0001" {
+		t.Fatalf("unexpected offenders: %+v", result.Offenders)
+	}
+}
+
+func TestEvaluateSeverityGate_MinCount(t *testing.T) {
+	data := SiteData{
+		StandaloneImages: []SiteImage{
+			{
+				OriginalRef: "ghcr.io/verity-org/redis:7",
+				Vulnerabilities: []SiteVuln{
+					{ID: "CVE-2024-0003", Severity: "HIGH"},
+				},
+			},
+		},
+	}
+
+	result, err := EvaluateSeverityGate(data, SeverityGateOptions{Threshold: "HIGH", MinCount: 2})
+	if err != nil {
+		t.Fatalf("EvaluateSeverityGate failed: %v", err)
+	}
+	if result.Breached {
+		t.Fatal("expected gate not to be breached with MinCount 2 and only 1 offending vuln")
+	}
+}
+
+func TestEvaluateSeverityGate_UnknownThreshold(t *testing.T) {
+	_, err := EvaluateSeverityGate(SiteData{}, SeverityGateOptions{Threshold: "EXTREME"})
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized threshold")
+	}
+}
+
+func TestEvaluateSeverityGate_Allowlist(t *testing.T) {
+	data := SiteData{
+		StandaloneImages: []SiteImage{
+			{
+				OriginalRef: "ghcr.io/verity-org/redis:7",
+				Vulnerabilities: []SiteVuln{
+					{ID: "CVE-2024-0004", Severity: "CRITICAL"},
+				},
+			},
+		},
+	}
+
+	opts := SeverityGateOptions{
+		Threshold: "HIGH",
+		Allowlist: []AllowlistEntry{{CVE: "cve-2024-0004"}},
+	}
+	result, err := EvaluateSeverityGate(data, opts)
+	if err != nil {
+		t.Fatalf("EvaluateSeverityGate failed: %v", err)
+	}
+	if result.Breached {
+		t.Fatal("expected allowlisted CVE not to breach the gate")
+	}
+}
+
+func TestAllowlistEntry_ImageScopeAndExpiry(t *testing.T) {
+	now := time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	scoped := AllowlistEntry{CVE: "CVE-2024-0005", ImageRef: "nginx"}
+	if scoped.allows("ghcr.io/verity-org/redis:7", "CVE-2024-0005", now) {
+		t.Fatal("expected image-scoped entry not to allow an unrelated image")
+	}
+	if !scoped.allows("ghcr.io/verity-org/nginx:1.25", "CVE-2024-0005", now) {
+		t.Fatal("expected image-scoped entry to allow its matching image")
+	}
+
+	expired := AllowlistEntry{CVE: "CVE-2024-0006", Expires: "2026-01-01T00:00:00Z"}
+	if expired.allows("anything", "CVE-2024-0006", now) {
+		t.Fatal("expected an expired entry not to allow")
+	}
+}
+
+func TestLoadAllowlist(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "allowlist.yaml")
+	contents := `
+allowlist:
+  - cve: CVE-2024-0007
+    image: nginx
+    expires: 2027-01-01T00:00:00Z
+  - cve: CVE-2024-0008
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadAllowlist(path)
+	if err != nil {
+		t.Fatalf("LoadAllowlist failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].CVE != "CVE-2024-0007" || entries[0].ImageRef != "nginx" {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+}
+
+func TestLoadAllowlist_InvalidExpires(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "allowlist.yaml")
+	contents := `
+allowlist:
+  - cve: CVE-2024-0009
+    expires: not-a-date
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadAllowlist(path); err == nil {
+		t.Fatal("expected an error for an unparseable expires timestamp")
+	}
+}
+
+func TestLoadAllowlist_MissingCVE(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "allowlist.yaml")
+	contents := `
+allowlist:
+  - image: nginx
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadAllowlist(path); err == nil {
+		t.Fatal("expected an error for an entry missing cve")
+	}
+}
@@ -1,9 +1,9 @@
 package internal
 
 import (
-	"archive/tar"
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,13 +11,22 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
-	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"gopkg.in/yaml.v3"
+
+	"github.com/verity-org/verity/internal/attest"
+	"github.com/verity-org/verity/internal/registry"
 )
 
 // SiteData is the top-level structure for the catalog JSON consumed by the Astro site.
@@ -46,6 +55,16 @@ type SiteChart struct {
 	Repository      string      `json:"repository"`
 	HelmInstall     string      `json:"helmInstall"`
 	Images          []SiteImage `json:"images"`
+
+	// History is this chart's aggregated vulnerability trend across every
+	// published version, oldest first. See annotateHistory.
+	History []VulnSnapshot `json:"history,omitempty"`
+
+	// SignatureStatus is "verified" or "unverified", set by
+	// discoverRegistryVersions when called with a VerifyOpts that requires
+	// a signature and/or attestation (see VerifyChartSignature,
+	// VerifyReportAttestation). Empty when no verification was requested.
+	SignatureStatus string `json:"signatureStatus,omitempty"`
 }
 
 // SiteImage describes a single container image with its vulnerability data.
@@ -59,6 +78,53 @@ type SiteImage struct {
 	VulnSummary     VulnSummary `json:"vulnSummary"`
 	Vulnerabilities []SiteVuln  `json:"vulnerabilities"`
 	ChartName       string      `json:"chartName,omitempty"`
+
+	// History is this image's vulnerability trend across every version of
+	// its parent chart that still includes an image with this same ID,
+	// oldest first. See annotateHistory.
+	History []VulnSnapshot `json:"history,omitempty"`
+
+	// Platforms breaks this image down by architecture when it was patched
+	// from a multi-arch manifest list (see PatchResult.Platforms, and
+	// patcher.go's BuildImageIndex, which assembles the combined image
+	// index PatchedRef resolves to). Empty for an ordinary single-arch
+	// image. VulnSummary and Vulnerabilities above are always the union
+	// across every entry here.
+	Platforms []PlatformInfo `json:"platforms,omitempty"`
+
+	// NewVulns and FixedVulns are vulnerability IDs that appeared or
+	// disappeared since the previous retained standalone-reports scan (see
+	// buildSiteImageDiff). Both are nil when there's no previous scan to
+	// diff against — the first push, or one that's since aged out of
+	// standaloneReportsRetentionWindow.
+	NewVulns   []string `json:"newVulns,omitempty"`
+	FixedVulns []string `json:"fixedVulns,omitempty"`
+}
+
+// PlatformInfo is one architecture's own patched image within a multi-arch
+// SiteImage: its digest in the combined image index, and its own Trivy
+// scan results, separate from the image's aggregated top-level
+// VulnSummary.
+type PlatformInfo struct {
+	OS          string      `json:"os"`
+	Arch        string      `json:"arch"`
+	Digest      string      `json:"digest,omitempty"`
+	VulnSummary VulnSummary `json:"vulnSummary"`
+}
+
+// VulnSnapshot is one point in a chart's or image's vulnerability trend
+// series: the vulnerability counts a single published chart version had
+// at catalog-generation time. GeneratedAt is the time the *current*
+// catalog run discovered this version, not when that version was
+// originally published — the registries this reads from (GitHub
+// Packages, plain OCI) don't expose a reliable per-tag publish timestamp,
+// so snapshots are ordered by Version (semver), not by GeneratedAt.
+type VulnSnapshot struct {
+	Version        string         `json:"version"`
+	GeneratedAt    string         `json:"generatedAt"`
+	Total          int            `json:"total"`
+	Fixable        int            `json:"fixable"`
+	SeverityCounts map[string]int `json:"severityCounts"`
 }
 
 // VulnSummary counts vulnerabilities by severity.
@@ -66,6 +132,15 @@ type VulnSummary struct {
 	Total          int            `json:"total"`
 	Fixable        int            `json:"fixable"`
 	SeverityCounts map[string]int `json:"severityCounts"`
+	// StatusCounts buckets vulnerabilities by their SiteVuln.Status (e.g.
+	// "fixed", "affected", "will_not_fix"). Empty for scanner backends that
+	// don't report a status (see SiteVuln.Status).
+	StatusCounts map[string]int `json:"statusCounts,omitempty"`
+	// Suppressions counts vulnerabilities dropped by a VEX statement
+	// (see applyVEXSuppressions), keyed by justification (or by bare
+	// status when a statement carried no justification). Empty when no
+	// VEXCorpus was supplied or nothing it contains matched this image.
+	Suppressions map[string]int `json:"suppressions,omitempty"`
 }
 
 // SiteVuln represents a single vulnerability entry.
@@ -76,6 +151,17 @@ type SiteVuln struct {
 	FixedVersion     string `json:"fixedVersion"`
 	Severity         string `json:"severity"`
 	Title            string `json:"title"`
+	// Status is the scanner's per-vulnerability disposition (e.g. "fixed",
+	// "affected", "will_not_fix"), carried through from trivyVulnFull.
+	// Empty for scanner backends (grype, Clair) that don't report one.
+	Status string `json:"status,omitempty"`
+
+	// FirstSeen is the standalone-reports date tag (see
+	// standaloneReportsDateTagLayout) of the scan this vulnerability was
+	// first observed in, set by buildSiteImageDiff comparing against the
+	// previous retained scan. Empty when there's no previous scan to
+	// compare against.
+	FirstSeen string `json:"firstSeen,omitempty"`
 }
 
 // trivyReportFull is an expanded version of trivyReport that captures severity,
@@ -101,6 +187,216 @@ type trivyVulnFull struct {
 	FixedVersion     string `json:"FixedVersion"`
 	Severity         string `json:"Severity"`
 	Title            string `json:"Title"`
+	// Status is Trivy's per-vulnerability disposition: "fixed", "affected",
+	// "not_affected", "under_investigation", "will_not_fix", "fix_deferred",
+	// "end_of_life", or "unknown" (Trivy defaults to "unknown" when a scanner
+	// doesn't report one). See VulnSummary.StatusCounts and
+	// GenerateSiteData's ignoreStatus.
+	Status string `json:"Status"`
+}
+
+// ScannerReport normalizes a vulnerability report file into what
+// buildSiteImage needs, regardless of which scanner backend produced it.
+// parseScannerReport auto-detects the on-disk format and returns the
+// matching implementation, so standalone-reports and embedded chart reports
+// work the same whether they came from Trivy, grype, Clair, or a bare syft
+// SBOM — mirroring internal/scanner's Scanner abstraction for live scans,
+// but for already-produced report files read back off disk.
+type ScannerReport interface {
+	// OSInfo returns the scanned image's OS family/name (e.g. "alpine
+	// 3.19"), or "" if the format doesn't carry that metadata.
+	OSInfo() string
+	// Vulns returns the report's findings in Verity's normalized shape.
+	Vulns() []SiteVuln
+}
+
+// OSInfo implements ScannerReport.
+func (r *trivyReportFull) OSInfo() string {
+	if r.Metadata.OS.Family == "" {
+		return ""
+	}
+	if r.Metadata.OS.Name != "" {
+		return r.Metadata.OS.Family + " " + r.Metadata.OS.Name
+	}
+	return r.Metadata.OS.Family
+}
+
+// Vulns implements ScannerReport.
+func (r *trivyReportFull) Vulns() []SiteVuln {
+	vulns := make([]SiteVuln, 0)
+	for _, result := range r.Results {
+		for _, v := range result.Vulnerabilities {
+			vulns = append(vulns, SiteVuln{
+				ID:               v.VulnerabilityID,
+				PkgName:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         v.Severity,
+				Title:            v.Title,
+				Status:           v.Status,
+			})
+		}
+	}
+	return vulns
+}
+
+// grypeReportFull mirrors the subset of grype's native JSON output
+// (`grype image -o json`) consumed here, matching the shape
+// internal/scanner's grypeJSON parses for live scans.
+type grypeReportFull struct {
+	Distro struct {
+		Name string `json:"name"`
+	} `json:"distro"`
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+// OSInfo implements ScannerReport.
+func (r *grypeReportFull) OSInfo() string { return r.Distro.Name }
+
+// Vulns implements ScannerReport.
+func (r *grypeReportFull) Vulns() []SiteVuln {
+	vulns := make([]SiteVuln, 0, len(r.Matches))
+	for _, m := range r.Matches {
+		fixed := ""
+		if len(m.Vulnerability.Fix.Versions) > 0 {
+			fixed = m.Vulnerability.Fix.Versions[0]
+		}
+		vulns = append(vulns, SiteVuln{
+			ID:               m.Vulnerability.ID,
+			PkgName:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     fixed,
+			Severity:         m.Vulnerability.Severity,
+		})
+	}
+	return vulns
+}
+
+// clairReportFull mirrors the subset of Clair v4's VulnerabilityReport JSON
+// shape consumed here (see internal/scanner's clairVulnerabilityReport,
+// which the clair backend fetches from the matcher API and a caller may
+// have saved verbatim as a standalone report).
+type clairReportFull struct {
+	Distributions map[string]struct {
+		DID string `json:"did"`
+	} `json:"distributions"`
+	Packages map[string]struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"packages"`
+	Vulnerabilities map[string]struct {
+		Name           string `json:"name"`
+		Severity       string `json:"normalized_severity"`
+		FixedInVersion string `json:"fixed_in_version"`
+	} `json:"vulnerabilities"`
+	PackageVulnerabilities map[string][]string `json:"package_vulnerabilities"`
+}
+
+// OSInfo implements ScannerReport.
+func (r *clairReportFull) OSInfo() string {
+	for _, d := range r.Distributions {
+		return d.DID
+	}
+	return ""
+}
+
+// Vulns implements ScannerReport.
+func (r *clairReportFull) Vulns() []SiteVuln {
+	vulns := make([]SiteVuln, 0)
+	for pkgID, vulnIDs := range r.PackageVulnerabilities {
+		pkg := r.Packages[pkgID]
+		for _, vulnID := range vulnIDs {
+			v := r.Vulnerabilities[vulnID]
+			vulns = append(vulns, SiteVuln{
+				ID:               v.Name,
+				PkgName:          pkg.Name,
+				InstalledVersion: pkg.Version,
+				FixedVersion:     v.FixedInVersion,
+				Severity:         v.Severity,
+			})
+		}
+	}
+	return vulns
+}
+
+// syftSBOMReport mirrors the subset of a syft CycloneDX SBOM (see
+// internal/attest.GenerateSBOM) consumed here. A bare SBOM carries no
+// vulnerability matches — those come from pairing it with a scanner — so
+// Vulns always returns an empty slice; OSInfo still surfaces the scanned
+// distro when syft recorded one as the SBOM's primary component.
+type syftSBOMReport struct {
+	Metadata struct {
+		Component struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"component"`
+	} `json:"metadata"`
+	BOMFormat string `json:"bomFormat"`
+}
+
+// OSInfo implements ScannerReport.
+func (r *syftSBOMReport) OSInfo() string {
+	if r.Metadata.Component.Name == "" {
+		return ""
+	}
+	if r.Metadata.Component.Version != "" {
+		return r.Metadata.Component.Name + " " + r.Metadata.Component.Version
+	}
+	return r.Metadata.Component.Name
+}
+
+// Vulns implements ScannerReport. Always empty: see syftSBOMReport.
+func (r *syftSBOMReport) Vulns() []SiteVuln { return make([]SiteVuln, 0) }
+
+// parseScannerReport reads the report file at path and auto-detects which
+// backend produced it (trivy, grype, clair-v1, or a bare syft-sbom) from
+// its distinguishing top-level JSON keys, returning the matching
+// ScannerReport. Reports with no recognized vulnerability data (e.g. an
+// SBOM with neither "matches" nor "vulnerabilities") are treated as
+// syft-sbom, since that's the only supported format without one.
+func parseScannerReport(path string) (ScannerReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Results         json.RawMessage `json:"Results"`
+		Matches         json.RawMessage `json:"matches"`
+		Vulnerabilities json.RawMessage `json:"vulnerabilities"`
+		BOMFormat       json.RawMessage `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parsing report %s: %w", path, err)
+	}
+
+	var report ScannerReport
+	switch {
+	case probe.Results != nil:
+		report = &trivyReportFull{}
+	case probe.Matches != nil:
+		report = &grypeReportFull{}
+	case probe.Vulnerabilities != nil:
+		report = &clairReportFull{}
+	default:
+		report = &syftSBOMReport{}
+	}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("parsing report %s: %w", path, err)
+	}
+	return report, nil
 }
 
 // SaveOverrides writes a mapping of sanitized image ref → original tag
@@ -143,6 +439,49 @@ func SaveImagePaths(results []*PatchResult, dir string) error {
 	return os.WriteFile(filepath.Join(dir, "paths.json"), data, 0o644)
 }
 
+// SavePlatformVariants writes a mapping of sanitized original image ref →
+// platform (e.g. "linux/amd64") → patched digest to a platforms.json file
+// in the given directory, one level deeper than overrides.json/paths.json
+// since a multi-arch image has several digests rather than one value.
+// matchReportsToImages reads this back to populate SiteImage.Platforms'
+// Digest field, which has no other source of truth once the per-platform
+// reports are all that's left on disk.
+func SavePlatformVariants(results []*PatchResult, dir string) error {
+	variants := make(map[string]map[string]string)
+	for _, r := range results {
+		if len(r.Variants) == 0 {
+			continue
+		}
+		key := sanitize(r.Original.Reference())
+		byPlatform := make(map[string]string, len(r.Variants))
+		for _, v := range r.Variants {
+			byPlatform[v.Platform.String()] = v.Digest
+		}
+		variants[key] = byPlatform
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(variants, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "platforms.json"), data, 0o644)
+}
+
+// loadPlatformVariants reads a platforms.json file and returns the mapping.
+func loadPlatformVariants(dir string) map[string]map[string]string {
+	data, err := os.ReadFile(filepath.Join(dir, "platforms.json"))
+	if err != nil {
+		return nil
+	}
+	var variants map[string]map[string]string
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return nil
+	}
+	return variants
+}
+
 // loadImagePaths reads a paths.json file and returns the mapping.
 func loadImagePaths(dir string) map[string]string {
 	data, err := os.ReadFile(filepath.Join(dir, "paths.json"))
@@ -172,48 +511,190 @@ func loadOverrides(dir string) map[string]string {
 // GenerateSiteData walks the charts directory and standalone images file
 // to produce a catalog.json for the Astro static site.
 // Reports are pulled from the OCI registry (embedded in chart packages
-// and standalone-reports artifact), not from local files.
-func GenerateSiteData(chartsDir, imagesFile, registry, outputPath string) error {
+// and standalone-reports artifact), not from local files. ignoreStatus
+// drops vulnerabilities whose Trivy Status (e.g. "will_not_fix",
+// "end_of_life") matches one of its entries before they're counted in
+// VulnSummary or listed in Vulnerabilities — see filterIgnoredStatuses.
+//
+// vexDir, if non-empty, is a directory of *.vex.json OpenVEX documents
+// (plus any inline `vex:` block in imagesFile) loaded into a VEXCorpus and
+// applied while building every SiteImage, suppressing vulns an
+// authoritative VEX statement resolves to "not_affected" or "fixed" — see
+// LoadVEXCorpus and applyVEXSuppressions.
+//
+// gateOpts, if non-nil, runs EvaluateSeverityGate against the produced
+// SiteData after catalog.json is written, so a breach still leaves the
+// catalog on disk for inspection. A breach is reported as an error so a
+// CI caller fails the same way a transient discovery error would; the
+// full SeverityGateResult (for the human-readable summary and the PR-
+// comment JSON diff) is recovered by calling EvaluateSeverityGate again
+// against the written catalog.json, or directly via the CLI's gate command.
+func GenerateSiteData(chartsDir, imagesFile, registry, outputPath string, ignoreStatus []string, vexDir string, gateOpts *SeverityGateOptions) error {
+	vex, err := LoadVEXCorpus(vexDir, imagesFile)
+	if err != nil {
+		return fmt.Errorf("loading VEX corpus: %w", err)
+	}
+
+	data, err := discoverCatalog(chartsDir, imagesFile, registry, ignoreStatus, vex)
+	if err != nil {
+		return err
+	}
+
+	trendsPath := filepath.Join(filepath.Dir(outputPath), "trends.json")
+	if err := writeTrendData(data.Charts, data.GeneratedAt, trendsPath); err != nil {
+		return fmt.Errorf("writing trend data: %w", err)
+	}
+
+	// Marshal and write
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling site data: %w", err)
+	}
+	if err := os.WriteFile(outputPath, out, 0o644); err != nil {
+		return err
+	}
+
+	if gateOpts != nil {
+		gate, err := EvaluateSeverityGate(data, *gateOpts)
+		if err != nil {
+			return fmt.Errorf("evaluating severity gate: %w", err)
+		}
+		if gate.Breached {
+			return fmt.Errorf("%w: %d vuln(s) at or above %s", errSeverityGateBreached, gate.Count, gate.Threshold)
+		}
+	}
+	return nil
+}
+
+// GenerateSiteDataFromJSON builds a catalog.json from a sign-and-attest
+// pipeline's images.json (see attest.ImagesJSONEntry) and local Trivy
+// report directories, rather than GenerateSiteData's chart-directory/OCI
+// registry discovery — for a CI step that runs right after scanning,
+// before anything is published as a wrapper chart, so there's no chart
+// directory or pushed registry artifact to discover from yet.
+//
+// For each entry, postReportsDir's report (the patched image's scan), if
+// present, becomes the SiteImage's current vulnerabilities; reportsDir's
+// report (the pre-patch scan), if also present, is diffed against it via
+// buildSiteImageDiff the same way a scan-to-scan trend is, so
+// NewVulns/FixedVulns reflect what patching actually changed. An entry
+// with neither report gets an empty VulnSummary rather than failing the
+// whole run.
+func GenerateSiteDataFromJSON(imagesJSON, reportsDir, postReportsDir, registry, outputPath, vexDir string) error {
+	entries, err := attest.ReadImagesJSON(imagesJSON)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", imagesJSON, err)
+	}
+
+	vex, err := LoadVEXCorpus(vexDir, "")
+	if err != nil {
+		return fmt.Errorf("loading VEX corpus: %w", err)
+	}
+
+	generatedAt := time.Now().UTC().Format(time.RFC3339)
+
+	var siteImages []SiteImage
+	for _, entry := range entries {
+		ref := entry.Original
+		sanitizedRef := sanitize(ref)
+
+		var report, prevReport ScannerReport
+		if postReportsDir != "" {
+			if r, err := parseScannerReport(filepath.Join(postReportsDir, sanitizedRef+".json")); err == nil {
+				report = r
+			}
+		}
+		if reportsDir != "" {
+			if r, err := parseScannerReport(filepath.Join(reportsDir, sanitizedRef+".json")); err == nil {
+				if report == nil {
+					report = r
+				} else {
+					prevReport = r
+				}
+			}
+		}
+
+		if report == nil {
+			siteImages = append(siteImages, SiteImage{
+				ID:              sanitizedRef,
+				OriginalRef:     ref,
+				PatchedRef:      entry.Patched,
+				Vulnerabilities: make([]SiteVuln, 0),
+				VulnSummary:     VulnSummary{SeverityCounts: make(map[string]int)},
+			})
+			continue
+		}
+		si := buildSiteImageDiff(sanitizedRef, ref, entry.Patched, "", "", report, prevReport, generatedAt, "", nil, vex)
+		siteImages = append(siteImages, si)
+	}
+
+	data := SiteData{
+		GeneratedAt:      generatedAt,
+		Registry:         registry,
+		StandaloneImages: siteImages,
+	}
+	data.Summary = computeSummary(data.Charts, data.StandaloneImages)
+
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling site data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	return os.WriteFile(outputPath, out, 0o644)
+}
+
+// errSeverityGateBreached is returned by GenerateSiteData when gateOpts is
+// set and EvaluateSeverityGate reports a breach, so callers (and the CLI's
+// exit code) can distinguish "gate failed" from a discovery/IO error
+// without parsing the message.
+var errSeverityGateBreached = errors.New("severity gate breached")
+
+// discoverCatalog runs the full chart/standalone-image discovery pipeline
+// and returns the resulting SiteData, without writing anything to disk.
+// GenerateSiteData builds on this for the static catalog.json/trends.json
+// site export; ServeCatalog builds on it to serve the same data live.
+func discoverCatalog(chartsDir, imagesFile, registry string, ignoreStatus []string, vex *VEXCorpus) (SiteData, error) {
 	data := SiteData{
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		Registry:    registry,
 	}
 
 	// Discover wrapper charts (all data pulled from OCI).
-	charts, err := discoverCharts(chartsDir, registry)
+	charts, err := discoverCharts(chartsDir, registry, ignoreStatus, vex)
 	if err != nil {
-		return fmt.Errorf("discovering charts: %w", err)
+		return SiteData{}, fmt.Errorf("discovering charts: %w", err)
 	}
+
+	// discoverCharts already returns one SiteChart per published version
+	// (see discoverRegistryVersions), so that's also the raw material for
+	// a vulnerability trend series: stamp each version's chart and image
+	// entries with the full series before anything gets deduplicated.
+	annotateHistory(charts)
 	data.Charts = charts
 
 	// Discover standalone images (reports pulled from OCI).
 	if imagesFile != "" {
-		standalone, err := discoverStandaloneImages(imagesFile, registry)
+		standalone, err := discoverStandaloneImages(imagesFile, registry, VerifyOpts{}, ignoreStatus, vex)
 		if err != nil {
-			return fmt.Errorf("discovering standalone images: %w", err)
+			return SiteData{}, fmt.Errorf("discovering standalone images: %w", err)
 		}
 		data.StandaloneImages = standalone
 	}
 
-	// Compute summary
 	data.Summary = computeSummary(data.Charts, data.StandaloneImages)
-
-	// Marshal and write
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
-		return fmt.Errorf("creating output directory: %w", err)
-	}
-
-	out, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling site data: %w", err)
-	}
-	return os.WriteFile(outputPath, out, 0o644)
+	return data, nil
 }
 
 // discoverCharts walks chartsDir/*/Chart.yaml to find wrapper charts.
 // All chart data (including reports) is pulled from the OCI registry;
 // the local chart directories only provide the chart name.
-func discoverCharts(chartsDir, registry string) ([]SiteChart, error) {
+func discoverCharts(chartsDir, registry string, ignoreStatus []string, vex *VEXCorpus) ([]SiteChart, error) {
 	entries, err := os.ReadDir(chartsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -235,14 +716,14 @@ func discoverCharts(chartsDir, registry string) ([]SiteChart, error) {
 
 		if registry != "" {
 			// Pull ALL versions from OCI (reports are embedded in the chart packages).
-			versions, err := discoverRegistryVersions(entry.Name(), "", "", registry)
+			versions, err := discoverRegistryVersions(entry.Name(), "", "", registry, VersionSelector{}, VerifyOpts{}, ignoreStatus, vex)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: could not discover registry versions for %s: %v\n", entry.Name(), err)
 			}
 			charts = append(charts, versions...)
 		} else {
 			// No registry — fall back to local chart parsing (no reports).
-			chart, err := parseWrapperChart(chartsDir, entry.Name(), registry)
+			chart, err := parseWrapperChart(chartsDir, entry.Name(), registry, ignoreStatus, vex)
 			if err != nil {
 				return nil, fmt.Errorf("parsing chart %s: %w", entry.Name(), err)
 			}
@@ -261,13 +742,17 @@ func discoverCharts(chartsDir, registry string) ([]SiteChart, error) {
 
 // discoverRegistryVersions queries the GitHub Packages API for all published
 // versions of a chart, pulls each one, and returns SiteChart entries with
-// full data (including embedded Trivy reports).
+// full data (including embedded Trivy reports). selector narrows which tags
+// are pulled (see VersionSelector); the zero value pulls every version.
+// verify's zero value skips signature/attestation checks entirely, leaving
+// SiteChart.SignatureStatus empty (see VerifyChartSignature).
 // If skipVersion is non-empty, that version is excluded from the results.
-func discoverRegistryVersions(chartName, skipVersion, repository, registry string) ([]SiteChart, error) {
+func discoverRegistryVersions(chartName, skipVersion, repository, registry string, selector VersionSelector, verify VerifyOpts, ignoreStatus []string, vex *VEXCorpus) ([]SiteChart, error) {
 	tags, err := listChartTags(registry, chartName)
 	if err != nil {
 		return nil, err
 	}
+	tags = selector.Filter(tags)
 
 	const maxConsecutiveFailures = 5
 
@@ -295,7 +780,7 @@ func discoverRegistryVersions(chartName, skipVersion, repository, registry strin
 			Repository: fmt.Sprintf("oci://%s/charts", registry),
 		}
 
-		_, dlErr := DownloadChart(dep, tmpDir)
+		_, _, dlErr := DownloadChart(dep, tmpDir, VerifyNever, "")
 		if dlErr != nil {
 			_ = os.RemoveAll(tmpDir)
 			fmt.Fprintf(os.Stderr, "Warning: could not pull %s:%s: %v\n", chartName, tag, dlErr)
@@ -307,7 +792,7 @@ func discoverRegistryVersions(chartName, skipVersion, repository, registry strin
 			continue
 		}
 
-		chart, parseErr := parseWrapperChart(tmpDir, chartName, registry)
+		chart, parseErr := parseWrapperChart(tmpDir, chartName, registry, ignoreStatus, vex)
 		_ = os.RemoveAll(tmpDir)
 		if parseErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not parse %s:%s: %v\n", chartName, tag, parseErr)
@@ -319,6 +804,23 @@ func discoverRegistryVersions(chartName, skipVersion, repository, registry strin
 			continue
 		}
 
+		if verify.RequireSignature || verify.RequireAttestation {
+			chartRef := fmt.Sprintf("%s/charts/%s:%s", registry, chartName, tag)
+			chart.SignatureStatus = "verified"
+			if verify.RequireSignature {
+				if err := VerifyChartSignature(chartRef, verify); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: signature verification failed for %s: %v\n", chartRef, err)
+					chart.SignatureStatus = "unverified"
+				}
+			}
+			if verify.RequireAttestation {
+				if _, err := VerifyReportAttestation(chartRef, verify); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: attestation verification failed for %s: %v\n", chartRef, err)
+					chart.SignatureStatus = "unverified"
+				}
+			}
+		}
+
 		consecutiveFailures = 0
 		charts = append(charts, chart)
 	}
@@ -457,8 +959,34 @@ func isVersionTag(tag string) bool {
 	return true
 }
 
+// isChannelTag returns true if tag is one of the floating channel aliases
+// PushChannelTags pushes alongside a chart's real version tags: "latest",
+// "stable", a bare major ("1"), or a major.minor ("1.2"). These point at
+// the same digest as a real version tag, so VersionSelector.Filter excludes
+// them from discoverRegistryVersions' per-version pull loop.
+func isChannelTag(tag string) bool {
+	if tag == "latest" || tag == "stable" {
+		return true
+	}
+	parts := strings.Split(tag, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" {
+			return false
+		}
+		for _, r := range p {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // parseWrapperChart reads a wrapper chart's metadata, values, and reports.
-func parseWrapperChart(chartsDir, name, registry string) (SiteChart, error) {
+func parseWrapperChart(chartsDir, name, registry string, ignoreStatus []string, vex *VEXCorpus) (SiteChart, error) {
 	chartDir := filepath.Join(chartsDir, name)
 
 	// Parse Chart.yaml
@@ -489,11 +1017,21 @@ func parseWrapperChart(chartsDir, name, registry string) (SiteChart, error) {
 		return SiteChart{}, fmt.Errorf("parsing values: %w", err)
 	}
 
-	// Discover reports and match to images
+	// Discover reports and match to images. reports/ is gitignored by
+	// design and absent from most chart packages; when it's missing, fall
+	// back to fetching each image's Trivy report via the OCI 1.1
+	// referrers API instead of falling straight to matchReportsToImages'
+	// zero-vuln stub entries.
 	reportsDir := filepath.Join(chartDir, "reports")
+	if registry != "" && !dirExists(reportsDir) {
+		if err := fetchChartReportsViaReferrers(registry, name, cf.Version, reportsDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch reports via referrers for %s: %v\n", name, err)
+		}
+	}
 	overrides := loadOverrides(chartDir)
 	imagePaths := loadImagePaths(chartDir)
-	images, err := matchReportsToImages(reportsDir, patchedImages, overrides, imagePaths, registry, name)
+	platformDigests := loadPlatformVariants(chartDir)
+	images, err := matchReportsToImages(reportsDir, patchedImages, overrides, imagePaths, platformDigests, registry, name, ignoreStatus, vex)
 	if err != nil {
 		return SiteChart{}, fmt.Errorf("matching reports: %w", err)
 	}
@@ -514,7 +1052,7 @@ func parseWrapperChart(chartsDir, name, registry string) (SiteChart, error) {
 		// Fallback for older chart packages that lack paths.json:
 		// scan the bundled upstream chart to determine which images
 		// belong to this version.
-		upstreamImages, scanErr := ScanForImages(upstreamDir)
+		upstreamImages, scanErr := ScanForImages(context.Background(), upstreamDir)
 		if scanErr == nil && len(upstreamImages) > 0 {
 			allowed := make(map[string]bool)
 			for _, uimg := range upstreamImages {
@@ -658,9 +1196,40 @@ func collectPatchedImages(node any, path string, result map[string]patchedImageI
 	}
 }
 
+// knownPlatformOS and knownPlatformArch are the OS/architecture tokens
+// patcher.go's platformSuffix produces for a multi-arch image's per-platform
+// report filenames (e.g. "..._linux_amd64.json"). splitPlatformSuffix uses
+// them to tell a multi-arch report stem apart from an ordinary single-arch
+// one, whose tag could otherwise coincidentally end the same way.
+var (
+	knownPlatformOS   = map[string]bool{"linux": true, "windows": true, "darwin": true}
+	knownPlatformArch = map[string]bool{"amd64": true, "arm64": true, "arm": true, "386": true, "ppc64le": true, "s390x": true, "riscv64": true}
+)
+
+// splitPlatformSuffix recognizes a copyPlatformReports filename stem of the
+// form "<original-ref>_<os>_<arch>[_<variant>]" (see patcher.go's
+// platformSuffix) and splits it into the base (original-ref, still
+// sanitized) and the Platform it names. ok is false for an ordinary
+// single-arch report stem, which the caller should treat as-is.
+func splitPlatformSuffix(stem string) (base string, platform Platform, ok bool) {
+	parts := strings.Split(stem, "_")
+	if len(parts) >= 4 && knownPlatformOS[parts[len(parts)-3]] && knownPlatformArch[parts[len(parts)-2]] {
+		idx := len(parts) - 3
+		return strings.Join(parts[:idx], "_"), Platform{OS: parts[idx], Arch: parts[idx+1], Variant: parts[idx+2]}, true
+	}
+	if len(parts) >= 3 && knownPlatformOS[parts[len(parts)-2]] && knownPlatformArch[parts[len(parts)-1]] {
+		idx := len(parts) - 2
+		return strings.Join(parts[:idx], "_"), Platform{OS: parts[idx], Arch: parts[idx+1]}, true
+	}
+	return "", Platform{}, false
+}
+
 // matchReportsToImages reads Trivy JSON reports from the reports directory
-// and creates SiteImage entries for each one.
-func matchReportsToImages(reportsDir string, patchedImages map[string]patchedImageInfo, overrides, imagePaths map[string]string, registry, chartName string) ([]SiteImage, error) {
+// and creates SiteImage entries for each one. A multi-arch image's several
+// per-platform reports (see copyPlatformReports) are grouped by
+// splitPlatformSuffix into a single SiteImage with a Platforms breakdown,
+// instead of one unrelated SiteImage per architecture.
+func matchReportsToImages(reportsDir string, patchedImages map[string]patchedImageInfo, overrides, imagePaths map[string]string, platformDigests map[string]map[string]string, registry, chartName string, ignoreStatus []string, vex *VEXCorpus) ([]SiteImage, error) {
 	entries, err := os.ReadDir(reportsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -669,21 +1238,29 @@ func matchReportsToImages(reportsDir string, patchedImages map[string]patchedIma
 		return nil, err
 	}
 
+	multiArch := make(map[string][]platformReport)
+
 	var images []SiteImage
 	for _, entry := range entries {
 		if !strings.HasSuffix(entry.Name(), ".json") {
 			continue
 		}
 
+		// Reconstruct original ref from sanitized filename
+		// Filename: quay.io_brancz_kube-rbac-proxy_v0.14.0.json
+		sanitizedName := strings.TrimSuffix(entry.Name(), ".json")
+
+		if base, p, ok := splitPlatformSuffix(sanitizedName); ok {
+			multiArch[base] = append(multiArch[base], platformReport{platform: p, path: filepath.Join(reportsDir, entry.Name())})
+			continue
+		}
+
 		reportPath := filepath.Join(reportsDir, entry.Name())
-		report, err := parseTrivyReportFull(reportPath)
+		report, err := parseScannerReport(reportPath)
 		if err != nil {
 			return nil, fmt.Errorf("parsing report %s: %w", entry.Name(), err)
 		}
 
-		// Reconstruct original ref from sanitized filename
-		// Filename: quay.io_brancz_kube-rbac-proxy_v0.14.0.json
-		sanitizedName := strings.TrimSuffix(entry.Name(), ".json")
 		originalRef := unsanitize(sanitizedName)
 
 		// Build patched ref
@@ -695,19 +1272,116 @@ func matchReportsToImages(reportsDir string, patchedImages map[string]patchedIma
 			valuesPath = imagePaths[sanitizedName]
 		}
 
-		img := buildSiteImage(sanitizedName, originalRef, patchedRef, valuesPath, chartName, report)
+		img := buildSiteImage(sanitizedName, originalRef, patchedRef, valuesPath, chartName, report, ignoreStatus, vex)
 		if ov, ok := overrides[sanitizedName]; ok {
 			img.OverriddenFrom = ov
 		}
 		images = append(images, img)
 	}
 
+	for base, reports := range multiArch {
+		img, err := buildMultiArchSiteImage(base, reports, patchedImages, overrides, imagePaths, platformDigests[base], registry, chartName, ignoreStatus, vex)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+
 	sort.Slice(images, func(i, j int) bool {
 		return images[i].OriginalRef < images[j].OriginalRef
 	})
 	return images, nil
 }
 
+// platformReport is one multi-arch image's per-platform Trivy report, as
+// grouped by splitPlatformSuffix.
+type platformReport struct {
+	platform Platform
+	path     string
+}
+
+// buildMultiArchSiteImage merges the per-platform Trivy reports
+// splitPlatformSuffix grouped for one multi-arch image into a single
+// SiteImage: VulnSummary and Vulnerabilities are the union across every
+// platform, and Platforms records each platform's own counts, OS family,
+// and (when digests is non-nil) patched digest.
+func buildMultiArchSiteImage(sanitizedName string, reports []platformReport, patchedImages map[string]patchedImageInfo, overrides, imagePaths, digests map[string]string, registry, chartName string, ignoreStatus []string, vex *VEXCorpus) (SiteImage, error) {
+	sort.Slice(reports, func(i, j int) bool { return reports[i].platform.String() < reports[j].platform.String() })
+
+	originalRef := unsanitize(sanitizedName)
+	patchedRef := buildPatchedRef(originalRef, registry)
+	valuesPath := findValuesPath(originalRef, patchedImages)
+	if valuesPath == "" && imagePaths != nil {
+		valuesPath = imagePaths[sanitizedName]
+	}
+
+	severityCounts := make(map[string]int)
+	statusCounts := make(map[string]int)
+	suppressions := make(map[string]int)
+	vulns := make([]SiteVuln, 0)
+	platforms := make([]PlatformInfo, 0, len(reports))
+	osInfo := ""
+
+	for _, pr := range reports {
+		report, err := parseScannerReport(pr.path)
+		if err != nil {
+			return SiteImage{}, fmt.Errorf("parsing report %s: %w", pr.path, err)
+		}
+		variant := buildSiteImage(sanitizedName, originalRef, patchedRef, valuesPath, chartName, report, ignoreStatus, vex)
+		if osInfo == "" {
+			osInfo = variant.OS
+		}
+		vulns = append(vulns, variant.Vulnerabilities...)
+		for sev, n := range variant.VulnSummary.SeverityCounts {
+			severityCounts[sev] += n
+		}
+		for status, n := range variant.VulnSummary.StatusCounts {
+			statusCounts[status] += n
+		}
+		for reason, n := range variant.VulnSummary.Suppressions {
+			suppressions[reason] += n
+		}
+		platforms = append(platforms, PlatformInfo{
+			OS:          pr.platform.OS,
+			Arch:        pr.platform.Arch,
+			Digest:      digests[pr.platform.String()],
+			VulnSummary: variant.VulnSummary,
+		})
+	}
+
+	fixable := 0
+	for _, v := range vulns {
+		if v.FixedVersion != "" {
+			fixable++
+		}
+	}
+	if len(suppressions) == 0 {
+		suppressions = nil
+	}
+
+	img := SiteImage{
+		ID:          sanitizedName,
+		OriginalRef: originalRef,
+		PatchedRef:  patchedRef,
+		ValuesPath:  valuesPath,
+		OS:          osInfo,
+		ChartName:   chartName,
+		VulnSummary: VulnSummary{
+			Total:          len(vulns),
+			Fixable:        fixable,
+			SeverityCounts: severityCounts,
+			StatusCounts:   statusCounts,
+			Suppressions:   suppressions,
+		},
+		Vulnerabilities: vulns,
+		Platforms:       platforms,
+	}
+	if ov, ok := overrides[sanitizedName]; ok {
+		img.OverriddenFrom = ov
+	}
+	return img, nil
+}
+
 // unsanitize attempts to reconstruct an image reference from a sanitized filename.
 // sanitize replaces / and : with _, so we need heuristics to reverse it.
 // Format: registry_path_repo_tag → registry/path/repo:tag
@@ -795,56 +1469,132 @@ func parseTrivyReportFull(path string) (*trivyReportFull, error) {
 	return &report, nil
 }
 
-// buildSiteImage creates a SiteImage from a Trivy report.
-func buildSiteImage(id, originalRef, patchedRef, valuesPath, chartName string, report *trivyReportFull) SiteImage {
-	osInfo := ""
-	if report.Metadata.OS.Family != "" {
-		osInfo = report.Metadata.OS.Family
-		if report.Metadata.OS.Name != "" {
-			osInfo += " " + report.Metadata.OS.Name
-		}
-	}
+// buildSiteImage creates a SiteImage from a normalized scanner report,
+// regardless of which backend (trivy, grype, clair-v1, syft-sbom) produced
+// it — see ScannerReport and parseScannerReport. ignoreStatus drops
+// vulnerabilities matching one of its per-vulnerability Trivy statuses
+// (e.g. "will_not_fix", "end_of_life") before they're counted or listed —
+// see filterIgnoredStatuses. vex additionally drops vulns an authoritative
+// VEX statement resolves to "not_affected" or "fixed", recording why on
+// VulnSummary.Suppressions — see applyVEXSuppressions. vex may be nil.
+func buildSiteImage(id, originalRef, patchedRef, valuesPath, chartName string, report ScannerReport, ignoreStatus []string, vex *VEXCorpus) SiteImage {
+	vulns := filterIgnoredStatuses(report.Vulns(), ignoreStatus)
+	vulns, suppressions := applyVEXSuppressions(vulns, vex, originalRef)
+
+	summary := summarizeVulns(vulns)
+	summary.Suppressions = suppressions
 
-	vulns := make([]SiteVuln, 0)
-	severityCounts := make(map[string]int)
+	return SiteImage{
+		ID:              id,
+		OriginalRef:     originalRef,
+		PatchedRef:      patchedRef,
+		ValuesPath:      valuesPath,
+		OS:              report.OSInfo(),
+		ChartName:       chartName,
+		VulnSummary:     summary,
+		Vulnerabilities: vulns,
+	}
+}
+
+// summarizeVulns buckets vulns by severity (an empty Severity is counted as
+// "UNKNOWN") and counts how many have a fix available, the same
+// severity/fixable breakdown buildSiteImage has always computed for
+// SiteImage.VulnSummary — factored out so PatchResult's Before/After stats
+// (see patcher.go) can be built from a plain vuln list the same way.
+func summarizeVulns(vulns []SiteVuln) VulnSummary {
+	severityCounts := make(map[string]int)
+	statusCounts := make(map[string]int)
 	fixable := 0
+	for _, v := range vulns {
+		sev := v.Severity
+		if sev == "" {
+			sev = "UNKNOWN"
+		}
+		severityCounts[sev]++
+		if v.Status != "" {
+			statusCounts[v.Status]++
+		}
+		if v.FixedVersion != "" {
+			fixable++
+		}
+	}
+	return VulnSummary{
+		Total:          len(vulns),
+		Fixable:        fixable,
+		SeverityCounts: severityCounts,
+		StatusCounts:   statusCounts,
+	}
+}
 
-	for _, result := range report.Results {
-		for _, v := range result.Vulnerabilities {
-			vuln := SiteVuln{
-				ID:               v.VulnerabilityID,
-				PkgName:          v.PkgName,
-				InstalledVersion: v.InstalledVersion,
-				FixedVersion:     v.FixedVersion,
-				Severity:         v.Severity,
-				Title:            v.Title,
-			}
-			vulns = append(vulns, vuln)
-			sev := v.Severity
-			if sev == "" {
-				sev = "UNKNOWN"
-			}
-			severityCounts[sev]++
-			if v.FixedVersion != "" {
-				fixable++
-			}
+// filterIgnoredStatuses drops every vuln whose Status is in ignoreStatus
+// (matched case-insensitively, since Trivy's --ignore-status flag and JSON
+// output both use lowercase statuses but users may type e.g.
+// "WILL_NOT_FIX"). A vuln with no Status is never dropped, since
+// ignoreStatus can only describe dispositions a scanner actually reports.
+func filterIgnoredStatuses(vulns []SiteVuln, ignoreStatus []string) []SiteVuln {
+	if len(ignoreStatus) == 0 {
+		return vulns
+	}
+	ignored := make(map[string]bool, len(ignoreStatus))
+	for _, s := range ignoreStatus {
+		ignored[strings.ToLower(s)] = true
+	}
+
+	filtered := make([]SiteVuln, 0, len(vulns))
+	for _, v := range vulns {
+		if v.Status != "" && ignored[strings.ToLower(v.Status)] {
+			continue
 		}
+		filtered = append(filtered, v)
 	}
+	return filtered
+}
 
-	return SiteImage{
-		ID:          id,
-		OriginalRef: originalRef,
-		PatchedRef:  patchedRef,
-		ValuesPath:  valuesPath,
-		OS:          osInfo,
-		ChartName:   chartName,
-		VulnSummary: VulnSummary{
-			Total:          len(vulns),
-			Fixable:        fixable,
-			SeverityCounts: severityCounts,
-		},
-		Vulnerabilities: vulns,
+// buildSiteImageDiff builds a SiteImage like buildSiteImage, additionally
+// diffing report against prevReport (the previous retained standalone-reports
+// scan, or nil if none exists) to populate NewVulns, FixedVulns, and each
+// SiteVuln's FirstSeen. generatedAt and prevGeneratedAt are the date tags
+// (see standaloneReportsDateTagLayout) of the current and previous scans.
+func buildSiteImageDiff(id, originalRef, patchedRef, valuesPath, chartName string, report, prevReport ScannerReport, generatedAt, prevGeneratedAt string, ignoreStatus []string, vex *VEXCorpus) SiteImage {
+	si := buildSiteImage(id, originalRef, patchedRef, valuesPath, chartName, report, ignoreStatus, vex)
+	if prevReport == nil {
+		for i := range si.Vulnerabilities {
+			si.Vulnerabilities[i].FirstSeen = generatedAt
+		}
+		return si
+	}
+
+	// Apply the same VEX corpus to the previous scan's vulns before diffing,
+	// so a vuln that's merely newly-suppressed (not actually patched) drops
+	// out of both prevIDs and curIDs together instead of being counted as
+	// FixedVulns.
+	prevVulns, _ := applyVEXSuppressions(filterIgnoredStatuses(prevReport.Vulns(), ignoreStatus), vex, originalRef)
+	prevIDs := make(map[string]bool)
+	for _, v := range prevVulns {
+		prevIDs[v.ID] = true
+	}
+	curIDs := make(map[string]bool, len(si.Vulnerabilities))
+	for _, v := range si.Vulnerabilities {
+		curIDs[v.ID] = true
 	}
+
+	for i := range si.Vulnerabilities {
+		v := &si.Vulnerabilities[i]
+		if prevIDs[v.ID] {
+			v.FirstSeen = prevGeneratedAt
+		} else {
+			v.FirstSeen = generatedAt
+			si.NewVulns = append(si.NewVulns, v.ID)
+		}
+	}
+	for vulnID := range prevIDs {
+		if !curIDs[vulnID] {
+			si.FixedVulns = append(si.FixedVulns, vulnID)
+		}
+	}
+	sort.Strings(si.NewVulns)
+	sort.Strings(si.FixedVulns)
+	return si
 }
 
 func dirExists(path string) bool {
@@ -854,26 +1604,40 @@ func dirExists(path string) bool {
 
 // SaveStandaloneReports copies Trivy reports from PatchResults into a
 // local directory. This is used during assembly before pushing to OCI.
-func SaveStandaloneReports(results []*PatchResult, reportsDir string) error {
+func SaveStandaloneReports(results []*PatchResult, reportsDir string, opts PushOptions) error {
 	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
 		return fmt.Errorf("creating standalone reports dir: %w", err)
 	}
 
-	for _, r := range results {
+	err := parallelForEach(len(results), opts.Concurrency, opts.QPS, func(i int) error {
+		r := results[i]
 		// Prefer the upstream (pre-patch) report for "before" data.
 		src := r.UpstreamReportPath
 		if src == "" {
 			src = r.ReportPath
 		}
 		if src == "" {
-			continue
+			return nil
 		}
 		// Use the original image ref for the filename, not the patched one.
-		reportName := sanitize(r.Original.Reference()) + ".json"
-		destPath := filepath.Join(reportsDir, reportName)
+		sanitizedRef := sanitize(r.Original.Reference())
+		destPath := filepath.Join(reportsDir, sanitizedRef+".json")
 		if err := copyFile(src, destPath); err != nil {
 			return fmt.Errorf("copying report for %s: %w", r.Original.Reference(), err)
 		}
+
+		// Also persist a normalized copy alongside the original scanner
+		// output, so consumers that don't want to auto-detect the source
+		// format (see parseScannerReport) can read Trivy-compat JSON
+		// regardless of which backend produced src.
+		normPath := filepath.Join(reportsDir, sanitizedRef+".verity-report.json")
+		if err := writeNormalizedReport(destPath, normPath); err != nil {
+			return fmt.Errorf("normalizing report for %s: %w", r.Original.Reference(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Save override metadata for site data generation.
@@ -884,130 +1648,595 @@ func SaveStandaloneReports(results []*PatchResult, reportsDir string) error {
 	return nil
 }
 
+// writeNormalizedReport parses the scanner report at srcPath (auto-detecting
+// its format via parseScannerReport) and writes its vulnerabilities to
+// destPath in Trivy-compat JSON, the one shape every backend's report can be
+// read back as regardless of which produced it (mirroring
+// internal/scanner.Report.MarshalTrivyCompat for live scans).
+func writeNormalizedReport(srcPath, destPath string) error {
+	report, err := parseScannerReport(srcPath)
+	if err != nil {
+		return err
+	}
+
+	doc := trivyReportFull{
+		Results: []trivyResultFull{{Vulnerabilities: make([]trivyVulnFull, 0)}},
+	}
+	doc.Metadata.OS.Family = report.OSInfo()
+	for _, v := range report.Vulns() {
+		doc.Results[0].Vulnerabilities = append(doc.Results[0].Vulnerabilities, trivyVulnFull{
+			VulnerabilityID:  v.ID,
+			PkgName:          v.PkgName,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Severity:         v.Severity,
+			Title:            v.Title,
+		})
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling normalized report: %w", err)
+	}
+	return os.WriteFile(destPath, data, 0o644)
+}
+
+// standaloneReportsLayerTitle is the OCI annotation key a standalone
+// report's original on-disk filename is stored under, matching the
+// convention OCI artifact tooling (e.g. ORAS) uses for per-layer filenames.
+const standaloneReportsLayerTitle = "org.opencontainers.image.title"
+
+// ociLayoutSourcePrefix marks a discoverStandaloneImages "registry"
+// argument as a path to a local OCI image-layout directory (see
+// ExportStandaloneReportsOCILayout) rather than a live registry host, e.g.
+// "oci-layout:///mnt/transfer/reports".
+const ociLayoutSourcePrefix = "oci-layout://"
+
+// standaloneReportsLayerDigest is the OCI annotation key a standalone
+// report layer's own content digest is mirrored under. It duplicates what
+// the layer descriptor already carries, but makes the content-addressing
+// visible in the manifest itself: an unchanged report pushed again reuses
+// the same digest, so the registry dedups the blob instead of storing it
+// twice (see PushStandaloneReports).
+const standaloneReportsLayerDigest = "dev.verity.report.digest"
+
+const (
+	// standaloneReportsConfigMediaType marks the standalone-reports
+	// artifact as Verity's own artifact type, rather than a generic OCI
+	// image, so tooling (and humans running `crane manifest`) can tell
+	// this isn't meant to be run as a container.
+	standaloneReportsConfigMediaType = "application/vnd.verity.reports.v1+json"
+	// standaloneReportsLayerMediaType marks each layer as a standalone
+	// Trivy report, replacing the single opaque tarball layer earlier
+	// versions pushed.
+	standaloneReportsLayerMediaType = "application/vnd.cncf.trivy.report.v1+json"
+	// standaloneReportsManifestPredicateType is the in-toto predicate the
+	// reports manifest (the list of report filenames included in a push)
+	// is attested under, so `cosign verify-attestation` can confirm which
+	// reports a given push was supposed to contain.
+	standaloneReportsManifestPredicateType = "https://verity.dev/attestations/standalone-reports/v1"
+)
+
+// standaloneReportsDateTagLayout is the tag format PushStandaloneReports
+// stamps every push with (e.g. "2024-03-02"), alongside the floating
+// "latest" alias, so buildSiteImageDiff's previous-scan lookup can list and
+// order pushes by date without needing a separate index file.
+const standaloneReportsDateTagLayout = "2006-01-02"
+
+// standaloneReportsRetentionWindow bounds how many dated pushes
+// PushStandaloneReports keeps before pruning the oldest, so the registry
+// doesn't accumulate an unbounded history of report artifacts.
+const standaloneReportsRetentionWindow = 10
+
+// defaultPushConcurrency is how many report files SaveStandaloneReports and
+// PushStandaloneReports read/build in parallel when PushOptions.Concurrency
+// is unset.
+const defaultPushConcurrency = 4
+
+// maxPushAttempts bounds retryWithBackoff's exponential backoff loop around
+// registry calls in PushStandaloneReports.
+const maxPushAttempts = 5
+
+// PushOptions configures the concurrency and registry request rate
+// SaveStandaloneReports and PushStandaloneReports use when handling large
+// numbers of report files, mirroring SignOptions/VerifyOpts's convention of
+// one options struct per call rather than a growing parameter list.
+type PushOptions struct {
+	// Concurrency bounds how many report files are processed in parallel.
+	// Defaults to defaultPushConcurrency when <= 0.
+	Concurrency int
+	// QPS rate-limits those parallel operations, shared across every
+	// worker, so a large fleet of images doesn't trip a registry's abuse
+	// detection. Unlimited when <= 0.
+	QPS float64
+
+	// RegistryClient pushes/tags the standalone reports artifact in
+	// PushStandaloneReports. Nil uses registry.New(registry.Options{});
+	// tests can inject a stub instead.
+	RegistryClient registry.Client
+}
+
+// parallelForEach runs fn(i) for every i in [0,n) across a bounded worker
+// pool, pacing calls to opts' QPS. It waits for every worker to finish
+// before returning, so SaveStandaloneReports and buildReportLayers get a
+// complete, deterministic result (or the first error) rather than aborting
+// mid-flight on the first failure.
+func parallelForEach(n, concurrency int, qps float64, fn func(i int) error) error {
+	if concurrency <= 0 {
+		concurrency = defaultPushConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+	pace := newPacer(qps)
+
+	jobs := make(chan int)
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pace.wait()
+				errs <- fn(i)
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < n; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+	wg.Wait()
+	close(errs)
+
+	var firstErr error
+	for err := range errs {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// pacer throttles parallelForEach's workers to a shared rate, rather than
+// letting each worker run at the configured QPS independently.
+type pacer struct {
+	interval time.Duration
+	mu       sync.Mutex
+	last     time.Time
+}
+
+// newPacer returns a pacer enforcing qps, or nil (a no-op) when qps <= 0.
+func newPacer(qps float64) *pacer {
+	if qps <= 0 {
+		return nil
+	}
+	return &pacer{interval: time.Duration(float64(time.Second) / qps)}
+}
+
+func (p *pacer) wait() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if wait := time.Until(p.last.Add(p.interval)); wait > 0 {
+		time.Sleep(wait)
+	}
+	p.last = time.Now()
+}
+
+// retryWithBackoff retries fn up to maxAttempts times with exponential
+// backoff when it fails with a registry 429 or 5xx — the transient errors a
+// large concurrent push is most likely to hit. Any other error returns
+// immediately, since a retry wouldn't change the outcome.
+func retryWithBackoff(fn func() error, maxAttempts int) error {
+	backoff := time.Second
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !isRetryableRegistryError(err) || attempt == maxAttempts-1 {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return err
+}
+
+// isRetryableRegistryError reports whether err is a registry 429 or 5xx
+// response, per go-containerregistry's transport.Error — the shape crane
+// calls surface rate-limit and server errors as.
+func isRetryableRegistryError(err error) bool {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		return terr.StatusCode == http.StatusTooManyRequests || terr.StatusCode >= 500
+	}
+	return false
+}
+
+// reportLayer is one report file built into an OCI layer by
+// buildReportLayers, ready for PushStandaloneReports to append to the
+// artifact in order.
+type reportLayer struct {
+	name   string
+	layer  v1.Layer
+	digest v1.Hash
+}
+
+// buildReportLayers reads and digests entries (already filtered to
+// standalone report JSON files) in parallel per opts, preserving entries'
+// order in the returned slice so PushStandaloneReports' artifact layers —
+// and therefore its digest — don't depend on goroutine scheduling.
+// Resumability against partial failures is left to crane.Push itself: the
+// underlying remote.Write already skips any blob the registry reports as
+// present by digest, so a retried push after a transient failure doesn't
+// re-upload layers that already landed.
+func buildReportLayers(reportsDir string, entries []os.DirEntry, opts PushOptions) ([]reportLayer, error) {
+	layers := make([]reportLayer, len(entries))
+	err := parallelForEach(len(entries), opts.Concurrency, opts.QPS, func(i int) error {
+		e := entries[i]
+		data, err := os.ReadFile(filepath.Join(reportsDir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", e.Name(), err)
+		}
+		layer := static.NewLayer(data, types.MediaType(standaloneReportsLayerMediaType))
+		digest, err := layer.Digest()
+		if err != nil {
+			return fmt.Errorf("digesting layer for %s: %w", e.Name(), err)
+		}
+		layers[i] = reportLayer{name: e.Name(), layer: layer, digest: digest}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// StandaloneReportsPushResult records what PushStandaloneReports pushed:
+// the OCI reference and digest of the reports artifact, and the cosign
+// signature/attestation it produced if signing was requested.
+type StandaloneReportsPushResult struct {
+	Reference      string
+	Digest         string
+	CosignResult   *attest.SignResult
+	AttestationRef string
+}
+
 // PushStandaloneReports pushes all standalone reports in reportsDir to
-// the OCI registry as a single image artifact at:
+// the OCI registry as an artifact at:
 //
-//	{registry}/standalone-reports:latest
+//	{registry}/standalone-reports:{date}   (standaloneReportsDateTagLayout)
+//	{registry}/standalone-reports:latest   (alias to the same digest)
 //
-// Each JSON report file becomes a layer in the OCI image.
-func PushStandaloneReports(reportsDir, registry string) error {
-	ref := registry + "/standalone-reports:latest"
-
-	entries, err := os.ReadDir(reportsDir)
+// The dated tag is retained (up to standaloneReportsRetentionWindow pushes,
+// pruned oldest-first) so buildSiteImageDiff can diff the current scan
+// against a previous one; "latest" keeps existing consumers that only want
+// the current snapshot working unchanged. Each JSON report file becomes its
+// own layer (standaloneReportsLayerMediaType), annotated with its original
+// filename under standaloneReportsLayerTitle, in an artifact whose config
+// carries standaloneReportsConfigMediaType — rather than the single opaque
+// tarball layer earlier versions pushed. When sign is true, the pushed
+// digest is also cosign-signed and given an in-toto attestation listing its
+// reports (see pullStandaloneReports, VerifyChartSignature, which this
+// reuses since both are "verify a cosign signature on an OCI artifact" with
+// no chart-specific logic involved). Report files are read and digested up
+// to opts.Concurrency at a time, paced to opts.QPS, so pushing a fleet of
+// hundreds of images' reports doesn't serialize on disk I/O (see
+// buildReportLayers); the push and tag calls themselves retry with
+// exponential backoff on a registry 429/5xx (see retryWithBackoff).
+// buildStandaloneReportsImage reads the JSON report files in reportsDir (up
+// to opts.Concurrency at a time, paced to opts.QPS — see buildReportLayers)
+// and assembles them into a single OCI image, one layer per report file, in
+// the same shape PushStandaloneReports has always pushed to a live
+// registry. It's shared with ExportStandaloneReportsOCILayout so the
+// registry and local oci-layout transports produce byte-for-byte the same
+// artifact, just written to different destinations.
+func buildStandaloneReportsImage(reportsDir string, opts PushOptions) (v1.Image, []string, error) {
+	allEntries, err := os.ReadDir(reportsDir)
 	if err != nil {
-		return fmt.Errorf("reading reports dir: %w", err)
+		return nil, nil, fmt.Errorf("reading reports dir: %w", err)
 	}
-
-	// Build a tar archive of the reports directory content.
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	for _, e := range entries {
+	var entries []os.DirEntry
+	for _, e := range allEntries {
 		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
 			continue
 		}
-		data, err := os.ReadFile(filepath.Join(reportsDir, e.Name()))
+		entries = append(entries, e)
+	}
+
+	reportLayers, err := buildReportLayers(reportsDir, entries, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	img := mutate.ConfigMediaType(empty.Image, types.MediaType(standaloneReportsConfigMediaType))
+	var reportNames []string
+	for _, rl := range reportLayers {
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer: rl.layer,
+			Annotations: map[string]string{
+				standaloneReportsLayerTitle:  rl.name,
+				standaloneReportsLayerDigest: rl.digest.String(),
+			},
+		})
 		if err != nil {
-			return fmt.Errorf("reading %s: %w", e.Name(), err)
+			return nil, nil, fmt.Errorf("adding layer for %s: %w", rl.name, err)
 		}
-		hdr := &tar.Header{
-			Name: e.Name(),
-			Mode: 0o644,
-			Size: int64(len(data)),
+		reportNames = append(reportNames, rl.name)
+	}
+
+	return img, reportNames, nil
+}
+
+func PushStandaloneReports(reportsDir, registryHost string, sign bool, signKey string, opts PushOptions) (*StandaloneReportsPushResult, error) {
+	repo := registryHost + "/standalone-reports"
+	dateTag := time.Now().UTC().Format(standaloneReportsDateTagLayout)
+	ref := repo + ":" + dateTag
+
+	client := opts.RegistryClient
+	if client == nil {
+		client = registry.New(registry.Options{})
+	}
+
+	img, reportNames, err := buildStandaloneReportsImage(reportsDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if err := retryWithBackoff(func() error { return client.PushArtifact(ctx, ref, img) }, maxPushAttempts); err != nil {
+		return nil, fmt.Errorf("pushing %s: %w", ref, err)
+	}
+	latestRef := repo + ":latest"
+	if err := retryWithBackoff(func() error { return client.Copy(ctx, ref, latestRef) }, maxPushAttempts); err != nil {
+		return nil, fmt.Errorf("tagging %s as latest: %w", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("computing digest for %s: %w", ref, err)
+	}
+	result := &StandaloneReportsPushResult{Reference: ref, Digest: digest.String()}
+	fmt.Printf("Pushed standalone reports → %s (%s)\n", ref, result.Digest)
+
+	if err := pruneOldStandaloneReports(repo); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to prune old standalone reports: %v\n", err)
+	}
+
+	if sign {
+		digestRef := fmt.Sprintf("%s@%s", repo, digest.String())
+
+		signResult, err := attest.SignImage(ctx, digestRef, attest.SignOptions{Key: signKey})
+		if err != nil {
+			return result, fmt.Errorf("signing standalone reports artifact: %w", err)
 		}
-		if err := tw.WriteHeader(hdr); err != nil {
-			return err
+		result.CosignResult = signResult
+		fmt.Printf("Signed standalone reports (%s) → %s\n", signResult.Digest, signResult.SignatureRef)
+
+		manifest, err := json.Marshal(reportNames)
+		if err != nil {
+			return result, fmt.Errorf("marshaling reports manifest: %w", err)
 		}
-		if _, err := tw.Write(data); err != nil {
-			return err
+		attRef, err := attest.AttestCustom(ctx, digestRef, standaloneReportsManifestPredicateType, manifest, attest.AttestOptions{Key: signKey})
+		if err != nil {
+			return result, fmt.Errorf("attesting standalone reports artifact: %w", err)
 		}
+		result.AttestationRef = attRef
+		fmt.Printf("Reports manifest attestation → %s\n", attRef)
 	}
-	if err := tw.Close(); err != nil {
+
+	return result, nil
+}
+
+// ExportStandaloneReportsOCILayout writes the standalone reports in
+// reportsDir to outDir as a proper OCI image-layout directory (index.json
+// plus content-addressed blobs under blobs/sha256/) instead of pushing to a
+// live registry — the same artifact shape PushStandaloneReports produces,
+// just written locally. This mirrors the "oci-archive:"/"docker save" style
+// export Docker/moby and skopeo use for air-gapped transfer: an operator
+// can tar outDir themselves and hand-carry it between environments with no
+// intermediate registry. There is no dated-tag retention or "previous scan"
+// concept for this transport — a layout export is a one-shot snapshot, so
+// callers wanting a diff against an earlier scan need to keep the prior
+// export around themselves (see discoverStandaloneImages's oci-layout://
+// handling, which skips buildSiteImageDiff's previous-scan lookup entirely
+// for this source).
+func ExportStandaloneReportsOCILayout(reportsDir, outDir string, opts PushOptions) error {
+	img, _, err := buildStandaloneReportsImage(reportsDir, opts)
+	if err != nil {
 		return err
 	}
 
-	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
-		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
-	})
+	lp, err := layout.Write(outDir, empty.Index)
 	if err != nil {
-		return fmt.Errorf("creating OCI layer: %w", err)
+		return fmt.Errorf("initializing OCI layout at %s: %w", outDir, err)
+	}
+	if err := lp.AppendImage(img, layout.WithAnnotations(map[string]string{
+		"org.opencontainers.image.ref.name": "standalone-reports",
+	})); err != nil {
+		return fmt.Errorf("writing standalone reports image to OCI layout at %s: %w", outDir, err)
 	}
 
-	img, err := mutate.AppendLayers(empty.Image, layer)
+	digest, err := img.Digest()
 	if err != nil {
-		return fmt.Errorf("building OCI image: %w", err)
+		return fmt.Errorf("computing digest: %w", err)
 	}
+	fmt.Printf("Exported standalone reports → %s (%s)\n", outDir, digest.String())
 
-	if err := crane.Push(img, ref); err != nil {
-		return fmt.Errorf("pushing %s: %w", ref, err)
+	return nil
+}
+
+// pruneOldStandaloneReports deletes dated standalone-reports tags beyond
+// standaloneReportsRetentionWindow, keeping the most recent ones so the
+// registry doesn't accumulate an unbounded history while still leaving
+// enough of a window for buildSiteImageDiff's previous-scan lookup.
+func pruneOldStandaloneReports(repo string) error {
+	dateTags, err := listStandaloneReportsDateTags(repo)
+	if err != nil {
+		return err
+	}
+	if len(dateTags) <= standaloneReportsRetentionWindow {
+		return nil
+	}
+	for _, tag := range dateTags[standaloneReportsRetentionWindow:] {
+		if err := crane.Delete(repo + ":" + tag); err != nil {
+			return fmt.Errorf("deleting old standalone-reports tag %s: %w", tag, err)
+		}
 	}
-	fmt.Printf("Pushed standalone reports → %s\n", ref)
 	return nil
 }
 
-// pullStandaloneReports pulls the standalone-reports artifact from OCI
-// and extracts the reports into a temporary directory.
-func pullStandaloneReports(registry string) (string, error) {
-	ref := registry + "/standalone-reports:latest"
+// listStandaloneReportsDateTags lists repo's tags matching
+// standaloneReportsDateTagLayout (i.e. excluding "latest" and any
+// cosign ".sig"/".att" tags), newest first.
+func listStandaloneReportsDateTags(repo string) ([]string, error) {
+	tags, err := crane.ListTags(repo)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s tags: %w", repo, err)
+	}
+	var dateTags []string
+	for _, t := range tags {
+		if _, err := time.Parse(standaloneReportsDateTagLayout, t); err == nil {
+			dateTags = append(dateTags, t)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dateTags)))
+	return dateTags, nil
+}
+
+// pullStandaloneReports pulls the "latest" standalone-reports artifact —
+// see pullStandaloneReportsTag for what it fetches and how verify is
+// applied.
+func pullStandaloneReports(registry string, wantNames map[string]bool, verify VerifyOpts) (string, error) {
+	return pullStandaloneReportsTag(registry+"/standalone-reports:latest", wantNames, verify)
+}
+
+// pullPreviousStandaloneReports finds the dated standalone-reports push
+// immediately before the most recent one and pulls its reports the same
+// way pullStandaloneReports does, for buildSiteImageDiff to compare
+// against. Returns ("", "", nil) — not an error — when there's no previous
+// push to diff against: the very first push, or one standaloneReportsRetentionWindow
+// has already pruned.
+func pullPreviousStandaloneReports(registry string, wantNames map[string]bool, verify VerifyOpts) (dir, date string, err error) {
+	repo := registry + "/standalone-reports"
+	dateTags, err := listStandaloneReportsDateTags(repo)
+	if err != nil {
+		return "", "", err
+	}
+	if len(dateTags) < 2 {
+		return "", "", nil
+	}
+
+	prevTag := dateTags[1]
+	dir, err = pullStandaloneReportsTag(repo+":"+prevTag, wantNames, verify)
+	if err != nil {
+		return "", "", err
+	}
+	return dir, prevTag, nil
+}
+
+// pullStandaloneReportsTag pulls the standalone-reports artifact at ref
+// (a fully qualified "repo:tag" reference) and extracts into a temporary
+// directory only the layers whose title annotation is in wantNames — the
+// rest are left unfetched, so a caller that only cares about a handful of
+// images out of a large fleet doesn't pay to download every report. Pass a
+// nil/empty wantNames to fetch everything. If verify.RequireSignature is
+// set, the artifact's cosign signature is checked before (and its digest
+// re-checked after) pulling; a missing/invalid signature or a pulled digest
+// that doesn't match what was verified fails closed rather than falling
+// back to unverified reports (see discoverStandaloneImages).
+func pullStandaloneReportsTag(ref string, wantNames map[string]bool, verify VerifyOpts) (string, error) {
+	if verify.RequireSignature {
+		if err := VerifyChartSignature(ref, verify); err != nil {
+			return "", fmt.Errorf("standalone reports artifact failed signature verification: %w", err)
+		}
+	}
 
 	img, err := crane.Pull(ref)
 	if err != nil {
 		return "", fmt.Errorf("pulling %s: %w", ref, err)
 	}
 
-	tmpDir, err := os.MkdirTemp("", "verity-standalone-reports-")
+	digest, err := img.Digest()
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("computing digest for %s: %w", ref, err)
+	}
+
+	if verify.RequireSignature {
+		digestRef := fmt.Sprintf("%s@%s", ref, digest.String())
+		if err := VerifyChartSignature(digestRef, verify); err != nil {
+			return "", fmt.Errorf("pulled standalone reports digest %s failed verification: %w", digest, err)
+		}
+	}
+
+	return extractStandaloneReportLayers(img, ref, wantNames)
+}
+
+// extractStandaloneReportLayers reads img's manifest and layers and writes
+// to a new temporary directory the contents of each layer whose title
+// annotation is in wantNames (or every layer, if wantNames is empty) — the
+// shared extraction step behind both the live-registry pull
+// (pullStandaloneReportsTag) and the local oci-layout import
+// (importStandaloneReportsOCILayout), which differ only in how they obtain
+// img. srcDesc is used solely to annotate error messages with where img
+// came from.
+func extractStandaloneReportLayers(img v1.Image, srcDesc string, wantNames map[string]bool) (string, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return "", fmt.Errorf("reading manifest for %s: %w", srcDesc, err)
 	}
 
 	layers, err := img.Layers()
 	if err != nil {
-		_ = os.RemoveAll(tmpDir)
 		return "", fmt.Errorf("reading layers: %w", err)
 	}
+	if len(layers) != len(manifest.Layers) {
+		return "", fmt.Errorf("layer count mismatch for %s", srcDesc)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "verity-standalone-reports-")
+	if err != nil {
+		return "", err
+	}
+
+	for i, layer := range layers {
+		name := manifest.Layers[i].Annotations[standaloneReportsLayerTitle]
+		if name == "" {
+			continue
+		}
+		if len(wantNames) > 0 && !wantNames[name] {
+			continue
+		}
+		// Sanitize the file name to prevent Zip Slip (path traversal).
+		clean := filepath.Base(name)
+		if clean == "." || clean == ".." {
+			continue
+		}
+		dest := filepath.Join(tmpDir, clean)
+		rel, err := filepath.Rel(tmpDir, dest)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
 
-	for _, layer := range layers {
 		rc, err := layer.Uncompressed()
 		if err != nil {
 			_ = os.RemoveAll(tmpDir)
-			return "", fmt.Errorf("decompressing layer: %w", err)
+			return "", fmt.Errorf("reading layer %s: %w", name, err)
 		}
-		err = func() error {
-			defer func() {
-				if err := rc.Close(); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to close layer reader: %v\n", err)
-				}
-			}()
-			tr := tar.NewReader(rc)
-			for {
-				hdr, err := tr.Next()
-				if err != nil {
-					break
-				}
-				if hdr.Typeflag != tar.TypeReg {
-					continue
-				}
-				// Sanitize the file name to prevent Zip Slip (path traversal).
-				clean := filepath.Base(hdr.Name)
-				if clean == "." || clean == ".." {
-					continue
-				}
-				dest := filepath.Join(tmpDir, clean)
-				// Verify the resolved path is inside tmpDir using filepath.Rel.
-				rel, err := filepath.Rel(tmpDir, dest)
-				if err != nil || strings.HasPrefix(rel, "..") {
-					continue
-				}
-				data, err := io.ReadAll(tr)
-				if err != nil {
-					return fmt.Errorf("reading %s from tar: %w", hdr.Name, err)
-				}
-				if err := os.WriteFile(dest, data, 0o644); err != nil {
-					return err
-				}
-			}
-			return nil
-		}()
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
 		if err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return "", fmt.Errorf("reading layer %s: %w", name, err)
+		}
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
 			_ = os.RemoveAll(tmpDir)
 			return "", err
 		}
@@ -1016,19 +2245,81 @@ func pullStandaloneReports(registry string) (string, error) {
 	return tmpDir, nil
 }
 
+// importStandaloneReportsOCILayout reads the standalone-reports artifact
+// from a local OCI image-layout directory (as written by
+// ExportStandaloneReportsOCILayout) instead of pulling it from a live
+// registry, and extracts the layers in wantNames into a new temporary
+// directory. Unlike pullStandaloneReportsTag there's no signature
+// verification here — a hand-carried directory has no registry-side
+// identity to check against, so that's left to whatever transfer mechanism
+// the operator trusts (e.g. checksumming the tarball before copying it
+// across the gap).
+func importStandaloneReportsOCILayout(dir string, wantNames map[string]bool) (string, error) {
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		return "", fmt.Errorf("reading OCI layout at %s: %w", dir, err)
+	}
+	index, err := lp.ImageIndex()
+	if err != nil {
+		return "", fmt.Errorf("reading OCI layout index at %s: %w", dir, err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return "", fmt.Errorf("reading OCI layout manifest at %s: %w", dir, err)
+	}
+	if len(indexManifest.Manifests) == 0 {
+		return "", fmt.Errorf("OCI layout at %s has no images", dir)
+	}
+
+	img, err := index.Image(indexManifest.Manifests[0].Digest)
+	if err != nil {
+		return "", fmt.Errorf("reading image from OCI layout at %s: %w", dir, err)
+	}
+	return extractStandaloneReportLayers(img, dir, wantNames)
+}
+
 // discoverStandaloneImages reads the standalone images values file and
-// pulls reports from the OCI registry standalone-reports artifact.
-func discoverStandaloneImages(imagesFile, registry string) ([]SiteImage, error) {
-	images, err := ParseImagesFile(imagesFile)
+// pulls reports from the OCI registry standalone-reports artifact. When
+// verify.RequireSignature is set, a pull failure (including failed
+// signature verification) fails closed — this function returns an error
+// instead of silently falling back to reports-less images, since callers
+// asking for verification want a tamper-evident guarantee, not a best effort.
+func discoverStandaloneImages(imagesFile, registry string, verify VerifyOpts, ignoreStatus []string, vex *VEXCorpus) ([]SiteImage, error) {
+	images, err := ParseImagesFile(context.Background(), imagesFile)
 	if err != nil {
 		return nil, err
 	}
 
-	// Pull reports from OCI.
+	// Only fetch the report layers this imagesFile actually lists, rather
+	// than the whole standalone-reports artifact.
+	wantNames := make(map[string]bool, len(images))
+	for _, img := range images {
+		wantNames[sanitize(img.Reference())+".json"] = true
+	}
+
+	ociLayoutPath, fromOCILayout := strings.CutPrefix(registry, ociLayoutSourcePrefix)
+
+	// Pull reports from OCI — either a live registry, or a local oci-layout
+	// directory (see ExportStandaloneReportsOCILayout).
 	var reportsDir string
-	if registry != "" {
-		dir, err := pullStandaloneReports(registry)
+	generatedAt := time.Now().UTC().Format(standaloneReportsDateTagLayout)
+	if fromOCILayout {
+		dir, err := importStandaloneReportsOCILayout(ociLayoutPath, wantNames)
+		if err != nil {
+			return nil, fmt.Errorf("importing standalone reports from OCI layout: %w", err)
+		}
+		reportsDir = dir
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to clean up temp dir: %v\n", err)
+			}
+		}()
+	} else if registry != "" {
+		dir, err := pullStandaloneReports(registry, wantNames, verify)
 		if err != nil {
+			if verify.RequireSignature {
+				return nil, fmt.Errorf("pulling standalone reports from OCI: %w", err)
+			}
 			fmt.Fprintf(os.Stderr, "Warning: could not pull standalone reports from OCI: %v\n", err)
 		} else {
 			reportsDir = dir
@@ -1040,24 +2331,59 @@ func discoverStandaloneImages(imagesFile, registry string) ([]SiteImage, error)
 		}
 	}
 
+	// Best-effort: pull the previous retained scan so buildSiteImageDiff can
+	// flag new/fixed vulnerabilities. Its absence (first push, or one that's
+	// aged out of standaloneReportsRetentionWindow) just means no diff, not
+	// a failure — unlike the current scan above, nothing here is required.
+	// A local oci-layout export is a one-shot snapshot with no dated-tag
+	// history, so there's never a previous scan to diff against for that
+	// source.
+	var prevReportsDir, prevGeneratedAt string
+	if reportsDir != "" && !fromOCILayout {
+		dir, date, err := pullPreviousStandaloneReports(registry, wantNames, verify)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not pull previous standalone reports from OCI: %v\n", err)
+		} else if dir != "" {
+			prevReportsDir, prevGeneratedAt = dir, date
+			defer func() {
+				if err := os.RemoveAll(dir); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to clean up temp dir: %v\n", err)
+				}
+			}()
+		}
+	}
+
 	var overrides map[string]string
 	if reportsDir != "" {
 		overrides = loadOverrides(reportsDir)
 	}
 
+	// A local oci-layout source isn't a push destination, so there's no
+	// registry host to build a patched ref against.
+	patchedRefRegistry := registry
+	if fromOCILayout {
+		patchedRefRegistry = ""
+	}
+
 	var siteImages []SiteImage
 	for _, img := range images {
 		ref := img.Reference()
 		sanitizedRef := sanitize(ref)
 
-		patchedRef := buildPatchedRef(ref, registry)
+		patchedRef := buildPatchedRef(ref, patchedRefRegistry)
 
 		var si SiteImage
 		if reportsDir != "" {
 			reportPath := filepath.Join(reportsDir, sanitizedRef+".json")
-			report, err := parseTrivyReportFull(reportPath)
+			report, err := parseScannerReport(reportPath)
 			if err == nil {
-				si = buildSiteImage(sanitizedRef, ref, patchedRef, img.Path, "", report)
+				var prevReport ScannerReport
+				if prevReportsDir != "" {
+					if pr, err := parseScannerReport(filepath.Join(prevReportsDir, sanitizedRef+".json")); err == nil {
+						prevReport = pr
+					}
+				}
+				si = buildSiteImageDiff(sanitizedRef, ref, patchedRef, img.Path, "", report, prevReport, generatedAt, prevGeneratedAt, ignoreStatus, vex)
 			}
 		}
 		if si.ID == "" {
@@ -1104,3 +2430,119 @@ func computeSummary(charts []SiteChart, standalone []SiteImage) SiteSummary {
 
 	return summary
 }
+
+// annotateHistory groups charts (one entry per published version, as
+// discoverCharts returns them) by name, builds each chart's and each of
+// its images' vulnerability trend series in ascending-semver order, and
+// stamps that same series onto every version's SiteChart.History and
+// SiteImage.History. Versions whose Version string doesn't parse as
+// semver are excluded from the series (but keep their own catalog entry
+// untouched otherwise).
+func annotateHistory(charts []SiteChart) {
+	byName := make(map[string][]int, len(charts))
+	for i, c := range charts {
+		byName[c.Name] = append(byName[c.Name], i)
+	}
+
+	for _, indices := range byName {
+		type versioned struct {
+			idx int
+			ver *semver.Version
+		}
+		var versions []versioned
+		for _, idx := range indices {
+			v, err := semver.NewVersion(charts[idx].Version)
+			if err != nil {
+				continue
+			}
+			versions = append(versions, versioned{idx, v})
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i].ver.LessThan(versions[j].ver) })
+
+		chartHistory := make([]VulnSnapshot, 0, len(versions))
+		imageHistory := make(map[string][]VulnSnapshot)
+		for _, v := range versions {
+			c := charts[v.idx]
+			chartHistory = append(chartHistory, chartSnapshot(c))
+			for _, img := range c.Images {
+				imageHistory[img.ID] = append(imageHistory[img.ID], imageSnapshot(c.Version, img))
+			}
+		}
+
+		for _, idx := range indices {
+			charts[idx].History = chartHistory
+			for i, img := range charts[idx].Images {
+				charts[idx].Images[i].History = imageHistory[img.ID]
+			}
+		}
+	}
+}
+
+// chartSnapshot summarizes one version of a chart into a single
+// VulnSnapshot by summing its images' vulnerability counts.
+func chartSnapshot(c SiteChart) VulnSnapshot {
+	snap := VulnSnapshot{
+		Version:        c.Version,
+		SeverityCounts: make(map[string]int),
+	}
+	for _, img := range c.Images {
+		snap.Total += img.VulnSummary.Total
+		snap.Fixable += img.VulnSummary.Fixable
+		for sev, n := range img.VulnSummary.SeverityCounts {
+			snap.SeverityCounts[sev] += n
+		}
+	}
+	return snap
+}
+
+// imageSnapshot summarizes one version of a single image into a VulnSnapshot.
+func imageSnapshot(version string, img SiteImage) VulnSnapshot {
+	return VulnSnapshot{
+		Version:        version,
+		Total:          img.VulnSummary.Total,
+		Fixable:        img.VulnSummary.Fixable,
+		SeverityCounts: img.VulnSummary.SeverityCounts,
+	}
+}
+
+// ChartTrend is one chart's entry in trends.json: its own aggregated
+// history plus a per-image-ID breakdown of the same series.
+type ChartTrend struct {
+	History []VulnSnapshot            `json:"history"`
+	Images  map[string][]VulnSnapshot `json:"images"`
+}
+
+// TrendData is the top-level structure written to trends.json, letting
+// the Astro site render "vulns over time" charts without having to derive
+// them itself from the full catalog.json.
+type TrendData struct {
+	GeneratedAt string                `json:"generatedAt"`
+	Charts      map[string]ChartTrend `json:"charts"`
+}
+
+// writeTrendData builds a TrendData from charts (already annotated by
+// annotateHistory) and writes it to outputPath as JSON.
+func writeTrendData(charts []SiteChart, generatedAt, outputPath string) error {
+	trends := TrendData{GeneratedAt: generatedAt, Charts: make(map[string]ChartTrend)}
+	for _, c := range charts {
+		if _, ok := trends.Charts[c.Name]; ok {
+			// Every version of a chart carries the same full History
+			// series (see annotateHistory), so the first one is enough.
+			continue
+		}
+		ct := ChartTrend{History: c.History, Images: make(map[string][]VulnSnapshot, len(c.Images))}
+		for _, img := range c.Images {
+			ct.Images[img.ID] = img.History
+		}
+		trends.Charts[c.Name] = ct
+	}
+
+	data, err := json.MarshalIndent(trends, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling trend data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	return os.WriteFile(outputPath, data, 0o644)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -96,7 +97,7 @@ dependencies:
 
 	// Run GenerateSiteData (no registry — falls back to local parsing)
 	outputPath := filepath.Join(tmpDir, "output", "catalog.json")
-	err := GenerateSiteData(chartsDir, imagesFile, "", outputPath)
+	err := GenerateSiteData(chartsDir, imagesFile, "", outputPath, nil, "", nil)
 	if err != nil {
 		t.Fatalf("GenerateSiteData failed: %v", err)
 	}
@@ -177,6 +178,76 @@ dependencies:
 	}
 }
 
+func TestGenerateSiteData_IgnoreStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	chartsDir := filepath.Join(tmpDir, "charts")
+	chartDir := filepath.Join(chartsDir, "myapp")
+	reportsDir := filepath.Join(chartDir, "reports")
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	chartYaml := `apiVersion: v2
+name: myapp
+description: myapp with Copa-patched container images
+type: application
+version: 1.0.0-0
+dependencies:
+    - name: myapp
+      version: "1.0.0"
+      repository: oci://ghcr.io/example/charts
+`
+	if err := os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte(chartYaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	valuesYaml := `myapp:
+    image:
+        registry: ghcr.io/testorg
+        repository: myorg/myapp
+        tag: v1.0.0-patched
+`
+	if err := os.WriteFile(filepath.Join(chartDir, "values.yaml"), []byte(valuesYaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := map[string]interface{}{
+		"Results": []map[string]interface{}{
+			{
+				"Vulnerabilities": []map[string]interface{}{
+					{"VulnerabilityID": "CVE-2024-0001", "Severity": "HIGH", "FixedVersion": "1.1.2", "Status": "fixed"},
+					{"VulnerabilityID": "CVE-2024-0002", "Severity": "LOW", "Status": "will_not_fix"},
+				},
+			},
+		},
+	}
+	reportJSON, _ := json.Marshal(report)
+	if err := os.WriteFile(filepath.Join(reportsDir, "docker.io_myorg_myapp_v1.0.0.json"), reportJSON, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	outputPath := filepath.Join(tmpDir, "output", "catalog.json")
+	if err := GenerateSiteData(chartsDir, "", "", outputPath, []string{"will_not_fix"}, "", nil); err != nil {
+		t.Fatalf("GenerateSiteData failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	var siteData SiteData
+	if err := json.Unmarshal(data, &siteData); err != nil {
+		t.Fatalf("failed to parse output JSON: %v", err)
+	}
+
+	img := siteData.Charts[0].Images[0]
+	if img.VulnSummary.Total != 1 {
+		t.Fatalf("expected 1 vuln after filtering will_not_fix, got %d", img.VulnSummary.Total)
+	}
+	if img.Vulnerabilities[0].ID != "CVE-2024-0001" {
+		t.Errorf("expected remaining vuln to be CVE-2024-0001, got %s", img.Vulnerabilities[0].ID)
+	}
+}
+
 func TestParseTrivyReportFull(t *testing.T) {
 	tmpDir := t.TempDir()
 	reportJSON := `{
@@ -184,8 +255,8 @@ func TestParseTrivyReportFull(t *testing.T) {
 		"Results": [
 			{
 				"Vulnerabilities": [
-					{"VulnerabilityID":"CVE-2024-0001","PkgName":"openssl","InstalledVersion":"1.1.1","FixedVersion":"1.1.2","Severity":"HIGH","Title":"test vuln"},
-					{"VulnerabilityID":"CVE-2024-0002","PkgName":"zlib","InstalledVersion":"1.2.11","FixedVersion":"","Severity":"","Title":"unknown sev"}
+					{"VulnerabilityID":"CVE-2024-0001","PkgName":"openssl","InstalledVersion":"1.1.1","FixedVersion":"1.1.2","Severity":"HIGH","Title":"test vuln","Status":"affected"},
+					{"VulnerabilityID":"CVE-2024-0002","PkgName":"zlib","InstalledVersion":"1.2.11","FixedVersion":"","Severity":"","Title":"unknown sev","Status":"will_not_fix"}
 				]
 			}
 		]
@@ -215,6 +286,69 @@ func TestParseTrivyReportFull(t *testing.T) {
 	if report.Results[0].Vulnerabilities[0].Severity != "HIGH" {
 		t.Errorf("expected HIGH, got %s", report.Results[0].Vulnerabilities[0].Severity)
 	}
+	if report.Results[0].Vulnerabilities[1].Status != "will_not_fix" {
+		t.Errorf("expected will_not_fix, got %s", report.Results[0].Vulnerabilities[1].Status)
+	}
+
+	vulns := report.Vulns()
+	if vulns[0].Status != "affected" {
+		t.Errorf("expected Vulns() to carry Status through, got %s", vulns[0].Status)
+	}
+}
+
+func TestSummarizeVulnsStatusCounts(t *testing.T) {
+	vulns := []SiteVuln{
+		{ID: "CVE-1", Severity: "HIGH", FixedVersion: "1.2.3", Status: "fixed"},
+		{ID: "CVE-2", Severity: "LOW", Status: "will_not_fix"},
+		{ID: "CVE-3", Severity: "LOW", Status: "will_not_fix"},
+		{ID: "CVE-4", Severity: "MEDIUM"},
+	}
+
+	summary := summarizeVulns(vulns)
+	if summary.Total != 4 {
+		t.Errorf("expected total 4, got %d", summary.Total)
+	}
+	if summary.StatusCounts["fixed"] != 1 {
+		t.Errorf("expected 1 fixed, got %d", summary.StatusCounts["fixed"])
+	}
+	if summary.StatusCounts["will_not_fix"] != 2 {
+		t.Errorf("expected 2 will_not_fix, got %d", summary.StatusCounts["will_not_fix"])
+	}
+	if _, ok := summary.StatusCounts[""]; ok {
+		t.Error("expected no entry for an empty status")
+	}
+}
+
+func TestFilterIgnoredStatuses(t *testing.T) {
+	vulns := []SiteVuln{
+		{ID: "CVE-1", Status: "fixed"},
+		{ID: "CVE-2", Status: "will_not_fix"},
+		{ID: "CVE-3", Status: "end_of_life"},
+		{ID: "CVE-4"},
+	}
+
+	tests := []struct {
+		name         string
+		ignoreStatus []string
+		wantIDs      []string
+	}{
+		{name: "no filter", ignoreStatus: nil, wantIDs: []string{"CVE-1", "CVE-2", "CVE-3", "CVE-4"}},
+		{name: "drop one status", ignoreStatus: []string{"will_not_fix"}, wantIDs: []string{"CVE-1", "CVE-3", "CVE-4"}},
+		{name: "case-insensitive", ignoreStatus: []string{"WILL_NOT_FIX", "END_OF_LIFE"}, wantIDs: []string{"CVE-1", "CVE-4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterIgnoredStatuses(vulns, tt.ignoreStatus)
+			gotIDs := make([]string, len(got))
+			for i, v := range got {
+				gotIDs[i] = v.ID
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("filterIgnoredStatuses() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
 }
 
 func TestUnsanitize(t *testing.T) {
@@ -268,6 +402,48 @@ func TestSanitizeRoundTrip(t *testing.T) {
 	}
 }
 
+func TestSplitPlatformSuffix(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantBase  string
+		wantPlat  Platform
+		wantMatch bool
+	}{
+		{
+			input:     "docker.io_library_nginx_1.25.3_linux_amd64",
+			wantBase:  "docker.io_library_nginx_1.25.3",
+			wantPlat:  Platform{OS: "linux", Arch: "amd64"},
+			wantMatch: true,
+		},
+		{
+			input:     "docker.io_library_nginx_1.25.3_linux_arm_v7",
+			wantBase:  "docker.io_library_nginx_1.25.3",
+			wantPlat:  Platform{OS: "linux", Arch: "arm", Variant: "v7"},
+			wantMatch: true,
+		},
+		{
+			// No recognizable os/arch suffix: an ordinary single-arch stem.
+			input:     "quay.io_brancz_kube-rbac-proxy_v0.14.0",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			base, platform, ok := splitPlatformSuffix(tt.input)
+			if ok != tt.wantMatch {
+				t.Fatalf("splitPlatformSuffix(%q) ok = %v, want %v", tt.input, ok, tt.wantMatch)
+			}
+			if !ok {
+				return
+			}
+			if base != tt.wantBase || platform != tt.wantPlat {
+				t.Errorf("splitPlatformSuffix(%q) = (%q, %+v), want (%q, %+v)", tt.input, base, platform, tt.wantBase, tt.wantPlat)
+			}
+		})
+	}
+}
+
 func TestSaveStandaloneReports(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -294,7 +470,7 @@ func TestSaveStandaloneReports(t *testing.T) {
 	}
 
 	destDir := filepath.Join(tmpDir, "reports")
-	if err := SaveStandaloneReports(results, destDir); err != nil {
+	if err := SaveStandaloneReports(results, destDir, PushOptions{}); err != nil {
 		t.Fatalf("SaveStandaloneReports failed: %v", err)
 	}
 
@@ -341,7 +517,7 @@ func TestDiscoverStandaloneImagesNoRegistry(t *testing.T) {
 	}
 
 	// No registry → no OCI pull, but should return image entries with empty vulns.
-	images, err := discoverStandaloneImages(imagesFile, "")
+	images, err := discoverStandaloneImages(imagesFile, "", VerifyOpts{}, nil, nil)
 	if err != nil {
 		t.Fatalf("discoverStandaloneImages failed: %v", err)
 	}
@@ -432,6 +608,59 @@ func TestComputeSummaryMultipleVersions(t *testing.T) {
 	}
 }
 
+func TestAnnotateHistory(t *testing.T) {
+	charts := []SiteChart{
+		{
+			Name:    "prometheus",
+			Version: "28.9.1-4",
+			Images: []SiteImage{
+				{ID: "quay.io_prometheus_prometheus_v3.2.1", VulnSummary: VulnSummary{Total: 5, Fixable: 3, SeverityCounts: map[string]int{"HIGH": 3, "LOW": 2}}},
+			},
+		},
+		{
+			Name:    "prometheus",
+			Version: "28.9.1-5",
+			Images: []SiteImage{
+				{ID: "quay.io_prometheus_prometheus_v3.2.1", VulnSummary: VulnSummary{Total: 2, Fixable: 1, SeverityCounts: map[string]int{"MEDIUM": 2}}},
+			},
+		},
+		{
+			Name:    "victoria-logs-single",
+			Version: "not-semver",
+			Images: []SiteImage{
+				{ID: "docker.io_victoriametrics_victoria-logs_v1.0.0", VulnSummary: VulnSummary{Total: 1}},
+			},
+		},
+	}
+
+	annotateHistory(charts)
+
+	// The non-semver victoria-logs-single version has no parseable
+	// version, so its history series is empty rather than erroring.
+	if len(charts[2].History) != 0 {
+		t.Errorf("expected empty history for non-semver chart, got %v", charts[2].History)
+	}
+
+	// Every prometheus version gets the same full, ascending-semver series.
+	for i := 0; i < 2; i++ {
+		history := charts[i].History
+		if len(history) != 2 {
+			t.Fatalf("chart[%d].History = %v, want 2 entries", i, history)
+		}
+		if history[0].Version != "28.9.1-4" || history[1].Version != "28.9.1-5" {
+			t.Errorf("chart[%d].History versions = %v, want ascending 28.9.1-4, 28.9.1-5", i, history)
+		}
+		if history[0].Total != 5 || history[1].Total != 2 {
+			t.Errorf("chart[%d].History totals = %+v, want [5, 2]", i, history)
+		}
+
+		imgHistory := charts[i].Images[0].History
+		if len(imgHistory) != 2 || imgHistory[0].Total != 5 || imgHistory[1].Total != 2 {
+			t.Errorf("chart[%d].Images[0].History = %+v, want [{...Total:5} {...Total:2}]", i, imgHistory)
+		}
+	}
+}
+
 // Integration tests against the public ghcr.io/descope registry.
 
 func TestListGitHubPackageTags(t *testing.T) {
@@ -502,6 +731,10 @@ func TestDiscoverRegistryVersions(t *testing.T) {
 		"28.9.1-5",
 		"oci://ghcr.io/descope/charts",
 		"ghcr.io/descope",
+		VersionSelector{},
+		VerifyOpts{},
+		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("discoverRegistryVersions failed: %v", err)
@@ -574,6 +807,10 @@ func TestDiscoverRegistryVersionsNonExistent(t *testing.T) {
 		"1.0.0",
 		"oci://ghcr.io/descope/charts",
 		"ghcr.io/descope",
+		VersionSelector{},
+		VerifyOpts{},
+		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("expected nil error, got: %v", err)
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+)
+
+//go:embed templates/site/*.html.tmpl
+var defaultSiteTemplates embed.FS
+
+// siteTemplates holds the three page templates SiteRenderer.Render needs,
+// loaded either from the embedded default theme or a --theme override dir
+// (see loadTemplates).
+type siteTemplates struct {
+	index, chart, image *template.Template
+}
+
+// severityOrder lists every severityRank key from most to least severe, the
+// iteration order imagePageView.VulnsBySeverity is walked in so the image
+// page's sections always read Critical → Unknown regardless of map order.
+var severityOrder = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// SiteRenderer writes SiteData out as a browsable static HTML site — an
+// index of charts, one page per chart listing its images and versions, and
+// one page per image with a full per-CVE table — so a generated
+// catalog.json can be published (e.g. to GitHub Pages) without a separate
+// frontend build. See ServeCatalog for the live-HTTP equivalent of the same
+// data.
+type SiteRenderer struct {
+	// ThemeDir, if set, overrides the embedded default templates with
+	// user-supplied "index.html.tmpl"/"chart.html.tmpl"/"image.html.tmpl"
+	// files, the same override convention report.GenerateReports uses for
+	// its own --template-dir (here exposed as --theme).
+	ThemeDir string
+}
+
+// imagePageEntry is one row in a chart page's image listing.
+type imagePageEntry struct {
+	ID          string
+	OriginalRef string
+	Page        string
+	Total       int
+	Fixable     int
+}
+
+// chartIndexEntry is one row in the site index's chart listing.
+type chartIndexEntry struct {
+	Name    string
+	Version string
+	Page    string
+	Total   int
+	Fixable int
+}
+
+type indexView struct {
+	SiteData
+	Charts []chartIndexEntry
+}
+
+type chartPageView struct {
+	SiteChart
+	Images []imagePageEntry
+}
+
+type imagePageView struct {
+	SiteImage
+	ChartName       string
+	SeverityOrder   []string
+	VulnsBySeverity map[string][]SiteVuln
+}
+
+// Render writes data out to outputDir as index.html, one charts/{name}-{version}.html
+// per chart, and one images/{id}.html per image (chart-embedded and
+// standalone alike).
+func (r SiteRenderer) Render(data SiteData, outputDir string) error {
+	tmpl, err := r.loadTemplates()
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{outputDir, filepath.Join(outputDir, "charts"), filepath.Join(outputDir, "images")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating %s: %w", dir, err)
+		}
+	}
+
+	var index indexView
+	index.SiteData = data
+
+	for _, c := range data.Charts {
+		chartPage := chartFilename(c)
+		var images []imagePageEntry
+		for _, img := range c.Images {
+			if err := renderImagePage(tmpl, outputDir, img, c.Name); err != nil {
+				return err
+			}
+			images = append(images, imagePageEntry{
+				ID: img.ID, OriginalRef: img.OriginalRef, Page: imageFilename(img),
+				Total: img.VulnSummary.Total, Fixable: img.VulnSummary.Fixable,
+			})
+		}
+
+		if err := renderPage(tmpl.chart, filepath.Join(outputDir, "charts", chartPage), chartPageView{SiteChart: c, Images: images}); err != nil {
+			return err
+		}
+		index.Charts = append(index.Charts, chartIndexEntry{
+			Name: c.Name, Version: c.Version, Page: filepath.Join("charts", chartPage),
+			Total: c.chartTotalVulns(), Fixable: c.chartFixableVulns(),
+		})
+	}
+
+	for _, img := range data.StandaloneImages {
+		if err := renderImagePage(tmpl, outputDir, img, ""); err != nil {
+			return err
+		}
+	}
+
+	return renderPage(tmpl.index, filepath.Join(outputDir, "index.html"), index)
+}
+
+func renderImagePage(tmpl *siteTemplates, outputDir string, img SiteImage, chartName string) error {
+	view := imagePageView{
+		SiteImage:       img,
+		ChartName:       chartName,
+		SeverityOrder:   severityOrder,
+		VulnsBySeverity: vulnsBySeverity(img.Vulnerabilities),
+	}
+	return renderPage(tmpl.image, filepath.Join(outputDir, "images", imageFilename(img)), view)
+}
+
+// vulnsBySeverity groups vulns by their (upper-cased) Severity, falling
+// back to "UNKNOWN" for anything severityRank doesn't recognize, so the
+// image page can render one section per severityOrder entry.
+func vulnsBySeverity(vulns []SiteVuln) map[string][]SiteVuln {
+	grouped := make(map[string][]SiteVuln)
+	for _, v := range vulns {
+		sev := v.Severity
+		if _, ok := severityRank[sev]; !ok {
+			sev = "UNKNOWN"
+		}
+		grouped[sev] = append(grouped[sev], v)
+	}
+	return grouped
+}
+
+func chartFilename(c SiteChart) string {
+	return sanitize(c.Name+":"+c.Version) + ".html"
+}
+
+func imageFilename(img SiteImage) string {
+	return sanitize(img.ID) + ".html"
+}
+
+func (c SiteChart) chartTotalVulns() int {
+	total := 0
+	for _, img := range c.Images {
+		total += img.VulnSummary.Total
+	}
+	return total
+}
+
+func (c SiteChart) chartFixableVulns() int {
+	fixable := 0
+	for _, img := range c.Images {
+		fixable += img.VulnSummary.Fixable
+	}
+	return fixable
+}
+
+func renderPage(tmpl *template.Template, path string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	if err := tmpl.Execute(f, data); err != nil {
+		return fmt.Errorf("rendering %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadTemplates parses index.html.tmpl, chart.html.tmpl, and image.html.tmpl
+// from r.ThemeDir if set, otherwise from the embedded default theme —
+// mirroring report.loadHTMLTemplates' --template-dir override convention.
+func (r SiteRenderer) loadTemplates() (*siteTemplates, error) {
+	funcs := template.FuncMap{"cveLink": cveAdvisoryLink}
+
+	if r.ThemeDir != "" {
+		index, err := template.New("index.html.tmpl").Funcs(funcs).ParseFiles(filepath.Join(r.ThemeDir, "index.html.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("loading custom index template: %w", err)
+		}
+		chart, err := template.New("chart.html.tmpl").Funcs(funcs).ParseFiles(filepath.Join(r.ThemeDir, "chart.html.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("loading custom chart template: %w", err)
+		}
+		image, err := template.New("image.html.tmpl").Funcs(funcs).ParseFiles(filepath.Join(r.ThemeDir, "image.html.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("loading custom image template: %w", err)
+		}
+		return &siteTemplates{index: index, chart: chart, image: image}, nil
+	}
+
+	index, err := template.New("index.html.tmpl").Funcs(funcs).ParseFS(defaultSiteTemplates, "templates/site/index.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("loading default index template: %w", err)
+	}
+	chart, err := template.New("chart.html.tmpl").Funcs(funcs).ParseFS(defaultSiteTemplates, "templates/site/chart.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("loading default chart template: %w", err)
+	}
+	image, err := template.New("image.html.tmpl").Funcs(funcs).ParseFS(defaultSiteTemplates, "templates/site/image.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("loading default image template: %w", err)
+	}
+	return &siteTemplates{index: index, chart: chart, image: image}, nil
+}
+
+// cveAdvisoryLink builds an NVD detail page URL for a CVE ID, the same
+// upstream advisory report.nvdLink links image pages' tables to.
+func cveAdvisoryLink(cveID string) string {
+	return "https://nvd.nist.gov/vuln/detail/" + cveID
+}
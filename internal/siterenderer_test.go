@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSiteRendererRenderWritesPages(t *testing.T) {
+	data := SiteData{
+		Registry:    "ghcr.io/verity-org",
+		GeneratedAt: "2026-07-31T00:00:00Z",
+		Charts: []SiteChart{
+			{
+				Name:    "web",
+				Version: "1.0.0",
+				Images: []SiteImage{
+					{
+						ID:          "sha256:abc",
+						OriginalRef: "example.com/web:1.0",
+						VulnSummary: VulnSummary{Total: 2, Fixable: 1},
+						Vulnerabilities: []SiteVuln{
+							{ID: "CVE-2024-1", Severity: "CRITICAL", PkgName: "libc"},
+							{ID: "CVE-2024-2", Severity: "LOW", PkgName: "zlib"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputDir := t.TempDir()
+	if err := (SiteRenderer{}).Render(data, outputDir); err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+
+	for _, rel := range []string{
+		"index.html",
+		filepath.Join("charts", "web_1.0.0.html"),
+		filepath.Join("images", "sha256_abc.html"),
+	} {
+		if _, err := os.Stat(filepath.Join(outputDir, rel)); err != nil {
+			t.Errorf("expected %s to be written: %v", rel, err)
+		}
+	}
+}
+
+func TestVulnsBySeverityGroupsAndFallsBackToUnknown(t *testing.T) {
+	grouped := vulnsBySeverity([]SiteVuln{
+		{ID: "CVE-2024-1", Severity: "HIGH"},
+		{ID: "CVE-2024-2", Severity: "bogus"},
+	})
+	if len(grouped["HIGH"]) != 1 {
+		t.Errorf("grouped[HIGH] = %v, want 1 entry", grouped["HIGH"])
+	}
+	if len(grouped["UNKNOWN"]) != 1 {
+		t.Errorf("grouped[UNKNOWN] = %v, want 1 entry for unrecognized severity", grouped["UNKNOWN"])
+	}
+}
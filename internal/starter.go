@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultStartersDir returns the directory `verity starter add` installs
+// starter chart trees into, and AssembleResults reads them back from:
+// $XDG_DATA_HOME/verity/starters, falling back to
+// $HOME/.local/share/verity/starters when XDG_DATA_HOME isn't set —
+// mirroring Helm's own $XDG_DATA_HOME/helm/starters convention.
+func DefaultStartersDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "verity", "starters")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".local", "share", "verity", "starters")
+	}
+	return filepath.Join(home, ".local", "share", "verity", "starters")
+}
+
+// ListStarters returns the names of starter chart trees installed in dir,
+// one per subdirectory. A missing dir yields an empty list, not an error —
+// the same "nothing installed yet" case as an empty directory.
+func ListStarters(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading starters dir: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// AddStarter copies the chart tree at srcPath into dir/name, so
+// CreateWrapperChart can later scaffold a wrapper chart from it by name.
+func AddStarter(dir, name, srcPath string) error {
+	dest := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("creating starters dir: %w", err)
+	}
+	if err := copyTree(srcPath, dest, nil); err != nil {
+		return fmt.Errorf("adding starter %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveStarter deletes the starter chart tree dir/name.
+func RemoveStarter(dir, name string) error {
+	if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("removing starter %s: %w", name, err)
+	}
+	return nil
+}
+
+// StarterOptions selects a starter chart tree (see CopyStarter) for
+// CreateWrapperChart to scaffold chartDir from, in addition to its
+// built-in Chart.yaml/values.yaml/.helmignore layout. Dir is the starters
+// root (see DefaultStartersDir), Name is the starter subdirectory under
+// it, and ImagePaths feeds the starter's <IMAGEPATH> token (see
+// starterTokens). A zero-value StarterOptions (Name == "") is a no-op —
+// CreateWrapperChart scaffolds exactly as it did before starters existed.
+type StarterOptions struct {
+	Dir        string
+	Name       string
+	ImagePaths []string
+}
+
+// starterTokens are the placeholder strings CopyStarter rewrites in every
+// text file of a starter tree, modeled on Helm's own `helm create
+// --starter` token set (<CHARTNAME>, <CHARTVERSION>) plus a
+// verity-specific <IMAGEPATH> token expanding to the values.yaml path of
+// every image the chart declares, one per line, so a starter's
+// NetworkPolicy/PodSecurityPolicy-replacement templates can enumerate them
+// without knowing the chart's images ahead of time.
+func starterTokens(chartName, chartVersion string, imagePaths []string) map[string]string {
+	return map[string]string{
+		"<CHARTNAME>":    chartName,
+		"<CHARTVERSION>": chartVersion,
+		"<IMAGEPATH>":    strings.Join(imagePaths, "\n"),
+	}
+}
+
+// CopyStarter scaffolds chartDir from the starter tree dir/name (as
+// installed by AddStarter), rewriting <CHARTNAME>/<CHARTVERSION>/<IMAGEPATH>
+// tokens (see starterTokens) in every text file. Files this copies are
+// later overlaid by CreateWrapperChart's own Chart.yaml/values.yaml and by
+// AssembleResults' generated SBOM/VEX/vuln-predicate/vuln-summary — a
+// starter is additive (extra templates like a NetworkPolicy or a
+// PodSecurityPolicy replacement), not a replacement for those generated
+// files.
+func CopyStarter(dir, name, chartDir, chartName, chartVersion string, imagePaths []string) error {
+	src := filepath.Join(dir, name)
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("starter %s: %w", name, err)
+	}
+	tokens := starterTokens(chartName, chartVersion, imagePaths)
+	return copyTree(src, chartDir, tokens)
+}
+
+// copyTree recursively copies src into dst, creating directories as
+// needed. When tokens is non-nil, every token's key is replaced by its
+// value in files that look like text (see isBinary); tokens is nil for
+// AddStarter's plain "install a starter" copy, which must preserve binary
+// assets (e.g. icon.png) byte-for-byte regardless of content.
+func copyTree(src, dst string, tokens map[string]string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		if tokens != nil && !isBinary(data) {
+			text := string(data)
+			for token, value := range tokens {
+				text = strings.ReplaceAll(text, token, value)
+			}
+			data = []byte(text)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// isBinary reports whether data looks like binary content (a null byte in
+// its first 512 bytes) — the same heuristic net/http.DetectContentType's
+// callers conventionally use — so copyTree only attempts token replacement
+// on files that look like text.
+func isBinary(data []byte) bool {
+	n := len(data)
+	if n > 512 {
+		n = 512
+	}
+	for _, b := range data[:n] {
+		if b == 0 {
+			return true
+		}
+	}
+	return false
+}
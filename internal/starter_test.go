@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddListRemoveStarter(t *testing.T) {
+	startersDir := filepath.Join(t.TempDir(), "starters")
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "Chart.yaml"), []byte("name: <CHARTNAME>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddStarter(startersDir, "minimal", src); err != nil {
+		t.Fatalf("AddStarter() error: %v", err)
+	}
+
+	names, err := ListStarters(startersDir)
+	if err != nil {
+		t.Fatalf("ListStarters() error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "minimal" {
+		t.Errorf("ListStarters() = %v, want [minimal]", names)
+	}
+
+	if err := RemoveStarter(startersDir, "minimal"); err != nil {
+		t.Fatalf("RemoveStarter() error: %v", err)
+	}
+	names, err = ListStarters(startersDir)
+	if err != nil {
+		t.Fatalf("ListStarters() error after remove: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("ListStarters() after remove = %v, want empty", names)
+	}
+}
+
+func TestListStartersMissingDir(t *testing.T) {
+	names, err := ListStarters(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("ListStarters() on missing dir returned error: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("ListStarters() on missing dir = %v, want empty", names)
+	}
+}
+
+func TestCopyStarterRewritesTokens(t *testing.T) {
+	startersDir := t.TempDir()
+	templatesDir := filepath.Join(startersDir, "demo", "templates")
+	if err := os.MkdirAll(templatesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "name: <CHARTNAME>\nversion: <CHARTVERSION>\nimages:\n<IMAGEPATH>\n"
+	if err := os.WriteFile(filepath.Join(templatesDir, "extra.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	chartDir := t.TempDir()
+	if err := CopyStarter(startersDir, "demo", chartDir, "myapp", "1.0.0", []string{"image", "sidecar.image"}); err != nil {
+		t.Fatalf("CopyStarter() error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(chartDir, "templates", "extra.yaml"))
+	if err != nil {
+		t.Fatalf("reading copied file: %v", err)
+	}
+	want := "name: myapp\nversion: 1.0.0\nimages:\nimage\nsidecar.image\n"
+	if string(got) != want {
+		t.Errorf("CopyStarter() content = %q, want %q", got, want)
+	}
+}
+
+func TestCopyStarterMissing(t *testing.T) {
+	if err := CopyStarter(t.TempDir(), "nope", t.TempDir(), "myapp", "1.0.0", nil); err == nil {
+		t.Error("expected error for missing starter, got nil")
+	}
+}
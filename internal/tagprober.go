@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"golang.org/x/time/rate"
+)
+
+// ErrUnauthorized wraps a registry probe error that was a 401/403 response,
+// so callers can tell "the registry rejected our credentials" apart from
+// "this tag genuinely doesn't exist" (a plain 404, which TagProber reports
+// as exists=false with a nil error) and from a transport-level failure
+// (DNS, TLS, connection refused). Before this distinction existed,
+// tagChecker's bare bool return meant a Docker Hub rate-limit response
+// looked identical to a missing tag, so ResolveImageTag would silently try
+// the wrong tag variant instead of surfacing the throttling.
+var ErrUnauthorized = errors.New("registry rejected credentials")
+
+// TagProber checks whether an image reference exists in a remote registry
+// and resolves the digest it currently points to. It's the pluggable
+// successor to the bare tagChecker/digestResolver func vars in scanner.go:
+// a TagProber instance carries its own auth, cache, and rate limiter, so a
+// caller that needs private-registry credentials or backoff under
+// registry throttling doesn't have to reach around those package-level
+// hooks to get it.
+type TagProber interface {
+	// Exists reports whether ref resolves to a manifest. err is nil and
+	// exists is false for an ordinary "no such tag" (404); a non-nil err
+	// means the probe itself failed — ErrUnauthorized for bad credentials,
+	// or a transport/network error otherwise — and exists should not be
+	// trusted.
+	Exists(ctx context.Context, ref string) (exists bool, err error)
+	// Digest resolves ref to the manifest digest it currently points to.
+	// Like Exists, a missing tag is ("", nil); a non-nil error means the
+	// probe failed rather than that the tag doesn't exist.
+	Digest(ctx context.Context, ref string) (digest string, err error)
+}
+
+// ProbeCache memoizes TagProber results by an arbitrary string key, so a
+// scan that asks about the same ref more than once (e.g. the same
+// appVersion fallback guess across many subcharts sharing an image) only
+// hits the registry for it once.
+type ProbeCache interface {
+	Get(key string) (string, bool)
+	Set(key, value string)
+}
+
+// lruCache is an in-memory ProbeCache holding at most size entries,
+// evicting the least recently used on overflow.
+type lruCache struct {
+	size int
+
+	mu    sync.Mutex
+	order []string // most-recently-used last
+	data  map[string]string
+}
+
+// NewLRUCache returns an in-memory ProbeCache bounded to size entries.
+func NewLRUCache(size int) ProbeCache {
+	return &lruCache{size: size, data: make(map[string]string, size)}
+}
+
+func (c *lruCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	if ok {
+		c.touch(key)
+	}
+	return v, ok
+}
+
+func (c *lruCache) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.data[key]; !exists && len(c.data) >= c.size {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.data, oldest)
+	}
+	c.data[key] = value
+	c.touch(key)
+}
+
+// touch moves key to the end of c.order (most-recently-used). Caller must
+// hold c.mu.
+func (c *lruCache) touch(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, key)
+}
+
+// HostRateLimiter rate-limits registry round trips, one token-bucket
+// (golang.org/x/time/rate) per host, so a probe run against a throttled
+// registry backs off without also slowing down requests to every other
+// registry the same scan touches.
+type HostRateLimiter struct {
+	rps   rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewHostRateLimiter returns a HostRateLimiter allowing rps requests per
+// second (with a burst of up to burst) to each distinct host.
+func NewHostRateLimiter(rps float64, burst int) *HostRateLimiter {
+	return &HostRateLimiter{
+		rps:      rate.Limit(rps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Wait blocks until host's bucket has a token to spend, or ctx is done.
+func (h *HostRateLimiter) Wait(ctx context.Context, host string) error {
+	h.mu.Lock()
+	limiter, ok := h.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(h.rps, h.burst)
+		h.limiters[host] = limiter
+	}
+	h.mu.Unlock()
+	return limiter.Wait(ctx)
+}
+
+// CraneProber is the default TagProber, backed by go-containerregistry's
+// crane package.
+type CraneProber struct {
+	// Options are extra crane.Options applied to every call (e.g.
+	// crane.WithPlatform to constrain manifest-list lookups to one
+	// platform).
+	Options []crane.Option
+	// Keychain resolves registry credentials when OptionsForRef doesn't
+	// return an override for ref's host; nil uses authn.DefaultKeychain.
+	Keychain authn.Keychain
+	// OptionsForRef, when set, supplies per-ref crane.Options ahead of
+	// Options/Keychain — e.g. repoConfigOverride's RepoConfig-driven mTLS
+	// and explicit basic/bearer auth for one registry host. A nil return
+	// with a nil error means "no override for this ref," so Keychain is
+	// still applied.
+	OptionsForRef func(ctx context.Context, ref string) ([]crane.Option, error)
+	// Cache memoizes Exists/Digest results; nil disables caching.
+	Cache ProbeCache
+	// Limiter, when set, is consulted before every registry round trip,
+	// keyed by ref's host.
+	Limiter *HostRateLimiter
+}
+
+// NewDefaultCraneProber returns the CraneProber verity uses by default:
+// linux/amd64-constrained (matching pullAndSaveOCI's scan pull), chaining
+// the ambient docker/cosign keychain ahead of the cloud credential helpers
+// k8schain.NewNoClient wires up for ECR/GCR/ACR — so an image in any of
+// those registries resolves using whatever credentials the environment
+// already has, without requiring `docker login`/`aws ecr get-login-password`
+// first — backed by a small in-memory cache and a conservative per-host
+// rate limit so a chart with many images on the same registry doesn't trip
+// Docker Hub's anonymous throttling mid-scan.
+func NewDefaultCraneProber(ctx context.Context) *CraneProber {
+	keychain := authn.Keychain(authn.DefaultKeychain)
+	if kc, err := k8schain.NewNoClient(ctx); err == nil {
+		keychain = authn.NewMultiKeychain(authn.DefaultKeychain, kc)
+	}
+	return &CraneProber{
+		Options:       []crane.Option{crane.WithPlatform(&v1.Platform{OS: "linux", Architecture: "amd64"})},
+		Keychain:      keychain,
+		OptionsForRef: repoConfigOverride,
+		Cache:         NewLRUCache(256),
+		Limiter:       NewHostRateLimiter(10, 5),
+	}
+}
+
+// Exists implements TagProber.
+func (p *CraneProber) Exists(ctx context.Context, ref string) (bool, error) {
+	const prefix = "exists:"
+	if p.Cache != nil {
+		if v, ok := p.Cache.Get(prefix + ref); ok {
+			return v == "true", nil
+		}
+	}
+
+	opts, err := p.craneOptions(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+	if p.Limiter != nil {
+		if err := p.Limiter.Wait(ctx, hostOf(ref)); err != nil {
+			return false, err
+		}
+	}
+
+	_, headErr := crane.Head(ref, opts...)
+	exists, probeErr := classifyProbeError(headErr)
+	if probeErr != nil {
+		return false, probeErr
+	}
+	if p.Cache != nil {
+		p.Cache.Set(prefix+ref, fmt.Sprintf("%v", exists))
+	}
+	return exists, nil
+}
+
+// Digest implements TagProber.
+func (p *CraneProber) Digest(ctx context.Context, ref string) (string, error) {
+	const prefix = "digest:"
+	if p.Cache != nil {
+		if v, ok := p.Cache.Get(prefix + ref); ok {
+			return v, nil
+		}
+	}
+
+	opts, err := p.craneOptions(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if p.Limiter != nil {
+		if err := p.Limiter.Wait(ctx, hostOf(ref)); err != nil {
+			return "", err
+		}
+	}
+
+	desc, headErr := crane.Head(ref, opts...)
+	exists, probeErr := classifyProbeError(headErr)
+	if probeErr != nil {
+		return "", probeErr
+	}
+	if !exists {
+		return "", nil
+	}
+	digest := desc.Digest.String()
+	if p.Cache != nil {
+		p.Cache.Set(prefix+ref, digest)
+	}
+	return digest, nil
+}
+
+// craneOptions builds the crane.Option list for one call against ref:
+// p.OptionsForRef's override (if any) first, then p.Options, then
+// p.Keychain (or DefaultKeychain), then ctx.
+func (p *CraneProber) craneOptions(ctx context.Context, ref string) ([]crane.Option, error) {
+	var opts []crane.Option
+	if p.OptionsForRef != nil {
+		override, err := p.OptionsForRef(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, override...)
+	}
+	opts = append(opts, p.Options...)
+
+	keychain := p.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	opts = append(opts, crane.WithAuthFromKeychain(keychain), crane.WithContext(ctx))
+	return opts, nil
+}
+
+// classifyProbeError turns a crane.Head error into (exists, err):
+// a 404 means (false, nil) — the tag just doesn't exist; a 401/403 means
+// (false, ErrUnauthorized); anything else (including a nil err, meaning
+// the head succeeded) is passed through as-is.
+func classifyProbeError(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		switch terr.StatusCode {
+		case http.StatusNotFound:
+			return false, nil
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return false, fmt.Errorf("%w: %s", ErrUnauthorized, err)
+		}
+	}
+	return false, err
+}
+
+// defaultProber is the TagProber tagChecker/digestResolver (see
+// scanner.go) and imageExists (see patcher.go) delegate to when no test
+// has overridden those hooks. Built lazily so its k8schain ambient-
+// credential lookup doesn't run at package init for the common case.
+var (
+	defaultProberOnce sync.Once
+	defaultProberVal  *CraneProber
+)
+
+func defaultProber() *CraneProber {
+	defaultProberOnce.Do(func() {
+		defaultProberVal = NewDefaultCraneProber(context.Background())
+	})
+	return defaultProberVal
+}
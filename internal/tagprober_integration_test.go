@@ -0,0 +1,97 @@
+//go:build integration
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+)
+
+// newTestRegistry creates an in-process OCI registry and returns its host address.
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+	r := registry.New()
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// pushTag pushes a scratch image with the given tag to the test registry.
+func pushTag(t *testing.T, host, repo, tag string) {
+	t.Helper()
+	ref := fmt.Sprintf("%s/%s:%s", host, repo, tag)
+	if err := crane.Push(empty.Image, ref, crane.Insecure); err != nil {
+		t.Fatalf("pushTag(%q): %v", ref, err)
+	}
+}
+
+func newInsecureProber() *CraneProber {
+	return &CraneProber{
+		Options: []crane.Option{crane.Insecure},
+		Cache:   NewLRUCache(16),
+	}
+}
+
+func TestCraneProber_Exists_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	pushTag(t, host, "library/nginx", "1.25.0")
+
+	p := newInsecureProber()
+	ctx := context.Background()
+
+	exists, err := p.Exists(ctx, host+"/library/nginx:1.25.0")
+	if err != nil {
+		t.Fatalf("Exists() error = %v", err)
+	}
+	if !exists {
+		t.Error("Exists() = false, want true for a pushed tag")
+	}
+
+	exists, err = p.Exists(ctx, host+"/library/nginx:missing")
+	if err != nil {
+		t.Fatalf("Exists() for missing tag error = %v, want nil", err)
+	}
+	if exists {
+		t.Error("Exists() = true, want false for a tag that was never pushed")
+	}
+}
+
+func TestCraneProber_Digest_Integration(t *testing.T) {
+	host := newTestRegistry(t)
+	pushTag(t, host, "library/nginx", "1.25.0")
+
+	p := newInsecureProber()
+	ctx := context.Background()
+
+	digest, err := p.Digest(ctx, host+"/library/nginx:1.25.0")
+	if err != nil {
+		t.Fatalf("Digest() error = %v", err)
+	}
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Errorf("Digest() = %q, want a sha256: digest", digest)
+	}
+
+	// A second call should be served from the cache but return the same digest.
+	cached, err := p.Digest(ctx, host+"/library/nginx:1.25.0")
+	if err != nil {
+		t.Fatalf("Digest() (cached) error = %v", err)
+	}
+	if cached != digest {
+		t.Errorf("Digest() (cached) = %q, want %q", cached, digest)
+	}
+
+	digest, err = p.Digest(ctx, host+"/library/nginx:missing")
+	if err != nil {
+		t.Fatalf("Digest() for missing tag error = %v, want nil", err)
+	}
+	if digest != "" {
+		t.Errorf("Digest() for missing tag = %q, want empty string", digest)
+	}
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+func TestClassifyProbeError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantExists bool
+		wantErr    error // checked with errors.Is; nil means "no error"
+	}{
+		{"nil error means exists", nil, true, nil},
+		{"404 means not found, no error", &transport.Error{StatusCode: http.StatusNotFound}, false, nil},
+		{"401 wraps ErrUnauthorized", &transport.Error{StatusCode: http.StatusUnauthorized}, false, ErrUnauthorized},
+		{"403 wraps ErrUnauthorized", &transport.Error{StatusCode: http.StatusForbidden}, false, ErrUnauthorized},
+		{"500 passes through unclassified", &transport.Error{StatusCode: http.StatusInternalServerError}, false, nil},
+		{"plain non-transport error passes through", errors.New("dial tcp: connection refused"), false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := classifyProbeError(tt.err)
+			if exists != tt.wantExists {
+				t.Errorf("classifyProbeError() exists = %v, want %v", exists, tt.wantExists)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("classifyProbeError() err = %v, want wrapping %v", err, tt.wantErr)
+			}
+			if tt.name == "500 passes through unclassified" && !errors.Is(err, tt.err) {
+				t.Errorf("classifyProbeError() should pass the original 500 error through unchanged, got %v", err)
+			}
+			if tt.name == "plain non-transport error passes through" && err != tt.err {
+				t.Errorf("classifyProbeError() should pass a non-transport error through unchanged, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", "1")
+	c.Set("b", "2")
+
+	// Touch "a" so it's no longer the least recently used.
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Fatalf("Get(a) = %q, %v, want 1, true", v, ok)
+	}
+
+	// "b" is now the LRU entry; adding "c" should evict it, not "a".
+	c.Set("c", "3")
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted as the least recently used entry")
+	}
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, true (should survive eviction)", v, ok)
+	}
+	if v, ok := c.Get("c"); !ok || v != "3" {
+		t.Errorf("Get(c) = %q, %v, want 3, true", v, ok)
+	}
+}
+
+func TestHostRateLimiterPerHostIndependent(t *testing.T) {
+	limiter := NewHostRateLimiter(1, 1)
+	ctx := context.Background()
+
+	// Each host gets its own bucket, so exhausting host-a's single burst
+	// token must not block a request to host-b.
+	if err := limiter.Wait(ctx, "host-a"); err != nil {
+		t.Fatalf("Wait(host-a) #1 error: %v", err)
+	}
+	if err := limiter.Wait(ctx, "host-b"); err != nil {
+		t.Fatalf("Wait(host-b) should not be throttled by host-a's bucket: %v", err)
+	}
+}
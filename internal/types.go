@@ -8,6 +8,30 @@ type Image struct {
 	Repository string `yaml:"repository"`
 	Tag        string `yaml:"tag,omitempty"`
 	Path       string `yaml:"path"`
+
+	// Platforms lists the architectures this image's tag resolves to in a
+	// multi-arch manifest list (nil for an ordinary single-arch image).
+	// Populated by PatchImage when patching a multi-arch image — see
+	// PatchResult.Platforms, which carries the same slice.
+	Platforms []Platform `yaml:"platforms,omitempty"`
+}
+
+// Platform identifies one platform entry in a multi-arch image index
+// (e.g. "linux/amd64", "linux/arm64").
+type Platform struct {
+	OS      string `yaml:"os"`
+	Arch    string `yaml:"arch"`
+	Variant string `yaml:"variant,omitempty"`
+}
+
+// String formats p as "os/arch" ("os/arch/variant" when a variant is set),
+// matching the form go-containerregistry's v1.Platform.String() uses.
+func (p Platform) String() string {
+	s := p.OS + "/" + p.Arch
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
 }
 
 // Reference returns the full image reference string.
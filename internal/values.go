@@ -2,6 +2,9 @@ package internal
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +14,8 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	"gopkg.in/yaml.v3"
+
+	"github.com/verity-org/verity/internal/scanner"
 )
 
 // WrapperChart represents a Helm chart that wraps another chart with patched images.
@@ -19,49 +24,167 @@ type WrapperChart struct {
 	Version      string
 	Description  string
 	Dependencies []Dependency
+	// Annotations is written as Chart.yaml's top-level annotations map.
+	// CreateWrapperChart populates the org.verity.source-* keys here so a
+	// published chart carries its own provenance (see ListPublishedCharts,
+	// which reads them back via fetchWrapperChartMeta); PublishChart adds
+	// org.verity.published-at just before packaging, once the publish
+	// actually happens.
+	Annotations map[string]string
 }
 
 // CreateWrapperChart creates a complete Helm chart directory that subcharts the original
 // with patched image values. This allows users to install the wrapper chart and get
 // patched images while still being able to customize all original chart values.
 //
-// If registry is provided, it queries for existing wrapper chart versions to auto-increment
-// the patch level. Otherwise, defaults to patch level 0.
+// The wrapper version is content-addressable by default: a short prefix of a
+// sha256 digest computed over results (see contentDigest) is encoded into
+// the version suffix, so two runs against unchanged inputs always produce
+// the same version — eliminating the race where two concurrent runs both
+// pick the same "-N" suffix under the old monotonic counter. Set
+// legacyNumericVersions to fall back to that counter (queried from
+// registry, auto-incrementing) for compatibility with consumers that still
+// expect "{upstream-version}-{N}".
+//
+// Returns the wrapper chart version that was created, (unless
+// legacyNumericVersions is set) whether a wrapper with that exact version
+// already exists in registry — callers can skip re-publishing in that case,
+// since the content hash proves nothing changed — and the full content
+// digest results hashed to (see contentDigest), so a caller recording it
+// (e.g. AssembleResults's assemble-summary.json) can reuse this one
+// computation instead of re-hashing every result's report a second time.
+// Under legacyNumericVersions, where the digest isn't otherwise needed,
+// it's still computed best-effort for that purpose: a failure (e.g. an
+// unreadable Trivy report) only leaves valuesHash empty rather than
+// failing wrapper chart creation, unlike the non-legacy path where the
+// digest is the version itself.
 //
-// Returns the wrapper chart version that was created.
-func CreateWrapperChart(dep Dependency, results []*PatchResult, outputDir, registry string) (string, error) {
+// When starter.Name is set, starter's chart tree (see CopyStarter) is
+// copied into chartDir first; the Chart.yaml/values.yaml/.helmignore this
+// function generates are written on top of it, so a starter only needs to
+// contribute files the built-in scaffold doesn't already produce (extra
+// templates, most commonly).
+func CreateWrapperChart(dep Dependency, results []*PatchResult, outputDir, registry string, legacyNumericVersions bool, starter StarterOptions, destLayout string) (version string, alreadyPublished bool, valuesHash string, err error) {
 	chartName := dep.Name
 	chartDir := filepath.Join(outputDir, chartName)
 
 	if err := os.MkdirAll(chartDir, 0o755); err != nil {
-		return "", fmt.Errorf("creating chart directory: %w", err)
+		return "", false, "", fmt.Errorf("creating chart directory: %w", err)
 	}
 
-	// Determine patch level by querying registry for existing versions
-	patchLevel := 0
 	if registry != "" {
-		patchLevel = getNextPatchLevel(registry, chartName, dep.Version)
+		registry, err = NormalizeRegistryURL(registry)
+		if err != nil {
+			return "", false, "", fmt.Errorf("normalizing registry: %w", err)
+		}
+	}
+
+	// Pin a wildcard/range dependency version (e.g. "1.25.x", "^2.0.0")
+	// to a concrete upstream version before anything below uses
+	// dep.Version, so both Chart.yaml and the patch-level lookup are
+	// keyed off the same resolved version rather than the range itself.
+	var versionNote string
+	if isVersionRange(dep.Version) {
+		versionRange := dep.Version
+		resolved, err := ResolveDependencyVersion(dep)
+		if err != nil {
+			return "", false, "", fmt.Errorf("resolving version for %s: %w", dep.Name, err)
+		}
+		dep.Version = resolved
+		versionNote = fmt.Sprintf("# NOTE: %s version resolved from %q to %q\n", dep.Name, versionRange, resolved)
+	}
+
+	if legacyNumericVersions {
+		// The content digest isn't load-bearing here (the version comes
+		// from the registry's patch-level counter instead), so a failure
+		// to compute it (e.g. an unreadable Trivy report) only costs the
+		// valuesHash recorded on the caller's assemble-summary.json entry,
+		// not wrapper chart creation itself — unlike the non-legacy branch
+		// below, where the digest is the version.
+		if digest, err := contentDigest(results); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: computing content digest for %s: %v\n", chartName, err)
+		} else {
+			valuesHash = digest
+		}
+
+		patchLevel := 0
+		if registry != "" {
+			patchLevel = getNextPatchLevel(registry, chartName, dep.Version)
+		}
+		version = fmt.Sprintf("%s-%d", dep.Version, patchLevel)
+	} else {
+		valuesHash, err = contentDigest(results)
+		if err != nil {
+			return "", false, "", fmt.Errorf("computing content digest: %w", err)
+		}
+		version = fmt.Sprintf("%s-p%s", dep.Version, valuesHash[:8])
+		if registry != "" {
+			alreadyPublished, err = wrapperVersionExists(registry, chartName, version)
+			if err != nil {
+				return "", false, "", fmt.Errorf("checking for existing wrapper chart: %w", err)
+			}
+		}
 	}
 
-	version := fmt.Sprintf("%s-%d", dep.Version, patchLevel)
+	if starter.Name != "" {
+		if err := CopyStarter(starter.Dir, starter.Name, chartDir, chartName, version, starter.ImagePaths); err != nil {
+			return "", false, "", fmt.Errorf("scaffolding from starter %s: %w", starter.Name, err)
+		}
+	}
+
+	// Recorded as the org.verity.source-repository annotation below, before
+	// the embedded-dependency branch can blank dep.Repository out.
+	sourceRepository := dep.Repository
+
+	// A dependency with no remote repository (or an explicit file://
+	// one) is vendored rather than fetched, so embed its chart tree
+	// directly under chartDir/charts instead of writing an unresolvable
+	// repository into Chart.yaml. Helm picks up a chart physically
+	// present in charts/ without needing a dependencies: entry to fetch
+	// it from, and ScanForImages already recurses into it (see
+	// scanChart), so any images it carries land at
+	// "{chartName}.{subchart}.{path}" in values.yaml the same as any
+	// other nested image — no extra namespacing needed here.
+	if dep.Repository == "" || strings.HasPrefix(dep.Repository, "file://") {
+		embedded, err := embedLocalDependency(dep, chartDir)
+		if err != nil {
+			return "", false, "", fmt.Errorf("embedding local dependency %s: %w", dep.Name, err)
+		}
+		dep = embedded
+	}
 
 	// Create Chart.yaml
-	// Version format: {upstream-version}-{patch-level}
-	// Example: prometheus 25.8.0 → prometheus 25.8.0-0
-	// Patch level auto-increments when republishing the same upstream version
+	// Version format: {upstream-version}-p{8-hex-digest} (or, in legacy
+	// numeric mode, {upstream-version}-{patch-level})
+	// Example: prometheus 25.8.0 → prometheus 25.8.0-pa1b2c3d4
 	wrapper := WrapperChart{
 		Name:         chartName,
 		Version:      version,
 		Description:  dep.Name + " with Copa-patched container images",
 		Dependencies: []Dependency{dep},
+		Annotations: map[string]string{
+			"org.verity.source-chart":      dep.Name,
+			"org.verity.source-repository": sourceRepository,
+			"org.verity.source-version":    dep.Version,
+		},
 	}
 	if err := writeChartYaml(filepath.Join(chartDir, "Chart.yaml"), wrapper); err != nil {
-		return "", err
+		return "", false, "", err
 	}
 
 	// Create values.yaml with patched images namespaced under the dependency name
-	if err := GenerateNamespacedValuesOverride(dep.Name, results, filepath.Join(chartDir, "values.yaml")); err != nil {
-		return "", err
+	valuesPath := filepath.Join(chartDir, "values.yaml")
+	if err := GenerateNamespacedValuesOverride(dep.Name, results, valuesPath); err != nil {
+		return "", false, "", err
+	}
+	if versionNote != "" {
+		existing, err := os.ReadFile(valuesPath)
+		if err != nil {
+			return "", false, "", fmt.Errorf("reading values.yaml: %w", err)
+		}
+		if err := os.WriteFile(valuesPath, append([]byte(versionNote), existing...), 0o644); err != nil {
+			return "", false, "", fmt.Errorf("writing values.yaml: %w", err)
+		}
 	}
 
 	// Create .helmignore
@@ -75,33 +198,375 @@ func CreateWrapperChart(dep Dependency, results []*PatchResult, outputDir, regis
 .DS_Store
 `
 	if err := os.WriteFile(filepath.Join(chartDir, ".helmignore"), []byte(helmignore), 0o644); err != nil {
-		return "", fmt.Errorf("writing .helmignore: %w", err)
+		return "", false, "", fmt.Errorf("writing .helmignore: %w", err)
 	}
 
 	// Vulnerability reports are attached as in-toto attestations on each
 	// patched image in the registry, so they are not bundled in the chart.
+	// Multi-arch images are the one exception: with no single digest to
+	// attest per platform yet, their per-platform Trivy reports are copied
+	// into the chart directly so consumers can still inspect them.
+	if err := copyPlatformReports(results, chartDir); err != nil {
+		return "", false, "", fmt.Errorf("copying platform reports: %w", err)
+	}
 
 	// Save override metadata for site data generation.
 	if err := SaveOverrides(results, chartDir); err != nil {
-		return "", fmt.Errorf("saving overrides: %w", err)
+		return "", false, "", fmt.Errorf("saving overrides: %w", err)
 	}
 
 	// Save image paths so site data can populate valuesPath for all images.
 	if err := SaveImagePaths(results, chartDir); err != nil {
-		return "", fmt.Errorf("saving image paths: %w", err)
+		return "", false, "", fmt.Errorf("saving image paths: %w", err)
+	}
+
+	// Save per-platform digests so site data can populate SiteImage.Platforms
+	// for multi-arch images without re-resolving them from the registry.
+	if err := SavePlatformVariants(results, chartDir); err != nil {
+		return "", false, "", fmt.Errorf("saving platform variants: %w", err)
+	}
+
+	// Save provenance metadata — the original/patched digests, scan report
+	// digest, and remediated CVE IDs behind this release — so it can be
+	// signed as an in-toto attestation once the chart is packaged (see
+	// internal/attest.AttestChartProvenance, called from AssembleResults).
+	provenance, err := BuildWrapperProvenance(chartName, version, results, destLayout)
+	if err != nil {
+		return "", false, "", fmt.Errorf("building provenance for %s: %w", chartName, err)
 	}
+	if err := SaveWrapperProvenance(provenance, chartDir); err != nil {
+		return "", false, "", fmt.Errorf("saving provenance for %s: %w", chartName, err)
+	}
+
+	return version, alreadyPublished, valuesHash, nil
+}
+
+// WrapperProvenance is the in-toto predicate attached to a published
+// wrapper chart archive (see internal/attest.AttestChartProvenance),
+// binding the release to the exact scan evidence that produced it.
+type WrapperProvenance struct {
+	ChartName    string            `json:"chartName"`
+	ChartVersion string            `json:"chartVersion"`
+	Images       []ImageProvenance `json:"images"`
+}
+
+// ImageProvenance records, for one patched image in a wrapper chart, the
+// evidence tying its patched digest to the original it was built from and
+// the scan report that justified patching it.
+type ImageProvenance struct {
+	Original       string   `json:"original"`
+	Patched        string   `json:"patched"`
+	OriginalDigest string   `json:"originalDigest,omitempty"`
+	PatchedDigest  string   `json:"patchedDigest,omitempty"`
+	ReportDigest   string   `json:"reportDigest,omitempty"`
+	RemediatedCVEs []string `json:"remediatedCves,omitempty"`
+}
+
+// digestFromLayout resolves ref's digest by reading it straight out of
+// destLayout's OCI layout (see imageForRef in internal/patcher.go),
+// instead of the crane.Digest registry call BuildWrapperProvenance
+// otherwise makes — network access an air-gapped DestLayout bundle
+// doesn't have.
+func digestFromLayout(destLayout, ref string) (string, error) {
+	img, err := imageForRef(destLayout, ref)
+	if err != nil {
+		return "", err
+	}
+	h, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("digest for %s: %w", ref, err)
+	}
+	return h.String(), nil
+}
+
+// BuildWrapperProvenance assembles a WrapperProvenance from results.
+// Image digests are resolved best-effort: with destLayout empty, via
+// crane.Digest against a live registry; with destLayout set (an
+// air-gapped patch run's PatchOptions.DestLayout), via digestFromLayout
+// instead, reading the digest straight out of the bundle's index.json
+// rather than making a registry call the air-gapped environment can't
+// make. Either way, a resolution failure leaves the corresponding field
+// empty rather than failing the whole build, mirroring the
+// graceful-degradation pattern used elsewhere when registry state can't
+// be reached.
+func BuildWrapperProvenance(chartName, version string, results []*PatchResult, destLayout string) (*WrapperProvenance, error) {
+	provenance := &WrapperProvenance{ChartName: chartName, ChartVersion: version}
+
+	digestOf := crane.Digest
+	if destLayout != "" {
+		digestOf = func(ref string, _ ...crane.Option) (string, error) {
+			return digestFromLayout(destLayout, ref)
+		}
+	}
+
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		img := ImageProvenance{
+			Original: r.Original.Reference(),
+			Patched:  r.Patched.Reference(),
+		}
+		if digest, err := digestOf(img.Original); err == nil {
+			img.OriginalDigest = digest
+		}
+		if img.Patched != "" {
+			if digest, err := digestOf(img.Patched); err == nil {
+				img.PatchedDigest = digest
+			}
+		}
+		if r.ReportPath != "" {
+			if digest, err := hashReportFile(r.ReportPath); err == nil {
+				img.ReportDigest = "sha256:" + digest
+			}
+			if vulns, err := scanner.Normalize(r.ReportPath); err == nil {
+				for _, v := range vulns {
+					if v.FixedVersion != "" {
+						img.RemediatedCVEs = append(img.RemediatedCVEs, v.ID)
+					}
+				}
+			}
+		}
+		provenance.Images = append(provenance.Images, img)
+	}
+
+	return provenance, nil
+}
 
-	return version, nil
+// SaveWrapperProvenance writes provenance to provenance.json in chartDir,
+// alongside the override/image-path metadata SaveOverrides/SaveImagePaths
+// already save there.
+func SaveWrapperProvenance(provenance *WrapperProvenance, chartDir string) error {
+	data, err := json.MarshalIndent(provenance, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling provenance: %w", err)
+	}
+	return os.WriteFile(filepath.Join(chartDir, "provenance.json"), data, 0o644)
+}
+
+// LoadWrapperProvenance reads back the provenance.json CreateWrapperChart
+// wrote to chartDir via SaveWrapperProvenance. Callers that need the
+// per-image digest/CVE evidence after CreateWrapperChart has already run
+// (e.g. AssembleResults's --dry-run plan) should use this instead of
+// calling BuildWrapperProvenance a second time, which would re-resolve
+// every image's digest against the registry redundantly.
+func LoadWrapperProvenance(chartDir string) (*WrapperProvenance, error) {
+	data, err := os.ReadFile(filepath.Join(chartDir, "provenance.json"))
+	if err != nil {
+		return nil, fmt.Errorf("reading provenance.json: %w", err)
+	}
+	var provenance WrapperProvenance
+	if err := json.Unmarshal(data, &provenance); err != nil {
+		return nil, fmt.Errorf("parsing provenance.json: %w", err)
+	}
+	return &provenance, nil
+}
+
+// contentDigest computes a deterministic sha256 digest over results, so that
+// identical patch outcomes always hash to the same value regardless of
+// slice ordering or registry state. The digest is sensitive to each result's
+// original and patched references, its vulnerability count, and the content
+// of its Trivy report file (if any), so any change to what was patched or
+// what was found produces a different digest.
+func contentDigest(results []*PatchResult) (string, error) {
+	type entry struct {
+		original   string
+		patched    string
+		vulnCount  int
+		reportHash string
+	}
+
+	entries := make([]entry, 0, len(results))
+	for _, r := range results {
+		reportHash := ""
+		if r.ReportPath != "" {
+			h, err := hashReportFile(r.ReportPath)
+			if err != nil {
+				return "", fmt.Errorf("hashing report for %s: %w", r.Original.Reference(), err)
+			}
+			reportHash = h
+		}
+		entries = append(entries, entry{
+			original:   r.Original.Reference(),
+			patched:    r.Patched.Reference(),
+			vulnCount:  r.VulnCount,
+			reportHash: reportHash,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].original < entries[j].original
+	})
+
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s\n%s\n%d\n%s\n", e.original, e.patched, e.vulnCount, e.reportHash)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashReportFile returns the hex-encoded sha256 digest of the file at path.
+func hashReportFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// copyPlatformReports copies each multi-arch result's per-platform Trivy
+// reports (see PatchResult.PlatformReports) into chartDir/reports, named
+// "<original-ref>_<os>_<arch>.json" (e.g. "myrepo_nginx_1.25_linux_arm64.json").
+// Single-arch results have no PlatformReports and are skipped entirely, so
+// this is a no-op chart directory that never gains a reports/ subdirectory
+// unless at least one image was multi-arch.
+func copyPlatformReports(results []*PatchResult, chartDir string) error {
+	var reportsDir string
+	for _, r := range results {
+		for platform, reportPath := range r.PlatformReports {
+			if reportsDir == "" {
+				reportsDir = filepath.Join(chartDir, "reports")
+				if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+					return err
+				}
+			}
+
+			data, err := os.ReadFile(reportPath)
+			if err != nil {
+				return fmt.Errorf("reading report for %s (%s): %w", r.Original.Reference(), platform, err)
+			}
+
+			suffix := strings.ReplaceAll(platform, "/", "_")
+			name := sanitize(r.Original.Reference()) + "_" + suffix + ".json"
+			if err := os.WriteFile(filepath.Join(reportsDir, name), data, 0o644); err != nil {
+				return fmt.Errorf("writing report for %s (%s): %w", r.Original.Reference(), platform, err)
+			}
+		}
+	}
+	return nil
+}
+
+// embedChartReports copies each single-platform result's pre-patch Trivy
+// report (see PatchResult.ReportPath) into chartDir/reports, named after
+// PlatformKey(Original.Reference(), Platform) the same way
+// pushChartReportsAsReferrers names its scratch copies. Used for
+// AssembleResults' ReportsModeEmbed/ReportsModeBoth, as an alternative (or
+// supplement) to pushing reports as OCI 1.1 referrer artifacts. Multi-arch
+// results are left to copyPlatformReports, which this function's caller
+// always runs first regardless of reports mode, so the two never write the
+// same filename.
+func embedChartReports(results []*PatchResult, chartDir string) error {
+	var reportsDir string
+	for _, r := range results {
+		if r.ReportPath == "" || len(r.Platforms) > 0 {
+			continue
+		}
+		if reportsDir == "" {
+			reportsDir = filepath.Join(chartDir, "reports")
+			if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+				return err
+			}
+		}
+
+		data, err := os.ReadFile(r.ReportPath)
+		if err != nil {
+			return fmt.Errorf("reading report for %s: %w", r.Original.Reference(), err)
+		}
+		name := sanitize(PlatformKey(r.Original.Reference(), r.Platform)) + ".json"
+		if err := os.WriteFile(filepath.Join(reportsDir, name), data, 0o644); err != nil {
+			return fmt.Errorf("writing report for %s: %w", r.Original.Reference(), err)
+		}
+	}
+	return nil
+}
+
+// OnConflict selects how AssembleResults handles a chart whose
+// content-addressable version (see contentDigest) already exists in
+// registry. Because the version is itself a hash of the inputs, an exact
+// tag match means the content is, by construction, unchanged — there's
+// nothing to reconcile, just a policy choice for what to do about the
+// redundant run.
+type OnConflict string
+
+const (
+	// OnConflictBump is the default: skip the redundant publish and log
+	// "unchanged". Named to mirror the common skip-if-unchanged publishing
+	// pattern this flag controls, even though there's no actual version
+	// bump to perform here — the content hash already makes every version
+	// unique, so there's nothing to bump away from.
+	OnConflictBump OnConflict = "bump"
+	// OnConflictFail errors out instead of silently skipping, for a CI
+	// pipeline that wants a redundant run (e.g. a stale cache re-triggering
+	// a pipeline for inputs that didn't change) surfaced as a failure
+	// requiring attention rather than a quiet no-op.
+	OnConflictFail OnConflict = "fail"
+	// OnConflictOverwrite republishes anyway, re-pushing the chart under
+	// its existing tag — useful to refresh a signature or registry
+	// annotation on an already-published version without bumping it.
+	OnConflictOverwrite OnConflict = "overwrite"
+)
+
+// wrapperVersionExists reports whether chartName has already been published
+// to registry under the given version tag. Used to short-circuit
+// republishing when a content-addressable version (see contentDigest)
+// proves the inputs haven't changed since the last run.
+func wrapperVersionExists(registry, chartName, version string) (bool, error) {
+	chartRef := fmt.Sprintf("%s/charts/%s", registry, chartName)
+	tags, err := crane.ListTags(chartRef)
+	if err != nil {
+		// Chart doesn't exist yet in the registry, so this version can't
+		// already be published.
+		return false, nil
+	}
+	for _, tag := range tags {
+		if tag == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// remoteChartDigest HEADs chartName:version in registry, returning the
+// manifest digest wrapperVersionExists already confirmed is there — called
+// by AssembleResults once it decides to skip republishing, so the "unchanged"
+// log line and the skipped chart's assemble-summary.json entry still carry
+// a real digest instead of leaving it blank.
+func remoteChartDigest(registry, chartName, version string) (string, error) {
+	chartRef := fmt.Sprintf("%s/charts/%s:%s", registry, chartName, version)
+	digest, err := crane.Digest(chartRef)
+	if err != nil {
+		return "", fmt.Errorf("resolving digest for %s: %w", chartRef, err)
+	}
+	return digest, nil
+}
+
+// embedLocalDependency resolves dep via DownloadChart (which handles
+// repository: "" and file://... by copying the chart tree directly — see
+// copyLocalChart in helm.go) and vendors it into chartDir/charts/<name>.
+// The returned Dependency has Repository cleared, since a subchart that's
+// physically present in charts/ doesn't need one to be resolved by Helm.
+func embedLocalDependency(dep Dependency, chartDir string) (Dependency, error) {
+	chartsDir := filepath.Join(chartDir, "charts")
+	if err := os.MkdirAll(chartsDir, 0o755); err != nil {
+		return dep, fmt.Errorf("creating %s: %w", chartsDir, err)
+	}
+	if _, _, err := DownloadChart(dep, chartsDir, VerifyNever, ""); err != nil {
+		return dep, err
+	}
+	dep.Repository = ""
+	return dep, nil
 }
 
 func writeChartYaml(path string, chart WrapperChart) error {
 	type chartYaml struct {
-		APIVersion   string       `yaml:"apiVersion"`
-		Name         string       `yaml:"name"`
-		Description  string       `yaml:"description"`
-		Type         string       `yaml:"type"`
-		Version      string       `yaml:"version"`
-		Dependencies []Dependency `yaml:"dependencies"`
+		APIVersion   string            `yaml:"apiVersion"`
+		Name         string            `yaml:"name"`
+		Description  string            `yaml:"description"`
+		Type         string            `yaml:"type"`
+		Version      string            `yaml:"version"`
+		Dependencies []Dependency      `yaml:"dependencies"`
+		Annotations  map[string]string `yaml:"annotations,omitempty"`
 	}
 
 	c := chartYaml{
@@ -111,6 +576,7 @@ func writeChartYaml(path string, chart WrapperChart) error {
 		Type:         "application",
 		Version:      chart.Version,
 		Dependencies: chart.Dependencies,
+		Annotations:  chart.Annotations,
 	}
 
 	var buf bytes.Buffer
@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -371,13 +374,18 @@ func TestCreateWrapperChart(t *testing.T) {
 		},
 	}
 
-	version, err := CreateWrapperChart(dep, results, tmpDir, "")
+	version, _, _, err := CreateWrapperChart(dep, results, tmpDir, "", false, StarterOptions{}, "")
 	if err != nil {
 		t.Fatalf("CreateWrapperChart failed: %v", err)
 	}
 
-	if version != "25.8.0-0" {
-		t.Errorf("Expected version '25.8.0-0', got %s", version)
+	wantDigest, err := contentDigest(results)
+	if err != nil {
+		t.Fatalf("contentDigest() error: %v", err)
+	}
+	wantVersion := "25.8.0-p" + wantDigest[:8]
+	if version != wantVersion {
+		t.Errorf("Expected version %q, got %s", wantVersion, version)
 	}
 
 	chartDir := filepath.Join(tmpDir, "prometheus")
@@ -406,9 +414,9 @@ func TestCreateWrapperChart(t *testing.T) {
 		t.Errorf("Expected apiVersion 'v2', got %v", chart["apiVersion"])
 	}
 
-	// Check version mirrors upstream with patch level
-	if chart["version"] != "25.8.0-0" {
-		t.Errorf("Expected version '25.8.0-0', got %v", chart["version"])
+	// Check version mirrors upstream with content digest
+	if chart["version"] != wantVersion {
+		t.Errorf("Expected version %q, got %v", wantVersion, chart["version"])
 	}
 
 	// Check dependencies
@@ -500,6 +508,343 @@ func TestCreateWrapperChart(t *testing.T) {
 	}
 }
 
+func TestContentDigestDeterministic(t *testing.T) {
+	results := []*PatchResult{
+		{
+			Original:  Image{Repository: "prometheus/prometheus", Tag: "v2.48.0"},
+			Patched:   Image{Registry: "quay.io/verity", Repository: "prometheus", Tag: "v2.48.0-patched"},
+			VulnCount: 5,
+		},
+	}
+
+	first, err := contentDigest(results)
+	if err != nil {
+		t.Fatalf("contentDigest() error: %v", err)
+	}
+	second, err := contentDigest(results)
+	if err != nil {
+		t.Fatalf("contentDigest() error: %v", err)
+	}
+	if first != second {
+		t.Errorf("contentDigest() not deterministic: %q != %q", first, second)
+	}
+}
+
+func TestContentDigestSensitiveToVulnCount(t *testing.T) {
+	base := Image{Repository: "prometheus/prometheus", Tag: "v2.48.0"}
+	patched := Image{Registry: "quay.io/verity", Repository: "prometheus", Tag: "v2.48.0-patched"}
+
+	digestWith := func(vulnCount int) string {
+		d, err := contentDigest([]*PatchResult{{Original: base, Patched: patched, VulnCount: vulnCount}})
+		if err != nil {
+			t.Fatalf("contentDigest() error: %v", err)
+		}
+		return d
+	}
+
+	if digestWith(5) == digestWith(3) {
+		t.Error("contentDigest() should differ when VulnCount differs")
+	}
+}
+
+func TestCreateWrapperChartLegacyNumericVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	dep := Dependency{Name: "prometheus", Version: "25.8.0", Repository: "oci://ghcr.io/prometheus-community/charts"}
+	results := []*PatchResult{
+		{
+			Original:  Image{Repository: "prometheus/prometheus", Tag: "v2.48.0", Path: "server.image"},
+			Patched:   Image{Registry: "quay.io/verity", Repository: "prometheus", Tag: "v2.48.0-patched"},
+			VulnCount: 5,
+		},
+	}
+
+	version, alreadyPublished, _, err := CreateWrapperChart(dep, results, tmpDir, "", true, StarterOptions{}, "")
+	if err != nil {
+		t.Fatalf("CreateWrapperChart failed: %v", err)
+	}
+	if version != "25.8.0-0" {
+		t.Errorf("Expected legacy version '25.8.0-0', got %s", version)
+	}
+	if alreadyPublished {
+		t.Error("alreadyPublished should always be false in legacy numeric mode")
+	}
+}
+
+func TestCreateWrapperChart_EmbeddedFileDependency(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Build a two-level local chart tree: "outer" embeds "inner" as a
+	// vendored subchart, mirroring a real chart that bundles charts/ rather
+	// than declaring a remote dependency for it.
+	outerDir := filepath.Join(tmpDir, "source", "outer")
+	innerDir := filepath.Join(outerDir, "charts", "inner")
+	if err := os.MkdirAll(innerDir, 0o755); err != nil {
+		t.Fatalf("creating source chart tree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outerDir, "Chart.yaml"), []byte("apiVersion: v2\nname: outer\nversion: 1.0.0\n"), 0o644); err != nil {
+		t.Fatalf("writing outer Chart.yaml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(innerDir, "Chart.yaml"), []byte("apiVersion: v2\nname: inner\nversion: 1.0.0\n"), 0o644); err != nil {
+		t.Fatalf("writing inner Chart.yaml: %v", err)
+	}
+
+	dep := Dependency{Name: "outer", Version: "1.0.0", Repository: "file://" + outerDir}
+	results := []*PatchResult{
+		{
+			// Path reflects where scanChart would have namespaced an image
+			// found only in the nested "inner" subchart's own values.
+			Original:  Image{Repository: "leaf/image", Tag: "v1", Path: "inner.image"},
+			Patched:   Image{Registry: "quay.io/verity", Repository: "leaf-image", Tag: "v1-patched"},
+			VulnCount: 1,
+		},
+	}
+
+	outputDir := filepath.Join(tmpDir, "output")
+	version, _, _, err := CreateWrapperChart(dep, results, outputDir, "", false, StarterOptions{}, "")
+	if err != nil {
+		t.Fatalf("CreateWrapperChart failed: %v", err)
+	}
+
+	chartDir := filepath.Join(outputDir, "outer")
+
+	// The vendored dependency must be copied in full, inner subchart
+	// included, rather than left as an unresolvable file:// reference.
+	if _, err := os.Stat(filepath.Join(chartDir, "charts", "outer", "Chart.yaml")); err != nil {
+		t.Errorf("embedded outer chart not found: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(chartDir, "charts", "outer", "charts", "inner", "Chart.yaml")); err != nil {
+		t.Errorf("embedded inner subchart not found: %v", err)
+	}
+
+	chartData, err := os.ReadFile(filepath.Join(chartDir, "Chart.yaml"))
+	if err != nil {
+		t.Fatalf("reading Chart.yaml: %v", err)
+	}
+	var chart map[string]interface{}
+	if err := yaml.Unmarshal(chartData, &chart); err != nil {
+		t.Fatalf("parsing Chart.yaml: %v", err)
+	}
+	deps, ok := chart["dependencies"].([]interface{})
+	if !ok || len(deps) != 1 {
+		t.Fatalf("expected 1 dependency, got %v", chart["dependencies"])
+	}
+	depMap := deps[0].(map[string]interface{})
+	if repo, _ := depMap["repository"].(string); repo != "" {
+		t.Errorf("expected cleared repository for a vendored dependency, got %q", repo)
+	}
+
+	valuesData, err := os.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("reading values.yaml: %v", err)
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(valuesData, &values); err != nil {
+		t.Fatalf("parsing values.yaml: %v", err)
+	}
+	outer, ok := values["outer"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected top-level 'outer' key, got %v", values)
+	}
+	inner, ok := outer["inner"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested 'inner' key under 'outer', got %v", outer)
+	}
+	if _, ok := inner["image"]; !ok {
+		t.Errorf("expected 'image' key under 'outer.inner', got %v", inner)
+	}
+
+	wantDigest, err := contentDigest(results)
+	if err != nil {
+		t.Fatalf("contentDigest() error: %v", err)
+	}
+	if wantVersion := "1.0.0-p" + wantDigest[:8]; version != wantVersion {
+		t.Errorf("expected version %q, got %s", wantVersion, version)
+	}
+}
+
+func TestCreateWrapperChart_ResolvesVersionRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`entries:
+  redis:
+    - version: 18.1.0
+    - version: 18.1.5
+`))
+	}))
+	defer srv.Close()
+
+	dep := Dependency{Name: "redis", Version: "^18.0.0", Repository: srv.URL}
+	results := []*PatchResult{
+		{
+			Original:  Image{Repository: "bitnami/redis", Tag: "18.1.5", Path: "image"},
+			Patched:   Image{Registry: "quay.io/verity", Repository: "redis", Tag: "18.1.5-patched"},
+			VulnCount: 1,
+		},
+	}
+
+	tmpDir := t.TempDir()
+	version, _, _, err := CreateWrapperChart(dep, results, tmpDir, "", false, StarterOptions{}, "")
+	if err != nil {
+		t.Fatalf("CreateWrapperChart failed: %v", err)
+	}
+
+	wantDigest, err := contentDigest(results)
+	if err != nil {
+		t.Fatalf("contentDigest() error: %v", err)
+	}
+	if wantVersion := "18.1.5-p" + wantDigest[:8]; version != wantVersion {
+		t.Errorf("expected version %q, got %s", wantVersion, version)
+	}
+
+	chartData, err := os.ReadFile(filepath.Join(tmpDir, "redis", "Chart.yaml"))
+	if err != nil {
+		t.Fatalf("reading Chart.yaml: %v", err)
+	}
+	var chart map[string]interface{}
+	if err := yaml.Unmarshal(chartData, &chart); err != nil {
+		t.Fatalf("parsing Chart.yaml: %v", err)
+	}
+	deps, ok := chart["dependencies"].([]interface{})
+	if !ok || len(deps) != 1 {
+		t.Fatalf("expected 1 dependency, got %v", chart["dependencies"])
+	}
+	if depVersion, _ := deps[0].(map[string]interface{})["version"].(string); depVersion != "18.1.5" {
+		t.Errorf("expected dependency version resolved to %q, got %q", "18.1.5", depVersion)
+	}
+
+	valuesData, err := os.ReadFile(filepath.Join(tmpDir, "redis", "values.yaml"))
+	if err != nil {
+		t.Fatalf("reading values.yaml: %v", err)
+	}
+	if !strings.Contains(string(valuesData), `resolved from "^18.0.0" to "18.1.5"`) {
+		t.Errorf("expected values.yaml to note the resolved version, got:\n%s", valuesData)
+	}
+}
+
+func TestCreateWrapperChart_MalformedRegistryErrors(t *testing.T) {
+	dep := Dependency{Name: "prometheus", Version: "25.8.0", Repository: "oci://ghcr.io/prometheus-community/charts"}
+	results := []*PatchResult{
+		{Original: Image{Repository: "prometheus/prometheus", Tag: "v2.48.0", Path: "image"}, VulnCount: 1},
+	}
+
+	_, _, _, err := CreateWrapperChart(dep, results, t.TempDir(), "oci://", false, StarterOptions{}, "")
+	if err == nil {
+		t.Error("expected an error for a malformed registry, got nil")
+	}
+}
+
+func TestBuildWrapperProvenance(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report.json")
+	reportData := []byte(`{"Results":[{"Vulnerabilities":[{"VulnerabilityID":"CVE-2023-1234","FixedVersion":"1.2.3"},{"VulnerabilityID":"CVE-2023-0000"}]}]}`)
+	if err := os.WriteFile(reportPath, reportData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := []*PatchResult{
+		{
+			Original:   Image{Repository: "prometheus/prometheus", Tag: "v2.48.0"},
+			Patched:    Image{Registry: "quay.io/verity", Repository: "prometheus", Tag: "v2.48.0-patched"},
+			VulnCount:  1,
+			ReportPath: reportPath,
+		},
+		{
+			// Errored result — should be excluded from the provenance.
+			Original: Image{Repository: "some/broken", Tag: "latest"},
+			Error:    os.ErrNotExist,
+		},
+	}
+
+	provenance, err := BuildWrapperProvenance("prometheus", "25.8.0-pdeadbeef", results, "")
+	if err != nil {
+		t.Fatalf("BuildWrapperProvenance() error: %v", err)
+	}
+
+	if provenance.ChartName != "prometheus" || provenance.ChartVersion != "25.8.0-pdeadbeef" {
+		t.Errorf("unexpected chart identity: %+v", provenance)
+	}
+	if len(provenance.Images) != 1 {
+		t.Fatalf("expected 1 image (errored result excluded), got %d", len(provenance.Images))
+	}
+
+	img := provenance.Images[0]
+	if img.Original != "prometheus/prometheus:v2.48.0" {
+		t.Errorf("Original = %q, want prometheus/prometheus:v2.48.0", img.Original)
+	}
+	if img.Patched != "quay.io/verity/prometheus:v2.48.0-patched" {
+		t.Errorf("Patched = %q, want quay.io/verity/prometheus:v2.48.0-patched", img.Patched)
+	}
+	if img.ReportDigest == "" {
+		t.Error("expected ReportDigest to be set")
+	}
+	if len(img.RemediatedCVEs) != 1 || img.RemediatedCVEs[0] != "CVE-2023-1234" {
+		t.Errorf("RemediatedCVEs = %v, want [CVE-2023-1234]", img.RemediatedCVEs)
+	}
+}
+
+func TestSaveWrapperProvenance(t *testing.T) {
+	dir := t.TempDir()
+	provenance := &WrapperProvenance{
+		ChartName:    "prometheus",
+		ChartVersion: "25.8.0-pdeadbeef",
+		Images: []ImageProvenance{
+			{Original: "prometheus/prometheus:v2.48.0", Patched: "quay.io/verity/prometheus:v2.48.0-patched"},
+		},
+	}
+
+	if err := SaveWrapperProvenance(provenance, dir); err != nil {
+		t.Fatalf("SaveWrapperProvenance() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "provenance.json"))
+	if err != nil {
+		t.Fatalf("reading provenance.json: %v", err)
+	}
+
+	var got WrapperProvenance
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("parsing provenance.json: %v", err)
+	}
+	if got.ChartName != provenance.ChartName || got.ChartVersion != provenance.ChartVersion {
+		t.Errorf("got %+v, want %+v", got, provenance)
+	}
+}
+
+func TestEmbedChartReports(t *testing.T) {
+	dir := t.TempDir()
+	chartDir := t.TempDir()
+
+	reportPath := filepath.Join(dir, "nginx-report.json")
+	if err := os.WriteFile(reportPath, []byte(`{"Results":[]}`), 0o644); err != nil {
+		t.Fatalf("writing fixture report: %v", err)
+	}
+
+	results := []*PatchResult{
+		{
+			Original:   Image{Repository: "nginx", Tag: "1.25", Path: "image"},
+			ReportPath: reportPath,
+		},
+		{
+			// Multi-arch result: has Platforms set, so it's left to
+			// copyPlatformReports and must be skipped here.
+			Original:   Image{Repository: "redis", Tag: "7", Path: "image"},
+			ReportPath: reportPath,
+			Platforms:  []Platform{{OS: "linux", Arch: "amd64"}},
+		},
+	}
+
+	if err := embedChartReports(results, chartDir); err != nil {
+		t.Fatalf("embedChartReports() error: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(chartDir, "reports"))
+	if err != nil {
+		t.Fatalf("reading reports dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d report file(s), want 1: %v", len(entries), entries)
+	}
+}
+
 func TestGenerateNamespacedValuesOverride_OverrideComment(t *testing.T) {
 	results := []*PatchResult{
 		{
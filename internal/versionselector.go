@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// VersionSelector narrows the tags discoverRegistryVersions pulls from a
+// chart's registry repository, mirroring the channel semantics Helm uses for
+// dependency version ranges: "^1.2" (compatible with 1.2, excluding 2.0),
+// "~1.2.3" (patch-level only), ">=1.2 <2.0", "latest" (every version, the
+// default), and "stable" (every non-prerelease version). An empty selector
+// behaves like "latest".
+//
+// Constructed via NewVersionSelector; the zero value is equivalent to
+// NewVersionSelector("").
+type VersionSelector struct {
+	raw        string
+	constraint *semver.Constraints
+}
+
+// NewVersionSelector parses sel into a VersionSelector. "", "latest", and
+// "stable" are recognized directly; anything else is parsed as a semver
+// constraint via semver.NewConstraint (e.g. "^1.2", "~1.2.3", ">=1.2 <2.0").
+func NewVersionSelector(sel string) (VersionSelector, error) {
+	switch sel {
+	case "", "latest", "stable":
+		return VersionSelector{raw: sel}, nil
+	}
+	c, err := semver.NewConstraint(sel)
+	if err != nil {
+		return VersionSelector{}, fmt.Errorf("parsing version selector %q: %w", sel, err)
+	}
+	return VersionSelector{raw: sel, constraint: c}, nil
+}
+
+// Filter returns the subset of tags this selector admits. Floating channel
+// tags (e.g. "latest", "stable", "1", "1.2" — see isChannelTag) are always
+// excluded first, since they're aliases of a real version tag rather than
+// distinct versions; discoverRegistryVersions must not list them twice.
+func (s VersionSelector) Filter(tags []string) []string {
+	versioned := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !isChannelTag(t) {
+			versioned = append(versioned, t)
+		}
+	}
+
+	switch s.raw {
+	case "", "latest":
+		return versioned
+	case "stable":
+		out := make([]string, 0, len(versioned))
+		for _, t := range versioned {
+			if v, err := semver.NewVersion(t); err == nil && v.Prerelease() == "" {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+
+	out := make([]string, 0, len(versioned))
+	for _, t := range versioned {
+		v, err := semver.NewVersion(t)
+		if err != nil {
+			continue
+		}
+		if s.constraint.Check(v) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
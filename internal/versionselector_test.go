@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersionSelectorFilter(t *testing.T) {
+	tags := []string{"1.0.0", "1.2.3", "1.3.0-beta.1", "2.0.0", "latest", "stable", "1", "1.2"}
+
+	tests := []struct {
+		name string
+		sel  string
+		want []string
+	}{
+		{name: "empty selects all versions", sel: "", want: []string{"1.0.0", "1.2.3", "1.3.0-beta.1", "2.0.0"}},
+		{name: "latest selects all versions", sel: "latest", want: []string{"1.0.0", "1.2.3", "1.3.0-beta.1", "2.0.0"}},
+		{name: "stable excludes prereleases", sel: "stable", want: []string{"1.0.0", "1.2.3", "2.0.0"}},
+		{name: "caret constraint", sel: "^1.2", want: []string{"1.2.3"}},
+		{name: "range constraint", sel: ">=1.0 <2.0", want: []string{"1.0.0", "1.2.3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := NewVersionSelector(tt.sel)
+			if err != nil {
+				t.Fatalf("NewVersionSelector(%q) failed: %v", tt.sel, err)
+			}
+			got := sel.Filter(tags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVersionSelectorInvalid(t *testing.T) {
+	if _, err := NewVersionSelector("not-a-constraint!!"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestIsChannelTag(t *testing.T) {
+	tests := map[string]bool{
+		"latest":  true,
+		"stable":  true,
+		"1":       true,
+		"1.2":     true,
+		"1.2.3":   false,
+		"":        false,
+		"v1":      false,
+		"1.2.3-4": false,
+	}
+	for tag, want := range tests {
+		if got := isChannelTag(tag); got != want {
+			t.Errorf("isChannelTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
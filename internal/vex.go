@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// vexSuppressedStatuses are the OpenVEX statuses that mean "don't show this
+// as an open finding" — "not_affected" because the vulnerable code path
+// can't be reached, "fixed" because the maintainer has already confirmed a
+// fix landed (independent of whatever FixedVersion the scanner itself
+// reported). "affected" and "under_investigation" are left as ordinary
+// vulns; a VEX statement only suppresses, it never adds a finding a scanner
+// didn't already report.
+var vexSuppressedStatuses = map[string]bool{
+	"not_affected": true,
+	"fixed":        true,
+}
+
+// VEXProduct identifies what a VEXStatement applies to, matching OpenVEX's
+// "@id" convention: either a purl (e.g. "pkg:deb/debian/libssl@1.1") scoping
+// the statement to a package, or an image reference/digest (e.g.
+// "pkg:oci/myimage@sha256:abcd..." or a bare "sha256:abcd...") scoping it
+// to a whole image.
+type VEXProduct struct {
+	ID string `json:"@id" yaml:"@id"`
+}
+
+// matches reports whether this product covers the vulnerability found in
+// image imageRef's package pkgName. Digest-scoped products match against
+// imageRef (SiteImage.OriginalRef, which keeps any "@sha256:..." pin
+// intact, unlike the sanitized SiteImage.ID); purl-scoped products match
+// against the purl's package name.
+func (p VEXProduct) matches(imageRef, pkgName string) bool {
+	if idx := strings.Index(p.ID, "sha256:"); idx >= 0 {
+		digest := p.ID[idx:]
+		return imageRef != "" && strings.Contains(imageRef, digest)
+	}
+	if strings.HasPrefix(p.ID, "pkg:") {
+		name := purlPackageName(p.ID)
+		return name != "" && pkgName != "" && strings.EqualFold(name, pkgName)
+	}
+	return false
+}
+
+// purlPackageName extracts the package name component from a purl
+// ("pkg:type/namespace/name@version?qualifiers#subpath" → "name").
+func purlPackageName(purl string) string {
+	rest := strings.TrimPrefix(purl, "pkg:")
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 {
+		return ""
+	}
+	rest = rest[slash+1:]
+	if i := strings.LastIndexByte(rest, '/'); i >= 0 {
+		rest = rest[i+1:]
+	}
+	if i := strings.IndexByte(rest, '@'); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// VEXStatement is one OpenVEX-style disposition for a vulnerability against
+// a set of products. See VEXCorpus.Resolve for how statements are matched
+// and ranked.
+type VEXStatement struct {
+	Vulnerability struct {
+		Name string `json:"name" yaml:"name"`
+	} `json:"vulnerability" yaml:"vulnerability"`
+	Products []VEXProduct `json:"products" yaml:"products"`
+	// Status is an OpenVEX status: "not_affected", "affected", "fixed", or
+	// "under_investigation". Only "not_affected" and "fixed" suppress a
+	// finding — see vexSuppressedStatuses.
+	Status string `json:"status" yaml:"status"`
+	// Justification is the OpenVEX machine-readable reason (e.g.
+	// "component_not_present", "vulnerable_code_not_in_execute_path"),
+	// required by the spec when Status is "not_affected". Recorded on
+	// VulnSummary.Suppressions so the catalog can show why a CVE was
+	// filtered instead of silently dropping it.
+	Justification string `json:"justification,omitempty" yaml:"justification,omitempty"`
+	// Timestamp is this statement's own RFC3339 timestamp, overriding its
+	// document-level timestamp when set. A later timestamp always wins
+	// over an earlier one covering the same product/vulnerability.
+	Timestamp string `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// vexDocument is the subset of an OpenVEX document's top-level shape this
+// package reads: its own timestamp (used as every statement's fallback
+// timestamp) and the statements themselves.
+type vexDocument struct {
+	Timestamp  string         `json:"timestamp,omitempty"`
+	Statements []VEXStatement `json:"statements"`
+}
+
+// VEXCorpus is the full set of VEX statements a catalog run was given,
+// merged from a --vex-dir of *.vex.json documents and/or an inline `vex:`
+// block in the images config (see LoadVEXCorpus). A nil *VEXCorpus behaves
+// as an empty one: nothing is suppressed.
+type VEXCorpus struct {
+	statements []VEXStatement
+}
+
+// LoadVEXDir reads every *.vex.json file in dir and returns their combined
+// statements, defaulting each statement's Timestamp to its document's own
+// timestamp when the statement doesn't carry its own. A missing dir is not
+// an error — most charts won't have one.
+func LoadVEXDir(dir string) ([]VEXStatement, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading VEX directory %s: %w", dir, err)
+	}
+
+	var all []VEXStatement
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".vex.json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		var doc vexDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for _, s := range doc.Statements {
+			if s.Timestamp == "" {
+				s.Timestamp = doc.Timestamp
+			}
+			all = append(all, s)
+		}
+	}
+	return all, nil
+}
+
+// LoadInlineVEX reads a top-level `vex:` block from an images config file
+// (the same file ParseImagesFile scans for image references), ignoring
+// every other key. A file with no `vex:` block returns no statements.
+func LoadInlineVEX(path string) ([]VEXStatement, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var inline struct {
+		VEX []VEXStatement `yaml:"vex"`
+	}
+	if err := yaml.Unmarshal(data, &inline); err != nil {
+		return nil, fmt.Errorf("parsing vex block in %s: %w", path, err)
+	}
+	return inline.VEX, nil
+}
+
+// LoadVEXCorpus builds the combined VEXCorpus for a catalog run from a
+// --vex-dir (vexDir, may be empty) and the inline `vex:` block of an images
+// config (imagesFile, may be empty). Returns nil if neither source produced
+// any statements, so callers can treat "no VEX data" the same as a nil
+// gateOpts — nothing to apply.
+func LoadVEXCorpus(vexDir, imagesFile string) (*VEXCorpus, error) {
+	var statements []VEXStatement
+
+	dirStatements, err := LoadVEXDir(vexDir)
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, dirStatements...)
+
+	if imagesFile != "" {
+		inline, err := LoadInlineVEX(imagesFile)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, inline...)
+	}
+
+	if len(statements) == 0 {
+		return nil, nil
+	}
+	return &VEXCorpus{statements: statements}, nil
+}
+
+// Resolve returns the disposition of the latest-timestamped statement
+// covering vulnID against imageRef/pkgName, or found=false if no statement
+// in the corpus matches. Statements without a parseable timestamp sort
+// before every timestamped one, so an untimestamped statement can still be
+// overridden by a later, dated one.
+func (c *VEXCorpus) Resolve(imageRef, pkgName, vulnID string) (status, justification string, found bool) {
+	if c == nil {
+		return "", "", false
+	}
+
+	var latest *VEXStatement
+	var latestTime time.Time
+	for i := range c.statements {
+		s := &c.statements[i]
+		if !strings.EqualFold(s.Vulnerability.Name, vulnID) {
+			continue
+		}
+		matched := false
+		for _, p := range s.Products {
+			if p.matches(imageRef, pkgName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		ts, _ := time.Parse(time.RFC3339, s.Timestamp)
+		if latest == nil || ts.After(latestTime) {
+			latest, latestTime = s, ts
+		}
+	}
+	if latest == nil {
+		return "", "", false
+	}
+	return latest.Status, latest.Justification, true
+}
+
+// applyVEXSuppressions drops every vuln the corpus resolves to
+// "not_affected" or "fixed", returning the surviving vulns and a count of
+// how many were dropped per justification (falling back to the bare status
+// when no justification was given). A nil corpus is a no-op.
+func applyVEXSuppressions(vulns []SiteVuln, corpus *VEXCorpus, imageRef string) ([]SiteVuln, map[string]int) {
+	if corpus == nil {
+		return vulns, nil
+	}
+
+	kept := make([]SiteVuln, 0, len(vulns))
+	suppressions := make(map[string]int)
+	for _, v := range vulns {
+		status, justification, found := corpus.Resolve(imageRef, v.PkgName, v.ID)
+		if found && vexSuppressedStatuses[status] {
+			reason := justification
+			if reason == "" {
+				reason = status
+			}
+			suppressions[reason]++
+			continue
+		}
+		kept = append(kept, v)
+	}
+	if len(suppressions) == 0 {
+		return vulns, nil
+	}
+	return kept, suppressions
+}
@@ -0,0 +1,246 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVEXProductMatchesDigestScoped(t *testing.T) {
+	p := VEXProduct{ID: "pkg:oci/nginx@sha256:abcd1234"}
+	if !p.matches("sha256:abcd1234", "") {
+		t.Fatal("expected exact digest match")
+	}
+	if !p.matches("sha256:abcd1234extra", "") {
+		t.Fatal("expected digest prefix match")
+	}
+	if p.matches("sha256:deadbeef", "") {
+		t.Fatal("expected no match for different digest")
+	}
+	if p.matches("", "") {
+		t.Fatal("expected no match for empty image ID")
+	}
+}
+
+func TestVEXProductMatchesPurlScoped(t *testing.T) {
+	p := VEXProduct{ID: "pkg:deb/debian/libssl@1.1.1"}
+	if !p.matches("", "libssl") {
+		t.Fatal("expected purl package name match")
+	}
+	if !p.matches("", "LIBSSL") {
+		t.Fatal("expected case-insensitive purl match")
+	}
+	if p.matches("", "openssl") {
+		t.Fatal("expected no match for different package")
+	}
+	if p.matches("", "") {
+		t.Fatal("expected no match for empty package name")
+	}
+}
+
+func TestVEXProductMatchesDigestScopedWithinFullRef(t *testing.T) {
+	p := VEXProduct{ID: "sha256:abcd1234"}
+	if !p.matches("ghcr.io/verity-org/nginx@sha256:abcd1234", "") {
+		t.Fatal("expected a digest-scoped product to match a full image ref pinned to that digest")
+	}
+	if p.matches("ghcr.io/verity-org/nginx:1.25", "") {
+		t.Fatal("expected no match for a tag-pinned ref with no digest")
+	}
+}
+
+func TestVEXProductMatchesUnrecognizedID(t *testing.T) {
+	p := VEXProduct{ID: "not-a-purl-or-digest"}
+	if p.matches("sha256:abcd", "libssl") {
+		t.Fatal("expected no match for an unrecognized product ID")
+	}
+}
+
+func TestPurlPackageName(t *testing.T) {
+	tests := map[string]string{
+		"pkg:deb/debian/libssl@1.1.1":            "libssl",
+		"pkg:golang/github.com/foo/bar@v1.2.3":   "bar",
+		"pkg:deb/debian/libssl@1.1.1?arch=amd64": "libssl",
+		"pkg:deb/debian/libssl#subpath":          "libssl",
+		"pkg:deb/debian/libssl":                  "libssl",
+		"not-a-purl":                             "",
+	}
+	for purl, want := range tests {
+		if got := purlPackageName(purl); got != want {
+			t.Errorf("purlPackageName(%q) = %q, want %q", purl, got, want)
+		}
+	}
+}
+
+func TestVEXCorpusResolveLatestTimestampWins(t *testing.T) {
+	corpus := &VEXCorpus{statements: []VEXStatement{
+		{
+			Vulnerability: struct {
+				Name string `json:"name" yaml:"name"`
+			}{Name: "CVE-2024-0001"},
+			Products:  []VEXProduct{{ID: "pkg:deb/debian/libssl@1.1"}},
+			Status:    "under_investigation",
+			Timestamp: "2024-01-01T00:00:00Z",
+		},
+		{
+			Vulnerability: struct {
+				Name string `json:"name" yaml:"name"`
+			}{Name: "CVE-2024-0001"},
+			Products:      []VEXProduct{{ID: "pkg:deb/debian/libssl@1.1"}},
+			Status:        "not_affected",
+			Justification: "vulnerable_code_not_in_execute_path",
+			Timestamp:     "2024-06-01T00:00:00Z",
+		},
+	}}
+
+	status, justification, found := corpus.Resolve("", "libssl", "CVE-2024-0001")
+	if !found {
+		t.Fatal("expected a match")
+	}
+	if status != "not_affected" || justification != "vulnerable_code_not_in_execute_path" {
+		t.Fatalf("got status=%q justification=%q, want the later not_affected statement", status, justification)
+	}
+}
+
+func TestVEXCorpusResolveNoMatch(t *testing.T) {
+	corpus := &VEXCorpus{statements: []VEXStatement{
+		{
+			Vulnerability: struct {
+				Name string `json:"name" yaml:"name"`
+			}{Name: "CVE-2024-0001"},
+			Products: []VEXProduct{{ID: "pkg:deb/debian/libssl@1.1"}},
+			Status:   "not_affected",
+		},
+	}}
+
+	if _, _, found := corpus.Resolve("", "libssl", "CVE-2024-9999"); found {
+		t.Fatal("expected no match for a different vulnerability ID")
+	}
+	if _, _, found := corpus.Resolve("", "curl", "CVE-2024-0001"); found {
+		t.Fatal("expected no match for a different package")
+	}
+}
+
+func TestVEXCorpusResolveNilCorpus(t *testing.T) {
+	var corpus *VEXCorpus
+	if _, _, found := corpus.Resolve("", "libssl", "CVE-2024-0001"); found {
+		t.Fatal("expected a nil corpus to never match")
+	}
+}
+
+func TestApplyVEXSuppressionsDropsAndCounts(t *testing.T) {
+	corpus := &VEXCorpus{statements: []VEXStatement{
+		{
+			Vulnerability: struct {
+				Name string `json:"name" yaml:"name"`
+			}{Name: "CVE-2024-0001"},
+			Products:      []VEXProduct{{ID: "pkg:deb/debian/libssl@1.1"}},
+			Status:        "not_affected",
+			Justification: "component_not_present",
+		},
+		{
+			Vulnerability: struct {
+				Name string `json:"name" yaml:"name"`
+			}{Name: "CVE-2024-0002"},
+			Products: []VEXProduct{{ID: "pkg:deb/debian/curl@7.0"}},
+			Status:   "fixed",
+		},
+	}}
+
+	vulns := []SiteVuln{
+		{ID: "CVE-2024-0001", PkgName: "libssl", Severity: "HIGH"},
+		{ID: "CVE-2024-0002", PkgName: "curl", Severity: "MEDIUM"},
+		{ID: "CVE-2024-0003", PkgName: "openssl", Severity: "CRITICAL"},
+	}
+
+	kept, suppressions := applyVEXSuppressions(vulns, corpus, "")
+	if len(kept) != 1 || kept[0].ID != "CVE-2024-0003" {
+		t.Fatalf("unexpected kept vulns: %+v", kept)
+	}
+	if suppressions["component_not_present"] != 1 || suppressions["fixed"] != 1 {
+		t.Fatalf("unexpected suppression counts: %+v", suppressions)
+	}
+}
+
+func TestApplyVEXSuppressionsNilCorpusIsNoOp(t *testing.T) {
+	vulns := []SiteVuln{{ID: "CVE-2024-0001", PkgName: "libssl"}}
+	kept, suppressions := applyVEXSuppressions(vulns, nil, "")
+	if len(kept) != 1 {
+		t.Fatalf("expected vulns to pass through unchanged, got %+v", kept)
+	}
+	if suppressions != nil {
+		t.Fatalf("expected no suppressions, got %+v", suppressions)
+	}
+}
+
+func TestLoadVEXDir(t *testing.T) {
+	dir := t.TempDir()
+	doc := `{
+		"timestamp": "2024-01-01T00:00:00Z",
+		"statements": [
+			{"vulnerability": {"name": "CVE-2024-0001"}, "products": [{"@id": "pkg:deb/debian/libssl@1.1"}], "status": "not_affected"}
+		]
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "a.vex.json"), []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("not vex"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	statements, err := LoadVEXDir(dir)
+	if err != nil {
+		t.Fatalf("LoadVEXDir failed: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(statements))
+	}
+	if statements[0].Timestamp != "2024-01-01T00:00:00Z" {
+		t.Fatalf("expected statement to inherit document timestamp, got %q", statements[0].Timestamp)
+	}
+}
+
+func TestLoadVEXDirMissingIsNotAnError(t *testing.T) {
+	statements, err := LoadVEXDir(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("expected a missing dir to be ok, got: %v", err)
+	}
+	if statements != nil {
+		t.Fatalf("expected no statements, got %+v", statements)
+	}
+}
+
+func TestLoadInlineVEX(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "values.yaml")
+	content := `images:
+  - repository: nginx
+    tag: "1.25"
+vex:
+  - vulnerability:
+      name: CVE-2024-0001
+    products:
+      - "@id": "pkg:deb/debian/libssl@1.1"
+    status: not_affected
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	statements, err := LoadInlineVEX(path)
+	if err != nil {
+		t.Fatalf("LoadInlineVEX failed: %v", err)
+	}
+	if len(statements) != 1 || statements[0].Vulnerability.Name != "CVE-2024-0001" {
+		t.Fatalf("unexpected statements: %+v", statements)
+	}
+}
+
+func TestLoadVEXCorpusCombinesDirAndInlineReturnsNilWhenEmpty(t *testing.T) {
+	corpus, err := LoadVEXCorpus("", "")
+	if err != nil {
+		t.Fatalf("LoadVEXCorpus failed: %v", err)
+	}
+	if corpus != nil {
+		t.Fatal("expected a nil corpus when neither source has statements")
+	}
+}
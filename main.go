@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/descope/verity/internal"
 )
@@ -19,10 +20,13 @@ func main() {
 	buildkitAddr := flag.String("buildkit-addr", "", "BuildKit address for Copa (e.g. docker-container://buildkitd)")
 	reportDir := flag.String("report-dir", "", "directory to store Trivy JSON reports (default: temp dir)")
 	siteDataPath := flag.String("site-data", "", "generate site catalog JSON at this path")
+	repoConfigPath := flag.String("repo-config", os.Getenv("VERITY_REPO_CONFIG"), "path to repositories.yaml-style auth config for private registries/chart repos (env: VERITY_REPO_CONFIG)")
+	lockPath := flag.String("lock", "verity.lock", "path to the verity.lock file pinning resolved chart/image versions")
 
 	// Mode flags (mutually exclusive)
 	discover := flag.Bool("discover", false, "discover images and output GitHub Actions matrix JSON")
 	discoverDir := flag.String("discover-dir", ".verity", "output directory for discover artifacts")
+	minSeverity := flag.String("min-severity", "", "drop images from the discover matrix whose cached report (in -reports-dir) has nothing fixable at or above this severity (used with -discover)")
 	patchSingle := flag.Bool("patch-single", false, "patch a single image (for matrix jobs)")
 	image := flag.String("image", "", "image reference to patch (used with -patch-single)")
 	resultDir := flag.String("result-dir", "", "directory to write patch result JSON (used with -patch-single)")
@@ -34,11 +38,20 @@ func main() {
 	// Scan-only mode (no patching)
 	scan := flag.Bool("scan", false, "scan charts for images without patching (dry run)")
 	pushStandaloneReports := flag.Bool("push-standalone-reports", false, "push standalone reports to OCI registry")
+	sign := flag.Bool("sign", false, "cosign-sign the pushed standalone-reports artifact and attest its report manifest (used with -push-standalone-reports)")
+	signKey := flag.String("sign-key", "", "path to a cosign private key for -sign; omit for keyless (Fulcio/OIDC) signing")
+	concurrency := flag.Int("concurrency", 0, "max parallel report file operations (used with -push-standalone-reports or -export-standalone-reports-oci-layout; default 4)")
+	qps := flag.Float64("qps", 0, "max report file operations per second (used with -push-standalone-reports or -export-standalone-reports-oci-layout; default unlimited)")
+	exportStandaloneReportsOCILayout := flag.Bool("export-standalone-reports-oci-layout", false, "write standalone reports to a local OCI image-layout directory instead of pushing to a registry")
+	outDir := flag.String("out-dir", "", "output OCI image-layout directory (used with -export-standalone-reports-oci-layout)")
+	lockUpdate := flag.Bool("lock-update", false, "resolve charts/images and (re)write the verity.lock file")
+	serveCatalog := flag.Bool("serve-catalog", false, "serve the catalog live over HTTP instead of writing catalog.json")
+	serveAddr := flag.String("serve-addr", ":8080", "address to serve the catalog on (used with -serve-catalog)")
 	flag.Parse()
 
 	// Validate mutual exclusivity of mode flags.
 	modeCount := 0
-	for _, set := range []bool{*discover, *patchSingle, *assemble, *scan, *pushStandaloneReports} {
+	for _, set := range []bool{*discover, *patchSingle, *assemble, *scan, *pushStandaloneReports, *exportStandaloneReportsOCILayout, *lockUpdate, *serveCatalog} {
 		if set {
 			modeCount++
 		}
@@ -47,17 +60,33 @@ func main() {
 		modeCount = 1 // standalone -site-data mode
 	}
 	if modeCount > 1 {
-		log.Fatal("Only one mode flag may be specified at a time (-discover, -patch-single, -assemble, -scan, -site-data, -push-standalone-reports)")
+		log.Fatal("Only one mode flag may be specified at a time (-discover, -patch-single, -assemble, -scan, -site-data, -push-standalone-reports, -export-standalone-reports-oci-layout, -lock-update, -serve-catalog)")
 	}
 	// -site-data is valid as a standalone mode or combined with -assemble,
 	// but reject it with other modes to avoid silent no-ops.
-	if *siteDataPath != "" && (*discover || *patchSingle || *scan || *pushStandaloneReports) {
+	if *siteDataPath != "" && (*discover || *patchSingle || *scan || *pushStandaloneReports || *exportStandaloneReportsOCILayout || *lockUpdate) {
 		log.Fatal("-site-data can only be used standalone or with -assemble")
 	}
 
+	repoConfig, err := internal.LoadRepoConfig(*repoConfigPath)
+	if err != nil {
+		log.Fatalf("Failed to load repo config: %v", err)
+	}
+	internal.SetRepoConfig(repoConfig)
+
+	// -lock-update regenerates verity.lock, so it must not pin against the
+	// very lock it's about to overwrite.
+	if !*lockUpdate {
+		lock, err := internal.LoadLock(*lockPath)
+		if err != nil {
+			log.Fatalf("Failed to load %s: %v", *lockPath, err)
+		}
+		internal.SetLock(lock)
+	}
+
 	switch {
 	case *discover:
-		runDiscover(*chartFile, *imagesFile, *discoverDir)
+		runDiscover(*chartFile, *imagesFile, *discoverDir, *reportsDir, *minSeverity)
 	case *patchSingle:
 		runPatchSingle(*image, *registry, *buildkitAddr, *reportDir, *resultDir)
 	case *assemble:
@@ -65,7 +94,13 @@ func main() {
 	case *scan:
 		runScan(*chartFile, *imagesFile)
 	case *pushStandaloneReports:
-		runPushStandaloneReports(*reportsDir, *registry)
+		runPushStandaloneReports(*reportsDir, *registry, *sign, *signKey, *concurrency, *qps)
+	case *exportStandaloneReportsOCILayout:
+		runExportStandaloneReportsOCILayout(*reportsDir, *outDir, *concurrency, *qps)
+	case *lockUpdate:
+		runLockUpdate(*chartFile, *imagesFile, *lockPath)
+	case *serveCatalog:
+		runServeCatalog(*outputDir, *imagesFile, *registry, *serveAddr)
 	case *siteDataPath != "":
 		runSiteData(*outputDir, *imagesFile, *registry, *siteDataPath)
 	default:
@@ -77,6 +112,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  -scan                      List images found in charts (dry run)\n")
 		fmt.Fprintf(os.Stderr, "  -site-data                 Generate site catalog JSON from existing charts\n")
 		fmt.Fprintf(os.Stderr, "  -push-standalone-reports   Push standalone reports to OCI registry\n")
+		fmt.Fprintf(os.Stderr, "  -export-standalone-reports-oci-layout   Write standalone reports to a local OCI image-layout directory\n")
+		fmt.Fprintf(os.Stderr, "  -lock-update               Resolve charts/images and (re)write verity.lock\n")
+		fmt.Fprintf(os.Stderr, "  -serve-catalog             Serve the catalog live over HTTP (see -serve-addr)\n")
 		os.Exit(1)
 	}
 }
@@ -100,8 +138,10 @@ func parseOverridesFromFile(imagesFile string) []internal.ImageOverride {
 }
 
 // runDiscover scans charts and standalone images, then writes a manifest
-// and a GitHub Actions matrix JSON to discoverDir.
-func runDiscover(chartFile, imagesFile, discoverDir string) {
+// and a GitHub Actions matrix JSON to discoverDir. When minSeverity is set,
+// images with a cached report in reportsDir showing nothing fixable at or
+// above that severity are dropped from the matrix (see GenerateMatrix).
+func runDiscover(chartFile, imagesFile, discoverDir, reportsDir, minSeverity string) {
 	overrides := parseOverridesFromFile(imagesFile)
 
 	tmpDir, err := os.MkdirTemp("", "verity-discover-")
@@ -114,18 +154,21 @@ func runDiscover(chartFile, imagesFile, discoverDir string) {
 		}
 	}()
 
-	manifest, err := internal.DiscoverImages(chartFile, imagesFile, tmpDir)
+	manifest, reports, err := internal.DiscoverImages(chartFile, imagesFile, tmpDir, runtime.NumCPU())
 	if err != nil {
 		log.Fatalf("Discovery failed: %v", err)
 	}
+	for _, r := range reports {
+		fmt.Fprintf(os.Stderr, "Warning: %s (%s): %v\n", r.Chart, r.Stage, r.Err)
+	}
 
 	// Apply image tag overrides (e.g. distroless → debian) so the matrix
 	// contains Copa-compatible refs.
 	if len(overrides) > 0 {
-		applyOverridesToManifest(manifest, overrides)
+		applyOverridesToManifest(context.Background(), manifest, overrides)
 	}
 
-	matrix := internal.GenerateMatrix(manifest)
+	matrix := internal.GenerateMatrix(manifest, reportsDir, minSeverity, nil)
 
 	if err := internal.WriteDiscoveryOutput(manifest, matrix, discoverDir); err != nil {
 		log.Fatalf("Failed to write discovery output: %v", err)
@@ -137,13 +180,13 @@ func runDiscover(chartFile, imagesFile, discoverDir string) {
 }
 
 // applyOverridesToManifest applies image tag overrides to all images in a manifest.
-func applyOverridesToManifest(manifest *internal.DiscoveryManifest, overrides []internal.ImageOverride) {
+func applyOverridesToManifest(ctx context.Context, manifest *internal.DiscoveryManifest, overrides []internal.ImageOverride) {
 	for i, ch := range manifest.Charts {
 		images := make([]internal.Image, len(ch.Images))
 		for j, d := range ch.Images {
 			images[j] = internal.Image(d)
 		}
-		images = internal.ApplyOverrides(images, overrides)
+		images = internal.ApplyOverrides(ctx, images, overrides)
 		for j, img := range images {
 			manifest.Charts[i].Images[j].Tag = img.Tag
 		}
@@ -153,7 +196,7 @@ func applyOverridesToManifest(manifest *internal.DiscoveryManifest, overrides []
 		for j, d := range manifest.Standalone {
 			images[j] = internal.Image(d)
 		}
-		images = internal.ApplyOverrides(images, overrides)
+		images = internal.ApplyOverrides(ctx, images, overrides)
 		for j, img := range images {
 			manifest.Standalone[j].Tag = img.Tag
 		}
@@ -194,7 +237,7 @@ func runPatchSingle(imageRef, registry, buildkitAddr, reportDir, resultDir strin
 
 	fmt.Printf("Patching %s ...\n", imageRef)
 	ctx := context.Background()
-	if err := internal.PatchSingleImage(ctx, imageRef, opts, resultDir); err != nil {
+	if err := internal.PatchSingleImage(ctx, imageRef, "", opts, resultDir, nil); err != nil {
 		log.Fatalf("Patch failed: %v", err)
 	}
 	fmt.Println("Done.")
@@ -214,7 +257,7 @@ func runAssemble(manifestPath, resultsDir, reportsDir, outputDir, registry, imag
 	}
 
 	fmt.Println("Assembling wrapper charts from matrix results ...")
-	if err := internal.AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry); err != nil {
+	if err := internal.AssembleResults(manifestPath, resultsDir, reportsDir, outputDir, registry, "", false, false, false, false, "", "", internal.ReportsModeReferrer, "", "", internal.OnConflictBump); err != nil {
 		log.Fatalf("Assembly failed: %v", err)
 	}
 
@@ -236,19 +279,47 @@ func runSiteData(outputDir, imagesFile, registry, siteDataPath string) {
 	fmt.Printf("Site data → %s\n", siteDataPath)
 }
 
-// runPushStandaloneReports pushes standalone reports to the OCI registry.
-func runPushStandaloneReports(reportsDir, registry string) {
+// runServeCatalog serves the same data runSiteData writes to catalog.json,
+// but live over HTTP, so a registry can be browsed (or queried by CI)
+// without a separate build step.
+func runServeCatalog(outputDir, imagesFile, registry, addr string) {
+	if err := internal.ServeCatalog(outputDir, imagesFile, registry, addr); err != nil {
+		log.Fatalf("Catalog server failed: %v", err)
+	}
+}
+
+// runPushStandaloneReports pushes standalone reports to the OCI registry,
+// optionally cosign-signing the result and attesting its report manifest.
+func runPushStandaloneReports(reportsDir, registry string, sign bool, signKey string, concurrency int, qps float64) {
 	if reportsDir == "" {
 		log.Fatal("-reports-dir is required with -push-standalone-reports")
 	}
 	if registry == "" {
 		log.Fatal("-registry is required with -push-standalone-reports")
 	}
-	if err := internal.PushStandaloneReports(reportsDir, registry); err != nil {
+	opts := internal.PushOptions{Concurrency: concurrency, QPS: qps}
+	if _, err := internal.PushStandaloneReports(reportsDir, registry, sign, signKey, opts); err != nil {
 		log.Fatalf("Failed to push standalone reports: %v", err)
 	}
 }
 
+// runExportStandaloneReportsOCILayout writes standalone reports to a local
+// OCI image-layout directory for air-gapped transfer, in place of pushing
+// them to a live registry. Point -site-data's -registry at
+// "oci-layout://<out-dir>" later to read them back.
+func runExportStandaloneReportsOCILayout(reportsDir, outDir string, concurrency int, qps float64) {
+	if reportsDir == "" {
+		log.Fatal("-reports-dir is required with -export-standalone-reports-oci-layout")
+	}
+	if outDir == "" {
+		log.Fatal("-out-dir is required with -export-standalone-reports-oci-layout")
+	}
+	opts := internal.PushOptions{Concurrency: concurrency, QPS: qps}
+	if err := internal.ExportStandaloneReportsOCILayout(reportsDir, outDir, opts); err != nil {
+		log.Fatalf("Failed to export standalone reports: %v", err)
+	}
+}
+
 // runScan is a lightweight dry-run mode that lists all images found
 // in charts and standalone values without patching anything.
 func runScan(chartFile, imagesFile string) {
@@ -273,17 +344,17 @@ func runScan(chartFile, imagesFile string) {
 	for _, dep := range chart.Dependencies {
 		fmt.Printf("Chart %s@%s\n", dep.Name, dep.Version)
 
-		chartPath, err := internal.DownloadChart(dep, tmpDir)
+		chartPath, _, err := internal.DownloadChart(dep, tmpDir, internal.VerifyNever, "")
 		if err != nil {
 			log.Fatalf("Failed to download %s: %v", dep.Name, err)
 		}
 
-		images, err := internal.ScanForImages(chartPath)
+		images, err := internal.ScanForImages(context.Background(), chartPath)
 		if err != nil {
 			log.Fatalf("Failed to scan %s: %v", dep.Name, err)
 		}
 
-		images = internal.ApplyOverrides(images, overrides)
+		images = internal.ApplyOverrides(context.Background(), images, overrides)
 
 		fmt.Printf("  Found %d images\n", len(images))
 		for _, img := range images {
@@ -293,7 +364,7 @@ func runScan(chartFile, imagesFile string) {
 	}
 
 	if imagesFile != "" {
-		images, err := internal.ParseImagesFile(imagesFile)
+		images, err := internal.ParseImagesFile(context.Background(), imagesFile)
 		if err != nil {
 			log.Fatalf("Failed to parse %s: %v", imagesFile, err)
 		}
@@ -306,3 +377,63 @@ func runScan(chartFile, imagesFile string) {
 
 	fmt.Printf("\nTotal: %d images\n", total)
 }
+
+// runLockUpdate resolves every dependency chart and image the same way
+// -scan does, then (re)writes lockPath with what it found. It never
+// consults an existing lock file (main already skipped calling
+// internal.SetLock for this mode), so it always reflects the registry's
+// current state rather than reproducing a prior one.
+func runLockUpdate(chartFile, imagesFile, lockPath string) {
+	ctx := context.Background()
+	overrides := parseOverridesFromFile(imagesFile)
+
+	tmpDir, err := os.MkdirTemp("", "verity-lock-update-")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clean up temp dir: %v\n", err)
+		}
+	}()
+
+	chart, err := internal.ParseChartFile(chartFile)
+	if err != nil {
+		log.Fatalf("Failed to parse %s: %v", chartFile, err)
+	}
+
+	var allImages []internal.Image
+	for _, dep := range chart.Dependencies {
+		fmt.Printf("Resolving %s@%s\n", dep.Name, dep.Version)
+
+		chartPath, _, err := internal.DownloadChart(dep, tmpDir, internal.VerifyNever, "")
+		if err != nil {
+			log.Fatalf("Failed to download %s: %v", dep.Name, err)
+		}
+
+		images, err := internal.ScanForImages(ctx, chartPath)
+		if err != nil {
+			log.Fatalf("Failed to scan %s: %v", dep.Name, err)
+		}
+		images = internal.ApplyOverrides(ctx, images, overrides)
+		for _, img := range images {
+			allImages = append(allImages, internal.ResolveImageTag(ctx, img))
+		}
+	}
+
+	if imagesFile != "" {
+		images, err := internal.ParseImagesFile(ctx, imagesFile)
+		if err != nil {
+			log.Fatalf("Failed to parse %s: %v", imagesFile, err)
+		}
+		images = internal.ApplyOverrides(ctx, images, overrides)
+		for _, img := range images {
+			allImages = append(allImages, internal.ResolveImageTag(ctx, img))
+		}
+	}
+
+	if err := internal.WriteLock(lockPath, chart.Dependencies, allImages); err != nil {
+		log.Fatalf("Failed to write %s: %v", lockPath, err)
+	}
+	fmt.Printf("Wrote %s: %d chart(s), %d image(s)\n", lockPath, len(chart.Dependencies), len(allImages))
+}